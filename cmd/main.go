@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"payment/config"
 	"payment/internal/app"
+	"payment/internal/cli"
 	"payment/internal/domain/action"
 	"payment/pkg/logger"
 )
@@ -11,6 +14,16 @@ import (
 func main() {
 	ctx, cfg := context.Background(), config.MustLoad()
 
+	// Административные команды: логи уходят в stderr, чтобы не смешиваться с выводом
+	if args := os.Args[1:]; len(args) > 0 && args[0] != cli.CmdServe {
+		log := logger.NewWithWriter(cfg.DevLevel, os.Stderr)
+		if err := cli.Run(ctx, cfg, log, args, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	log := logger.New(cfg.DevLevel)
 	log.Info(ctx, action.ServiceSetup, "Logger and configuration setup has been finished...")
 
@@ -13,11 +13,37 @@ type (
 		Postgres postgres.Config
 		Server   Server
 		Broker   Broker
+		Billing  Billing
+		FX       FX
+		Risk     Risk
+		Redirect Redirect
+		Receipts Receipts
 		DevLevel string `env:"LEVEL"`
 	}
 
 	Server struct {
 		GRPCServer GRPCServer
+		HTTPServer HTTPServer
+		Auth       Auth
+	}
+
+	// Auth — ключи доступа к API в формате name:key через запятую.
+	// Имя ключа записывается в журнал аудита как вызывающая сторона.
+	Auth struct {
+		APIKeys        string `env:"API_KEYS"`                            // Ключи мерчантов
+		AdminAPIKeys   string `env:"ADMIN_API_KEYS"`                      // Ключи операторов; пусто — сервисы операторов недоступны
+		AllowAnonymous bool   `env:"API_ALLOW_ANONYMOUS" default:"false"` // Сервисы мерчанта доступны без ключа (только для разработки)
+	}
+
+	// HTTPServer — REST шлюз и публичные ссылки на оплату счетов. Ссылки обслуживаются на отдельном
+	// порту: наружу публикуется только он, REST шлюз остаётся во внутренней сети.
+	HTTPServer struct {
+		Port       string `env:"HTTP_PORT" default:"8080"`
+		PublicPort string `env:"PUBLIC_HTTP_PORT" default:"8081"`
+		PublicURL  string `env:"PUBLIC_BASE_URL" default:"http://localhost:8081"`
+		// Адреса и подсети прокси перед REST и gRPC через запятую; адрес клиента из X-Forwarded-For
+		// берётся только за ними. Пусто — адрес соединения
+		TrustedProxies string `env:"TRUSTED_PROXIES"`
 	}
 
 	GRPCServer struct {
@@ -27,10 +53,49 @@ type (
 		MaxConnectionAgeGrace time.Duration `env:"GRPC_MAX_CONNECTION_AGE_GRACE" default:"10s"`
 	}
 
+	Billing struct {
+		Enabled  bool          `env:"BILLING_ENABLED" default:"false"`
+		Interval time.Duration `env:"BILLING_INTERVAL" default:"1m"`
+	}
+
+	// FX — пересчёт валют, которые банк не принимает, в валюту расчётов.
+	FX struct {
+		SettlementCurrency string        `env:"SETTLEMENT_CURRENCY" default:"KZT"`
+		RatesFile          string        `env:"FX_RATES_FILE"` // CSV с курсами; пусто — курсы из БД
+		MaxRateAge         time.Duration `env:"FX_MAX_RATE_AGE" default:"24h"`
+	}
+
+	// Risk — проверка риска платежей перед обращением к банку.
+	Risk struct {
+		RulesFile string `env:"RISK_RULES_FILE"` // Файл правил; правила из БД действуют вместе с ним
+	}
+
+	// Redirect — адреса возврата покупателя с платёжной формы банка.
+	Redirect struct {
+		ReturnURL    string `env:"DEFAULT_RETURN_URL"`     // Если return_url не указан в запросе
+		FailURL      string `env:"DEFAULT_FAIL_URL"`       // Если error_url не указан в запросе
+		AllowedHosts string `env:"REDIRECT_ALLOWED_HOSTS"` // Через запятую; *.example.com — поддомены. Пусто — только хосты мерчанта из БД
+		AllowHTTP    bool   `env:"REDIRECT_ALLOW_HTTP" default:"false"`
+	}
+
+	// Receipts — чеки покупателю после списания и возврата.
+	Receipts struct {
+		Enabled   bool          `env:"RECEIPTS_ENABLED" default:"false"`
+		Interval  time.Duration `env:"RECEIPTS_INTERVAL" default:"1m"`
+		Provider  string        `env:"FISCAL_PROVIDER"` // Обязателен при RECEIPTS_ENABLED; local — заглушка для разработки
+		Dir       string        `env:"RECEIPTS_DIR"`    // Каталог для чеков локального провайдера; пусто — только лог
+		Seller    string        `env:"RECEIPT_SELLER_NAME"`
+		SellerTIN string        `env:"RECEIPT_SELLER_TIN"` // БИН/ИИН продавца
+	}
+
 	Broker struct {
 		Login    string `env:"BEREKE_MERCHANT_LOGIN"`
 		Password string `env:"BEREKE_MERCHANT_PASSWORD"`
 		Mode     string `env:"BEREKE_MERCHANT_MODE"`
+
+		// REST API шлюза для сохранённых карт; пусто — связки недоступны
+		APIURL           string `env:"BEREKE_API_URL"`
+		BindingReturnURL string `env:"BEREKE_BINDING_RETURN_URL"` // Обязателен вместе с BEREKE_API_URL
 	}
 )
 
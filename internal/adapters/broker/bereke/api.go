@@ -1,6 +1,7 @@
 package bereke
 
 import (
+	"errors"
 	"fmt"
 
 	bma "github.com/bsagat/bereke-merchant-api"
@@ -9,19 +10,46 @@ import (
 
 var Bereke_Broker string = "BEREKE"
 
+var ErrBindingReturnURLRequired = errors.New("binding return URL is required when REST API URL is set")
+
+// Options — настройки REST API шлюза, через который проводятся операции со связками.
+type Options struct {
+	APIURL           string // Адрес REST API (https://<host>/payment/rest/); пусто — связки недоступны
+	BindingReturnURL string // returnUrl заказов, оплачиваемых связкой; покупатель по нему не переходит
+}
+
 type BerekeClient struct {
 	merchant bma.API
+	rest     *restClient // nil, если REST API не настроен
+	login    string      // Логин мерчанта, по нему подбирается тариф комиссии
+
+	bindingReturnURL string
 }
 
-func NewClient(login, password string, mode types.Mode) (*BerekeClient, error) {
+func NewClient(login, password string, mode types.Mode, opts Options) (*BerekeClient, error) {
 	api, err := bma.NewWithLogin(login, password, mode)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new bma client: %v", err)
 	}
 
-	return &BerekeClient{
+	client := &BerekeClient{
 		merchant: api,
-	}, nil
+		login:    login,
+	}
+	if opts.APIURL != "" {
+		if opts.BindingReturnURL == "" {
+			return nil, ErrBindingReturnURLRequired
+		}
+		client.rest = newRESTClient(opts.APIURL, login, password)
+		client.bindingReturnURL = opts.BindingReturnURL
+	}
+
+	return client, nil
+}
+
+// Merchant — логин мерчанта у банка, под которым регистрируются заказы.
+func (c *BerekeClient) Merchant() string {
+	return c.login
 }
 
 func (c *BerekeClient) Ping() error {
@@ -0,0 +1,121 @@
+package bereke
+
+import (
+	"context"
+	"fmt"
+	"payment/internal/domain/models"
+
+	money "github.com/bsagat/bereke-merchant-api/currency"
+)
+
+// Клиент bereke-merchant-api не возвращает ID связки (bindingId) и не умеет оплату по связке,
+// поэтому связки работают через REST API шлюза (Options.APIURL). Заказы тогда регистрируются
+// с clientId, банк сохраняет оплаченную карту и возвращает bindingId в расширенном статусе заказа.
+// Без REST API операции со связками возвращают models.ErrBindingsNotSupported.
+
+// GetOrderBinding — связка, созданная при оплате заказа; nil, если карта не сохранена.
+func (c *BerekeClient) GetOrderBinding(ctx context.Context, paymentID string) (*models.Binding, error) {
+	const op = "BerekeClient.GetOrderBinding"
+
+	if c.rest == nil {
+		return nil, fmt.Errorf("%s: %w", op, models.ErrBindingsNotSupported)
+	}
+
+	res, err := c.rest.orderStatus(ctx, paymentID)
+	if err != nil {
+		return nil, transportError(op, err)
+	}
+	if res.code() != 0 {
+		return nil, orderError(op, res.code(), res.message())
+	}
+
+	if res.BindingInfo.BindingID == "" {
+		return nil, nil
+	}
+
+	return &models.Binding{
+		ID:        res.BindingInfo.BindingID,
+		UserID:    res.BindingInfo.ClientID,
+		Broker:    Bereke_Broker,
+		MaskedPan: res.CardAuthInfo.MaskedPan,
+		Expiry:    res.CardAuthInfo.Expiration,
+	}, nil
+}
+
+// ChargeBinding — регистрирует заказ и оплачивает его сохранённой картой (paymentOrderBinding.do).
+// Повторный вызов с тем же номером заказа не списывает деньги дважды: заказ, который банк уже
+// пытался оплатить, возвращается с текущим состоянием. Отказ банка возвращается статусом DECLINED
+// без ошибки, чтобы платёж был сохранён; ошибка означает, что результат оплаты неизвестен.
+func (c *BerekeClient) ChargeBinding(ctx context.Context, payment *models.Payment, bindingID string) (models.BrokerStatus, error) {
+	const op = "BerekeClient.ChargeBinding"
+
+	if c.rest == nil {
+		return models.BrokerStatus{}, fmt.Errorf("%s: %w", op, models.ErrBindingsNotSupported)
+	}
+
+	orderID, state, err := c.bindingOrder(ctx, op, payment)
+	if err != nil {
+		return models.BrokerStatus{}, err
+	}
+
+	payment.ID = orderID
+	payment.Broker = Bereke_Broker
+	payment.Merchant = c.login
+
+	// Заказ уже оплачивался
+	if mapOrderState(state) != models.OrderCreated {
+		return models.BrokerStatus{Status: mapOrderState(state), Code: state}, nil
+	}
+
+	payRes, err := c.rest.payByBinding(ctx, orderID, bindingID, payment.ClientIP)
+	if err != nil {
+		return models.BrokerStatus{}, transportError(op, err)
+	}
+
+	res, err := c.rest.orderStatus(ctx, orderID)
+	if err != nil {
+		return models.BrokerStatus{}, transportError(op, err)
+	}
+	if res.code() != 0 {
+		return models.BrokerStatus{}, orderError(op, res.code(), res.message())
+	}
+
+	state = res.PaymentAmountInfo.PaymentState
+	if payRes.code() != 0 && mapOrderState(state) != models.OrderDeclined {
+		return models.BrokerStatus{}, orderError(op, payRes.code(), payRes.message())
+	}
+
+	return models.BrokerStatus{Status: mapOrderState(state), Code: state}, nil
+}
+
+// bindingOrder регистрирует заказ для оплаты по связке и возвращает его ID и состояние.
+// Если заказ с таким номером уже зарегистрирован для того же покупателя, возвращается он.
+func (c *BerekeClient) bindingOrder(ctx context.Context, op string, payment *models.Payment) (string, string, error) {
+	reg, err := c.rest.register(ctx, restRegister, payment.OrderID, payment.UserID, payment.Amount,
+		fmt.Sprint(money.ToNumeric(payment.Currency)), c.bindingReturnURL, "")
+	if err != nil {
+		return "", "", transportError(op, err)
+	}
+
+	switch reg.code() {
+	case 0:
+		return reg.OrderID, stateCreated, nil
+	case errCodeDuplicateOrder:
+		// Заказ уже зарегистрирован, например, при повторе после обрыва связи
+	default:
+		return "", "", orderError(op, reg.code(), reg.message())
+	}
+
+	res, err := c.rest.orderStatusByNumber(ctx, payment.OrderID)
+	if err != nil {
+		return "", "", transportError(op, err)
+	}
+	if res.code() != 0 {
+		return "", "", orderError(op, res.code(), res.message())
+	}
+	if res.orderID() == "" || res.BindingInfo.ClientID != payment.UserID {
+		return "", "", orderError(op, errCodeDuplicateOrder, reg.message())
+	}
+
+	return res.orderID(), res.PaymentAmountInfo.PaymentState, nil
+}
@@ -0,0 +1,222 @@
+package bereke
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"payment/internal/domain/models"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeGateway — REST API шлюза с заказами в памяти.
+type fakeGateway struct {
+	mu       sync.Mutex
+	orders   map[string]*fakeOrder // По номеру заказа мерчанта
+	payments int                   // Вызовы paymentOrderBinding.do
+}
+
+type fakeOrder struct {
+	id, number, clientID, amount, state, bindingID string
+}
+
+func newFakeGateway(t *testing.T) (*fakeGateway, *BerekeClient) {
+	g := &fakeGateway{orders: make(map[string]*fakeOrder)}
+	srv := httptest.NewServer(g)
+	t.Cleanup(srv.Close)
+
+	return g, &BerekeClient{
+		rest:             newRESTClient(srv.URL+"/payment/rest", "merchant", "secret"),
+		login:            "merchant",
+		bindingReturnURL: "https://shop.example.com/return",
+	}
+}
+
+func (g *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if r.FormValue("userName") != "merchant" || r.FormValue("password") != "secret" {
+		writeJSON(w, map[string]any{"errorCode": "5", "errorMessage": "access denied"})
+		return
+	}
+
+	switch strings.TrimPrefix(r.URL.Path, "/payment/rest/") {
+	case restRegister:
+		number := r.FormValue("orderNumber")
+		if _, ok := g.orders[number]; ok {
+			writeJSON(w, map[string]any{"errorCode": 1, "errorMessage": "order already registered"})
+			return
+		}
+		order := &fakeOrder{id: "md-" + number, number: number, clientID: r.FormValue("clientId"),
+			amount: r.FormValue("amount"), state: stateCreated}
+		g.orders[number] = order
+		writeJSON(w, map[string]any{"orderId": order.id, "formUrl": "https://bank/form/" + order.id})
+
+	case restPaymentBinding:
+		g.payments++
+		order := g.byID(r.FormValue("mdOrder"))
+		if order == nil {
+			writeJSON(w, map[string]any{"errorCode": 6, "error": "no such order"})
+			return
+		}
+		if r.FormValue("bindingId") != "good-binding" {
+			order.state = stateDeclined
+			writeJSON(w, map[string]any{"errorCode": 2, "error": "declined"})
+			return
+		}
+		order.state, order.bindingID = stateDeposited, r.FormValue("bindingId")
+		writeJSON(w, map[string]any{"errorCode": 0})
+
+	case restOrderStatus:
+		order := g.orders[r.FormValue("orderNumber")]
+		if id := r.FormValue("orderId"); id != "" {
+			order = g.byID(id)
+		}
+		if order == nil {
+			writeJSON(w, map[string]any{"errorCode": "6", "errorMessage": "no such order"})
+			return
+		}
+		writeJSON(w, map[string]any{
+			"errorCode":         "0",
+			"orderNumber":       order.number,
+			"bindingInfo":       map[string]any{"clientId": order.clientID, "bindingId": order.bindingID},
+			"cardAuthInfo":      map[string]any{"maskedPan": "440043**0012", "expiration": "202812"},
+			"paymentAmountInfo": map[string]any{"paymentState": order.state},
+			"attributes":        []map[string]any{{"name": "mdOrder", "value": order.id}},
+		})
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (g *fakeGateway) byID(id string) *fakeOrder {
+	for _, order := range g.orders {
+		if order.id == id {
+			return order
+		}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestChargeBindingDeposited(t *testing.T) {
+	g, client := newFakeGateway(t)
+	payment := &models.Payment{OrderID: "sub-1-202501", UserID: "u1", Amount: 1500.5, Currency: "KZT"}
+
+	status, err := client.ChargeBinding(t.Context(), payment, "good-binding")
+	if err != nil {
+		t.Fatalf("ChargeBinding: %v", err)
+	}
+	if status.Status != models.OrderDeposited {
+		t.Fatalf("status = %s, want DEPOSITED", status.Status)
+	}
+	if payment.ID != "md-sub-1-202501" || payment.Broker != Bereke_Broker || payment.Merchant != "merchant" {
+		t.Fatalf("payment = %+v, want bank order ID, broker and merchant", payment)
+	}
+	if got := g.orders["sub-1-202501"].amount; got != "150050" {
+		t.Fatalf("registered amount = %s, want 150050 minor units", got)
+	}
+}
+
+func TestChargeBindingDeclined(t *testing.T) {
+	_, client := newFakeGateway(t)
+	payment := &models.Payment{OrderID: "order-1", UserID: "u1", Amount: 100, Currency: "KZT"}
+
+	status, err := client.ChargeBinding(t.Context(), payment, "expired-binding")
+	if err != nil {
+		t.Fatalf("ChargeBinding: %v, want DECLINED status without error", err)
+	}
+	if status.Status != models.OrderDeclined || payment.ID == "" {
+		t.Fatalf("status = %s, payment ID = %q; want DECLINED with bank order ID", status.Status, payment.ID)
+	}
+}
+
+func TestChargeBindingRetryDoesNotChargeTwice(t *testing.T) {
+	g, client := newFakeGateway(t)
+
+	first := &models.Payment{OrderID: "order-1", UserID: "u1", Amount: 100, Currency: "KZT"}
+	if _, err := client.ChargeBinding(t.Context(), first, "good-binding"); err != nil {
+		t.Fatalf("first ChargeBinding: %v", err)
+	}
+
+	retry := &models.Payment{OrderID: "order-1", UserID: "u1", Amount: 100, Currency: "KZT"}
+	status, err := client.ChargeBinding(t.Context(), retry, "good-binding")
+	if err != nil {
+		t.Fatalf("retry ChargeBinding: %v", err)
+	}
+	if status.Status != models.OrderDeposited || retry.ID != first.ID {
+		t.Fatalf("retry = %s %q, want DEPOSITED %q", status.Status, retry.ID, first.ID)
+	}
+	if g.payments != 1 {
+		t.Fatalf("paymentOrderBinding calls = %d, want 1", g.payments)
+	}
+}
+
+func TestChargeBindingUnpaidOrderIsCharged(t *testing.T) {
+	g, client := newFakeGateway(t)
+	g.orders["order-1"] = &fakeOrder{id: "md-order-1", number: "order-1", clientID: "u1", state: stateCreated}
+
+	payment := &models.Payment{OrderID: "order-1", UserID: "u1", Amount: 100, Currency: "KZT"}
+	status, err := client.ChargeBinding(t.Context(), payment, "good-binding")
+	if err != nil {
+		t.Fatalf("ChargeBinding: %v", err)
+	}
+	if status.Status != models.OrderDeposited || payment.ID != "md-order-1" || g.payments != 1 {
+		t.Fatalf("status = %s, ID = %q, payments = %d", status.Status, payment.ID, g.payments)
+	}
+}
+
+func TestChargeBindingForeignOrder(t *testing.T) {
+	g, client := newFakeGateway(t)
+	g.orders["order-1"] = &fakeOrder{id: "md-order-1", number: "order-1", clientID: "u2", state: stateDeposited}
+
+	payment := &models.Payment{OrderID: "order-1", UserID: "u1", Amount: 100, Currency: "KZT"}
+	if _, err := client.ChargeBinding(t.Context(), payment, "good-binding"); !errors.Is(err, models.ErrBrokerDuplicateOrder) {
+		t.Fatalf("ChargeBinding error = %v, want ErrBrokerDuplicateOrder", err)
+	}
+}
+
+func TestGetOrderBinding(t *testing.T) {
+	g, client := newFakeGateway(t)
+	g.orders["paid"] = &fakeOrder{id: "md-paid", number: "paid", clientID: "u1", state: stateDeposited, bindingID: "b-1"}
+	g.orders["no-card"] = &fakeOrder{id: "md-no-card", number: "no-card", clientID: "u1", state: stateDeposited}
+
+	binding, err := client.GetOrderBinding(t.Context(), "md-paid")
+	if err != nil {
+		t.Fatalf("GetOrderBinding: %v", err)
+	}
+	want := models.Binding{ID: "b-1", UserID: "u1", Broker: Bereke_Broker, MaskedPan: "440043**0012", Expiry: "202812"}
+	if binding == nil || *binding != want {
+		t.Fatalf("binding = %+v, want %+v", binding, want)
+	}
+
+	if binding, err := client.GetOrderBinding(t.Context(), "md-no-card"); err != nil || binding != nil {
+		t.Fatalf("GetOrderBinding without card = %+v, %v; want nil, nil", binding, err)
+	}
+
+	if _, err := client.GetOrderBinding(t.Context(), "missing"); !errors.Is(err, models.ErrBrokerOrderNotFound) {
+		t.Fatalf("GetOrderBinding(missing) error = %v, want ErrBrokerOrderNotFound", err)
+	}
+}
+
+func TestBindingsWithoutRESTAPI(t *testing.T) {
+	client := &BerekeClient{login: "merchant"}
+
+	if client.Supports(models.BindingPayment) {
+		t.Fatal("Supports(BindingPayment) = true without REST API")
+	}
+	if _, err := client.ChargeBinding(t.Context(), &models.Payment{}, "b-1"); !errors.Is(err, models.ErrBindingsNotSupported) {
+		t.Fatalf("ChargeBinding error = %v, want ErrBindingsNotSupported", err)
+	}
+	if _, err := client.GetOrderBinding(t.Context(), "md-1"); !errors.Is(err, models.ErrBindingsNotSupported) {
+		t.Fatalf("GetOrderBinding error = %v, want ErrBindingsNotSupported", err)
+	}
+}
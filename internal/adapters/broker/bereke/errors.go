@@ -0,0 +1,56 @@
+package bereke
+
+import (
+	"fmt"
+	"payment/internal/domain/models"
+)
+
+// Коды ошибок (errorCode) платёжного шлюза банка
+const (
+	errCodeDuplicateOrder  = 1 // Заказ с таким номером уже зарегистрирован
+	errCodeDeclined        = 2 // Заказ отклонён
+	errCodeUnknownCurrency = 3 // Неизвестная валюта
+	errCodeMissingParam    = 4 // Не указан обязательный параметр
+	errCodeInvalidParam    = 5 // Неверное значение параметра
+	errCodeNoSuchOrder     = 6 // Незарегистрированный заказ
+	errCodeSystemError     = 7 // Системная ошибка
+)
+
+// orderError приводит код ответа банка на регистрацию или запрос заказа к доменной ошибке
+func orderError(op string, errorCode int, message string) error {
+	var mapped error
+	switch errorCode {
+	case errCodeDuplicateOrder:
+		mapped = models.ErrBrokerDuplicateOrder
+	case errCodeDeclined:
+		mapped = models.ErrPaymentDeclined
+	case errCodeUnknownCurrency:
+		mapped = models.ErrBrokerUnsupportedCurrency
+	case errCodeNoSuchOrder:
+		mapped = models.ErrBrokerOrderNotFound
+	case errCodeSystemError:
+		mapped = models.ErrBrokerUnavailable
+	default:
+		mapped = models.ErrBrokerRejectedRequest
+	}
+
+	return fmt.Errorf("%s: %w: %d %s", op, mapped, errorCode, message)
+}
+
+// operationError приводит код ответа банка на списание, отмену или возврат к доменной ошибке.
+// Для этих операций код 5 означает неверную сумму (превышает доступную),
+// а код 7 — недопустимое для операции состояние заказа.
+func operationError(op string, errorCode int, message string) error {
+	switch errorCode {
+	case errCodeInvalidParam:
+		return fmt.Errorf("%s: %w: %d %s", op, models.ErrInsufficientFunds, errorCode, message)
+	case errCodeSystemError:
+		return fmt.Errorf("%s: %w: %d %s", op, models.ErrBrokerOperationImpossible, errorCode, message)
+	}
+	return orderError(op, errorCode, message)
+}
+
+// transportError оборачивает ошибку обращения к банку
+func transportError(op string, err error) error {
+	return fmt.Errorf("%s: %w: %w", op, models.ErrBrokerUnavailable, err)
+}
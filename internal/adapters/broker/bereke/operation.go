@@ -0,0 +1,39 @@
+package bereke
+
+import (
+	"context"
+	"fmt"
+	"payment/internal/domain/models"
+)
+
+// Клиент bereke-merchant-api умеет только регистрацию заказа с платёжной формой (register.do),
+// оплата по связке доступна при настроенном REST API шлюза.
+// Оплата токеном карты, через кошельки и QR в клиенте не реализованы, поэтому такие операции
+// возвращают models.ErrOperationNotSupported, а Supports сообщает о них сервису заранее.
+
+// Supports — поддерживает ли банк операцию оплаты.
+func (c *BerekeClient) Supports(operation models.PaymentOperation) bool {
+	switch operation {
+	case models.URLpayment:
+		return true
+	case models.BindingPayment:
+		return c.rest != nil
+	default:
+		return false
+	}
+}
+
+func (c *BerekeClient) ChargeCard(ctx context.Context, payment *models.Payment, cardToken string) (models.BrokerStatus, error) {
+	const op = "BerekeClient.ChargeCard"
+	return models.BrokerStatus{}, fmt.Errorf("%s: %w", op, models.ErrOperationNotSupported)
+}
+
+func (c *BerekeClient) ChargeWallet(ctx context.Context, payment *models.Payment, walletType, walletToken string) (models.BrokerStatus, error) {
+	const op = "BerekeClient.ChargeWallet"
+	return models.BrokerStatus{}, fmt.Errorf("%s: %w", op, models.ErrOperationNotSupported)
+}
+
+func (c *BerekeClient) CreateQROrder(ctx context.Context, payment *models.Payment) (string, error) {
+	const op = "BerekeClient.CreateQROrder"
+	return "", fmt.Errorf("%s: %w", op, models.ErrOperationNotSupported)
+}
@@ -0,0 +1,50 @@
+package bereke
+
+import (
+	"context"
+	"errors"
+	"payment/internal/domain/models"
+	"testing"
+)
+
+func TestSupports(t *testing.T) {
+	_, withREST := newFakeGateway(t)
+	withoutREST := &BerekeClient{login: "merchant"}
+
+	tests := []struct {
+		operation   models.PaymentOperation
+		withREST    bool
+		withoutREST bool
+	}{
+		{models.URLpayment, true, true},
+		{models.BindingPayment, true, false},
+		{models.CardPayment, false, false},
+		{models.WalletPayment, false, false},
+		{models.QRPayment, false, false},
+		{"SBP_payment", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := withREST.Supports(tt.operation); got != tt.withREST {
+			t.Errorf("Supports(%s) with REST API = %v, want %v", tt.operation, got, tt.withREST)
+		}
+		if got := withoutREST.Supports(tt.operation); got != tt.withoutREST {
+			t.Errorf("Supports(%s) without REST API = %v, want %v", tt.operation, got, tt.withoutREST)
+		}
+	}
+}
+
+func TestUnsupportedOperations(t *testing.T) {
+	c := &BerekeClient{login: "merchant"}
+	ctx, payment := context.Background(), &models.Payment{OrderID: "order-1", Amount: 100, Currency: "KZT"}
+
+	if _, err := c.ChargeCard(ctx, payment, "tok_1"); !errors.Is(err, models.ErrOperationNotSupported) {
+		t.Errorf("ChargeCard error = %v, want ErrOperationNotSupported", err)
+	}
+	if _, err := c.ChargeWallet(ctx, payment, models.WalletApplePay, "w1"); !errors.Is(err, models.ErrOperationNotSupported) {
+		t.Errorf("ChargeWallet error = %v, want ErrOperationNotSupported", err)
+	}
+	if _, err := c.CreateQROrder(ctx, payment); !errors.Is(err, models.ErrOperationNotSupported) {
+		t.Errorf("CreateQROrder error = %v, want ErrOperationNotSupported", err)
+	}
+}
@@ -2,7 +2,6 @@ package bereke
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"payment/internal/domain/models"
 	"time"
@@ -11,25 +10,27 @@ import (
 	"github.com/bsagat/bereke-merchant-api/models/code"
 )
 
-var (
-	ErrNoSuchOrder         = errors.New("no such order")
-	ErrOperationImpossible = errors.New("impossible for current transaction state")
-)
-
+// Описание, metadata и корзина платежа не передаются: клиент bereke-merchant-api регистрирует заказ
+// без description, jsonParams и orderBundle. Корзина хранится у нас для формирования чека.
 func (c *BerekeClient) CreateOrder(ctx context.Context, payment *models.Payment, returnURL, errorURL string) (string, error) {
 	const op = "BerekeClient.CreateOrder"
 
+	if c.rest != nil {
+		return c.registerWithClient(ctx, op, restRegister, payment, returnURL, errorURL)
+	}
+
 	res, err := c.merchant.RegisterOrderByNumber(ctx, payment.OrderID, payment.Amount, money.ToNumeric(payment.Currency), returnURL, errorURL)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return "", transportError(op, err)
 	}
 
 	if res.ErrorCode != code.Success {
-		return "", fmt.Errorf("%s: %d %s", op, res.ErrorCode, res.ErrorMessage)
+		return "", orderError(op, int(res.ErrorCode), res.ErrorMessage)
 	}
 
 	payment.ID = res.OrderID
 	payment.Broker = Bereke_Broker
+	payment.Merchant = c.login
 
 	return res.FormURL, nil
 }
@@ -37,34 +38,63 @@ func (c *BerekeClient) CreateOrder(ctx context.Context, payment *models.Payment,
 func (c *BerekeClient) CreateAuthOrder(ctx context.Context, payment *models.Payment, returnURL, errorURL string) (string, error) {
 	const op = "BerekeClient.CreateAuthOrder"
 
+	if c.rest != nil {
+		return c.registerWithClient(ctx, op, restRegisterPreAuth, payment, returnURL, errorURL)
+	}
+
 	res, err := c.merchant.AuthOrderByNumber(ctx, payment.OrderID, payment.Amount, money.ToNumeric(payment.Currency), returnURL, errorURL)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return "", transportError(op, err)
 	}
 
 	if res.ErrorCode != code.Success {
-		return "", fmt.Errorf("%s: %d %s", op, res.ErrorCode, res.ErrorMessage)
+		return "", orderError(op, int(res.ErrorCode), res.ErrorMessage)
+	}
+
+	payment.ID = res.OrderID
+	payment.Broker = Bereke_Broker
+	payment.Merchant = c.login
+
+	return res.FormURL, nil
+}
+
+// registerWithClient регистрирует заказ через REST API с clientId покупателя,
+// чтобы банк сохранил оплаченную карту как связку.
+func (c *BerekeClient) registerWithClient(ctx context.Context, op, method string, payment *models.Payment, returnURL, errorURL string) (string, error) {
+	res, err := c.rest.register(ctx, method, payment.OrderID, payment.UserID, payment.Amount,
+		fmt.Sprint(money.ToNumeric(payment.Currency)), returnURL, errorURL)
+	if err != nil {
+		return "", transportError(op, err)
+	}
+
+	if res.code() != int(code.Success) {
+		return "", orderError(op, res.code(), res.message())
 	}
 
 	payment.ID = res.OrderID
 	payment.Broker = Bereke_Broker
+	payment.Merchant = c.login
 
 	return res.FormURL, nil
 }
 
-func (c *BerekeClient) GetOrderStatus(ctx context.Context, paymentID string) (models.StatusType, error) {
+func (c *BerekeClient) GetOrderStatus(ctx context.Context, paymentID string) (models.BrokerStatus, error) {
 	const op = "BerekeClient.GetOrderStatus"
 
 	res, err := c.merchant.GetOrderStatusByID(ctx, paymentID)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return models.BrokerStatus{}, transportError(op, err)
 	}
 
 	if res.ErrorCode != code.Success {
-		return "", fmt.Errorf("%s: %d %s", op, res.ErrorCode, res.ErrorMessage)
+		return models.BrokerStatus{}, orderError(op, int(res.ErrorCode), res.ErrorMessage)
 	}
 
-	return models.StatusType(res.PaymentAmountInfo.PaymentState), nil
+	state := res.PaymentAmountInfo.PaymentState
+	return models.BrokerStatus{
+		Status: mapOrderState(string(state)),
+		Code:   fmt.Sprint(state),
+	}, nil
 }
 
 func (c *BerekeClient) GetOrderDetails(ctx context.Context, paymentID string) (models.Payment, error) {
@@ -72,11 +102,11 @@ func (c *BerekeClient) GetOrderDetails(ctx context.Context, paymentID string) (m
 
 	res, err := c.merchant.GetOrderStatusByID(ctx, paymentID)
 	if err != nil {
-		return models.Payment{}, fmt.Errorf("%s: %w", op, err)
+		return models.Payment{}, transportError(op, err)
 	}
 
 	if res.ErrorCode != code.Success {
-		return models.Payment{}, fmt.Errorf("%s: %d %s", op, res.ErrorCode, res.ErrorMessage)
+		return models.Payment{}, orderError(op, int(res.ErrorCode), res.ErrorMessage)
 	}
 
 	payment := models.Payment{
@@ -84,7 +114,7 @@ func (c *BerekeClient) GetOrderDetails(ctx context.Context, paymentID string) (m
 		Broker:    Bereke_Broker,
 		Amount:    res.Amount,
 		Currency:  money.ToAlpha(res.Currency),
-		Status:    models.StatusType(res.PaymentAmountInfo.PaymentState),
+		Status:    mapOrderState(string(res.PaymentAmountInfo.PaymentState)),
 		CreatedAt: time.UnixMilli(res.Date),
 		UserID:    res.BindingInfo.ClientID,
 	}
@@ -92,47 +122,47 @@ func (c *BerekeClient) GetOrderDetails(ctx context.Context, paymentID string) (m
 	return payment, nil
 }
 
-func (c *BerekeClient) ReversalOrder(ctx context.Context, orderID string, amount float64, currency string) error {
+func (c *BerekeClient) ReversalOrder(ctx context.Context, orderID string, amount float64, currency string) (string, error) {
 	const op = "BerekeClient.ReversalOrder"
 
 	res, err := c.merchant.ReversalOrderByID(ctx, amount, money.ToNumeric(currency), orderID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return "", transportError(op, err)
 	}
 
 	if res.ErrorCode != code.Success {
-		return fmt.Errorf("%s: %d %s", op, res.ErrorCode, res.ErrorMessage)
+		return fmt.Sprint(res.ErrorCode), operationError(op, int(res.ErrorCode), res.ErrorMessage)
 	}
 
-	return nil
+	return fmt.Sprint(res.ErrorCode), nil
 }
 
-func (c *BerekeClient) RefundOrder(ctx context.Context, orderID string, amount float64, currency string) error {
+func (c *BerekeClient) RefundOrder(ctx context.Context, orderID string, amount float64, currency string) (string, error) {
 	const op = "BerekeClient.RefundOrder"
 
 	res, err := c.merchant.RefundOrderByID(ctx, amount, money.ToNumeric(currency), orderID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return "", transportError(op, err)
 	}
 
 	if res.ErrorCode != code.Success {
-		return fmt.Errorf("%s: %d %s", op, res.ErrorCode, res.ErrorMessage)
+		return fmt.Sprint(res.ErrorCode), operationError(op, int(res.ErrorCode), res.ErrorMessage)
 	}
 
-	return nil
+	return fmt.Sprint(res.ErrorCode), nil
 }
 
-func (c *BerekeClient) DepositOrder(ctx context.Context, orderID string, amount float64, currency string) error {
+func (c *BerekeClient) DepositOrder(ctx context.Context, orderID string, amount float64, currency string) (string, error) {
 	const op = "BerekeClient.DepositOrder"
 
 	res, err := c.merchant.DepositOrderByNumber(ctx, orderID, amount, money.ToNumeric(currency))
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return "", transportError(op, err)
 	}
 
 	if res.ErrorCode != code.Success {
-		return fmt.Errorf("%s: %d %s", op, res.ErrorCode, res.ErrorMessage)
+		return fmt.Sprint(res.ErrorCode), operationError(op, int(res.ErrorCode), res.ErrorMessage)
 	}
 
-	return nil
+	return fmt.Sprint(res.ErrorCode), nil
 }
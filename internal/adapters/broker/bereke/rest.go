@@ -0,0 +1,186 @@
+package bereke
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Методы REST API платёжного шлюза, которых нет в клиенте bereke-merchant-api
+const (
+	restRegister        = "register.do"
+	restRegisterPreAuth = "registerPreAuth.do"
+	restOrderStatus     = "getOrderStatusExtended.do"
+	restPaymentBinding  = "paymentOrderBinding.do"
+)
+
+const (
+	restTimeout     = 30 * time.Second
+	restMaxResponse = 1 << 20
+)
+
+// restClient — обращения к REST API шлюза напрямую: регистрация заказа с clientId,
+// расширенный статус заказа со связкой и оплата по связке.
+type restClient struct {
+	baseURL  string
+	login    string
+	password string
+	http     *http.Client
+}
+
+func newRESTClient(baseURL, login, password string) *restClient {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return &restClient{
+		baseURL:  baseURL,
+		login:    login,
+		password: password,
+		http:     &http.Client{Timeout: restTimeout},
+	}
+}
+
+// restStatus — код и текст ошибки из ответа шлюза. Код приходит строкой или числом,
+// текст — в errorMessage или error в зависимости от метода.
+type restStatus struct {
+	ErrorCode    json.Number `json:"errorCode"`
+	ErrorMessage string      `json:"errorMessage"`
+	Error        string      `json:"error"`
+}
+
+func (s restStatus) code() int {
+	code, _ := strconv.Atoi(s.ErrorCode.String())
+	return code
+}
+
+func (s restStatus) message() string {
+	if s.ErrorMessage != "" {
+		return s.ErrorMessage
+	}
+	return s.Error
+}
+
+type restRegisterResponse struct {
+	restStatus
+	OrderID string `json:"orderId"`
+	FormURL string `json:"formUrl"`
+}
+
+type restOrderStatusResponse struct {
+	restStatus
+	OrderNumber string `json:"orderNumber"`
+	BindingInfo struct {
+		ClientID  string `json:"clientId"`
+		BindingID string `json:"bindingId"`
+	} `json:"bindingInfo"`
+	CardAuthInfo struct {
+		MaskedPan  string `json:"maskedPan"`
+		Expiration string `json:"expiration"` // YYYYMM
+	} `json:"cardAuthInfo"`
+	PaymentAmountInfo struct {
+		PaymentState string `json:"paymentState"`
+	} `json:"paymentAmountInfo"`
+	Attributes []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"attributes"`
+}
+
+// orderID — ID заказа в банке; при запросе по номеру заказа он приходит в атрибуте mdOrder.
+func (r restOrderStatusResponse) orderID() string {
+	for _, attr := range r.Attributes {
+		if attr.Name == "mdOrder" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// register регистрирует заказ методом method (register.do или registerPreAuth.do).
+// clientId связывает заказ с покупателем: по нему банк сохраняет карту и находит связки.
+func (c *restClient) register(ctx context.Context, method, orderNumber, clientID string, amount float64, currency, returnURL, failURL string) (restRegisterResponse, error) {
+	params := url.Values{
+		"orderNumber": {orderNumber},
+		"amount":      {minorUnits(amount)},
+		"currency":    {currency},
+		"returnUrl":   {returnURL},
+	}
+	if failURL != "" {
+		params.Set("failUrl", failURL)
+	}
+	if clientID != "" {
+		params.Set("clientId", clientID)
+	}
+
+	var res restRegisterResponse
+	err := c.call(ctx, method, params, &res)
+	return res, err
+}
+
+// orderStatus — расширенный статус заказа по ID заказа в банке.
+func (c *restClient) orderStatus(ctx context.Context, orderID string) (restOrderStatusResponse, error) {
+	var res restOrderStatusResponse
+	err := c.call(ctx, restOrderStatus, url.Values{"orderId": {orderID}}, &res)
+	return res, err
+}
+
+// orderStatusByNumber — расширенный статус заказа по номеру заказа мерчанта.
+func (c *restClient) orderStatusByNumber(ctx context.Context, orderNumber string) (restOrderStatusResponse, error) {
+	var res restOrderStatusResponse
+	err := c.call(ctx, restOrderStatus, url.Values{"orderNumber": {orderNumber}}, &res)
+	return res, err
+}
+
+// payByBinding оплачивает зарегистрированный заказ сохранённой картой.
+func (c *restClient) payByBinding(ctx context.Context, orderID, bindingID, ip string) (restStatus, error) {
+	params := url.Values{
+		"mdOrder":   {orderID},
+		"bindingId": {bindingID},
+	}
+	if ip != "" {
+		params.Set("ip", ip)
+	}
+
+	var res restStatus
+	err := c.call(ctx, restPaymentBinding, params, &res)
+	return res, err
+}
+
+// call выполняет POST запрос к методу шлюза и разбирает JSON ответ в out.
+// Ошибка означает, что ответ банка не получен; коды ошибок шлюза разбирает вызывающий.
+func (c *restClient) call(ctx context.Context, method string, params url.Values, out any) error {
+	params.Set("userName", c.login)
+	params.Set("password", c.password)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+method, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected HTTP status %d", method, res.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, restMaxResponse)).Decode(out); err != nil {
+		return fmt.Errorf("%s: failed to decode response: %w", method, err)
+	}
+	return nil
+}
+
+// minorUnits — сумма в минимальных единицах валюты, как её принимает шлюз.
+func minorUnits(amount float64) string {
+	return strconv.FormatInt(int64(math.Round(amount*100)), 10)
+}
@@ -0,0 +1,44 @@
+package bereke
+
+import (
+	"payment/internal/domain/models"
+	"strings"
+)
+
+// Состояния заказа в платёжном шлюзе банка (paymentAmountInfo.paymentState)
+const (
+	stateCreated         = "CREATED"          // Заказ зарегистрирован, оплата не начата
+	stateStarted         = "STARTED"          // Покупатель открыл платёжную форму
+	stateACSAuth         = "ACS_AUTH"         // Ожидается подтверждение 3-D Secure
+	statePending         = "PENDING"          // Оплата обрабатывается банком
+	stateApproved        = "APPROVED"         // Средства заблокированы (предавторизация)
+	statePartlyDeposited = "PARTLY_DEPOSITED" // Списана часть заблокированной суммы
+	stateDeposited       = "DEPOSITED"        // Средства списаны
+	stateDeclined        = "DECLINED"         // Оплата отклонена
+	stateReversed        = "REVERSED"         // Предавторизация отменена
+	statePartlyRefunded  = "PARTLY_REFUNDED"  // Возвращена часть средств
+	stateRefunded        = "REFUNDED"         // Средства возвращены полностью
+)
+
+var orderStates = map[string]models.StatusType{
+	stateCreated:         models.OrderCreated,
+	stateStarted:         models.OrderCreated,
+	stateACSAuth:         models.OrderPending3DS,
+	statePending:         models.OrderPending3DS,
+	stateApproved:        models.OrderApproved,
+	statePartlyDeposited: models.OrderPartiallyDeposited,
+	stateDeposited:       models.OrderDeposited,
+	stateDeclined:        models.OrderDeclined,
+	stateReversed:        models.OrderReversed,
+	statePartlyRefunded:  models.OrderPartiallyRefunded,
+	stateRefunded:        models.OrderRefunded,
+}
+
+// mapOrderState приводит состояние заказа в банке к статусу платежа.
+// Нераспознанное состояние возвращается как models.OrderUnknown.
+func mapOrderState(state string) models.StatusType {
+	if status, ok := orderStates[strings.ToUpper(strings.TrimSpace(state))]; ok {
+		return status
+	}
+	return models.OrderUnknown
+}
@@ -0,0 +1,57 @@
+package bereke
+
+import (
+	"payment/internal/domain/models"
+	"testing"
+)
+
+func TestMapOrderState(t *testing.T) {
+	tests := []struct {
+		state string
+		want  models.StatusType
+	}{
+		{stateCreated, models.OrderCreated},
+		{stateStarted, models.OrderCreated},
+		{stateACSAuth, models.OrderPending3DS},
+		{statePending, models.OrderPending3DS},
+		{stateApproved, models.OrderApproved},
+		{statePartlyDeposited, models.OrderPartiallyDeposited},
+		{stateDeposited, models.OrderDeposited},
+		{stateDeclined, models.OrderDeclined},
+		{stateReversed, models.OrderReversed},
+		{statePartlyRefunded, models.OrderPartiallyRefunded},
+		{stateRefunded, models.OrderRefunded},
+
+		// Регистр и пробелы не важны
+		{" deposited ", models.OrderDeposited},
+		{"Approved", models.OrderApproved},
+
+		// Нераспознанные состояния
+		{"", models.OrderUnknown},
+		{"CHARGEBACK", models.OrderUnknown},
+		{"1", models.OrderUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			if got := mapOrderState(tt.state); got != tt.want {
+				t.Errorf("mapOrderState(%q) = %s, want %s", tt.state, got, tt.want)
+			}
+		})
+	}
+}
+
+// Каждое известное состояние банка покрыто тестом и отображается в известный статус.
+func TestOrderStatesCovered(t *testing.T) {
+	for state, status := range orderStates {
+		if status == models.OrderUnknown || !models.IsStatusSupported(status) {
+			t.Errorf("state %s maps to unsupported status %s", state, status)
+		}
+		if mapOrderState(state) != status {
+			t.Errorf("mapOrderState(%s) != %s", state, status)
+		}
+	}
+	if len(orderStates) != 11 {
+		t.Errorf("orderStates has %d entries, update TestMapOrderState", len(orderStates))
+	}
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"encoding/json"
+	"math"
+	"payment/internal/domain/models"
+	"strconv"
+	"time"
+)
+
+// Тип значения колонки
+type columnKind int
+
+const (
+	kindText    columnKind = iota
+	kindNumber             // Курс
+	kindDecimal            // Сумма с фиксированным числом знаков после запятой (scale)
+	kindTime               // Момент времени (UTC)
+	kindJSON               // Объект: в JSON Lines вложенный, в CSV и Parquet — строка JSON
+)
+
+type column struct {
+	name  string
+	kind  columnKind
+	scale int // Знаков после запятой для kindDecimal, как в колонке Postgres
+
+	text   func(p models.Payment) string
+	number func(p models.Payment) float64
+	time   func(p models.Payment) time.Time
+	json   func(p models.Payment) any
+}
+
+func textColumn(name string, fn func(p models.Payment) string) column {
+	return column{name: name, kind: kindText, text: fn}
+}
+
+func numberColumn(name string, fn func(p models.Payment) float64) column {
+	return column{name: name, kind: kindNumber, number: fn}
+}
+
+func decimalColumn(name string, scale int, fn func(p models.Payment) float64) column {
+	return column{name: name, kind: kindDecimal, scale: scale, number: fn}
+}
+
+// unscaled — сумма целым числом единиц последнего знака (для scale 2 — минимальные единицы валюты).
+func (c column) unscaled(p models.Payment) int64 {
+	return int64(math.Round(c.number(p) * math.Pow10(c.scale)))
+}
+
+// columns — колонки выгрузки. Данные покупателя (контакты, IP адрес) не выгружаются.
+var columns = []column{
+	textColumn("payment_id", func(p models.Payment) string { return p.ID }),
+	textColumn("order_id", func(p models.Payment) string { return p.OrderID }),
+	textColumn("user_id", func(p models.Payment) string { return p.UserID }),
+	textColumn("merchant", func(p models.Payment) string { return p.Merchant }),
+	textColumn("broker", func(p models.Payment) string { return p.Broker }),
+	textColumn("operation", func(p models.Payment) string { return string(p.Operation) }),
+	textColumn("status", func(p models.Payment) string { return string(p.Status) }),
+	{name: "created_at", kind: kindTime, time: func(p models.Payment) time.Time { return p.CreatedAt }},
+	decimalColumn("amount", 2, func(p models.Payment) float64 { return p.Amount }),
+	textColumn("currency", func(p models.Payment) string { return p.Currency }),
+	decimalColumn("captured_amount", 2, func(p models.Payment) float64 { return p.CapturedAmount }),
+	decimalColumn("released_amount", 2, func(p models.Payment) float64 { return p.ReleasedAmount }),
+	decimalColumn("refunded_amount", 2, func(p models.Payment) float64 { return p.RefundedAmount }),
+	decimalColumn("fee_amount", 2, func(p models.Payment) float64 { return p.FeeAmount }),
+	decimalColumn("net_amount", 2, func(p models.Payment) float64 { return p.NetAmount() }),
+	decimalColumn("presentment_amount", 4, func(p models.Payment) float64 { return p.PresentmentAmount }),
+	textColumn("presentment_currency", func(p models.Payment) string { return p.PresentmentCurrency }),
+	numberColumn("fx_rate", func(p models.Payment) float64 { return p.FXRate }),
+	textColumn("fx_rate_source", func(p models.Payment) string { return p.FXSource }),
+	textColumn("risk_decision", func(p models.Payment) string { return string(p.RiskDecision) }),
+	textColumn("description", func(p models.Payment) string { return p.Description }),
+	{name: "metadata", kind: kindJSON, json: func(p models.Payment) any { return p.Metadata }},
+}
+
+// format — значение колонки строкой для CSV и Parquet.
+func (c column) format(p models.Payment) (string, error) {
+	switch c.kind {
+	case kindNumber, kindDecimal:
+		return strconv.FormatFloat(c.number(p), 'f', -1, 64), nil
+	case kindTime:
+		return c.time(p).UTC().Format(time.RFC3339Nano), nil
+	case kindJSON:
+		return jsonText(c.json(p))
+	default:
+		return c.text(p), nil
+	}
+}
+
+// jsonText — объект строкой JSON; пустой объект выгружается пустой строкой.
+func jsonText(v any) (string, error) {
+	if m, ok := v.(map[string]any); ok && len(m) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"payment/internal/domain/models"
+)
+
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+	record []string
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w), record: make([]string, len(columns))}
+}
+
+func (c *csvWriter) Write(p models.Payment) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	for i, col := range columns {
+		value, err := col.format(p)
+		if err != nil {
+			return err
+		}
+		c.record[i] = value
+	}
+	return c.w.Write(c.record)
+}
+
+// Close дописывает заголовок (для пустой выгрузки) и сбрасывает буфер.
+func (c *csvWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.header {
+		return nil
+	}
+	c.header = true
+
+	for i, col := range columns {
+		c.record[i] = col.name
+	}
+	return c.w.Write(c.record)
+}
@@ -0,0 +1,43 @@
+// Package export — выгрузка платежей в CSV, JSON Lines и Parquet.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Exporter создаёт потоковые писатели выгрузки. Каждый писатель держит в памяти не больше
+// одной группы строк (Parquet) или одной строки (CSV, JSON Lines).
+type Exporter struct{}
+
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+func (e *Exporter) NewWriter(format models.ExportFormat, w io.Writer) (ports.PaymentWriter, error) {
+	switch format {
+	case models.ExportCSV:
+		return newCSVWriter(w), nil
+	case models.ExportJSONL:
+		return newJSONLWriter(w), nil
+	case models.ExportParquet:
+		return newParquetWriter(w), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+func (e *Exporter) ContentType(format models.ExportFormat) string {
+	return contentTypes[format]
+}
+
+var contentTypes = map[models.ExportFormat]string{
+	models.ExportCSV:     "text/csv; charset=utf-8",
+	models.ExportJSONL:   "application/x-ndjson",
+	models.ExportParquet: "application/vnd.apache.parquet",
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"payment/internal/domain/models"
+	"time"
+)
+
+type jsonlWriter struct {
+	w *bufio.Writer
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	return &jsonlWriter{w: bufio.NewWriter(w)}
+}
+
+// Write пишет платёж одним объектом с ключами в порядке колонок.
+func (j *jsonlWriter) Write(p models.Payment) error {
+	j.w.WriteByte('{')
+	for i, col := range columns {
+		if i > 0 {
+			j.w.WriteByte(',')
+		}
+		key, _ := json.Marshal(col.name)
+		j.w.Write(key)
+		j.w.WriteByte(':')
+
+		var value any
+		switch col.kind {
+		case kindNumber, kindDecimal:
+			value = col.number(p)
+		case kindTime:
+			value = col.time(p).UTC().Format(time.RFC3339Nano)
+		case kindJSON:
+			value = col.json(p)
+		default:
+			value = col.text(p)
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		j.w.Write(b)
+	}
+	j.w.WriteByte('}')
+	return j.w.WriteByte('\n')
+}
+
+func (j *jsonlWriter) Close() error {
+	return j.w.Flush()
+}
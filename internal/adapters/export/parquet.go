@@ -0,0 +1,238 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"payment/internal/domain/models"
+)
+
+// Parquet пишется без сжатия и словарей: одна страница PLAIN на колонку в каждой группе строк,
+// все колонки обязательные (REQUIRED). В памяти держится одна группа строк.
+// Суммы пишутся как DECIMAL(18, scale) в INT64, чтобы не терять точность NUMERIC из Postgres.
+const parquetRowGroupSize = 10_000
+
+// Значения перечислений из parquet.thrift
+const (
+	parquetDouble    = 5
+	parquetInt64     = 2
+	parquetByteArray = 6
+
+	parquetRequired = 0
+
+	parquetUTF8            = 0
+	parquetDecimal         = 5
+	parquetTimestampMicros = 10
+
+	parquetDecimalPrecision = 18 // Наибольшая точность DECIMAL в INT64
+
+	parquetPlain        = 0
+	parquetRLE          = 3
+	parquetDataPage     = 0
+	parquetUncompressed = 0
+)
+
+var parquetMagic = []byte("PAR1")
+
+type parquetChunk struct {
+	offset int64
+	size   int64
+}
+
+type parquetWriter struct {
+	w      io.Writer
+	offset int64
+	err    error
+
+	rows      int              // Строк в текущей группе
+	values    []bytes.Buffer   // Значения колонок текущей группы (PLAIN)
+	rowGroups [][]parquetChunk // Записанные группы: колонки и их смещения
+	groupRows []int            // Число строк в записанных группах
+	total     int64            // Всего строк
+	scratch   [8]byte
+}
+
+func newParquetWriter(w io.Writer) *parquetWriter {
+	return &parquetWriter{w: w, values: make([]bytes.Buffer, len(columns))}
+}
+
+func (p *parquetWriter) Write(payment models.Payment) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	for i, col := range columns {
+		buf := &p.values[i]
+		switch col.kind {
+		case kindNumber:
+			binary.LittleEndian.PutUint64(p.scratch[:], math.Float64bits(col.number(payment)))
+			buf.Write(p.scratch[:])
+		case kindDecimal:
+			binary.LittleEndian.PutUint64(p.scratch[:], uint64(col.unscaled(payment)))
+			buf.Write(p.scratch[:])
+		case kindTime:
+			binary.LittleEndian.PutUint64(p.scratch[:], uint64(col.time(payment).UnixMicro()))
+			buf.Write(p.scratch[:])
+		default:
+			value, err := col.format(payment)
+			if err != nil {
+				return err
+			}
+			binary.LittleEndian.PutUint32(p.scratch[:4], uint32(len(value)))
+			buf.Write(p.scratch[:4])
+			buf.WriteString(value)
+		}
+	}
+
+	p.rows++
+	if p.rows >= parquetRowGroupSize {
+		return p.flush()
+	}
+	return nil
+}
+
+// Close записывает последнюю группу строк и метаданные файла.
+func (p *parquetWriter) Close() error {
+	if err := p.flush(); err != nil {
+		return err
+	}
+	if err := p.begin(); err != nil {
+		return err
+	}
+
+	meta := p.fileMetadata()
+	p.write(meta)
+	binary.LittleEndian.PutUint32(p.scratch[:4], uint32(len(meta)))
+	p.write(p.scratch[:4])
+	p.write(parquetMagic)
+	return p.err
+}
+
+// begin пишет сигнатуру в начало файла.
+func (p *parquetWriter) begin() error {
+	if p.offset == 0 {
+		p.write(parquetMagic)
+	}
+	return p.err
+}
+
+func (p *parquetWriter) write(b []byte) {
+	if p.err != nil {
+		return
+	}
+	n, err := p.w.Write(b)
+	p.offset += int64(n)
+	if err == nil && n < len(b) {
+		err = io.ErrShortWrite
+	}
+	p.err = err
+}
+
+// flush записывает накопленную группу строк: по странице данных на колонку.
+func (p *parquetWriter) flush() error {
+	if p.rows == 0 {
+		return p.err
+	}
+	if err := p.begin(); err != nil {
+		return err
+	}
+
+	chunks := make([]parquetChunk, len(columns))
+	for i := range columns {
+		values := p.values[i].Bytes()
+
+		header := newThriftWriter()
+		header.I32(1, parquetDataPage)
+		header.I32(2, int32(len(values)))
+		header.I32(3, int32(len(values)))
+		header.BeginStruct(5)
+		header.I32(1, int32(p.rows))
+		header.I32(2, parquetPlain)
+		header.I32(3, parquetRLE)
+		header.I32(4, parquetRLE)
+		header.EndStruct()
+		header.EndStruct()
+
+		chunks[i].offset = p.offset
+		p.write(header.Bytes())
+		p.write(values)
+		chunks[i].size = p.offset - chunks[i].offset
+		p.values[i].Reset()
+	}
+	if p.err != nil {
+		return p.err
+	}
+
+	p.rowGroups = append(p.rowGroups, chunks)
+	p.groupRows = append(p.groupRows, p.rows)
+	p.total += int64(p.rows)
+	p.rows = 0
+	return nil
+}
+
+func (p *parquetWriter) fileMetadata() []byte {
+	t := newThriftWriter()
+	t.I32(1, 1) // version
+
+	// Схема: корневой элемент и колонки
+	t.StructList(2, len(columns)+1, func(i int) {
+		if i == 0 {
+			t.String(4, "payment")
+			t.I32(5, int32(len(columns)))
+			return
+		}
+		col := columns[i-1]
+		physical, converted := parquetTypes(col.kind)
+		t.I32(1, physical)
+		t.I32(3, parquetRequired)
+		t.String(4, col.name)
+		if converted >= 0 {
+			t.I32(6, converted)
+		}
+		if col.kind == kindDecimal {
+			t.I32(7, int32(col.scale))
+			t.I32(8, parquetDecimalPrecision)
+		}
+	})
+	t.I64(3, p.total)
+
+	t.StructList(4, len(p.rowGroups), func(g int) {
+		chunks := p.rowGroups[g]
+		var groupSize int64
+		t.StructList(1, len(chunks), func(i int) {
+			physical, _ := parquetTypes(columns[i].kind)
+			t.I64(2, chunks[i].offset) // file_offset
+			t.BeginStruct(3)           // meta_data
+			t.I32(1, physical)
+			t.I32List(2, []int32{parquetPlain, parquetRLE})
+			t.StringList(3, []string{columns[i].name})
+			t.I32(4, parquetUncompressed)
+			t.I64(5, int64(p.groupRows[g]))
+			t.I64(6, chunks[i].size)
+			t.I64(7, chunks[i].size)
+			t.I64(9, chunks[i].offset) // data_page_offset
+			t.EndStruct()
+			groupSize += chunks[i].size
+		})
+		t.I64(2, groupSize)
+		t.I64(3, int64(p.groupRows[g]))
+	})
+	t.String(6, "payment export")
+	t.EndStruct()
+	return t.Bytes()
+}
+
+// parquetTypes — физический тип колонки и логический (converted) тип; -1 — без логического типа.
+func parquetTypes(kind columnKind) (physical, converted int32) {
+	switch kind {
+	case kindNumber:
+		return parquetDouble, -1
+	case kindDecimal:
+		return parquetInt64, parquetDecimal
+	case kindTime:
+		return parquetInt64, parquetTimestampMicros
+	default:
+		return parquetByteArray, parquetUTF8
+	}
+}
@@ -0,0 +1,414 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"payment/internal/domain/models"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParquetRoundTrip(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 30, 15, 123456000, time.UTC)
+	payments := []models.Payment{
+		{
+			ID: "bank-1", OrderID: "order-1", UserID: "u1", Merchant: "merchant", Broker: "BEREKE",
+			Operation: models.URLpayment, Status: models.OrderDeposited, CreatedAt: created,
+			Amount: 0.29, CapturedAmount: 0.29, Currency: "KZT",
+			PresentmentAmount: 12.3456, PresentmentCurrency: "USD", FXRate: 0.0021, FXSource: "file:rates.csv",
+			Metadata: map[string]any{"cart_id": "42"},
+		},
+		{
+			ID: "bank-2", OrderID: "заказ-2", UserID: "u2", Operation: models.BindingPayment, Status: models.OrderRefunded,
+			CreatedAt: created.Add(time.Hour), Amount: 1234567.89, CapturedAmount: 1234567.89,
+			RefundedAmount: 1234567.89, FeeAmount: 19.99, Currency: "KZT", Description: "Чай",
+		},
+	}
+	// Больше одной группы строк
+	for i := range parquetRowGroupSize {
+		payments = append(payments, models.Payment{ID: fmt.Sprintf("bulk-%d", i), Amount: 0.1, Currency: "KZT", CreatedAt: created})
+	}
+
+	var out bytes.Buffer
+	w := newParquetWriter(&out)
+	for _, p := range payments {
+		if err := w.Write(p); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	file := readParquet(t, out.Bytes())
+
+	if file.rows != int64(len(payments)) || file.rowGroups != 2 {
+		t.Fatalf("rows = %d in %d groups, want %d in 2", file.rows, file.rowGroups, len(payments))
+	}
+
+	// Схема: суммы — DECIMAL в INT64, курс — DOUBLE, время — TIMESTAMP_MICROS
+	wantSchema := map[string]parquetField{
+		"payment_id":         {physical: specByteArray, converted: specUTF8},
+		"created_at":         {physical: specInt64, converted: specTimestampMicros},
+		"amount":             {physical: specInt64, converted: specDecimal, scale: 2, precision: 18},
+		"net_amount":         {physical: specInt64, converted: specDecimal, scale: 2, precision: 18},
+		"presentment_amount": {physical: specInt64, converted: specDecimal, scale: 4, precision: 18},
+		"fx_rate":            {physical: specDouble, converted: -1},
+		"metadata":           {physical: specByteArray, converted: specUTF8},
+	}
+	for name, want := range wantSchema {
+		if got := file.schema[name]; got != want {
+			t.Errorf("schema %s = %+v, want %+v", name, got, want)
+		}
+	}
+	if !reflect.DeepEqual(file.names, specColumns) {
+		t.Fatalf("schema columns = %v, want %v", file.names, specColumns)
+	}
+
+	// Суммы без потери точности: целые числа единиц последнего знака
+	first, second := file.values[0], file.values[1]
+	for _, tt := range []struct {
+		row  map[string]any
+		name string
+		want any
+	}{
+		{first, "amount", int64(29)},
+		{first, "captured_amount", int64(29)},
+		{first, "net_amount", int64(29)},
+		{first, "presentment_amount", int64(123456)},
+		{first, "fx_rate", 0.0021},
+		{first, "created_at", created.UnixMicro()},
+		{first, "metadata", `{"cart_id":"42"}`},
+		{second, "order_id", "заказ-2"},
+		{second, "amount", int64(123456789)},
+		{second, "refunded_amount", int64(123456789)},
+		{second, "fee_amount", int64(1999)},
+		{second, "presentment_amount", int64(0)},
+		{second, "description", "Чай"},
+		{second, "metadata", ""},
+	} {
+		if got := tt.row[tt.name]; got != tt.want {
+			t.Errorf("%s of %s = %#v, want %#v", tt.name, tt.row["payment_id"], got, tt.want)
+		}
+	}
+
+	// Каждое значение каждой колонки совпадает с исходным платежом
+	for i, p := range payments {
+		for _, col := range columns {
+			var want any
+			switch col.kind {
+			case kindDecimal:
+				want = col.unscaled(p)
+			case kindNumber:
+				want = col.number(p)
+			case kindTime:
+				want = col.time(p).UnixMicro()
+			default:
+				s, err := col.format(p)
+				if err != nil {
+					t.Fatalf("format %s: %v", col.name, err)
+				}
+				want = s
+			}
+			if got := file.values[i][col.name]; got != want {
+				t.Fatalf("row %d %s = %#v, want %#v", i, col.name, got, want)
+			}
+		}
+	}
+}
+
+func TestParquetEmpty(t *testing.T) {
+	var out bytes.Buffer
+	if err := newParquetWriter(&out).Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	file := readParquet(t, out.Bytes())
+	if file.rows != 0 || file.rowGroups != 0 || !reflect.DeepEqual(file.names, specColumns) {
+		t.Fatalf("file = %d rows in %d groups with %d columns, want empty file with schema", file.rows, file.rowGroups, len(file.schema))
+	}
+}
+
+// Значения из спецификаций, а не из констант писателя: parquet.thrift (apache/parquet-format)
+// и Thrift Compact Protocol. Библиотеки чтения Parquet в зависимостях нет, поэтому файл разбирается
+// независимым декодером по спецификации.
+const (
+	specMagic = "PAR1"
+
+	// parquet.thrift: Type
+	specInt64     = 2
+	specDouble    = 5
+	specByteArray = 6
+
+	// parquet.thrift: ConvertedType
+	specUTF8            = 0
+	specDecimal         = 5
+	specTimestampMicros = 10
+
+	specRequired      = 0 // FieldRepetitionType
+	specPlain         = 0 // Encoding
+	specRLE           = 3 // Encoding
+	specUncompressed  = 0 // CompressionCodec
+	specDataPage      = 0 // PageType
+	specFormatVersion = 1 // FileMetaData.version
+
+	// Thrift Compact Protocol: типы полей
+	compactTrue   = 1
+	compactFalse  = 2
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+// specColumns — колонки выгрузки в порядке схемы.
+var specColumns = []string{
+	"payment_id", "order_id", "user_id", "merchant", "broker", "operation", "status", "created_at",
+	"amount", "currency", "captured_amount", "released_amount", "refunded_amount", "fee_amount", "net_amount",
+	"presentment_amount", "presentment_currency", "fx_rate", "fx_rate_source", "risk_decision", "description", "metadata",
+}
+
+// parquetField — тип колонки из схемы файла.
+type parquetField struct {
+	physical, converted int64
+	scale, precision    int64
+}
+
+type parquetFile struct {
+	names     []string // Колонки в порядке схемы
+	schema    map[string]parquetField
+	rows      int64
+	rowGroups int
+	values    []map[string]any // Строки по именам колонок
+}
+
+// readParquet читает файл по спецификации: метаданные из подвала и страницы PLAIN всех групп строк.
+// Проверяет сигнатуры, обязательные поля метаданных и их согласованность со страницами.
+func readParquet(t *testing.T, data []byte) parquetFile {
+	t.Helper()
+
+	if len(data) < 12 || string(data[:4]) != specMagic || string(data[len(data)-4:]) != specMagic {
+		t.Fatal("file has no PAR1 magic")
+	}
+	footer := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	metaStart := len(data) - 8 - footer
+	if metaStart < 4 {
+		t.Fatalf("footer length %d exceeds file size %d", footer, len(data))
+	}
+	r := &thriftReader{t: t, data: data, pos: metaStart}
+	meta := r.Struct()
+	if r.pos != len(data)-8 {
+		t.Fatalf("file metadata ends at %d, footer says %d", r.pos, len(data)-8)
+	}
+	if meta[1] != int64(specFormatVersion) {
+		t.Fatalf("format version = %v, want %d", meta[1], specFormatVersion)
+	}
+	if _, ok := meta[6].([]byte); !ok {
+		t.Fatal("file metadata has no created_by")
+	}
+
+	file := parquetFile{schema: make(map[string]parquetField), rows: meta[3].(int64)}
+	elements := meta[2].([]any)
+	if root := elements[0].(map[int16]any); root[5].(int64) != int64(len(elements)-1) {
+		t.Fatalf("root num_children = %d, want %d", root[5], len(elements)-1)
+	}
+	types := make([]int64, 0, len(elements)-1)
+	for _, e := range elements[1:] {
+		el := e.(map[int16]any)
+		name := string(el[4].([]byte))
+		if el[3] != int64(specRequired) {
+			t.Fatalf("column %s repetition = %v, want REQUIRED", name, el[3])
+		}
+		field := parquetField{physical: el[1].(int64), converted: -1}
+		if v, ok := el[6]; ok {
+			field.converted = v.(int64)
+		}
+		if v, ok := el[7]; ok {
+			field.scale = v.(int64)
+		}
+		if v, ok := el[8]; ok {
+			field.precision = v.(int64)
+		}
+		file.schema[name] = field
+		file.names = append(file.names, name)
+		types = append(types, field.physical)
+	}
+	names := file.names
+
+	groups, _ := meta[4].([]any)
+	file.rowGroups = len(groups)
+	var totalRows int64
+	for _, g := range groups {
+		group := g.(map[int16]any)
+		numRows := int(group[3].(int64))
+		totalRows += int64(numRows)
+		rows := make([]map[string]any, numRows)
+		for i := range rows {
+			rows[i] = make(map[string]any, len(names))
+		}
+
+		chunks := group[1].([]any)
+		if len(chunks) != len(names) {
+			t.Fatalf("row group has %d column chunks, want %d", len(chunks), len(names))
+		}
+		var groupSize int64
+		for c, chunk := range chunks {
+			cc := chunk.(map[int16]any)
+			cm := cc[3].(map[int16]any)
+			if got := string(cm[3].([]any)[0].([]byte)); got != names[c] {
+				t.Fatalf("column chunk %d path = %s, want %s", c, got, names[c])
+			}
+			if cm[1].(int64) != types[c] || int(cm[5].(int64)) != numRows {
+				t.Fatalf("column chunk %s type %d with %d values, want %d with %d", names[c], cm[1], cm[5], types[c], numRows)
+			}
+			if cm[4] != int64(specUncompressed) || cm[6] != cm[7] {
+				t.Fatalf("column chunk %s codec %v, sizes %v/%v; want uncompressed", names[c], cm[4], cm[6], cm[7])
+			}
+			if encodings := cm[2].([]any); !slices.Contains(encodings, any(int64(specPlain))) {
+				t.Fatalf("column chunk %s encodings = %v, want PLAIN", names[c], encodings)
+			}
+			if cc[2] != cm[9] {
+				t.Fatalf("column chunk %s file_offset %v, data page at %v", names[c], cc[2], cm[9])
+			}
+			groupSize += cm[6].(int64)
+
+			start := int(cm[9].(int64))
+			if start < 4 || start >= metaStart {
+				t.Fatalf("data page of %s at %d is outside the data section", names[c], start)
+			}
+			r := &thriftReader{t: t, data: data, pos: start}
+			header := r.Struct()
+			size := int(header[3].(int64))
+			if header[1] != int64(specDataPage) || header[2] != header[3] {
+				t.Fatalf("page of %s type %v, sizes %v/%v; want uncompressed DATA_PAGE", names[c], header[1], header[2], header[3])
+			}
+			page := header[5].(map[int16]any)
+			if int(page[1].(int64)) != numRows || page[2] != int64(specPlain) {
+				t.Fatalf("page of %s has %d values in encoding %v, want %d in PLAIN", names[c], page[1], page[2], numRows)
+			}
+			if page[3] != int64(specRLE) || page[4] != int64(specRLE) {
+				t.Fatalf("page of %s level encodings = %v/%v, want RLE", names[c], page[3], page[4])
+			}
+			if end := r.pos + size; end-start != int(cm[6].(int64)) {
+				t.Fatalf("column chunk %s size = %d, page ends at %d", names[c], cm[6], end)
+			}
+
+			// Колонки REQUIRED: уровней определения и повторения нет, страница — только значения PLAIN
+			values := data[r.pos : r.pos+size]
+			for i := range rows {
+				switch types[c] {
+				case specInt64:
+					rows[i][names[c]] = int64(binary.LittleEndian.Uint64(values))
+					values = values[8:]
+				case specDouble:
+					rows[i][names[c]] = math.Float64frombits(binary.LittleEndian.Uint64(values))
+					values = values[8:]
+				case specByteArray:
+					n := binary.LittleEndian.Uint32(values)
+					rows[i][names[c]] = string(values[4 : 4+n])
+					values = values[4+n:]
+				}
+			}
+			if len(values) != 0 {
+				t.Fatalf("page of %s has %d trailing bytes", names[c], len(values))
+			}
+		}
+		if group[2] != groupSize {
+			t.Fatalf("row group total_byte_size = %v, want %d", group[2], groupSize)
+		}
+		file.values = append(file.values, rows...)
+	}
+	if totalRows != file.rows {
+		t.Fatalf("num_rows = %d, row groups hold %d", file.rows, totalRows)
+	}
+	return file
+}
+
+// thriftReader — декодер Thrift Compact Protocol для проверки метаданных. Структуры читаются
+// в map по ID полей: целые — int64, строки — []byte, списки — []any.
+type thriftReader struct {
+	t    *testing.T
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) byte() byte {
+	if r.pos >= len(r.data) {
+		r.t.Fatalf("thrift: unexpected end of data at %d", r.pos)
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) varint() int64 {
+	v, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		r.t.Fatalf("thrift: bad varint at %d", r.pos)
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		r.t.Fatalf("thrift: bad uvarint at %d", r.pos)
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) Struct() map[int16]any {
+	fields := make(map[int16]any)
+	var last int16
+	for {
+		b := r.byte()
+		if b == 0 {
+			return fields
+		}
+		id := last + int16(b>>4)
+		if b>>4 == 0 {
+			id = int16(r.varint())
+		}
+		last = id
+		fields[id] = r.value(b & 0x0f)
+	}
+}
+
+func (r *thriftReader) value(typ byte) any {
+	switch typ {
+	case compactTrue:
+		return true
+	case compactFalse:
+		return false
+	case compactI32, compactI64:
+		return r.varint()
+	case compactBinary:
+		n := int(r.uvarint())
+		v := r.data[r.pos : r.pos+n]
+		r.pos += n
+		return v
+	case compactList:
+		header := r.byte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]any, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0f)
+		}
+		return list
+	case compactStruct:
+		return r.Struct()
+	default:
+		r.t.Fatalf("thrift: unsupported type %d at %d", typ, r.pos)
+		return nil
+	}
+}
@@ -0,0 +1,129 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Типы полей Thrift Compact Protocol, которым кодируются метаданные Parquet
+const (
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter — минимальный кодировщик Thrift Compact Protocol: только типы, нужные для
+// заголовков страниц и метаданных файла Parquet.
+type thriftWriter struct {
+	buf  bytes.Buffer
+	last []int16 // ID последнего поля в каждой открытой структуре
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{last: []int16{0}}
+}
+
+func (t *thriftWriter) Bytes() []byte {
+	return t.buf.Bytes()
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	last := &t.last[len(t.last)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.varint(int64(id))
+	}
+	*last = id
+}
+
+func (t *thriftWriter) varint(v int64) {
+	t.buf.Write(binary.AppendVarint(nil, v))
+}
+
+func (t *thriftWriter) uvarint(v uint64) {
+	t.buf.Write(binary.AppendUvarint(nil, v))
+}
+
+func (t *thriftWriter) I32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.varint(int64(v))
+}
+
+func (t *thriftWriter) I64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.varint(v)
+}
+
+func (t *thriftWriter) Bool(id int16, v bool) {
+	if v {
+		t.field(id, thriftTrue)
+	} else {
+		t.field(id, thriftFalse)
+	}
+}
+
+func (t *thriftWriter) String(id int16, v string) {
+	t.field(id, thriftBinary)
+	t.rawString(v)
+}
+
+func (t *thriftWriter) rawString(v string) {
+	t.uvarint(uint64(len(v)))
+	t.buf.WriteString(v)
+}
+
+// BeginStruct открывает вложенную структуру в поле id (id 0 — элемент списка).
+func (t *thriftWriter) BeginStruct(id int16) {
+	if id != 0 {
+		t.field(id, thriftStruct)
+	}
+	t.last = append(t.last, 0)
+}
+
+// EndStruct закрывает структуру, в том числе корневую.
+func (t *thriftWriter) EndStruct() {
+	t.buf.WriteByte(0)
+	if len(t.last) > 1 {
+		t.last = t.last[:len(t.last)-1]
+	}
+}
+
+func (t *thriftWriter) list(id int16, elem byte, size int) {
+	t.field(id, thriftList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elem)
+	} else {
+		t.buf.WriteByte(0xf0 | elem)
+		t.uvarint(uint64(size))
+	}
+}
+
+func (t *thriftWriter) I32List(id int16, values []int32) {
+	t.list(id, thriftI32, len(values))
+	for _, v := range values {
+		t.varint(int64(v))
+	}
+}
+
+func (t *thriftWriter) StringList(id int16, values []string) {
+	t.list(id, thriftBinary, len(values))
+	for _, v := range values {
+		t.rawString(v)
+	}
+}
+
+// StructList пишет список структур; write заполняет i-й элемент между BeginStruct(0) и EndStruct.
+func (t *thriftWriter) StructList(id int16, size int, write func(i int)) {
+	t.list(id, thriftStruct, size)
+	for i := 0; i < size; i++ {
+		t.BeginStruct(0)
+		write(i)
+		t.EndStruct()
+	}
+}
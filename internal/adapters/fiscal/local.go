@@ -0,0 +1,67 @@
+// Package fiscal — фискальные провайдеры (операторы фискальных данных).
+package fiscal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/pkg/logger"
+	"strings"
+)
+
+const LocalProviderName = "local"
+
+// LocalProvider — заглушка оператора фискальных данных для разработки и тестовых стендов.
+// Фискальный признак вычисляется из ID чека, поэтому повторная регистрация возвращает тот же признак.
+// Вместо отправки покупателю чек сохраняется в каталог dir (если задан) и пишется в лог.
+type LocalProvider struct {
+	dir string
+	log logger.Logger
+}
+
+func NewLocalProvider(dir string, log logger.Logger) (*LocalProvider, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, err
+		}
+	}
+	return &LocalProvider{dir: dir, log: log}, nil
+}
+
+func (p *LocalProvider) Name() string {
+	return LocalProviderName
+}
+
+func (p *LocalProvider) Register(_ context.Context, receipt models.Receipt) (models.Fiscalization, error) {
+	sum := sha256.Sum256([]byte(receipt.ID))
+	return models.Fiscalization{
+		Provider: LocalProviderName,
+		Number:   strings.ToUpper(hex.EncodeToString(sum[:8])),
+	}, nil
+}
+
+func (p *LocalProvider) Send(ctx context.Context, receipt models.Receipt, document models.ReceiptDocument) error {
+	path := ""
+	if p.dir != "" {
+		name := fmt.Sprintf("%s-%s.%s", receipt.PaymentID, strings.ToLower(string(receipt.Kind)), strings.ToLower(string(document.Format)))
+		path = filepath.Join(p.dir, filepath.Base(name))
+		if err := os.WriteFile(path, document.Content, 0o640); err != nil {
+			return err
+		}
+	}
+
+	// Контакты покупателя не пишутся в лог
+	p.log.Info(ctx, action.SendReceipt, "receipt is delivered by local fiscal provider",
+		"payment_id", receipt.PaymentID,
+		"kind", receipt.Kind,
+		"email", receipt.Customer.Email != "",
+		"phone", receipt.Customer.Phone != "",
+		"path", path,
+	)
+	return nil
+}
@@ -0,0 +1,165 @@
+// Package fx — поставщик курсов валют из CSV файла.
+package fx
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"payment/internal/domain/models"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrInvalidRates = errors.New("invalid fx rates file")
+
+// Колонки файла курсов; as_of необязательна
+const (
+	columnBase  = "base"
+	columnQuote = "quote"
+	columnRate  = "rate"
+	columnAsOf  = "as_of"
+)
+
+// FileProvider отдаёт курсы из CSV файла с заголовком base,quote,rate[,as_of].
+// Файл перечитывается, когда меняется время его изменения, поэтому курсы обновляются без перезапуска.
+type FileProvider struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	rates   map[string]models.FXRate
+}
+
+func NewFileProvider(path string) (*FileProvider, error) {
+	p := &FileProvider{path: path}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Rate — курс пары валют из файла.
+func (p *FileProvider) Rate(_ context.Context, base, quote string) (models.FXRate, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.reload(); err != nil {
+		return models.FXRate{}, err
+	}
+
+	rate, ok := p.rates[base+"/"+quote]
+	if !ok {
+		return models.FXRate{}, models.ErrFXRateNotFound
+	}
+	return rate, nil
+}
+
+// reload перечитывает файл, если он изменился. Вызывается под мьютексом.
+func (p *FileProvider) reload() error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRates, err)
+	}
+	if p.rates != nil && info.ModTime().Equal(p.modTime) {
+		return nil
+	}
+
+	f, err := os.Open(p.path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRates, err)
+	}
+	defer f.Close()
+
+	list, err := ParseRates(f, "file:"+filepath.Base(p.path), info.ModTime())
+	if err != nil {
+		return err
+	}
+
+	// Из нескольких курсов пары берётся самый свежий
+	rates := make(map[string]models.FXRate, len(list))
+	for _, r := range list {
+		key := r.Base + "/" + r.Quote
+		if prev, ok := rates[key]; !ok || r.AsOf.After(prev.AsOf) {
+			rates[key] = r
+		}
+	}
+
+	p.rates, p.modTime = rates, info.ModTime()
+	return nil
+}
+
+// ParseRates читает курсы из CSV с заголовком base,quote,rate[,as_of].
+// Без колонки as_of курс считается действующим на момент asOf.
+func ParseRates(r io.Reader, source string, asOf time.Time) ([]models.FXRate, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrInvalidRates, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{columnBase, columnQuote, columnRate} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: column %q is not found", ErrInvalidRates, name)
+		}
+	}
+	asOfIdx, hasAsOf := columns[columnAsOf]
+
+	var rates []models.FXRate
+	for lineNo := 2; ; lineNo++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidRates, lineNo, err)
+		}
+
+		field := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		rate := models.FXRate{
+			Base:   strings.ToUpper(field(columns[columnBase])),
+			Quote:  strings.ToUpper(field(columns[columnQuote])),
+			Source: source,
+			AsOf:   asOf,
+		}
+		if rate.Base == "" && rate.Quote == "" {
+			continue
+		}
+		if len(rate.Base) != 3 || len(rate.Quote) != 3 {
+			return nil, fmt.Errorf("%w: line %d: currencies must be ISO 4217 codes", ErrInvalidRates, lineNo)
+		}
+
+		value := strings.ReplaceAll(field(columns[columnRate]), ",", ".")
+		if rate.Rate, err = strconv.ParseFloat(value, 64); err != nil || rate.Rate <= 0 {
+			return nil, fmt.Errorf("%w: line %d: rate %q must be a positive number", ErrInvalidRates, lineNo, value)
+		}
+
+		if hasAsOf && field(asOfIdx) != "" {
+			if rate.AsOf, err = time.Parse(time.RFC3339, field(asOfIdx)); err != nil {
+				return nil, fmt.Errorf("%w: line %d: as_of %q must be RFC 3339", ErrInvalidRates, lineNo, field(asOfIdx))
+			}
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
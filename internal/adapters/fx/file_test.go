@@ -0,0 +1,110 @@
+package fx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"payment/internal/domain/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRates(t *testing.T) {
+	asOf := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	input := "\ufeffBase, Quote, Rate, As_of\n" +
+		"usd,kzt,\"512,34\",2025-03-02T10:00:00Z\n" +
+		",,,\n" +
+		"EUR,KZT,560.1,\n"
+
+	rates, err := ParseRates(strings.NewReader(input), "test", asOf)
+	if err != nil {
+		t.Fatalf("ParseRates: %v", err)
+	}
+
+	want := []models.FXRate{
+		{Base: "USD", Quote: "KZT", Rate: 512.34, Source: "test", AsOf: time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC)},
+		{Base: "EUR", Quote: "KZT", Rate: 560.1, Source: "test", AsOf: asOf},
+	}
+	if len(rates) != len(want) {
+		t.Fatalf("rates = %+v, want %+v", rates, want)
+	}
+	for i := range want {
+		if rates[i] != want[i] {
+			t.Fatalf("rate %d = %+v, want %+v", i, rates[i], want[i])
+		}
+	}
+}
+
+func TestParseRatesInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"missing column", "base,quote\nUSD,KZT\n"},
+		{"not a currency", "base,quote,rate\nDOLLAR,KZT,500\n"},
+		{"zero rate", "base,quote,rate\nUSD,KZT,0\n"},
+		{"negative rate", "base,quote,rate\nUSD,KZT,-1\n"},
+		{"bad rate", "base,quote,rate\nUSD,KZT,abc\n"},
+		{"bad as_of", "base,quote,rate,as_of\nUSD,KZT,500,01.03.2025\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRates(strings.NewReader(tt.input), "test", time.Now()); !errors.Is(err, ErrInvalidRates) {
+				t.Fatalf("ParseRates error = %v, want ErrInvalidRates", err)
+			}
+		})
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.csv")
+	write := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	write("base,quote,rate,as_of\n"+
+		"USD,KZT,500,2025-03-01T00:00:00Z\n"+
+		"USD,KZT,510,2025-03-02T00:00:00Z\n"+
+		"USD,KZT,505,2025-03-01T12:00:00Z\n", start)
+
+	p, err := NewFileProvider(path)
+	if err != nil {
+		t.Fatalf("NewFileProvider: %v", err)
+	}
+
+	rate, err := p.Rate(t.Context(), "USD", "KZT")
+	if err != nil {
+		t.Fatalf("Rate: %v", err)
+	}
+	if rate.Rate != 510 || rate.Source != "file:rates.csv" {
+		t.Fatalf("rate = %+v, want the latest rate 510 from file:rates.csv", rate)
+	}
+	if _, err := p.Rate(t.Context(), "KZT", "USD"); !errors.Is(err, models.ErrFXRateNotFound) {
+		t.Fatalf("Rate(KZT/USD) error = %v, want ErrFXRateNotFound", err)
+	}
+
+	// Изменённый файл перечитывается без перезапуска
+	write("base,quote,rate\nUSD,KZT,520\n", start.Add(time.Minute))
+	if rate, err = p.Rate(t.Context(), "USD", "KZT"); err != nil || rate.Rate != 520 {
+		t.Fatalf("Rate after update = %+v, %v; want 520", rate, err)
+	}
+	if !rate.AsOf.Equal(start.Add(time.Minute)) {
+		t.Fatalf("as_of = %v, want file modification time", rate.AsOf)
+	}
+
+	// Ошибка в изменённом файле возвращается, а не скрывается старыми курсами
+	write("base,quote,rate\nUSD,KZT,oops\n", start.Add(2*time.Minute))
+	if _, err := p.Rate(t.Context(), "USD", "KZT"); !errors.Is(err, ErrInvalidRates) {
+		t.Fatalf("Rate with broken file error = %v, want ErrInvalidRates", err)
+	}
+}
@@ -0,0 +1,122 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"payment/config"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Заголовок с ключом API: "Bearer <key>". REST шлюз передаёт Authorization автоматически.
+const authorizationHeader = "authorization"
+
+var ErrInvalidAPIKeys = errors.New("invalid API keys configuration")
+
+// Сервисы, доступные только по ключам операторов
+var adminServices = map[string]bool{
+	paymentv1.Admin_ServiceDesc.ServiceName:   true,
+	paymentv1.Ledger_ServiceDesc.ServiceName:  true,
+	paymentv1.Exports_ServiceDesc.ServiceName: true,
+}
+
+// principal — владелец ключа API.
+type principal struct {
+	name  string
+	admin bool
+}
+
+// Authenticator проверяет ключи API и доступ к сервисам.
+// Ключи хранятся как SHA-256, поэтому поиск не зависит от совпадения префикса ключа.
+type Authenticator struct {
+	keys        map[[sha256.Size]byte]principal
+	requireKeys bool // Сервисы мерчанта без ключа недоступны, если анонимный доступ не включён явно
+}
+
+func NewAuthenticator(cfg config.Auth) (*Authenticator, error) {
+	a := &Authenticator{keys: make(map[[sha256.Size]byte]principal)}
+
+	merchants, err := parseAPIKeys(cfg.APIKeys)
+	if err != nil {
+		return nil, fmt.Errorf("API_KEYS: %w", err)
+	}
+	admins, err := parseAPIKeys(cfg.AdminAPIKeys)
+	if err != nil {
+		return nil, fmt.Errorf("ADMIN_API_KEYS: %w", err)
+	}
+
+	for key, name := range merchants {
+		a.keys[sha256.Sum256([]byte(key))] = principal{name: name}
+	}
+	for key, name := range admins {
+		a.keys[sha256.Sum256([]byte(key))] = principal{name: name, admin: true}
+	}
+	a.requireKeys = !cfg.AllowAnonymous
+
+	return a, nil
+}
+
+// parseAPIKeys разбирает ключи вида name:key,... и возвращает имена по ключам.
+func parseAPIKeys(value string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		name, key, ok := strings.Cut(pair, ":")
+		name, key = strings.TrimSpace(name), strings.TrimSpace(key)
+		if !ok || name == "" || key == "" {
+			return nil, fmt.Errorf("%w: expected name:key, got %q", ErrInvalidAPIKeys, name)
+		}
+		if _, dup := keys[key]; dup {
+			return nil, fmt.Errorf("%w: duplicate key for %q", ErrInvalidAPIKeys, name)
+		}
+		keys[key] = name
+	}
+	return keys, nil
+}
+
+// authenticate проверяет ключ из метаданных запроса и доступ к методу fullMethod.
+// Возвращает имя владельца ключа или пустую строку для анонимного вызова сервиса мерчанта.
+func (a *Authenticator) authenticate(ctx context.Context, fullMethod string) (string, error) {
+	var key string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(authorizationHeader); len(values) > 0 {
+			scheme, token, _ := strings.Cut(values[0], " ")
+			if !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+				return "", status.Error(codes.Unauthenticated, "authorization must be a Bearer API key")
+			}
+			key = strings.TrimSpace(token)
+		}
+	}
+
+	admin := adminServices[serviceName(fullMethod)]
+	if key == "" {
+		if admin || a.requireKeys {
+			return "", status.Error(codes.Unauthenticated, "API key is required")
+		}
+		return "", nil
+	}
+
+	p, ok := a.keys[sha256.Sum256([]byte(key))]
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "API key is invalid")
+	}
+	if admin && !p.admin {
+		return "", status.Error(codes.PermissionDenied, "operator API key is required")
+	}
+	return p.name, nil
+}
+
+// serviceName — имя сервиса из полного имени метода /payment.v1.Admin/ListAuditLog.
+func serviceName(fullMethod string) string {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service
+}
@@ -0,0 +1,79 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"payment/config"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthenticate(t *testing.T) {
+	auth, err := NewAuthenticator(config.Auth{APIKeys: "shop:merchant-key", AdminAPIKeys: "ops:admin-key"})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	noKeys, err := NewAuthenticator(config.Auth{})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	open, err := NewAuthenticator(config.Auth{AllowAnonymous: true})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+
+	const (
+		paymentMethod = "/payment.v1.Payment/RefundPayment"
+		adminMethod   = "/payment.v1.Admin/ListAuditLog"
+	)
+
+	tests := []struct {
+		name   string
+		auth   *Authenticator
+		header string
+		method string
+		want   string
+		code   codes.Code
+	}{
+		{name: "merchant key", auth: auth, header: "Bearer merchant-key", method: paymentMethod, want: "shop"},
+		{name: "admin key on merchant service", auth: auth, header: "Bearer admin-key", method: paymentMethod, want: "ops"},
+		{name: "admin key", auth: auth, header: "bearer admin-key", method: adminMethod, want: "ops"},
+		{name: "merchant key on admin service", auth: auth, header: "Bearer merchant-key", method: adminMethod, code: codes.PermissionDenied},
+		{name: "unknown key", auth: auth, header: "Bearer other", method: paymentMethod, code: codes.Unauthenticated},
+		{name: "not bearer", auth: auth, header: "Basic merchant-key", method: paymentMethod, code: codes.Unauthenticated},
+		{name: "no key with merchant keys", auth: auth, method: paymentMethod, code: codes.Unauthenticated},
+		{name: "no key without merchant keys", auth: noKeys, method: paymentMethod, code: codes.Unauthenticated},
+		{name: "no key with anonymous access", auth: open, method: paymentMethod, want: ""},
+		{name: "anonymous access still checks keys", auth: open, header: "Bearer other", method: paymentMethod, code: codes.Unauthenticated},
+		{name: "no admin keys", auth: open, method: adminMethod, code: codes.Unauthenticated},
+		{name: "no admin keys with key", auth: open, header: "Bearer admin-key", method: adminMethod, code: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationHeader, tt.header))
+			}
+
+			got, err := tt.auth.authenticate(ctx, tt.method)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("authenticate() code = %v, want %v (err %v)", code, tt.code, err)
+			}
+			if got != tt.want {
+				t.Errorf("authenticate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAuthenticatorInvalidKeys(t *testing.T) {
+	for _, keys := range []string{"shop", "shop:", ":key", "a:key,b:key"} {
+		if _, err := NewAuthenticator(config.Auth{APIKeys: keys}); !errors.Is(err, ErrInvalidAPIKeys) {
+			t.Errorf("NewAuthenticator(%q) error = %v, want ErrInvalidAPIKeys", keys, err)
+		}
+	}
+}
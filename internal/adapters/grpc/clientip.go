@@ -0,0 +1,136 @@
+package grpcserver
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+var ErrInvalidTrustedProxies = errors.New("invalid trusted proxies configuration")
+
+// Заголовок с секретом REST шлюза этого процесса
+const gatewayTokenHeader = "x-gateway-token"
+
+// TrustedProxies — прокси перед сервисом, которым можно верить в заголовке X-Forwarded-For.
+// Каждый прокси дописывает в заголовок адрес, от которого получил запрос, а всё левее
+// мог заполнить сам клиент. Поэтому адрес клиента — первый справа адрес не из списка.
+type TrustedProxies struct {
+	prefixes []netip.Prefix
+	gateway  string // Секрет REST шлюза; пусто — шлюзу не доверяем
+}
+
+// ParseTrustedProxies разбирает адреса и подсети через запятую: 10.0.0.0/8,192.168.1.10.
+func ParseTrustedProxies(value string) (TrustedProxies, error) {
+	var p TrustedProxies
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		if strings.Contains(item, "/") {
+			prefix, err := netip.ParsePrefix(item)
+			if err != nil {
+				return TrustedProxies{}, fmt.Errorf("%w: %q is not a CIDR subnet", ErrInvalidTrustedProxies, item)
+			}
+			p.prefixes = append(p.prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(item)
+		if err != nil {
+			return TrustedProxies{}, fmt.Errorf("%w: %q is not an IP address", ErrInvalidTrustedProxies, item)
+		}
+		addr = addr.Unmap()
+		p.prefixes = append(p.prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return p, nil
+}
+
+// withGateway доверяет запросам REST шлюза этого процесса, которые несут секрет token.
+// Шлюз обращается к gRPC серверу через loopback, но loopback сам по себе не доверен:
+// иначе любой локальный процесс мог бы подменить адрес клиента в X-Forwarded-For.
+func (p TrustedProxies) withGateway(token string) TrustedProxies {
+	p.gateway = token
+	return p
+}
+
+// fromGateway сообщает, что запрос пришёл через REST шлюз этого процесса.
+func (p TrustedProxies) fromGateway(md metadata.MD) bool {
+	if p.gateway == "" {
+		return false
+	}
+	for _, token := range md.Get(gatewayTokenHeader) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(p.gateway)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func (p TrustedProxies) trusted(addr netip.Addr) bool {
+	for _, prefix := range p.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP — адрес клиента по адресу соединения remote и заголовкам X-Forwarded-For.
+// Заголовок учитывается, только если соединение пришло от доверенного прокси: адреса
+// проверяются справа налево, и первый недоверенный считается клиентом. Если все адреса
+// доверенные или дальше в заголовке мусор, клиентом считается последний проверенный адрес.
+func (p TrustedProxies) clientIP(forwarded []string, remote string) string {
+	return p.resolve(forwarded, remote, false)
+}
+
+// gatewayClientIP — clientIP для запроса через REST шлюз: соединение от шлюза доверено,
+// а адрес HTTP клиента, который шлюз дописал в X-Forwarded-For, проверяется по списку.
+func (p TrustedProxies) gatewayClientIP(forwarded []string, remote string) string {
+	return p.resolve(forwarded, remote, true)
+}
+
+func (p TrustedProxies) resolve(forwarded []string, remote string, viaGateway bool) string {
+	host := remote
+	if h, _, err := net.SplitHostPort(remote); err == nil {
+		host = h
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	addr = addr.Unmap()
+	if !viaGateway && !p.trusted(addr) {
+		return addr.String()
+	}
+
+	hops := strings.Split(strings.Join(forwarded, ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, ok := parseHop(hops[i])
+		if !ok {
+			break
+		}
+		addr = hop
+		if !p.trusted(addr) {
+			break
+		}
+	}
+	return addr.String()
+}
+
+// parseHop разбирает адрес из X-Forwarded-For; прокси иногда дописывают порт.
+func parseHop(value string) (netip.Addr, bool) {
+	value = strings.TrimSpace(value)
+	if addr, err := netip.ParseAddr(value); err == nil {
+		return addr.Unmap(), true
+	}
+	if addrPort, err := netip.ParseAddrPort(value); err == nil {
+		return addrPort.Addr().Unmap(), true
+	}
+	return netip.Addr{}, false
+}
@@ -0,0 +1,110 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"payment/config"
+	"payment/internal/domain/models"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8, 192.168.1.10, 2001:db8::/32")
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		forwarded []string
+		remote    string
+		want      string
+	}{
+		{"direct client", nil, "203.0.113.7:52000", "203.0.113.7"},
+		{"untrusted peer cannot spoof", []string{"1.1.1.1"}, "203.0.113.7:52000", "203.0.113.7"},
+		{"behind trusted proxy", []string{"203.0.113.7"}, "10.0.0.5:443", "203.0.113.7"},
+		{"spoofed left-most hop", []string{"1.1.1.1, 203.0.113.7"}, "10.0.0.5:443", "203.0.113.7"},
+		{"chain of trusted proxies", []string{"1.1.1.1, 203.0.113.7, 192.168.1.10"}, "10.0.0.5:443", "203.0.113.7"},
+		{"several headers", []string{"1.1.1.1", "203.0.113.7, 10.1.1.1"}, "10.0.0.5:443", "203.0.113.7"},
+		{"hop with port", []string{"203.0.113.7:1234"}, "10.0.0.5:443", "203.0.113.7"},
+		{"ipv6 hop", []string{"[2001:db9::1]:443"}, "10.0.0.5:443", "2001:db9::1"},
+		{"ipv6 trusted proxy", []string{"203.0.113.7"}, "[2001:db8::5]:443", "203.0.113.7"},
+		{"garbage hop stops the walk", []string{"203.0.113.7, unknown"}, "10.0.0.5:443", "10.0.0.5"},
+		{"all hops trusted", []string{"10.2.2.2, 10.3.3.3"}, "10.0.0.5:443", "10.2.2.2"},
+		{"trusted proxy without header", nil, "10.0.0.5:443", "10.0.0.5"},
+		{"loopback is not trusted", []string{"1.1.1.1, 203.0.113.7"}, "127.0.0.1:40000", "127.0.0.1"},
+		{"ipv4-mapped peer", []string{"1.1.1.1"}, "[::ffff:203.0.113.7]:52000", "203.0.113.7"},
+		{"remote without port", nil, "203.0.113.7", "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := proxies.clientIP(tt.forwarded, tt.remote); got != tt.want {
+				t.Fatalf("clientIP(%q, %q) = %q, want %q", tt.forwarded, tt.remote, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithCallerGatewayIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies: %v", err)
+	}
+	proxies = proxies.withGateway("secret")
+	auth, err := NewAuthenticator(config.Auth{AllowAnonymous: true})
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want string
+	}{
+		{"gateway adds HTTP client", metadata.Pairs(gatewayTokenHeader, "secret", forwardedForHeader, "1.1.1.1, 203.0.113.7"), "203.0.113.7"},
+		{"gateway behind trusted proxy", metadata.Pairs(gatewayTokenHeader, "secret", forwardedForHeader, "203.0.113.7, 10.0.0.5"), "203.0.113.7"},
+		{"local caller without token", metadata.Pairs(forwardedForHeader, "203.0.113.7"), "127.0.0.1"},
+		{"local caller with wrong token", metadata.Pairs(gatewayTokenHeader, "guess", forwardedForHeader, "203.0.113.7"), "127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 40000}})
+			ctx, err := withCaller(metadata.NewIncomingContext(ctx, tt.md), auth, proxies, "/payment.v1.Payment/GetPayment")
+			if err != nil {
+				t.Fatalf("withCaller: %v", err)
+			}
+			if got := models.CallerFromContext(ctx).IP; got != tt.want {
+				t.Fatalf("client IP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if (TrustedProxies{}).fromGateway(metadata.Pairs(gatewayTokenHeader, "")) {
+		t.Fatal("empty gateway token is trusted")
+	}
+}
+
+func TestClientIPWithoutTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies("")
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies: %v", err)
+	}
+
+	if got := proxies.clientIP([]string{"1.1.1.1"}, "10.0.0.5:443"); got != "10.0.0.5" {
+		t.Fatalf("clientIP = %q, want peer address when no proxies are trusted", got)
+	}
+}
+
+func TestParseTrustedProxiesInvalid(t *testing.T) {
+	for _, value := range []string{"10.0.0.0/33", "proxy.local", "10.0.0.1/8/8", "10.0.0"} {
+		if _, err := ParseTrustedProxies(value); !errors.Is(err, ErrInvalidTrustedProxies) {
+			t.Errorf("ParseTrustedProxies(%q) error = %v, want ErrInvalidTrustedProxies", value, err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package grpcserver
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/internal/service"
+	"payment/pkg/logger"
+	"strconv"
+)
+
+// Страница оплаты счёта. Платёж создаётся только отправкой формы (POST), поэтому
+// предпросмотр ссылки в мессенджерах и поисковые роботы не регистрируют заказы у банка.
+var invoicePage = template.Must(template.New("invoice").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Оплата счёта</title>
+</head>
+<body>
+<main>
+<h1>Оплата счёта</h1>
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+<p><strong>{{.Amount}} {{.Currency}}</strong></p>
+<form method="post">
+<button type="submit">Оплатить</button>
+</form>
+</main>
+</body>
+</html>
+`))
+
+type invoicePageData struct {
+	Description string
+	Amount      string
+	Currency    string
+}
+
+// newInvoiceLinks — обработчик публичных ссылок на оплату счетов. Обслуживается отдельным
+// листенером: покупателю доступны только эти маршруты, REST шлюз мерчанта остаётся внутренним.
+// GET показывает страницу счёта, POST создаёт платёж и перенаправляет на платёжную форму банка.
+func newInvoiceLinks(invoices ports.InvoiceService, proxies TrustedProxies, log logger.Logger) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /i/{code}", func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		code := r.PathValue("code")
+
+		invoice, err := invoices.InvoiceByCode(ctx, code)
+		if err != nil {
+			log.Warn(ctx, action.OpenInvoice, "failed to show invoice page", "code", code, "error", err.Error())
+			msg, status := invoiceLinkError(err)
+			http.Error(w, msg, status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = invoicePage.Execute(w, invoicePageData{
+			Description: invoice.Description,
+			Amount:      strconv.FormatFloat(invoice.Amount, 'f', 2, 64),
+			Currency:    invoice.Currency,
+		})
+	})
+
+	mux.HandleFunc("POST /i/{code}", func(w http.ResponseWriter, r *http.Request) {
+		code := r.PathValue("code")
+		ctx := models.WithCaller(r.Context(), models.Caller{
+			Actor:  "public:" + r.RemoteAddr,
+			Method: "POST /i/{code}",
+			IP:     proxies.clientIP(r.Header.Values("X-Forwarded-For"), r.RemoteAddr),
+		})
+
+		paymentURL, err := invoices.OpenInvoice(ctx, code)
+		if err != nil {
+			log.Warn(ctx, action.OpenInvoice, "failed to open invoice link", "code", code, "error", err.Error())
+			msg, status := invoiceLinkError(err)
+			http.Error(w, msg, status)
+			return
+		}
+
+		http.Redirect(w, r, paymentURL, http.StatusSeeOther)
+	})
+
+	return mux
+}
+
+// invoiceLinkError возвращает текст и HTTP код для покупателя, не раскрывая внутренних ошибок.
+func invoiceLinkError(err error) (string, int) {
+	switch {
+	case errors.Is(err, repo.ErrInvoiceNotFound):
+		return "invoice is not found", http.StatusNotFound
+	case errors.Is(err, service.ErrInvoicePaid):
+		return "invoice is already paid", http.StatusGone
+	case errors.Is(err, service.ErrInvoiceExpired):
+		return "invoice is expired", http.StatusGone
+	case errors.Is(err, repo.ErrOrderIDConflict):
+		return "payment is already being created, please try again", http.StatusConflict
+	case errors.Is(err, service.ErrPaymentInReview):
+		return "payment is under review, please try again later", http.StatusAccepted
+	case errors.Is(err, service.ErrPaymentDenied):
+		return "payment is declined", http.StatusForbidden
+	case errors.Is(err, models.ErrLimitExceeded):
+		return "payment limit exceeded", http.StatusTooManyRequests
+	case errors.Is(err, models.ErrBrokerUnavailable):
+		return "payment provider is unavailable", http.StatusBadGateway
+	default:
+		return "failed to open invoice", http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,163 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/internal/service"
+	"payment/pkg/logger"
+	"strings"
+	"testing"
+)
+
+// fakeInvoices — счета по коду; OpenInvoice считает созданные платежи.
+type fakeInvoices struct {
+	ports.InvoiceService
+
+	invoices map[string]models.Invoice
+	opened   int
+}
+
+func (f *fakeInvoices) InvoiceByCode(ctx context.Context, code string) (models.Invoice, error) {
+	invoice, ok := f.invoices[code]
+	if !ok {
+		return models.Invoice{}, service.ErrInvoicePaid
+	}
+	return invoice, nil
+}
+
+func (f *fakeInvoices) OpenInvoice(ctx context.Context, code string) (string, error) {
+	if _, ok := f.invoices[code]; !ok {
+		return "", service.ErrInvoicePaid
+	}
+	f.opened++
+	return "https://bank.example.com/form/" + code, nil
+}
+
+func newInvoiceGateway(t *testing.T) (*fakeInvoices, *httptest.Server) {
+	invoices := &fakeInvoices{invoices: map[string]models.Invoice{
+		"abc": {Code: "abc", Amount: 1500.5, Currency: "KZT", Description: "<b>Заказ 42</b>"},
+	}}
+
+	srv := httptest.NewServer(newInvoiceLinks(invoices, TrustedProxies{}, logger.NewWithWriter("error", io.Discard)))
+	t.Cleanup(srv.Close)
+	return invoices, srv
+}
+
+func TestInvoiceLinkGetShowsPageWithoutPayment(t *testing.T) {
+	invoices, srv := newInvoiceGateway(t)
+
+	res, err := http.Get(srv.URL + "/i/abc")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode != http.StatusOK || !strings.HasPrefix(res.Header.Get("Content-Type"), "text/html") {
+		t.Fatalf("GET = %d %s, want 200 HTML page", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(string(body), "1500.50 KZT") || !strings.Contains(string(body), `method="post"`) {
+		t.Fatalf("page does not show the amount and payment form:\n%s", body)
+	}
+	if strings.Contains(string(body), "<b>") {
+		t.Fatal("invoice description is not escaped")
+	}
+	if invoices.opened != 0 {
+		t.Fatalf("GET created %d payments, want 0", invoices.opened)
+	}
+}
+
+func TestInvoiceLinkPostRedirectsToPaymentForm(t *testing.T) {
+	invoices, srv := newInvoiceGateway(t)
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	res, err := client.Post(srv.URL+"/i/abc", "application/x-www-form-urlencoded", nil)
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusSeeOther || res.Header.Get("Location") != "https://bank.example.com/form/abc" {
+		t.Fatalf("POST = %d %s, want 303 to the payment form", res.StatusCode, res.Header.Get("Location"))
+	}
+	if invoices.opened != 1 {
+		t.Fatalf("POST created %d payments, want 1", invoices.opened)
+	}
+}
+
+func TestInvoiceLinkClosedInvoice(t *testing.T) {
+	_, srv := newInvoiceGateway(t)
+
+	res, err := http.Get(srv.URL + "/i/paid")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusGone {
+		t.Fatalf("GET paid invoice = %d, want 410", res.StatusCode)
+	}
+}
+
+func TestInvoiceLinksServeOnlyInvoiceRoutes(t *testing.T) {
+	invoices, srv := newInvoiceGateway(t)
+
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/v1/payments/bank-1", http.StatusNotFound},
+		{http.MethodPost, "/v1/payments", http.StatusNotFound},
+		{http.MethodGet, "/v1/admin/limits", http.StatusNotFound},
+		{http.MethodGet, "/i/abc/extra", http.StatusNotFound},
+		{http.MethodDelete, "/i/abc", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.method, tt.path, err)
+		}
+		res.Body.Close()
+		if res.StatusCode != tt.want {
+			t.Errorf("%s %s = %d, want %d", tt.method, tt.path, res.StatusCode, tt.want)
+		}
+	}
+	if invoices.opened != 0 {
+		t.Fatalf("requests created %d payments, want 0", invoices.opened)
+	}
+}
+
+func TestInvoiceLinkError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{repo.ErrInvoiceNotFound, http.StatusNotFound},
+		{service.ErrInvoicePaid, http.StatusGone},
+		{service.ErrInvoiceExpired, http.StatusGone},
+		{fmt.Errorf("adopt: %w", repo.ErrOrderIDConflict), http.StatusConflict},
+		{service.ErrPaymentInReview, http.StatusAccepted},
+		{service.ErrPaymentDenied, http.StatusForbidden},
+		{models.ErrLimitExceeded, http.StatusTooManyRequests},
+		{models.ErrBrokerUnavailable, http.StatusBadGateway},
+		{errors.New("connection reset"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if _, got := invoiceLinkError(tt.err); got != tt.want {
+			t.Errorf("invoiceLinkError(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+
+	// Внутренняя ошибка покупателю не раскрывается
+	if msg, _ := invoiceLinkError(errors.New("connection reset")); strings.Contains(msg, "connection") {
+		t.Fatalf("invoiceLinkError discloses internal error: %q", msg)
+	}
+}
@@ -2,13 +2,100 @@ package grpcserver
 
 import (
 	"context"
+	"payment/internal/domain/models"
 	"payment/pkg/logger"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
+// Заголовок с вызывающей стороной со слов клиента. Сохраняется в журнале аудита как claimed_actor.
+// Через REST шлюз передаётся как Grpc-Metadata-X-Actor.
+const actorHeader = "x-actor"
+
+// Заголовок с адресом клиента от прокси. REST шлюз дописывает в него адрес HTTP клиента.
+const forwardedForHeader = "x-forwarded-for"
+
+// CallerInterceptor проверяет ключ API и сохраняет в контексте вызывающую сторону и метод для журнала аудита.
+func CallerInterceptor(auth *Authenticator, proxies TrustedProxies) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := withCaller(ctx, auth, proxies, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// CallerStreamInterceptor — CallerInterceptor для потоковых методов.
+func CallerStreamInterceptor(auth *Authenticator, proxies TrustedProxies) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := withCaller(stream.Context(), auth, proxies, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &callerStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// callerStream — поток с контекстом, в котором сохранена вызывающая сторона.
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
+
+// withCaller проверяет ключ API и сохраняет вызывающую сторону в контексте.
+// Вызывающая сторона — имя ключа API, без ключа — адрес клиента. Заголовок x-actor сохраняется
+// только как заявленная клиентом сторона. Адрес клиента берётся из X-Forwarded-For только за доверенными прокси
+// и REST шлюзом этого процесса.
+func withCaller(ctx context.Context, auth *Authenticator, proxies TrustedProxies, method string) (context.Context, error) {
+	name, err := auth.authenticate(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+
+	caller := models.Caller{Method: method}
+	if name != "" {
+		caller.Actor = "key:" + name
+	}
+
+	var remote string
+	if p, ok := peer.FromContext(ctx); ok {
+		remote = p.Addr.String()
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(actorHeader); len(values) > 0 {
+		caller.ClaimedActor = values[0]
+	}
+	if proxies.fromGateway(md) {
+		caller.IP = proxies.gatewayClientIP(md.Get(forwardedForHeader), remote)
+	} else {
+		caller.IP = proxies.clientIP(md.Get(forwardedForHeader), remote)
+	}
+	if caller.Actor == "" {
+		caller.Actor = "peer:" + caller.IP
+	}
+
+	return models.WithCaller(ctx, caller), nil
+}
+
 func LoggingInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
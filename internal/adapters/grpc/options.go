@@ -8,7 +8,7 @@ import (
 	"google.golang.org/grpc/keepalive"
 )
 
-func GetOptions(cfg config.GRPCServer, log logger.Logger) []grpc.ServerOption {
+func GetOptions(cfg config.GRPCServer, auth *Authenticator, proxies TrustedProxies, log logger.Logger) []grpc.ServerOption {
 	var opts []grpc.ServerOption
 
 	opts = append(opts,
@@ -17,7 +17,13 @@ func GetOptions(cfg config.GRPCServer, log logger.Logger) []grpc.ServerOption {
 			MaxConnectionAge:      cfg.MaxConnectionAge,
 			MaxConnectionAgeGrace: cfg.MaxConnectionAgeGrace,
 		}),
-		grpc.UnaryInterceptor(LoggingInterceptor(log)),
+		grpc.ChainUnaryInterceptor(
+			CallerInterceptor(auth, proxies),
+			LoggingInterceptor(log),
+		),
+		grpc.ChainStreamInterceptor(
+			CallerStreamInterceptor(auth, proxies),
+		),
 	)
 	return opts
 }
@@ -30,10 +30,17 @@ type CreatePaymentRequest struct {
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
-	ReturnUrl     string                 `protobuf:"bytes,5,opt,name=return_url,json=returnUrl,proto3" json:"return_url,omitempty"`
-	ErrorUrl      string                 `protobuf:"bytes,6,opt,name=error_url,json=errorUrl,proto3" json:"error_url,omitempty"`
-	Operation     string                 `protobuf:"bytes,7,opt,name=operation,proto3" json:"operation,omitempty"`
-	Metadata      *structpb.Struct       `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ReturnUrl     string                 `protobuf:"bytes,5,opt,name=return_url,json=returnUrl,proto3" json:"return_url,omitempty"`  // URL_payment: возврат после оплаты на платёжной форме; пусто — DEFAULT_RETURN_URL
+	ErrorUrl      string                 `protobuf:"bytes,6,opt,name=error_url,json=errorUrl,proto3" json:"error_url,omitempty"`     // URL_payment: возврат после ошибки на платёжной форме; пусто — DEFAULT_FAIL_URL
+	Operation     string                 `protobuf:"bytes,7,opt,name=operation,proto3" json:"operation,omitempty"`                   // URL_payment, BINDING_payment, CARD_payment, QR_payment, WALLET_payment
+	Metadata      *structpb.Struct       `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`                     // произвольные данные мерчанта (ID корзины, SKU и т.п.)
+	Splits        []*SplitRule           `protobuf:"bytes,9,rep,name=splits,proto3" json:"splits,omitempty"`                         // доли продавцов, остаток — комиссия платформы
+	BindingId     string                 `protobuf:"bytes,10,opt,name=binding_id,json=bindingId,proto3" json:"binding_id,omitempty"` // BINDING_payment: сохранённая карта пользователя
+	CardToken     string                 `protobuf:"bytes,11,opt,name=card_token,json=cardToken,proto3" json:"card_token,omitempty"` // CARD_payment: токен карты от токенизатора, не номер карты
+	Wallet        *WalletToken           `protobuf:"bytes,12,opt,name=wallet,proto3" json:"wallet,omitempty"`                        // WALLET_payment: токен Apple Pay / Google Pay
+	Description   string                 `protobuf:"bytes,13,opt,name=description,proto3" json:"description,omitempty"`              // описание для покупателя
+	Customer      *Customer              `protobuf:"bytes,14,opt,name=customer,proto3" json:"customer,omitempty"`                    // контакты для отправки чека
+	Items         []*CartItem            `protobuf:"bytes,15,rep,name=items,proto3" json:"items,omitempty"`                          // позиции чека; сумма позиций должна совпадать с amount
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -124,17 +131,300 @@ func (x *CreatePaymentRequest) GetMetadata() *structpb.Struct {
 	return nil
 }
 
+func (x *CreatePaymentRequest) GetSplits() []*SplitRule {
+	if x != nil {
+		return x.Splits
+	}
+	return nil
+}
+
+func (x *CreatePaymentRequest) GetBindingId() string {
+	if x != nil {
+		return x.BindingId
+	}
+	return ""
+}
+
+func (x *CreatePaymentRequest) GetCardToken() string {
+	if x != nil {
+		return x.CardToken
+	}
+	return ""
+}
+
+func (x *CreatePaymentRequest) GetWallet() *WalletToken {
+	if x != nil {
+		return x.Wallet
+	}
+	return nil
+}
+
+func (x *CreatePaymentRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreatePaymentRequest) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *CreatePaymentRequest) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Customer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"` // E.164, например +77011234567
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Customer) Reset() {
+	*x = Customer{}
+	mi := &file_payment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Customer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Customer) ProtoMessage() {}
+
+func (x *Customer) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Customer.ProtoReflect.Descriptor instead.
+func (*Customer) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Customer) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Customer) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+type CartItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`                    // цена за единицу в валюте платежа
+	TaxRate       float64                `protobuf:"fixed64,4,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"` // ставка НДС в процентах, 0 — без НДС
+	Amount        float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`                  // только в ответе: quantity * price
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartItem) Reset() {
+	*x = CartItem{}
+	mi := &file_payment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartItem) ProtoMessage() {}
+
+func (x *CartItem) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartItem.ProtoReflect.Descriptor instead.
+func (*CartItem) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CartItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CartItem) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CartItem) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CartItem) GetTaxRate() float64 {
+	if x != nil {
+		return x.TaxRate
+	}
+	return 0
+}
+
+func (x *CartItem) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type WalletToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // APPLE_PAY, GOOGLE_PAY
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletToken) Reset() {
+	*x = WalletToken{}
+	mi := &file_payment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletToken) ProtoMessage() {}
+
+func (x *WalletToken) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletToken.ProtoReflect.Descriptor instead.
+func (*WalletToken) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WalletToken) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WalletToken) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SplitRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SellerId      string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitRule) Reset() {
+	*x = SplitRule{}
+	mi := &file_payment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitRule) ProtoMessage() {}
+
+func (x *SplitRule) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitRule.ProtoReflect.Descriptor instead.
+func (*SplitRule) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SplitRule) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *SplitRule) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
 type CreatePaymentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
-	PaymentUrl    string                 `protobuf:"bytes,2,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`
+	PaymentUrl    string                 `protobuf:"bytes,2,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"` // URL_payment: платёжная форма
+	QrPayload     string                 `protobuf:"bytes,3,opt,name=qr_payload,json=qrPayload,proto3" json:"qr_payload,omitempty"`    // QR_payment: содержимое QR кода
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                           // прямые операции проводятся сразу и возвращают итоговый статус
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreatePaymentResponse) Reset() {
 	*x = CreatePaymentResponse{}
-	mi := &file_payment_proto_msgTypes[1]
+	mi := &file_payment_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -146,7 +436,7 @@ func (x *CreatePaymentResponse) String() string {
 func (*CreatePaymentResponse) ProtoMessage() {}
 
 func (x *CreatePaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[1]
+	mi := &file_payment_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,7 +449,7 @@ func (x *CreatePaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePaymentResponse.ProtoReflect.Descriptor instead.
 func (*CreatePaymentResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{1}
+	return file_payment_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreatePaymentResponse) GetPaymentId() string {
@@ -176,22 +466,39 @@ func (x *CreatePaymentResponse) GetPaymentUrl() string {
 	return ""
 }
 
+func (x *CreatePaymentResponse) GetQrPayload() string {
+	if x != nil {
+		return x.QrPayload
+	}
+	return ""
+}
+
+func (x *CreatePaymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 type AuthPaymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
-	ReturnUrl     string                 `protobuf:"bytes,5,opt,name=return_url,json=returnUrl,proto3" json:"return_url,omitempty"`
-	ErrorUrl      string                 `protobuf:"bytes,6,opt,name=error_url,json=errorUrl,proto3" json:"error_url,omitempty"`
+	ReturnUrl     string                 `protobuf:"bytes,5,opt,name=return_url,json=returnUrl,proto3" json:"return_url,omitempty"` // пусто — DEFAULT_RETURN_URL
+	ErrorUrl      string                 `protobuf:"bytes,6,opt,name=error_url,json=errorUrl,proto3" json:"error_url,omitempty"`    // пусто — DEFAULT_FAIL_URL
 	Metadata      *structpb.Struct       `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Description   string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	Customer      *Customer              `protobuf:"bytes,9,opt,name=customer,proto3" json:"customer,omitempty"`
+	Items         []*CartItem            `protobuf:"bytes,10,rep,name=items,proto3" json:"items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AuthPaymentRequest) Reset() {
 	*x = AuthPaymentRequest{}
-	mi := &file_payment_proto_msgTypes[2]
+	mi := &file_payment_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -203,7 +510,7 @@ func (x *AuthPaymentRequest) String() string {
 func (*AuthPaymentRequest) ProtoMessage() {}
 
 func (x *AuthPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[2]
+	mi := &file_payment_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -216,7 +523,7 @@ func (x *AuthPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthPaymentRequest.ProtoReflect.Descriptor instead.
 func (*AuthPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{2}
+	return file_payment_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *AuthPaymentRequest) GetOrderId() string {
@@ -268,17 +575,39 @@ func (x *AuthPaymentRequest) GetMetadata() *structpb.Struct {
 	return nil
 }
 
+func (x *AuthPaymentRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AuthPaymentRequest) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *AuthPaymentRequest) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
 type AuthPaymentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
 	PaymentUrl    string                 `protobuf:"bytes,2,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // REVIEW — платёж отложен на ручную проверку риска, payment_url пустой
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AuthPaymentResponse) Reset() {
 	*x = AuthPaymentResponse{}
-	mi := &file_payment_proto_msgTypes[3]
+	mi := &file_payment_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -290,7 +619,7 @@ func (x *AuthPaymentResponse) String() string {
 func (*AuthPaymentResponse) ProtoMessage() {}
 
 func (x *AuthPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[3]
+	mi := &file_payment_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,7 +632,7 @@ func (x *AuthPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthPaymentResponse.ProtoReflect.Descriptor instead.
 func (*AuthPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{3}
+	return file_payment_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *AuthPaymentResponse) GetPaymentId() string {
@@ -320,18 +649,27 @@ func (x *AuthPaymentResponse) GetPaymentUrl() string {
 	return ""
 }
 
+func (x *AuthPaymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 type DepositPaymentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
 	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
 	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	OrderId       string                 `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // альтернатива payment_id
+	Final         bool                   `protobuf:"varint,5,opt,name=final,proto3" json:"final,omitempty"`                   // разблокировать остаток авторизованной суммы после списания
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DepositPaymentRequest) Reset() {
 	*x = DepositPaymentRequest{}
-	mi := &file_payment_proto_msgTypes[4]
+	mi := &file_payment_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -343,7 +681,7 @@ func (x *DepositPaymentRequest) String() string {
 func (*DepositPaymentRequest) ProtoMessage() {}
 
 func (x *DepositPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[4]
+	mi := &file_payment_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -356,52 +694,4893 @@ func (x *DepositPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DepositPaymentRequest.ProtoReflect.Descriptor instead.
 func (*DepositPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{4}
+	return file_payment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DepositPaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *DepositPaymentRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *DepositPaymentRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *DepositPaymentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *DepositPaymentRequest) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+type DepositPaymentResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Status          string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	CapturedAmount  float64                `protobuf:"fixed64,2,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"`
+	RemainingAmount float64                `protobuf:"fixed64,3,opt,name=remaining_amount,json=remainingAmount,proto3" json:"remaining_amount,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DepositPaymentResponse) Reset() {
+	*x = DepositPaymentResponse{}
+	mi := &file_payment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepositPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepositPaymentResponse) ProtoMessage() {}
+
+func (x *DepositPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepositPaymentResponse.ProtoReflect.Descriptor instead.
+func (*DepositPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DepositPaymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DepositPaymentResponse) GetCapturedAmount() float64 {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return 0
+}
+
+func (x *DepositPaymentResponse) GetRemainingAmount() float64 {
+	if x != nil {
+		return x.RemainingAmount
+	}
+	return 0
+}
+
+type RefundPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // альтернатива payment_id
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`                // 0 — вернуть весь остаток списанной суммы
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`              // по умолчанию валюта платежа
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentRequest) Reset() {
+	*x = RefundPaymentRequest{}
+	mi := &file_payment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentRequest) ProtoMessage() {}
+
+func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
+func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RefundPaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RefundPaymentRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type RefundPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentResponse) Reset() {
+	*x = RefundPaymentResponse{}
+	mi := &file_payment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentResponse) ProtoMessage() {}
+
+func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentResponse.ProtoReflect.Descriptor instead.
+func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RefundPaymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ReversalPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	OrderId       string                 `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // альтернатива payment_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReversalPaymentRequest) Reset() {
+	*x = ReversalPaymentRequest{}
+	mi := &file_payment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReversalPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReversalPaymentRequest) ProtoMessage() {}
+
+func (x *ReversalPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReversalPaymentRequest.ProtoReflect.Descriptor instead.
+func (*ReversalPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReversalPaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *ReversalPaymentRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ReversalPaymentRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ReversalPaymentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ReversalPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReversalPaymentResponse) Reset() {
+	*x = ReversalPaymentResponse{}
+	mi := &file_payment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReversalPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReversalPaymentResponse) ProtoMessage() {}
+
+func (x *ReversalPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReversalPaymentResponse.ProtoReflect.Descriptor instead.
+func (*ReversalPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReversalPaymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentRequest) Reset() {
+	*x = GetPaymentRequest{}
+	mi := &file_payment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentRequest) ProtoMessage() {}
+
+func (x *GetPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetPaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+type GetPaymentByOrderIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentByOrderIdRequest) Reset() {
+	*x = GetPaymentByOrderIdRequest{}
+	mi := &file_payment_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentByOrderIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentByOrderIdRequest) ProtoMessage() {}
+
+func (x *GetPaymentByOrderIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentByOrderIdRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentByOrderIdRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetPaymentByOrderIdRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetPaymentResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId           string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId             string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId              string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount              float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency            string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status              string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Operation           string                 `protobuf:"bytes,8,opt,name=operation,proto3" json:"operation,omitempty"`
+	Broker              string                 `protobuf:"bytes,9,opt,name=broker,proto3" json:"broker,omitempty"`
+	Metadata            *structpb.Struct       `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	AuthorizedAmount    float64                `protobuf:"fixed64,11,opt,name=authorized_amount,json=authorizedAmount,proto3" json:"authorized_amount,omitempty"`
+	CapturedAmount      float64                `protobuf:"fixed64,12,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"`
+	ReleasedAmount      float64                `protobuf:"fixed64,13,opt,name=released_amount,json=releasedAmount,proto3" json:"released_amount,omitempty"`
+	RemainingAmount     float64                `protobuf:"fixed64,14,opt,name=remaining_amount,json=remainingAmount,proto3" json:"remaining_amount,omitempty"`
+	FeeAmount           float64                `protobuf:"fixed64,15,opt,name=fee_amount,json=feeAmount,proto3" json:"fee_amount,omitempty"` // комиссии банка за списание и возвраты
+	RefundedAmount      float64                `protobuf:"fixed64,16,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	NetAmount           float64                `protobuf:"fixed64,17,opt,name=net_amount,json=netAmount,proto3" json:"net_amount,omitempty"`                         // списано за вычетом возвратов и комиссий
+	PresentmentAmount   float64                `protobuf:"fixed64,18,opt,name=presentment_amount,json=presentmentAmount,proto3" json:"presentment_amount,omitempty"` // сумма в валюте покупателя
+	PresentmentCurrency string                 `protobuf:"bytes,19,opt,name=presentment_currency,json=presentmentCurrency,proto3" json:"presentment_currency,omitempty"`
+	FxRate              float64                `protobuf:"fixed64,20,opt,name=fx_rate,json=fxRate,proto3" json:"fx_rate,omitempty"` // курс пересчёта presentment_currency в currency (1 — без пересчёта)
+	FxRateSource        string                 `protobuf:"bytes,21,opt,name=fx_rate_source,json=fxRateSource,proto3" json:"fx_rate_source,omitempty"`
+	FxRateAt            *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=fx_rate_at,json=fxRateAt,proto3" json:"fx_rate_at,omitempty"`
+	RiskDecision        string                 `protobuf:"bytes,23,opt,name=risk_decision,json=riskDecision,proto3" json:"risk_decision,omitempty"` // ALLOW, REVIEW, DENY; пусто для платежей до проверки риска
+	RiskRules           []string               `protobuf:"bytes,24,rep,name=risk_rules,json=riskRules,proto3" json:"risk_rules,omitempty"`          // сработавшие правила риска
+	ClientIp            string                 `protobuf:"bytes,25,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	Description         string                 `protobuf:"bytes,26,opt,name=description,proto3" json:"description,omitempty"`
+	Customer            *Customer              `protobuf:"bytes,27,opt,name=customer,proto3" json:"customer,omitempty"`
+	Items               []*CartItem            `protobuf:"bytes,28,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetPaymentResponse) Reset() {
+	*x = GetPaymentResponse{}
+	mi := &file_payment_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentResponse) ProtoMessage() {}
+
+func (x *GetPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentResponse.ProtoReflect.Descriptor instead.
+func (*GetPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetPaymentResponse) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *GetPaymentResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GetPaymentResponse) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetBroker() string {
+	if x != nil {
+		return x.Broker
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetPaymentResponse) GetAuthorizedAmount() float64 {
+	if x != nil {
+		return x.AuthorizedAmount
+	}
+	return 0
+}
+
+func (x *GetPaymentResponse) GetCapturedAmount() float64 {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return 0
+}
+
+func (x *GetPaymentResponse) GetReleasedAmount() float64 {
+	if x != nil {
+		return x.ReleasedAmount
+	}
+	return 0
+}
+
+func (x *GetPaymentResponse) GetRemainingAmount() float64 {
+	if x != nil {
+		return x.RemainingAmount
+	}
+	return 0
+}
+
+func (x *GetPaymentResponse) GetFeeAmount() float64 {
+	if x != nil {
+		return x.FeeAmount
+	}
+	return 0
+}
+
+func (x *GetPaymentResponse) GetRefundedAmount() float64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
+func (x *GetPaymentResponse) GetNetAmount() float64 {
+	if x != nil {
+		return x.NetAmount
+	}
+	return 0
+}
+
+func (x *GetPaymentResponse) GetPresentmentAmount() float64 {
+	if x != nil {
+		return x.PresentmentAmount
+	}
+	return 0
+}
+
+func (x *GetPaymentResponse) GetPresentmentCurrency() string {
+	if x != nil {
+		return x.PresentmentCurrency
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetFxRate() float64 {
+	if x != nil {
+		return x.FxRate
+	}
+	return 0
+}
+
+func (x *GetPaymentResponse) GetFxRateSource() string {
+	if x != nil {
+		return x.FxRateSource
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetFxRateAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FxRateAt
+	}
+	return nil
+}
+
+func (x *GetPaymentResponse) GetRiskDecision() string {
+	if x != nil {
+		return x.RiskDecision
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetRiskRules() []string {
+	if x != nil {
+		return x.RiskRules
+	}
+	return nil
+}
+
+func (x *GetPaymentResponse) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *GetPaymentResponse) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetPaymentStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // альтернатива payment_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentStatusRequest) Reset() {
+	*x = GetPaymentStatusRequest{}
+	mi := &file_payment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentStatusRequest) ProtoMessage() {}
+
+func (x *GetPaymentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetPaymentStatusRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *GetPaymentStatusRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetPaymentStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentStatusResponse) Reset() {
+	*x = GetPaymentStatusResponse{}
+	mi := &file_payment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentStatusResponse) ProtoMessage() {}
+
+func (x *GetPaymentStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetPaymentStatusResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetPaymentStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetPaymentHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // альтернатива payment_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentHistoryRequest) Reset() {
+	*x = GetPaymentHistoryRequest{}
+	mi := &file_payment_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentHistoryRequest) ProtoMessage() {}
+
+func (x *GetPaymentHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPaymentHistoryRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *GetPaymentHistoryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type PaymentStatusEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`                           // API | BANK_CALLBACK | RECONCILER
+	BrokerCode    string                 `protobuf:"bytes,4,opt,name=broker_code,json=brokerCode,proto3" json:"broker_code,omitempty"` // код ответа брокера
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentStatusEntry) Reset() {
+	*x = PaymentStatusEntry{}
+	mi := &file_payment_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentStatusEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentStatusEntry) ProtoMessage() {}
+
+func (x *PaymentStatusEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentStatusEntry.ProtoReflect.Descriptor instead.
+func (*PaymentStatusEntry) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PaymentStatusEntry) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PaymentStatusEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PaymentStatusEntry) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PaymentStatusEntry) GetBrokerCode() string {
+	if x != nil {
+		return x.BrokerCode
+	}
+	return ""
+}
+
+type GetPaymentHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	History       []*PaymentStatusEntry  `protobuf:"bytes,2,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentHistoryResponse) Reset() {
+	*x = GetPaymentHistoryResponse{}
+	mi := &file_payment_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentHistoryResponse) ProtoMessage() {}
+
+func (x *GetPaymentHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPaymentHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetPaymentHistoryResponse) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *GetPaymentHistoryResponse) GetHistory() []*PaymentStatusEntry {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type SuccessPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // альтернатива payment_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuccessPaymentRequest) Reset() {
+	*x = SuccessPaymentRequest{}
+	mi := &file_payment_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuccessPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuccessPaymentRequest) ProtoMessage() {}
+
+func (x *SuccessPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuccessPaymentRequest.ProtoReflect.Descriptor instead.
+func (*SuccessPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SuccessPaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *SuccessPaymentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type SuccessPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuccessPaymentResponse) Reset() {
+	*x = SuccessPaymentResponse{}
+	mi := &file_payment_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuccessPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuccessPaymentResponse) ProtoMessage() {}
+
+func (x *SuccessPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuccessPaymentResponse.ProtoReflect.Descriptor instead.
+func (*SuccessPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SuccessPaymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListPaymentsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Page     int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	UserId   string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Фильтры
+	Statuses      []string               `protobuf:"bytes,4,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Currencies    []string               `protobuf:"bytes,5,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	Broker        string                 `protobuf:"bytes,6,opt,name=broker,proto3" json:"broker,omitempty"`
+	OrderIdPrefix string                 `protobuf:"bytes,7,opt,name=order_id_prefix,json=orderIdPrefix,proto3" json:"order_id_prefix,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // включительно
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // не включительно
+	AmountMin     *float64               `protobuf:"fixed64,10,opt,name=amount_min,json=amountMin,proto3,oneof" json:"amount_min,omitempty"`
+	AmountMax     *float64               `protobuf:"fixed64,11,opt,name=amount_max,json=amountMax,proto3,oneof" json:"amount_max,omitempty"`
+	// Сортировка: created_at (по умолчанию) | amount; порядок: desc (по умолчанию) | asc
+	SortBy    string `protobuf:"bytes,12,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortOrder string `protobuf:"bytes,13,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	// Курсор из next_cursor предыдущего ответа. Имеет приоритет над page.
+	Cursor string `protobuf:"bytes,14,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Платежи, у которых metadata содержит все пары (совпадают только строковые значения).
+	// В REST: ?metadata[cart_id]=42
+	Metadata      map[string]string `protobuf:"bytes,15,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsRequest) Reset() {
+	*x = ListPaymentsRequest{}
+	mi := &file_payment_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsRequest) ProtoMessage() {}
+
+func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListPaymentsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetCurrencies() []string {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetBroker() string {
+	if x != nil {
+		return x.Broker
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetOrderIdPrefix() string {
+	if x != nil {
+		return x.OrderIdPrefix
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetAmountMin() float64 {
+	if x != nil && x.AmountMin != nil {
+		return *x.AmountMin
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetAmountMax() float64 {
+	if x != nil && x.AmountMax != nil {
+		return *x.AmountMax
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ListPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*GetPaymentResponse  `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsResponse) Reset() {
+	*x = ListPaymentsResponse{}
+	mi := &file_payment_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsResponse) ProtoMessage() {}
+
+func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListPaymentsResponse) GetPayments() []*GetPaymentResponse {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *ListPaymentsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListPaymentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Фильтры совпадают с ListPaymentsRequest; user_id необязателен
+type ExportPaymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // CSV (по умолчанию), JSONL, PARQUET
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Statuses      []string               `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Currencies    []string               `protobuf:"bytes,4,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	Broker        string                 `protobuf:"bytes,5,opt,name=broker,proto3" json:"broker,omitempty"`
+	OrderIdPrefix string                 `protobuf:"bytes,6,opt,name=order_id_prefix,json=orderIdPrefix,proto3" json:"order_id_prefix,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // включительно
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // не включительно
+	AmountMin     *float64               `protobuf:"fixed64,9,opt,name=amount_min,json=amountMin,proto3,oneof" json:"amount_min,omitempty"`
+	AmountMax     *float64               `protobuf:"fixed64,10,opt,name=amount_max,json=amountMax,proto3,oneof" json:"amount_max,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Сортировка: created_at (по умолчанию) | amount; порядок: asc (по умолчанию) | desc
+	SortBy        string `protobuf:"bytes,12,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortOrder     string `protobuf:"bytes,13,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportPaymentsRequest) Reset() {
+	*x = ExportPaymentsRequest{}
+	mi := &file_payment_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPaymentsRequest) ProtoMessage() {}
+
+func (x *ExportPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*ExportPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ExportPaymentsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportPaymentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportPaymentsRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ExportPaymentsRequest) GetCurrencies() []string {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+func (x *ExportPaymentsRequest) GetBroker() string {
+	if x != nil {
+		return x.Broker
+	}
+	return ""
+}
+
+func (x *ExportPaymentsRequest) GetOrderIdPrefix() string {
+	if x != nil {
+		return x.OrderIdPrefix
+	}
+	return ""
+}
+
+func (x *ExportPaymentsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ExportPaymentsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ExportPaymentsRequest) GetAmountMin() float64 {
+	if x != nil && x.AmountMin != nil {
+		return *x.AmountMin
+	}
+	return 0
+}
+
+func (x *ExportPaymentsRequest) GetAmountMax() float64 {
+	if x != nil && x.AmountMax != nil {
+		return *x.AmountMax
+	}
+	return 0
+}
+
+func (x *ExportPaymentsRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ExportPaymentsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ExportPaymentsRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+// Часть файла выгрузки. Файл — конкатенация data всех частей по порядку.
+type ExportPaymentsChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // только в первой части
+	Rows          int32                  `protobuf:"varint,3,opt,name=rows,proto3" json:"rows,omitempty"`                                 // только в последней части (пустые data): число выгруженных платежей
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportPaymentsChunk) Reset() {
+	*x = ExportPaymentsChunk{}
+	mi := &file_payment_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportPaymentsChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPaymentsChunk) ProtoMessage() {}
+
+func (x *ExportPaymentsChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPaymentsChunk.ProtoReflect.Descriptor instead.
+func (*ExportPaymentsChunk) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ExportPaymentsChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportPaymentsChunk) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportPaymentsChunk) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+type ListAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Result        string                 `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`                              // SUCCESS | FAILURE
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // включительно
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // не включительно
+	PageSize      int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Cursor        string                 `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor из предыдущего ответа
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditLogRequest) Reset() {
+	*x = ListAuditLogRequest{}
+	mi := &file_payment_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditLogRequest) ProtoMessage() {}
+
+func (x *ListAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListAuditLogRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *ListAuditLogRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListAuditLogRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ListAuditLogRequest) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *ListAuditLogRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListAuditLogRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListAuditLogRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuditLogRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type AuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	PaymentId     string                 `protobuf:"bytes,4,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Params        *structpb.Struct       `protobuf:"bytes,5,opt,name=params,proto3" json:"params,omitempty"`
+	Result        string                 `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	BrokerError   string                 `protobuf:"bytes,8,opt,name=broker_error,json=brokerError,proto3" json:"broker_error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ClaimedActor  string                 `protobuf:"bytes,10,opt,name=claimed_actor,json=claimedActor,proto3" json:"claimed_actor,omitempty"` // заголовок x-actor от клиента, не проверяется
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_payment_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AuditEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetParams() *structpb.Struct {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetBrokerError() string {
+	if x != nil {
+		return x.BrokerError
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetClaimedActor() string {
+	if x != nil {
+		return x.ClaimedActor
+	}
+	return ""
+}
+
+type ListAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditLogResponse) Reset() {
+	*x = ListAuditLogResponse{}
+	mi := &file_payment_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditLogResponse) ProtoMessage() {}
+
+func (x *ListAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListAuditLogResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListAuditLogResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type Binding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BindingId     string                 `protobuf:"bytes,1,opt,name=binding_id,json=bindingId,proto3" json:"binding_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Broker        string                 `protobuf:"bytes,3,opt,name=broker,proto3" json:"broker,omitempty"`
+	MaskedPan     string                 `protobuf:"bytes,4,opt,name=masked_pan,json=maskedPan,proto3" json:"masked_pan,omitempty"` // маскированный номер карты
+	Expiry        string                 `protobuf:"bytes,5,opt,name=expiry,proto3" json:"expiry,omitempty"`                        // срок действия карты (YYYYMM)
+	PaymentId     string                 `protobuf:"bytes,6,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"` // платёж, при котором карта сохранена
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Binding) Reset() {
+	*x = Binding{}
+	mi := &file_payment_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Binding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Binding) ProtoMessage() {}
+
+func (x *Binding) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Binding.ProtoReflect.Descriptor instead.
+func (*Binding) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Binding) GetBindingId() string {
+	if x != nil {
+		return x.BindingId
+	}
+	return ""
+}
+
+func (x *Binding) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Binding) GetBroker() string {
+	if x != nil {
+		return x.Broker
+	}
+	return ""
+}
+
+func (x *Binding) GetMaskedPan() string {
+	if x != nil {
+		return x.MaskedPan
+	}
+	return ""
+}
+
+func (x *Binding) GetExpiry() string {
+	if x != nil {
+		return x.Expiry
+	}
+	return ""
+}
+
+func (x *Binding) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Binding) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListBindingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBindingsRequest) Reset() {
+	*x = ListBindingsRequest{}
+	mi := &file_payment_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBindingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBindingsRequest) ProtoMessage() {}
+
+func (x *ListBindingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBindingsRequest.ProtoReflect.Descriptor instead.
+func (*ListBindingsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListBindingsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListBindingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bindings      []*Binding             `protobuf:"bytes,1,rep,name=bindings,proto3" json:"bindings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBindingsResponse) Reset() {
+	*x = ListBindingsResponse{}
+	mi := &file_payment_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBindingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBindingsResponse) ProtoMessage() {}
+
+func (x *ListBindingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBindingsResponse.ProtoReflect.Descriptor instead.
+func (*ListBindingsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListBindingsResponse) GetBindings() []*Binding {
+	if x != nil {
+		return x.Bindings
+	}
+	return nil
+}
+
+type DeleteBindingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BindingId     string                 `protobuf:"bytes,2,opt,name=binding_id,json=bindingId,proto3" json:"binding_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBindingRequest) Reset() {
+	*x = DeleteBindingRequest{}
+	mi := &file_payment_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBindingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBindingRequest) ProtoMessage() {}
+
+func (x *DeleteBindingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBindingRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBindingRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteBindingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteBindingRequest) GetBindingId() string {
+	if x != nil {
+		return x.BindingId
+	}
+	return ""
+}
+
+type DeleteBindingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBindingResponse) Reset() {
+	*x = DeleteBindingResponse{}
+	mi := &file_payment_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBindingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBindingResponse) ProtoMessage() {}
+
+func (x *DeleteBindingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBindingResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBindingResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{35}
+}
+
+type ChargeBindingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BindingId     string                 `protobuf:"bytes,3,opt,name=binding_id,json=bindingId,proto3" json:"binding_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChargeBindingRequest) Reset() {
+	*x = ChargeBindingRequest{}
+	mi := &file_payment_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChargeBindingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargeBindingRequest) ProtoMessage() {}
+
+func (x *ChargeBindingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargeBindingRequest.ProtoReflect.Descriptor instead.
+func (*ChargeBindingRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ChargeBindingRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ChargeBindingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChargeBindingRequest) GetBindingId() string {
+	if x != nil {
+		return x.BindingId
+	}
+	return ""
+}
+
+func (x *ChargeBindingRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ChargeBindingRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ChargeBindingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChargeBindingResponse) Reset() {
+	*x = ChargeBindingResponse{}
+	mi := &file_payment_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChargeBindingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargeBindingResponse) ProtoMessage() {}
+
+func (x *ChargeBindingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargeBindingResponse.ProtoReflect.Descriptor instead.
+func (*ChargeBindingResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ChargeBindingResponse) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *ChargeBindingResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ReviewPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"` // комментарий проверяющего
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPaymentRequest) Reset() {
+	*x = ReviewPaymentRequest{}
+	mi := &file_payment_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPaymentRequest) ProtoMessage() {}
+
+func (x *ReviewPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPaymentRequest.ProtoReflect.Descriptor instead.
+func (*ReviewPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ReviewPaymentRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *ReviewPaymentRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type RejectPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectPaymentResponse) Reset() {
+	*x = RejectPaymentResponse{}
+	mi := &file_payment_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectPaymentResponse) ProtoMessage() {}
+
+func (x *RejectPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectPaymentResponse.ProtoReflect.Descriptor instead.
+func (*RejectPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RejectPaymentResponse) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *RejectPaymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type Plan struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlanId         string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Amount         float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"` // стоимость одного периода
+	Currency       string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	IntervalMonths int32                  `protobuf:"varint,5,opt,name=interval_months,json=intervalMonths,proto3" json:"interval_months,omitempty"`
+	TrialDays      int32                  `protobuf:"varint,6,opt,name=trial_days,json=trialDays,proto3" json:"trial_days,omitempty"`
+	Active         bool                   `protobuf:"varint,7,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Plan) Reset() {
+	*x = Plan{}
+	mi := &file_payment_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Plan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plan) ProtoMessage() {}
+
+func (x *Plan) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plan.ProtoReflect.Descriptor instead.
+func (*Plan) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Plan) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *Plan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Plan) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Plan) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Plan) GetIntervalMonths() int32 {
+	if x != nil {
+		return x.IntervalMonths
+	}
+	return 0
+}
+
+func (x *Plan) GetTrialDays() int32 {
+	if x != nil {
+		return x.TrialDays
+	}
+	return 0
+}
+
+func (x *Plan) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Plan) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreatePlanRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Amount         float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency       string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	IntervalMonths int32                  `protobuf:"varint,4,opt,name=interval_months,json=intervalMonths,proto3" json:"interval_months,omitempty"`
+	TrialDays      int32                  `protobuf:"varint,5,opt,name=trial_days,json=trialDays,proto3" json:"trial_days,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreatePlanRequest) Reset() {
+	*x = CreatePlanRequest{}
+	mi := &file_payment_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlanRequest) ProtoMessage() {}
+
+func (x *CreatePlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlanRequest.ProtoReflect.Descriptor instead.
+func (*CreatePlanRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreatePlanRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePlanRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreatePlanRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreatePlanRequest) GetIntervalMonths() int32 {
+	if x != nil {
+		return x.IntervalMonths
+	}
+	return 0
+}
+
+func (x *CreatePlanRequest) GetTrialDays() int32 {
+	if x != nil {
+		return x.TrialDays
+	}
+	return 0
+}
+
+type ListPlansRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeInactive bool                   `protobuf:"varint,1,opt,name=include_inactive,json=includeInactive,proto3" json:"include_inactive,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListPlansRequest) Reset() {
+	*x = ListPlansRequest{}
+	mi := &file_payment_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlansRequest) ProtoMessage() {}
+
+func (x *ListPlansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlansRequest.ProtoReflect.Descriptor instead.
+func (*ListPlansRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListPlansRequest) GetIncludeInactive() bool {
+	if x != nil {
+		return x.IncludeInactive
+	}
+	return false
+}
+
+type ListPlansResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plans         []*Plan                `protobuf:"bytes,1,rep,name=plans,proto3" json:"plans,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlansResponse) Reset() {
+	*x = ListPlansResponse{}
+	mi := &file_payment_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlansResponse) ProtoMessage() {}
+
+func (x *ListPlansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlansResponse.ProtoReflect.Descriptor instead.
+func (*ListPlansResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListPlansResponse) GetPlans() []*Plan {
+	if x != nil {
+		return x.Plans
+	}
+	return nil
+}
+
+type Subscription struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId     string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	UserId             string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PlanId             string                 `protobuf:"bytes,3,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	BindingId          string                 `protobuf:"bytes,4,opt,name=binding_id,json=bindingId,proto3" json:"binding_id,omitempty"`
+	Status             string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // TRIALING | ACTIVE | PAST_DUE | CANCELED
+	CurrentPeriodStart *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=current_period_start,json=currentPeriodStart,proto3" json:"current_period_start,omitempty"`
+	CurrentPeriodEnd   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=current_period_end,json=currentPeriodEnd,proto3" json:"current_period_end,omitempty"`
+	NextChargeAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=next_charge_at,json=nextChargeAt,proto3" json:"next_charge_at,omitempty"`
+	Cycle              int32                  `protobuf:"varint,9,opt,name=cycle,proto3" json:"cycle,omitempty"` // количество оплаченных периодов
+	FailedAttempts     int32                  `protobuf:"varint,10,opt,name=failed_attempts,json=failedAttempts,proto3" json:"failed_attempts,omitempty"`
+	Credit             float64                `protobuf:"fixed64,11,opt,name=credit,proto3" json:"credit,omitempty"` // уменьшает следующее списание
+	CancelAtPeriodEnd  bool                   `protobuf:"varint,12,opt,name=cancel_at_period_end,json=cancelAtPeriodEnd,proto3" json:"cancel_at_period_end,omitempty"`
+	CanceledAt         *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=canceled_at,json=canceledAt,proto3" json:"canceled_at,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_payment_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Subscription) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *Subscription) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Subscription) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *Subscription) GetBindingId() string {
+	if x != nil {
+		return x.BindingId
+	}
+	return ""
+}
+
+func (x *Subscription) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Subscription) GetCurrentPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CurrentPeriodStart
+	}
+	return nil
+}
+
+func (x *Subscription) GetCurrentPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CurrentPeriodEnd
+	}
+	return nil
+}
+
+func (x *Subscription) GetNextChargeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextChargeAt
+	}
+	return nil
+}
+
+func (x *Subscription) GetCycle() int32 {
+	if x != nil {
+		return x.Cycle
+	}
+	return 0
+}
+
+func (x *Subscription) GetFailedAttempts() int32 {
+	if x != nil {
+		return x.FailedAttempts
+	}
+	return 0
+}
+
+func (x *Subscription) GetCredit() float64 {
+	if x != nil {
+		return x.Credit
+	}
+	return 0
+}
+
+func (x *Subscription) GetCancelAtPeriodEnd() bool {
+	if x != nil {
+		return x.CancelAtPeriodEnd
+	}
+	return false
+}
+
+func (x *Subscription) GetCanceledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CanceledAt
+	}
+	return nil
+}
+
+func (x *Subscription) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PlanId        string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	BindingId     string                 `protobuf:"bytes,3,opt,name=binding_id,json=bindingId,proto3" json:"binding_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSubscriptionRequest) Reset() {
+	*x = CreateSubscriptionRequest{}
+	mi := &file_payment_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CreateSubscriptionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetBindingId() string {
+	if x != nil {
+		return x.BindingId
+	}
+	return ""
+}
+
+type GetSubscriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSubscriptionRequest) Reset() {
+	*x = GetSubscriptionRequest{}
+	mi := &file_payment_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubscriptionRequest) ProtoMessage() {}
+
+func (x *GetSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*GetSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetSubscriptionRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+type CancelSubscriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	AtPeriodEnd    bool                   `protobuf:"varint,2,opt,name=at_period_end,json=atPeriodEnd,proto3" json:"at_period_end,omitempty"` // отменить по окончании оплаченного периода
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelSubscriptionRequest) Reset() {
+	*x = CancelSubscriptionRequest{}
+	mi := &file_payment_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSubscriptionRequest) ProtoMessage() {}
+
+func (x *CancelSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CancelSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CancelSubscriptionRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *CancelSubscriptionRequest) GetAtPeriodEnd() bool {
+	if x != nil {
+		return x.AtPeriodEnd
+	}
+	return false
+}
+
+type ChangeSubscriptionPlanRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	PlanId         string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChangeSubscriptionPlanRequest) Reset() {
+	*x = ChangeSubscriptionPlanRequest{}
+	mi := &file_payment_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeSubscriptionPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeSubscriptionPlanRequest) ProtoMessage() {}
+
+func (x *ChangeSubscriptionPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeSubscriptionPlanRequest.ProtoReflect.Descriptor instead.
+func (*ChangeSubscriptionPlanRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ChangeSubscriptionPlanRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *ChangeSubscriptionPlanRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+type SubscriptionEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubscriptionId string                 `protobuf:"bytes,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	Type           string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // CREATED | RENEWED | PAYMENT_FAILED | PAYMENT_PENDING | PLAN_CHANGED | CANCEL_SCHEDULED | CANCELED
+	PaymentId      string                 `protobuf:"bytes,4,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Amount         float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason         string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SubscriptionEvent) Reset() {
+	*x = SubscriptionEvent{}
+	mi := &file_payment_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriptionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionEvent) ProtoMessage() {}
+
+func (x *SubscriptionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionEvent.ProtoReflect.Descriptor instead.
+func (*SubscriptionEvent) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SubscriptionEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SubscriptionEvent) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *SubscriptionEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListSubscriptionEventsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionEventsRequest) Reset() {
+	*x = ListSubscriptionEventsRequest{}
+	mi := &file_payment_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionEventsRequest) ProtoMessage() {}
+
+func (x *ListSubscriptionEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionEventsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListSubscriptionEventsRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+type ListSubscriptionEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*SubscriptionEvent   `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionEventsResponse) Reset() {
+	*x = ListSubscriptionEventsResponse{}
+	mi := &file_payment_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionEventsResponse) ProtoMessage() {}
+
+func (x *ListSubscriptionEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionEventsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListSubscriptionEventsResponse) GetEvents() []*SubscriptionEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type CreateInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // необязательно: покупатель, от имени которого создаются платежи
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	MultiUse      bool                   `protobuf:"varint,6,opt,name=multi_use,json=multiUse,proto3" json:"multi_use,omitempty"`   // ссылку можно оплатить несколько раз
+	ReturnUrl     string                 `protobuf:"bytes,7,opt,name=return_url,json=returnUrl,proto3" json:"return_url,omitempty"` // шаблон, подстановки раскрываются при создании платежа
+	ErrorUrl      string                 `protobuf:"bytes,8,opt,name=error_url,json=errorUrl,proto3" json:"error_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvoiceRequest) Reset() {
+	*x = CreateInvoiceRequest{}
+	mi := &file_payment_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceRequest) ProtoMessage() {}
+
+func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CreateInvoiceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateInvoiceRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetMultiUse() bool {
+	if x != nil {
+		return x.MultiUse
+	}
+	return false
+}
+
+func (x *CreateInvoiceRequest) GetReturnUrl() string {
+	if x != nil {
+		return x.ReturnUrl
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetErrorUrl() string {
+	if x != nil {
+		return x.ErrorUrl
+	}
+	return ""
+}
+
+type GetInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvoiceId     string                 `protobuf:"bytes,1,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	mi := &file_payment_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetInvoiceRequest) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+type InvoicePayment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoicePayment) Reset() {
+	*x = InvoicePayment{}
+	mi := &file_payment_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoicePayment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoicePayment) ProtoMessage() {}
+
+func (x *InvoicePayment) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoicePayment.ProtoReflect.Descriptor instead.
+func (*InvoicePayment) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *InvoicePayment) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *InvoicePayment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *InvoicePayment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Invoice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvoiceId     string                 `protobuf:"bytes,1,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"` // публичная ссылка на оплату
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	MultiUse      bool                   `protobuf:"varint,9,opt,name=multi_use,json=multiUse,proto3" json:"multi_use,omitempty"`
+	Status        string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"` // OPEN | PAID | EXPIRED
+	PaidCount     int32                  `protobuf:"varint,11,opt,name=paid_count,json=paidCount,proto3" json:"paid_count,omitempty"`
+	Payments      []*InvoicePayment      `protobuf:"bytes,12,rep,name=payments,proto3" json:"payments,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invoice) Reset() {
+	*x = Invoice{}
+	mi := &file_payment_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invoice) ProtoMessage() {}
+
+func (x *Invoice) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Invoice) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+func (x *Invoice) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Invoice) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Invoice) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Invoice) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Invoice) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Invoice) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Invoice) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Invoice) GetMultiUse() bool {
+	if x != nil {
+		return x.MultiUse
+	}
+	return false
+}
+
+func (x *Invoice) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Invoice) GetPaidCount() int32 {
+	if x != nil {
+		return x.PaidCount
+	}
+	return 0
+}
+
+func (x *Invoice) GetPayments() []*InvoicePayment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *Invoice) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetSellerBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SellerId      string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSellerBalanceRequest) Reset() {
+	*x = GetSellerBalanceRequest{}
+	mi := &file_payment_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSellerBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSellerBalanceRequest) ProtoMessage() {}
+
+func (x *GetSellerBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSellerBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetSellerBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetSellerBalanceRequest) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+type SellerBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Available     float64                `protobuf:"fixed64,2,opt,name=available,proto3" json:"available,omitempty"` // начислено и ещё не выплачено
+	PaidOut       float64                `protobuf:"fixed64,3,opt,name=paid_out,json=paidOut,proto3" json:"paid_out,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SellerBalance) Reset() {
+	*x = SellerBalance{}
+	mi := &file_payment_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SellerBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SellerBalance) ProtoMessage() {}
+
+func (x *SellerBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SellerBalance.ProtoReflect.Descriptor instead.
+func (*SellerBalance) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SellerBalance) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SellerBalance) GetAvailable() float64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *SellerBalance) GetPaidOut() float64 {
+	if x != nil {
+		return x.PaidOut
+	}
+	return 0
+}
+
+type GetSellerBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SellerId      string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Balances      []*SellerBalance       `protobuf:"bytes,2,rep,name=balances,proto3" json:"balances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSellerBalanceResponse) Reset() {
+	*x = GetSellerBalanceResponse{}
+	mi := &file_payment_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSellerBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSellerBalanceResponse) ProtoMessage() {}
+
+func (x *GetSellerBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSellerBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetSellerBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetSellerBalanceResponse) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *GetSellerBalanceResponse) GetBalances() []*SellerBalance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+type CreatePayoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePayoutRequest) Reset() {
+	*x = CreatePayoutRequest{}
+	mi := &file_payment_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePayoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePayoutRequest) ProtoMessage() {}
+
+func (x *CreatePayoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePayoutRequest.ProtoReflect.Descriptor instead.
+func (*CreatePayoutRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{59}
+}
+
+type GetPayoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PayoutId      string                 `protobuf:"bytes,1,opt,name=payout_id,json=payoutId,proto3" json:"payout_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPayoutRequest) Reset() {
+	*x = GetPayoutRequest{}
+	mi := &file_payment_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPayoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPayoutRequest) ProtoMessage() {}
+
+func (x *GetPayoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPayoutRequest.ProtoReflect.Descriptor instead.
+func (*GetPayoutRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetPayoutRequest) GetPayoutId() string {
+	if x != nil {
+		return x.PayoutId
+	}
+	return ""
+}
+
+type PayoutLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SellerId      string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Entries       int32                  `protobuf:"varint,4,opt,name=entries,proto3" json:"entries,omitempty"` // количество проводок в выплате
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayoutLine) Reset() {
+	*x = PayoutLine{}
+	mi := &file_payment_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayoutLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayoutLine) ProtoMessage() {}
+
+func (x *PayoutLine) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayoutLine.ProtoReflect.Descriptor instead.
+func (*PayoutLine) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *PayoutLine) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *PayoutLine) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PayoutLine) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PayoutLine) GetEntries() int32 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+type Payout struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PayoutId      string                 `protobuf:"bytes,1,opt,name=payout_id,json=payoutId,proto3" json:"payout_id,omitempty"`
+	Lines         []*PayoutLine          `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payout) Reset() {
+	*x = Payout{}
+	mi := &file_payment_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payout) ProtoMessage() {}
+
+func (x *Payout) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payout.ProtoReflect.Descriptor instead.
+func (*Payout) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *Payout) GetPayoutId() string {
+	if x != nil {
+		return x.PayoutId
+	}
+	return ""
+}
+
+func (x *Payout) GetLines() []*PayoutLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Payout) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetTrialBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"` // по умолчанию — текущий момент
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrialBalanceRequest) Reset() {
+	*x = GetTrialBalanceRequest{}
+	mi := &file_payment_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrialBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrialBalanceRequest) ProtoMessage() {}
+
+func (x *GetTrialBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrialBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetTrialBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetTrialBalanceRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+type TrialBalanceLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // ASSET | LIABILITY | REVENUE | EXPENSE | MEMO
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Debit         float64                `protobuf:"fixed64,4,opt,name=debit,proto3" json:"debit,omitempty"`
+	Credit        float64                `protobuf:"fixed64,5,opt,name=credit,proto3" json:"credit,omitempty"`
+	Balance       float64                `protobuf:"fixed64,6,opt,name=balance,proto3" json:"balance,omitempty"` // дебет минус кредит
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrialBalanceLine) Reset() {
+	*x = TrialBalanceLine{}
+	mi := &file_payment_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrialBalanceLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrialBalanceLine) ProtoMessage() {}
+
+func (x *TrialBalanceLine) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrialBalanceLine.ProtoReflect.Descriptor instead.
+func (*TrialBalanceLine) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *TrialBalanceLine) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *TrialBalanceLine) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TrialBalanceLine) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TrialBalanceLine) GetDebit() float64 {
+	if x != nil {
+		return x.Debit
+	}
+	return 0
+}
+
+func (x *TrialBalanceLine) GetCredit() float64 {
+	if x != nil {
+		return x.Credit
+	}
+	return 0
+}
+
+func (x *TrialBalanceLine) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type GetTrialBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	Balanced      bool                   `protobuf:"varint,2,opt,name=balanced,proto3" json:"balanced,omitempty"`
+	Lines         []*TrialBalanceLine    `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrialBalanceResponse) Reset() {
+	*x = GetTrialBalanceResponse{}
+	mi := &file_payment_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrialBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrialBalanceResponse) ProtoMessage() {}
+
+func (x *GetTrialBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrialBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetTrialBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetTrialBalanceResponse) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *GetTrialBalanceResponse) GetBalanced() bool {
+	if x != nil {
+		return x.Balanced
+	}
+	return false
+}
+
+func (x *GetTrialBalanceResponse) GetLines() []*TrialBalanceLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// Заголовки колонок файла выписки. Незаданные поля берутся по умолчанию:
+// payment_id, order_id, amount, currency, status
+type ColumnMapping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Delimiter     string                 `protobuf:"bytes,6,opt,name=delimiter,proto3" json:"delimiter,omitempty"`                                                                         // по умолчанию ","
+	MinorUnits    bool                   `protobuf:"varint,7,opt,name=minor_units,json=minorUnits,proto3" json:"minor_units,omitempty"`                                                    // суммы указаны в копейках
+	Statuses      map[string]string      `protobuf:"bytes,8,rep,name=statuses,proto3" json:"statuses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // статус банка -> статус платежа
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColumnMapping) Reset() {
+	*x = ColumnMapping{}
+	mi := &file_payment_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColumnMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnMapping) ProtoMessage() {}
+
+func (x *ColumnMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnMapping.ProtoReflect.Descriptor instead.
+func (*ColumnMapping) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ColumnMapping) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *ColumnMapping) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ColumnMapping) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ColumnMapping) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ColumnMapping) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ColumnMapping) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+func (x *ColumnMapping) GetMinorUnits() bool {
+	if x != nil {
+		return x.MinorUnits
+	}
+	return false
+}
+
+func (x *ColumnMapping) GetStatuses() map[string]string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type RunReconciliationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"` // CSV с заголовком в первой строке
+	Mapping       *ColumnMapping         `protobuf:"bytes,3,opt,name=mapping,proto3" json:"mapping,omitempty"`
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunReconciliationRequest) Reset() {
+	*x = RunReconciliationRequest{}
+	mi := &file_payment_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunReconciliationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunReconciliationRequest) ProtoMessage() {}
+
+func (x *RunReconciliationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunReconciliationRequest.ProtoReflect.Descriptor instead.
+func (*RunReconciliationRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *RunReconciliationRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *RunReconciliationRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *RunReconciliationRequest) GetMapping() *ColumnMapping {
+	if x != nil {
+		return x.Mapping
+	}
+	return nil
+}
+
+func (x *RunReconciliationRequest) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *RunReconciliationRequest) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
+type GetReconciliationRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ReconciliationId string                 `protobuf:"bytes,1,opt,name=reconciliation_id,json=reconciliationId,proto3" json:"reconciliation_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetReconciliationRequest) Reset() {
+	*x = GetReconciliationRequest{}
+	mi := &file_payment_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReconciliationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReconciliationRequest) ProtoMessage() {}
+
+func (x *GetReconciliationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReconciliationRequest.ProtoReflect.Descriptor instead.
+func (*GetReconciliationRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetReconciliationRequest) GetReconciliationId() string {
+	if x != nil {
+		return x.ReconciliationId
+	}
+	return ""
+}
+
+type Discrepancy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // MISSING_LOCALLY | MISSING_AT_BANK | AMOUNT_MISMATCH | STATUS_MISMATCH
+	PaymentId     string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	BankAmount    float64                `protobuf:"fixed64,4,opt,name=bank_amount,json=bankAmount,proto3" json:"bank_amount,omitempty"`
+	LocalAmount   float64                `protobuf:"fixed64,5,opt,name=local_amount,json=localAmount,proto3" json:"local_amount,omitempty"`
+	BankStatus    string                 `protobuf:"bytes,6,opt,name=bank_status,json=bankStatus,proto3" json:"bank_status,omitempty"`
+	LocalStatus   string                 `protobuf:"bytes,7,opt,name=local_status,json=localStatus,proto3" json:"local_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Discrepancy) Reset() {
+	*x = Discrepancy{}
+	mi := &file_payment_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Discrepancy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Discrepancy) ProtoMessage() {}
+
+func (x *Discrepancy) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Discrepancy.ProtoReflect.Descriptor instead.
+func (*Discrepancy) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *Discrepancy) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Discrepancy) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Discrepancy) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Discrepancy) GetBankAmount() float64 {
+	if x != nil {
+		return x.BankAmount
+	}
+	return 0
+}
+
+func (x *Discrepancy) GetLocalAmount() float64 {
+	if x != nil {
+		return x.LocalAmount
+	}
+	return 0
+}
+
+func (x *Discrepancy) GetBankStatus() string {
+	if x != nil {
+		return x.BankStatus
+	}
+	return ""
+}
+
+func (x *Discrepancy) GetLocalStatus() string {
+	if x != nil {
+		return x.LocalStatus
+	}
+	return ""
+}
+
+type Reconciliation struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ReconciliationId string                 `protobuf:"bytes,1,opt,name=reconciliation_id,json=reconciliationId,proto3" json:"reconciliation_id,omitempty"`
+	FileName         string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	PeriodStart      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	Lines            int32                  `protobuf:"varint,5,opt,name=lines,proto3" json:"lines,omitempty"`
+	Matched          int32                  `protobuf:"varint,6,opt,name=matched,proto3" json:"matched,omitempty"`
+	Discrepancies    []*Discrepancy         `protobuf:"bytes,7,rep,name=discrepancies,proto3" json:"discrepancies,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Reconciliation) Reset() {
+	*x = Reconciliation{}
+	mi := &file_payment_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reconciliation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reconciliation) ProtoMessage() {}
+
+func (x *Reconciliation) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reconciliation.ProtoReflect.Descriptor instead.
+func (*Reconciliation) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *Reconciliation) GetReconciliationId() string {
+	if x != nil {
+		return x.ReconciliationId
+	}
+	return ""
+}
+
+func (x *Reconciliation) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *Reconciliation) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *Reconciliation) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
+func (x *Reconciliation) GetLines() int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+func (x *Reconciliation) GetMatched() int32 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *Reconciliation) GetDiscrepancies() []*Discrepancy {
+	if x != nil {
+		return x.Discrepancies
+	}
+	return nil
+}
+
+func (x *Reconciliation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Ставка для платежей на сумму до up_to включительно (0 — без ограничения, только последняя)
+type FeeTier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpTo          float64                `protobuf:"fixed64,1,opt,name=up_to,json=upTo,proto3" json:"up_to,omitempty"`
+	Percent       float64                `protobuf:"fixed64,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	Fixed         float64                `protobuf:"fixed64,3,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeeTier) Reset() {
+	*x = FeeTier{}
+	mi := &file_payment_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeTier) ProtoMessage() {}
+
+func (x *FeeTier) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeTier.ProtoReflect.Descriptor instead.
+func (*FeeTier) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *FeeTier) GetUpTo() float64 {
+	if x != nil {
+		return x.UpTo
+	}
+	return 0
+}
+
+func (x *FeeTier) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *FeeTier) GetFixed() float64 {
+	if x != nil {
+		return x.Fixed
+	}
+	return 0
+}
+
+// Пустые broker, merchant и currency подходят под любое значение;
+// из подходящих тарифов применяется самый конкретный (мерчант, затем банк, затем валюта)
+type CreateFeeScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Broker        string                 `protobuf:"bytes,1,opt,name=broker,proto3" json:"broker,omitempty"`
+	Merchant      string                 `protobuf:"bytes,2,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Percent       float64                `protobuf:"fixed64,4,opt,name=percent,proto3" json:"percent,omitempty"`
+	Fixed         float64                `protobuf:"fixed64,5,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	MinFee        float64                `protobuf:"fixed64,6,opt,name=min_fee,json=minFee,proto3" json:"min_fee,omitempty"`                // 0 — без ограничения
+	MaxFee        float64                `protobuf:"fixed64,7,opt,name=max_fee,json=maxFee,proto3" json:"max_fee,omitempty"`                // 0 — без ограничения
+	Tiers         []*FeeTier             `protobuf:"bytes,8,rep,name=tiers,proto3" json:"tiers,omitempty"`                                  // по возрастанию up_to; если заданы, заменяют percent и fixed
+	RefundFixed   float64                `protobuf:"fixed64,9,opt,name=refund_fixed,json=refundFixed,proto3" json:"refund_fixed,omitempty"` // комиссия за каждый возврат
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFeeScheduleRequest) Reset() {
+	*x = CreateFeeScheduleRequest{}
+	mi := &file_payment_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFeeScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFeeScheduleRequest) ProtoMessage() {}
+
+func (x *CreateFeeScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFeeScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CreateFeeScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CreateFeeScheduleRequest) GetBroker() string {
+	if x != nil {
+		return x.Broker
+	}
+	return ""
+}
+
+func (x *CreateFeeScheduleRequest) GetMerchant() string {
+	if x != nil {
+		return x.Merchant
+	}
+	return ""
+}
+
+func (x *CreateFeeScheduleRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateFeeScheduleRequest) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *CreateFeeScheduleRequest) GetFixed() float64 {
+	if x != nil {
+		return x.Fixed
+	}
+	return 0
+}
+
+func (x *CreateFeeScheduleRequest) GetMinFee() float64 {
+	if x != nil {
+		return x.MinFee
+	}
+	return 0
+}
+
+func (x *CreateFeeScheduleRequest) GetMaxFee() float64 {
+	if x != nil {
+		return x.MaxFee
+	}
+	return 0
+}
+
+func (x *CreateFeeScheduleRequest) GetTiers() []*FeeTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+func (x *CreateFeeScheduleRequest) GetRefundFixed() float64 {
+	if x != nil {
+		return x.RefundFixed
+	}
+	return 0
+}
+
+type ListFeeSchedulesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeInactive bool                   `protobuf:"varint,1,opt,name=include_inactive,json=includeInactive,proto3" json:"include_inactive,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListFeeSchedulesRequest) Reset() {
+	*x = ListFeeSchedulesRequest{}
+	mi := &file_payment_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeeSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeeSchedulesRequest) ProtoMessage() {}
+
+func (x *ListFeeSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeeSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListFeeSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListFeeSchedulesRequest) GetIncludeInactive() bool {
+	if x != nil {
+		return x.IncludeInactive
+	}
+	return false
+}
+
+type DeactivateFeeScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateFeeScheduleRequest) Reset() {
+	*x = DeactivateFeeScheduleRequest{}
+	mi := &file_payment_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateFeeScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateFeeScheduleRequest) ProtoMessage() {}
+
+func (x *DeactivateFeeScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateFeeScheduleRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateFeeScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *DeactivateFeeScheduleRequest) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+type FeeSchedule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Broker        string                 `protobuf:"bytes,2,opt,name=broker,proto3" json:"broker,omitempty"`
+	Merchant      string                 `protobuf:"bytes,3,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Percent       float64                `protobuf:"fixed64,5,opt,name=percent,proto3" json:"percent,omitempty"`
+	Fixed         float64                `protobuf:"fixed64,6,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	MinFee        float64                `protobuf:"fixed64,7,opt,name=min_fee,json=minFee,proto3" json:"min_fee,omitempty"`
+	MaxFee        float64                `protobuf:"fixed64,8,opt,name=max_fee,json=maxFee,proto3" json:"max_fee,omitempty"`
+	Tiers         []*FeeTier             `protobuf:"bytes,9,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	RefundFixed   float64                `protobuf:"fixed64,10,opt,name=refund_fixed,json=refundFixed,proto3" json:"refund_fixed,omitempty"`
+	Active        bool                   `protobuf:"varint,11,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeeSchedule) Reset() {
+	*x = FeeSchedule{}
+	mi := &file_payment_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeSchedule) ProtoMessage() {}
+
+func (x *FeeSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeSchedule.ProtoReflect.Descriptor instead.
+func (*FeeSchedule) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *FeeSchedule) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetBroker() string {
+	if x != nil {
+		return x.Broker
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetMerchant() string {
+	if x != nil {
+		return x.Merchant
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetFixed() float64 {
+	if x != nil {
+		return x.Fixed
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetMinFee() float64 {
+	if x != nil {
+		return x.MinFee
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetMaxFee() float64 {
+	if x != nil {
+		return x.MaxFee
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetTiers() []*FeeTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+func (x *FeeSchedule) GetRefundFixed() float64 {
+	if x != nil {
+		return x.RefundFixed
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *FeeSchedule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListFeeSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*FeeSchedule         `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeeSchedulesResponse) Reset() {
+	*x = ListFeeSchedulesResponse{}
+	mi := &file_payment_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeeSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeeSchedulesResponse) ProtoMessage() {}
+
+func (x *ListFeeSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeeSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListFeeSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ListFeeSchedulesResponse) GetSchedules() []*FeeSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+// Лимит платежей в валюте. Пустой subject применяет лимит к каждому пользователю (мерчанту) отдельно.
+// Нулевое значение — без ограничения, хотя бы одно значение должно быть задано.
+type CreateLimitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scope         string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`     // USER или MERCHANT
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"` // ID пользователя или логин мерчанта у банка
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	MaxSingle     float64                `protobuf:"fixed64,4,opt,name=max_single,json=maxSingle,proto3" json:"max_single,omitempty"` // максимальная сумма одного платежа
+	Daily         float64                `protobuf:"fixed64,5,opt,name=daily,proto3" json:"daily,omitempty"`                          // за календарный день (UTC)
+	Monthly       float64                `protobuf:"fixed64,6,opt,name=monthly,proto3" json:"monthly,omitempty"`                      // за календарный месяц (UTC)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLimitRequest) Reset() {
+	*x = CreateLimitRequest{}
+	mi := &file_payment_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLimitRequest) ProtoMessage() {}
+
+func (x *CreateLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLimitRequest.ProtoReflect.Descriptor instead.
+func (*CreateLimitRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *CreateLimitRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *CreateLimitRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
 }
 
-func (x *DepositPaymentRequest) GetPaymentId() string {
+func (x *CreateLimitRequest) GetCurrency() string {
 	if x != nil {
-		return x.PaymentId
+		return x.Currency
 	}
 	return ""
 }
 
-func (x *DepositPaymentRequest) GetAmount() float64 {
+func (x *CreateLimitRequest) GetMaxSingle() float64 {
 	if x != nil {
-		return x.Amount
+		return x.MaxSingle
 	}
 	return 0
 }
 
-func (x *DepositPaymentRequest) GetCurrency() string {
+func (x *CreateLimitRequest) GetDaily() float64 {
 	if x != nil {
-		return x.Currency
+		return x.Daily
 	}
-	return ""
+	return 0
 }
 
-type DepositPaymentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CreateLimitRequest) GetMonthly() float64 {
+	if x != nil {
+		return x.Monthly
+	}
+	return 0
 }
 
-func (x *DepositPaymentResponse) Reset() {
-	*x = DepositPaymentResponse{}
-	mi := &file_payment_proto_msgTypes[5]
+type ListLimitsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeInactive bool                   `protobuf:"varint,1,opt,name=include_inactive,json=includeInactive,proto3" json:"include_inactive,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListLimitsRequest) Reset() {
+	*x = ListLimitsRequest{}
+	mi := &file_payment_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DepositPaymentResponse) String() string {
+func (x *ListLimitsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DepositPaymentResponse) ProtoMessage() {}
+func (*ListLimitsRequest) ProtoMessage() {}
 
-func (x *DepositPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[5]
+func (x *ListLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,41 +5591,40 @@ func (x *DepositPaymentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DepositPaymentResponse.ProtoReflect.Descriptor instead.
-func (*DepositPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListLimitsRequest.ProtoReflect.Descriptor instead.
+func (*ListLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *DepositPaymentResponse) GetStatus() string {
+func (x *ListLimitsRequest) GetIncludeInactive() bool {
 	if x != nil {
-		return x.Status
+		return x.IncludeInactive
 	}
-	return ""
+	return false
 }
 
-type RefundPaymentRequest struct {
+type DeactivateLimitRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	LimitId       string                 `protobuf:"bytes,1,opt,name=limit_id,json=limitId,proto3" json:"limit_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefundPaymentRequest) Reset() {
-	*x = RefundPaymentRequest{}
-	mi := &file_payment_proto_msgTypes[6]
+func (x *DeactivateLimitRequest) Reset() {
+	*x = DeactivateLimitRequest{}
+	mi := &file_payment_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefundPaymentRequest) String() string {
+func (x *DeactivateLimitRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefundPaymentRequest) ProtoMessage() {}
+func (*DeactivateLimitRequest) ProtoMessage() {}
 
-func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[6]
+func (x *DeactivateLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,47 +5635,48 @@ func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
-func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *RefundPaymentRequest) GetPaymentId() string {
-	if x != nil {
-		return x.PaymentId
-	}
-	return ""
+// Deprecated: Use DeactivateLimitRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateLimitRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *RefundPaymentRequest) GetReason() string {
+func (x *DeactivateLimitRequest) GetLimitId() string {
 	if x != nil {
-		return x.Reason
+		return x.LimitId
 	}
 	return ""
 }
 
-type RefundPaymentResponse struct {
+type PaymentLimit struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	LimitId       string                 `protobuf:"bytes,1,opt,name=limit_id,json=limitId,proto3" json:"limit_id,omitempty"`
+	Scope         string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	MaxSingle     float64                `protobuf:"fixed64,5,opt,name=max_single,json=maxSingle,proto3" json:"max_single,omitempty"`
+	Daily         float64                `protobuf:"fixed64,6,opt,name=daily,proto3" json:"daily,omitempty"`
+	Monthly       float64                `protobuf:"fixed64,7,opt,name=monthly,proto3" json:"monthly,omitempty"`
+	Active        bool                   `protobuf:"varint,8,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefundPaymentResponse) Reset() {
-	*x = RefundPaymentResponse{}
-	mi := &file_payment_proto_msgTypes[7]
+func (x *PaymentLimit) Reset() {
+	*x = PaymentLimit{}
+	mi := &file_payment_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefundPaymentResponse) String() string {
+func (x *PaymentLimit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefundPaymentResponse) ProtoMessage() {}
+func (*PaymentLimit) ProtoMessage() {}
 
-func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[7]
+func (x *PaymentLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -508,100 +5687,96 @@ func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefundPaymentResponse.ProtoReflect.Descriptor instead.
-func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use PaymentLimit.ProtoReflect.Descriptor instead.
+func (*PaymentLimit) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *RefundPaymentResponse) GetStatus() string {
+func (x *PaymentLimit) GetLimitId() string {
 	if x != nil {
-		return x.Status
+		return x.LimitId
 	}
 	return ""
 }
 
-type ReversalPaymentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
-	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PaymentLimit) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
 }
 
-func (x *ReversalPaymentRequest) Reset() {
-	*x = ReversalPaymentRequest{}
-	mi := &file_payment_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *PaymentLimit) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
 }
 
-func (x *ReversalPaymentRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *PaymentLimit) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
 }
 
-func (*ReversalPaymentRequest) ProtoMessage() {}
-
-func (x *ReversalPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[8]
+func (x *PaymentLimit) GetMaxSingle() float64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.MaxSingle
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use ReversalPaymentRequest.ProtoReflect.Descriptor instead.
-func (*ReversalPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{8}
+func (x *PaymentLimit) GetDaily() float64 {
+	if x != nil {
+		return x.Daily
+	}
+	return 0
 }
 
-func (x *ReversalPaymentRequest) GetPaymentId() string {
+func (x *PaymentLimit) GetMonthly() float64 {
 	if x != nil {
-		return x.PaymentId
+		return x.Monthly
 	}
-	return ""
+	return 0
 }
 
-func (x *ReversalPaymentRequest) GetAmount() float64 {
+func (x *PaymentLimit) GetActive() bool {
 	if x != nil {
-		return x.Amount
+		return x.Active
 	}
-	return 0
+	return false
 }
 
-func (x *ReversalPaymentRequest) GetCurrency() string {
+func (x *PaymentLimit) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Currency
+		return x.CreatedAt
 	}
-	return ""
+	return nil
 }
 
-type ReversalPaymentResponse struct {
+type ListLimitsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Limits        []*PaymentLimit        `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReversalPaymentResponse) Reset() {
-	*x = ReversalPaymentResponse{}
-	mi := &file_payment_proto_msgTypes[9]
+func (x *ListLimitsResponse) Reset() {
+	*x = ListLimitsResponse{}
+	mi := &file_payment_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReversalPaymentResponse) String() string {
+func (x *ListLimitsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReversalPaymentResponse) ProtoMessage() {}
+func (*ListLimitsResponse) ProtoMessage() {}
 
-func (x *ReversalPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[9]
+func (x *ListLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -612,40 +5787,43 @@ func (x *ReversalPaymentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReversalPaymentResponse.ProtoReflect.Descriptor instead.
-func (*ReversalPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListLimitsResponse.ProtoReflect.Descriptor instead.
+func (*ListLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *ReversalPaymentResponse) GetStatus() string {
+func (x *ListLimitsResponse) GetLimits() []*PaymentLimit {
 	if x != nil {
-		return x.Status
+		return x.Limits
 	}
-	return ""
+	return nil
 }
 
-type GetPaymentRequest struct {
+type GetReceiptRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // альтернатива payment_id
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`                      // SALE (по умолчанию), REFUND
+	Format        string                 `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`                  // HTML (по умолчанию), PDF
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPaymentRequest) Reset() {
-	*x = GetPaymentRequest{}
-	mi := &file_payment_proto_msgTypes[10]
+func (x *GetReceiptRequest) Reset() {
+	*x = GetReceiptRequest{}
+	mi := &file_payment_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPaymentRequest) String() string {
+func (x *GetReceiptRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPaymentRequest) ProtoMessage() {}
+func (*GetReceiptRequest) ProtoMessage() {}
 
-func (x *GetPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[10]
+func (x *GetReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -656,49 +5834,64 @@ func (x *GetPaymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPaymentRequest.ProtoReflect.Descriptor instead.
-func (*GetPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetReceiptRequest.ProtoReflect.Descriptor instead.
+func (*GetReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *GetPaymentRequest) GetPaymentId() string {
+func (x *GetReceiptRequest) GetPaymentId() string {
 	if x != nil {
 		return x.PaymentId
 	}
 	return ""
 }
 
-type GetPaymentResponse struct {
+func (x *GetReceiptRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetReceiptRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GetReceiptRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type ResendReceiptRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
-	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Operation     string                 `protobuf:"bytes,8,opt,name=operation,proto3" json:"operation,omitempty"`
-	Broker        string                 `protobuf:"bytes,9,opt,name=broker,proto3" json:"broker,omitempty"`
-	Metadata      *structpb.Struct       `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // альтернатива payment_id
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`                      // SALE (по умолчанию), REFUND
+	Customer      *Customer              `protobuf:"bytes,4,opt,name=customer,proto3" json:"customer,omitempty"`              // пусто — контакты, переданные при создании платежа
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPaymentResponse) Reset() {
-	*x = GetPaymentResponse{}
-	mi := &file_payment_proto_msgTypes[11]
+func (x *ResendReceiptRequest) Reset() {
+	*x = ResendReceiptRequest{}
+	mi := &file_payment_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPaymentResponse) String() string {
+func (x *ResendReceiptRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPaymentResponse) ProtoMessage() {}
+func (*ResendReceiptRequest) ProtoMessage() {}
 
-func (x *GetPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[11]
+func (x *ResendReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -709,191 +5902,219 @@ func (x *GetPaymentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPaymentResponse.ProtoReflect.Descriptor instead.
-func (*GetPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ResendReceiptRequest.ProtoReflect.Descriptor instead.
+func (*ResendReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *GetPaymentResponse) GetPaymentId() string {
+func (x *ResendReceiptRequest) GetPaymentId() string {
 	if x != nil {
 		return x.PaymentId
 	}
 	return ""
 }
 
-func (x *GetPaymentResponse) GetOrderId() string {
+func (x *ResendReceiptRequest) GetOrderId() string {
 	if x != nil {
 		return x.OrderId
 	}
 	return ""
 }
 
-func (x *GetPaymentResponse) GetUserId() string {
+func (x *ResendReceiptRequest) GetKind() string {
 	if x != nil {
-		return x.UserId
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *GetPaymentResponse) GetAmount() float64 {
+func (x *ResendReceiptRequest) GetCustomer() *Customer {
 	if x != nil {
-		return x.Amount
+		return x.Customer
 	}
-	return 0
+	return nil
 }
 
-func (x *GetPaymentResponse) GetCurrency() string {
+type Receipt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceiptId     string                 `protobuf:"bytes,1,opt,name=receipt_id,json=receiptId,proto3" json:"receipt_id,omitempty"`
+	PaymentId     string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`   // PENDING, ISSUED, FAILED
+	Amount        float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"` // в валюте покупателя
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Provider      string                 `protobuf:"bytes,7,opt,name=provider,proto3" json:"provider,omitempty"`
+	FiscalNumber  string                 `protobuf:"bytes,8,opt,name=fiscal_number,json=fiscalNumber,proto3" json:"fiscal_number,omitempty"`
+	FiscalUrl     string                 `protobuf:"bytes,9,opt,name=fiscal_url,json=fiscalUrl,proto3" json:"fiscal_url,omitempty"`
+	Attempts      int32                  `protobuf:"varint,10,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                 `protobuf:"bytes,11,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	SentAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	Document      *ReceiptDocument       `protobuf:"bytes,15,opt,name=document,proto3" json:"document,omitempty"` // только в GetReceipt
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Receipt) Reset() {
+	*x = Receipt{}
+	mi := &file_payment_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receipt) ProtoMessage() {}
+
+func (x *Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[84]
 	if x != nil {
-		return x.Currency
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *GetPaymentResponse) GetStatus() string {
+// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *Receipt) GetReceiptId() string {
 	if x != nil {
-		return x.Status
+		return x.ReceiptId
 	}
 	return ""
 }
 
-func (x *GetPaymentResponse) GetCreatedAt() *timestamppb.Timestamp {
+func (x *Receipt) GetPaymentId() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.PaymentId
 	}
-	return nil
+	return ""
 }
 
-func (x *GetPaymentResponse) GetOperation() string {
+func (x *Receipt) GetKind() string {
 	if x != nil {
-		return x.Operation
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *GetPaymentResponse) GetBroker() string {
+func (x *Receipt) GetStatus() string {
 	if x != nil {
-		return x.Broker
+		return x.Status
 	}
 	return ""
 }
 
-func (x *GetPaymentResponse) GetMetadata() *structpb.Struct {
+func (x *Receipt) GetAmount() float64 {
 	if x != nil {
-		return x.Metadata
+		return x.Amount
 	}
-	return nil
-}
-
-type GetPaymentStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetPaymentStatusRequest) Reset() {
-	*x = GetPaymentStatusRequest{}
-	mi := &file_payment_proto_msgTypes[12]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return 0
 }
 
-func (x *GetPaymentStatusRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Receipt) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
 }
 
-func (*GetPaymentStatusRequest) ProtoMessage() {}
-
-func (x *GetPaymentStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[12]
+func (x *Receipt) GetProvider() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Provider
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetPaymentStatusRequest.ProtoReflect.Descriptor instead.
-func (*GetPaymentStatusRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{12}
+func (x *Receipt) GetFiscalNumber() string {
+	if x != nil {
+		return x.FiscalNumber
+	}
+	return ""
 }
 
-func (x *GetPaymentStatusRequest) GetPaymentId() string {
+func (x *Receipt) GetFiscalUrl() string {
 	if x != nil {
-		return x.PaymentId
+		return x.FiscalUrl
 	}
 	return ""
 }
 
-type GetPaymentStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Receipt) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
 }
 
-func (x *GetPaymentStatusResponse) Reset() {
-	*x = GetPaymentStatusResponse{}
-	mi := &file_payment_proto_msgTypes[13]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Receipt) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
 }
 
-func (x *GetPaymentStatusResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Receipt) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (*GetPaymentStatusResponse) ProtoMessage() {}
-
-func (x *GetPaymentStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[13]
+func (x *Receipt) GetIssuedAt() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.IssuedAt
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetPaymentStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetPaymentStatusResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{13}
+func (x *Receipt) GetSentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAt
+	}
+	return nil
 }
 
-func (x *GetPaymentStatusResponse) GetStatus() string {
+func (x *Receipt) GetDocument() *ReceiptDocument {
 	if x != nil {
-		return x.Status
+		return x.Document
 	}
-	return ""
+	return nil
 }
 
-type SuccessPaymentRequest struct {
+type ReceiptDocument struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Content       []byte                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"` // в REST — base64
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SuccessPaymentRequest) Reset() {
-	*x = SuccessPaymentRequest{}
-	mi := &file_payment_proto_msgTypes[14]
+func (x *ReceiptDocument) Reset() {
+	*x = ReceiptDocument{}
+	mi := &file_payment_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SuccessPaymentRequest) String() string {
+func (x *ReceiptDocument) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SuccessPaymentRequest) ProtoMessage() {}
+func (*ReceiptDocument) ProtoMessage() {}
 
-func (x *SuccessPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[14]
+func (x *ReceiptDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -904,40 +6125,53 @@ func (x *SuccessPaymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SuccessPaymentRequest.ProtoReflect.Descriptor instead.
-func (*SuccessPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ReceiptDocument.ProtoReflect.Descriptor instead.
+func (*ReceiptDocument) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *SuccessPaymentRequest) GetPaymentId() string {
+func (x *ReceiptDocument) GetFormat() string {
 	if x != nil {
-		return x.PaymentId
+		return x.Format
 	}
 	return ""
 }
 
-type SuccessPaymentResponse struct {
+func (x *ReceiptDocument) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ReceiptDocument) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type ListCurrenciesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SuccessPaymentResponse) Reset() {
-	*x = SuccessPaymentResponse{}
-	mi := &file_payment_proto_msgTypes[15]
+func (x *ListCurrenciesRequest) Reset() {
+	*x = ListCurrenciesRequest{}
+	mi := &file_payment_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SuccessPaymentResponse) String() string {
+func (x *ListCurrenciesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SuccessPaymentResponse) ProtoMessage() {}
+func (*ListCurrenciesRequest) ProtoMessage() {}
 
-func (x *SuccessPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[15]
+func (x *ListCurrenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -948,42 +6182,37 @@ func (x *SuccessPaymentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SuccessPaymentResponse.ProtoReflect.Descriptor instead.
-func (*SuccessPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *SuccessPaymentResponse) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
+// Deprecated: Use ListCurrenciesRequest.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{86}
 }
 
-type ListPaymentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type Currency struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Code            string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // ISO 4217
+	NumericCode     string                 `protobuf:"bytes,2,opt,name=numeric_code,json=numericCode,proto3" json:"numeric_code,omitempty"`
+	Exponent        int32                  `protobuf:"varint,3,opt,name=exponent,proto3" json:"exponent,omitempty"`                                      // количество знаков минорной единицы
+	Enabled         bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`                                        // доступна мерчанту
+	BrokerSupported bool                   `protobuf:"varint,5,opt,name=broker_supported,json=brokerSupported,proto3" json:"broker_supported,omitempty"` // банк принимает без пересчёта
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ListPaymentsRequest) Reset() {
-	*x = ListPaymentsRequest{}
-	mi := &file_payment_proto_msgTypes[16]
+func (x *Currency) Reset() {
+	*x = Currency{}
+	mi := &file_payment_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPaymentsRequest) String() string {
+func (x *Currency) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPaymentsRequest) ProtoMessage() {}
+func (*Currency) ProtoMessage() {}
 
-func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[16]
+func (x *Currency) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -994,55 +6223,69 @@ func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPaymentsRequest.ProtoReflect.Descriptor instead.
-func (*ListPaymentsRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use Currency.ProtoReflect.Descriptor instead.
+func (*Currency) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *ListPaymentsRequest) GetPage() int32 {
+func (x *Currency) GetCode() string {
 	if x != nil {
-		return x.Page
+		return x.Code
 	}
-	return 0
+	return ""
 }
 
-func (x *ListPaymentsRequest) GetPageSize() int32 {
+func (x *Currency) GetNumericCode() string {
 	if x != nil {
-		return x.PageSize
+		return x.NumericCode
+	}
+	return ""
+}
+
+func (x *Currency) GetExponent() int32 {
+	if x != nil {
+		return x.Exponent
 	}
 	return 0
 }
 
-func (x *ListPaymentsRequest) GetUserId() string {
+func (x *Currency) GetEnabled() bool {
 	if x != nil {
-		return x.UserId
+		return x.Enabled
 	}
-	return ""
+	return false
 }
 
-type ListPaymentsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Payments      []*GetPaymentResponse  `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Currency) GetBrokerSupported() bool {
+	if x != nil {
+		return x.BrokerSupported
+	}
+	return false
 }
 
-func (x *ListPaymentsResponse) Reset() {
-	*x = ListPaymentsResponse{}
-	mi := &file_payment_proto_msgTypes[17]
+type ListCurrenciesResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Currencies         []*Currency            `protobuf:"bytes,1,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	SettlementCurrency string                 `protobuf:"bytes,2,opt,name=settlement_currency,json=settlementCurrency,proto3" json:"settlement_currency,omitempty"` // валюта расчётов для пересчитанных платежей
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListCurrenciesResponse) Reset() {
+	*x = ListCurrenciesResponse{}
+	mi := &file_payment_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPaymentsResponse) String() string {
+func (x *ListCurrenciesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPaymentsResponse) ProtoMessage() {}
+func (*ListCurrenciesResponse) ProtoMessage() {}
 
-func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[17]
+func (x *ListCurrenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1053,23 +6296,23 @@ func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPaymentsResponse.ProtoReflect.Descriptor instead.
-func (*ListPaymentsResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ListCurrenciesResponse.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{88}
 }
 
-func (x *ListPaymentsResponse) GetPayments() []*GetPaymentResponse {
+func (x *ListCurrenciesResponse) GetCurrencies() []*Currency {
 	if x != nil {
-		return x.Payments
+		return x.Currencies
 	}
 	return nil
 }
 
-func (x *ListPaymentsResponse) GetTotal() int32 {
+func (x *ListCurrenciesResponse) GetSettlementCurrency() string {
 	if x != nil {
-		return x.Total
+		return x.SettlementCurrency
 	}
-	return 0
+	return ""
 }
 
 type HealthCheckRequest struct {
@@ -1080,7 +6323,7 @@ type HealthCheckRequest struct {
 
 func (x *HealthCheckRequest) Reset() {
 	*x = HealthCheckRequest{}
-	mi := &file_payment_proto_msgTypes[18]
+	mi := &file_payment_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1092,7 +6335,7 @@ func (x *HealthCheckRequest) String() string {
 func (*HealthCheckRequest) ProtoMessage() {}
 
 func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[18]
+	mi := &file_payment_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1105,7 +6348,7 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
 func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{18}
+	return file_payment_proto_rawDescGZIP(), []int{89}
 }
 
 type HealthCheckResponse struct {
@@ -1119,7 +6362,7 @@ type HealthCheckResponse struct {
 
 func (x *HealthCheckResponse) Reset() {
 	*x = HealthCheckResponse{}
-	mi := &file_payment_proto_msgTypes[19]
+	mi := &file_payment_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1131,7 +6374,7 @@ func (x *HealthCheckResponse) String() string {
 func (*HealthCheckResponse) ProtoMessage() {}
 
 func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[19]
+	mi := &file_payment_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1144,7 +6387,7 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{19}
+	return file_payment_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *HealthCheckResponse) GetDatabaseOk() bool {
@@ -1173,7 +6416,7 @@ var File_payment_proto protoreflect.FileDescriptor
 const file_payment_proto_rawDesc = "" +
 	"\n" +
 	"\rpayment.proto\x12\n" +
-	"payment.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"\x8d\x02\n" +
+	"payment.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xab\x04\n" +
 	"\x14CreatePaymentRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
@@ -1183,12 +6426,40 @@ const file_payment_proto_rawDesc = "" +
 	"return_url\x18\x05 \x01(\tR\treturnUrl\x12\x1b\n" +
 	"\terror_url\x18\x06 \x01(\tR\berrorUrl\x12\x1c\n" +
 	"\toperation\x18\a \x01(\tR\toperation\x123\n" +
-	"\bmetadata\x18\b \x01(\v2\x17.google.protobuf.StructR\bmetadata\"W\n" +
+	"\bmetadata\x18\b \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12-\n" +
+	"\x06splits\x18\t \x03(\v2\x15.payment.v1.SplitRuleR\x06splits\x12\x1d\n" +
+	"\n" +
+	"binding_id\x18\n" +
+	" \x01(\tR\tbindingId\x12\x1d\n" +
+	"\n" +
+	"card_token\x18\v \x01(\tR\tcardToken\x12/\n" +
+	"\x06wallet\x18\f \x01(\v2\x17.payment.v1.WalletTokenR\x06wallet\x12 \n" +
+	"\vdescription\x18\r \x01(\tR\vdescription\x120\n" +
+	"\bcustomer\x18\x0e \x01(\v2\x14.payment.v1.CustomerR\bcustomer\x12*\n" +
+	"\x05items\x18\x0f \x03(\v2\x14.payment.v1.CartItemR\x05items\"6\n" +
+	"\bCustomer\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\"\x83\x01\n" +
+	"\bCartItem\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x19\n" +
+	"\btax_rate\x18\x04 \x01(\x01R\ataxRate\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\"7\n" +
+	"\vWalletToken\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"@\n" +
+	"\tSplitRule\x12\x1b\n" +
+	"\tseller_id\x18\x01 \x01(\tR\bsellerId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"\x8e\x01\n" +
 	"\x15CreatePaymentResponse\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x1f\n" +
 	"\vpayment_url\x18\x02 \x01(\tR\n" +
-	"paymentUrl\"\xed\x01\n" +
+	"paymentUrl\x12\x1d\n" +
+	"\n" +
+	"qr_payload\x18\x03 \x01(\tR\tqrPayload\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"\xed\x02\n" +
 	"\x12AuthPaymentRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
@@ -1197,35 +6468,50 @@ const file_payment_proto_rawDesc = "" +
 	"\n" +
 	"return_url\x18\x05 \x01(\tR\treturnUrl\x12\x1b\n" +
 	"\terror_url\x18\x06 \x01(\tR\berrorUrl\x123\n" +
-	"\bmetadata\x18\a \x01(\v2\x17.google.protobuf.StructR\bmetadata\"U\n" +
+	"\bmetadata\x18\a \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x120\n" +
+	"\bcustomer\x18\t \x01(\v2\x14.payment.v1.CustomerR\bcustomer\x12*\n" +
+	"\x05items\x18\n" +
+	" \x03(\v2\x14.payment.v1.CartItemR\x05items\"m\n" +
 	"\x13AuthPaymentResponse\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x1f\n" +
 	"\vpayment_url\x18\x02 \x01(\tR\n" +
-	"paymentUrl\"j\n" +
+	"paymentUrl\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\x9b\x01\n" +
 	"\x15DepositPaymentRequest\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
-	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"0\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x19\n" +
+	"\border_id\x18\x04 \x01(\tR\aorderId\x12\x14\n" +
+	"\x05final\x18\x05 \x01(\bR\x05final\"\x84\x01\n" +
 	"\x16DepositPaymentResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"M\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12'\n" +
+	"\x0fcaptured_amount\x18\x02 \x01(\x01R\x0ecapturedAmount\x12)\n" +
+	"\x10remaining_amount\x18\x03 \x01(\x01R\x0fremainingAmount\"\x9c\x01\n" +
 	"\x14RefundPaymentRequest\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x16\n" +
-	"\x06reason\x18\x02 \x01(\tR\x06reason\"/\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"/\n" +
 	"\x15RefundPaymentResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"k\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\x86\x01\n" +
 	"\x16ReversalPaymentRequest\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
-	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"1\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x19\n" +
+	"\border_id\x18\x04 \x01(\tR\aorderId\"1\n" +
 	"\x17ReversalPaymentResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\"2\n" +
 	"\x11GetPaymentRequest\x12\x1d\n" +
 	"\n" +
-	"payment_id\x18\x01 \x01(\tR\tpaymentId\"\xd9\x02\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\"7\n" +
+	"\x1aGetPaymentByOrderIdRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\xa6\b\n" +
 	"\x12GetPaymentResponse\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
@@ -1239,31 +6525,497 @@ const file_payment_proto_rawDesc = "" +
 	"\toperation\x18\b \x01(\tR\toperation\x12\x16\n" +
 	"\x06broker\x18\t \x01(\tR\x06broker\x123\n" +
 	"\bmetadata\x18\n" +
-	" \x01(\v2\x17.google.protobuf.StructR\bmetadata\"8\n" +
+	" \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12+\n" +
+	"\x11authorized_amount\x18\v \x01(\x01R\x10authorizedAmount\x12'\n" +
+	"\x0fcaptured_amount\x18\f \x01(\x01R\x0ecapturedAmount\x12'\n" +
+	"\x0freleased_amount\x18\r \x01(\x01R\x0ereleasedAmount\x12)\n" +
+	"\x10remaining_amount\x18\x0e \x01(\x01R\x0fremainingAmount\x12\x1d\n" +
+	"\n" +
+	"fee_amount\x18\x0f \x01(\x01R\tfeeAmount\x12'\n" +
+	"\x0frefunded_amount\x18\x10 \x01(\x01R\x0erefundedAmount\x12\x1d\n" +
+	"\n" +
+	"net_amount\x18\x11 \x01(\x01R\tnetAmount\x12-\n" +
+	"\x12presentment_amount\x18\x12 \x01(\x01R\x11presentmentAmount\x121\n" +
+	"\x14presentment_currency\x18\x13 \x01(\tR\x13presentmentCurrency\x12\x17\n" +
+	"\afx_rate\x18\x14 \x01(\x01R\x06fxRate\x12$\n" +
+	"\x0efx_rate_source\x18\x15 \x01(\tR\ffxRateSource\x128\n" +
+	"\n" +
+	"fx_rate_at\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampR\bfxRateAt\x12#\n" +
+	"\rrisk_decision\x18\x17 \x01(\tR\friskDecision\x12\x1d\n" +
+	"\n" +
+	"risk_rules\x18\x18 \x03(\tR\triskRules\x12\x1b\n" +
+	"\tclient_ip\x18\x19 \x01(\tR\bclientIp\x12 \n" +
+	"\vdescription\x18\x1a \x01(\tR\vdescription\x120\n" +
+	"\bcustomer\x18\x1b \x01(\v2\x14.payment.v1.CustomerR\bcustomer\x12*\n" +
+	"\x05items\x18\x1c \x03(\v2\x14.payment.v1.CartItemR\x05items\"S\n" +
 	"\x17GetPaymentStatusRequest\x12\x1d\n" +
 	"\n" +
-	"payment_id\x18\x01 \x01(\tR\tpaymentId\"2\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"2\n" +
 	"\x18GetPaymentStatusResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"6\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"T\n" +
+	"\x18GetPaymentHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"\xa0\x01\n" +
+	"\x12PaymentStatusEntry\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x1f\n" +
+	"\vbroker_code\x18\x04 \x01(\tR\n" +
+	"brokerCode\"t\n" +
+	"\x19GetPaymentHistoryResponse\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x128\n" +
+	"\ahistory\x18\x02 \x03(\v2\x1e.payment.v1.PaymentStatusEntryR\ahistory\"Q\n" +
 	"\x15SuccessPaymentRequest\x12\x1d\n" +
 	"\n" +
-	"payment_id\x18\x01 \x01(\tR\tpaymentId\"0\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\"0\n" +
 	"\x16SuccessPaymentResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"_\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\x93\x05\n" +
 	"\x13ListPaymentsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\tR\x06userId\"h\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bstatuses\x18\x04 \x03(\tR\bstatuses\x12\x1e\n" +
+	"\n" +
+	"currencies\x18\x05 \x03(\tR\n" +
+	"currencies\x12\x16\n" +
+	"\x06broker\x18\x06 \x01(\tR\x06broker\x12&\n" +
+	"\x0forder_id_prefix\x18\a \x01(\tR\rorderIdPrefix\x12=\n" +
+	"\fcreated_from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\"\n" +
+	"\n" +
+	"amount_min\x18\n" +
+	" \x01(\x01H\x00R\tamountMin\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"amount_max\x18\v \x01(\x01H\x01R\tamountMax\x88\x01\x01\x12\x17\n" +
+	"\asort_by\x18\f \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\r \x01(\tR\tsortOrder\x12\x16\n" +
+	"\x06cursor\x18\x0e \x01(\tR\x06cursor\x12I\n" +
+	"\bmetadata\x18\x0f \x03(\v2-.payment.v1.ListPaymentsRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\r\n" +
+	"\v_amount_minB\r\n" +
+	"\v_amount_max\"\x89\x01\n" +
 	"\x14ListPaymentsResponse\x12:\n" +
 	"\bpayments\x18\x01 \x03(\v2\x1e.payment.v1.GetPaymentResponseR\bpayments\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\xe6\x04\n" +
+	"\x15ExportPaymentsRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bstatuses\x18\x03 \x03(\tR\bstatuses\x12\x1e\n" +
+	"\n" +
+	"currencies\x18\x04 \x03(\tR\n" +
+	"currencies\x12\x16\n" +
+	"\x06broker\x18\x05 \x01(\tR\x06broker\x12&\n" +
+	"\x0forder_id_prefix\x18\x06 \x01(\tR\rorderIdPrefix\x12=\n" +
+	"\fcreated_from\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\"\n" +
+	"\n" +
+	"amount_min\x18\t \x01(\x01H\x00R\tamountMin\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"amount_max\x18\n" +
+	" \x01(\x01H\x01R\tamountMax\x88\x01\x01\x12K\n" +
+	"\bmetadata\x18\v \x03(\v2/.payment.v1.ExportPaymentsRequest.MetadataEntryR\bmetadata\x12\x17\n" +
+	"\asort_by\x18\f \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\r \x01(\tR\tsortOrder\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\r\n" +
+	"\v_amount_minB\r\n" +
+	"\v_amount_max\"`\n" +
+	"\x13ExportPaymentsChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04rows\x18\x03 \x01(\x05R\x04rows\"\xa9\x02\n" +
+	"\x13ListAuditLogRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\tR\x06result\x12=\n" +
+	"\fcreated_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\tR\x06cursor\"\xcb\x02\n" +
+	"\n" +
+	"AuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x04 \x01(\tR\tpaymentId\x12/\n" +
+	"\x06params\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x06params\x12\x16\n" +
+	"\x06result\x18\x06 \x01(\tR\x06result\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12!\n" +
+	"\fbroker_error\x18\b \x01(\tR\vbrokerError\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12#\n" +
+	"\rclaimed_actor\x18\n" +
+	" \x01(\tR\fclaimedActor\"i\n" +
+	"\x14ListAuditLogResponse\x120\n" +
+	"\aentries\x18\x01 \x03(\v2\x16.payment.v1.AuditEntryR\aentries\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xea\x01\n" +
+	"\aBinding\x12\x1d\n" +
+	"\n" +
+	"binding_id\x18\x01 \x01(\tR\tbindingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06broker\x18\x03 \x01(\tR\x06broker\x12\x1d\n" +
+	"\n" +
+	"masked_pan\x18\x04 \x01(\tR\tmaskedPan\x12\x16\n" +
+	"\x06expiry\x18\x05 \x01(\tR\x06expiry\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x06 \x01(\tR\tpaymentId\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\".\n" +
+	"\x13ListBindingsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"G\n" +
+	"\x14ListBindingsResponse\x12/\n" +
+	"\bbindings\x18\x01 \x03(\v2\x13.payment.v1.BindingR\bbindings\"N\n" +
+	"\x14DeleteBindingRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"binding_id\x18\x02 \x01(\tR\tbindingId\"\x17\n" +
+	"\x15DeleteBindingResponse\"\x9d\x01\n" +
+	"\x14ChargeBindingRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"binding_id\x18\x03 \x01(\tR\tbindingId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"N\n" +
+	"\x15ChargeBindingResponse\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"I\n" +
+	"\x14ReviewPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\"N\n" +
+	"\x15RejectPaymentResponse\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x82\x02\n" +
+	"\x04Plan\x12\x17\n" +
+	"\aplan_id\x18\x01 \x01(\tR\x06planId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12'\n" +
+	"\x0finterval_months\x18\x05 \x01(\x05R\x0eintervalMonths\x12\x1d\n" +
+	"\n" +
+	"trial_days\x18\x06 \x01(\x05R\ttrialDays\x12\x16\n" +
+	"\x06active\x18\a \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa3\x01\n" +
+	"\x11CreatePlanRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12'\n" +
+	"\x0finterval_months\x18\x04 \x01(\x05R\x0eintervalMonths\x12\x1d\n" +
+	"\n" +
+	"trial_days\x18\x05 \x01(\x05R\ttrialDays\"=\n" +
+	"\x10ListPlansRequest\x12)\n" +
+	"\x10include_inactive\x18\x01 \x01(\bR\x0fincludeInactive\";\n" +
+	"\x11ListPlansResponse\x12&\n" +
+	"\x05plans\x18\x01 \x03(\v2\x10.payment.v1.PlanR\x05plans\"\xfa\x04\n" +
+	"\fSubscription\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x17\n" +
+	"\aplan_id\x18\x03 \x01(\tR\x06planId\x12\x1d\n" +
+	"\n" +
+	"binding_id\x18\x04 \x01(\tR\tbindingId\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12L\n" +
+	"\x14current_period_start\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x12currentPeriodStart\x12H\n" +
+	"\x12current_period_end\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x10currentPeriodEnd\x12@\n" +
+	"\x0enext_charge_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\fnextChargeAt\x12\x14\n" +
+	"\x05cycle\x18\t \x01(\x05R\x05cycle\x12'\n" +
+	"\x0ffailed_attempts\x18\n" +
+	" \x01(\x05R\x0efailedAttempts\x12\x16\n" +
+	"\x06credit\x18\v \x01(\x01R\x06credit\x12/\n" +
+	"\x14cancel_at_period_end\x18\f \x01(\bR\x11cancelAtPeriodEnd\x12;\n" +
+	"\vcanceled_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"canceledAt\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"l\n" +
+	"\x19CreateSubscriptionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aplan_id\x18\x02 \x01(\tR\x06planId\x12\x1d\n" +
+	"\n" +
+	"binding_id\x18\x03 \x01(\tR\tbindingId\"A\n" +
+	"\x16GetSubscriptionRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\"h\n" +
+	"\x19CancelSubscriptionRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\x12\"\n" +
+	"\rat_period_end\x18\x02 \x01(\bR\vatPeriodEnd\"a\n" +
+	"\x1dChangeSubscriptionPlanRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\x12\x17\n" +
+	"\aplan_id\x18\x02 \x01(\tR\x06planId\"\xea\x01\n" +
+	"\x11SubscriptionEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\tR\x0esubscriptionId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x04 \x01(\tR\tpaymentId\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"H\n" +
+	"\x1dListSubscriptionEventsRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\"W\n" +
+	"\x1eListSubscriptionEventsResponse\x125\n" +
+	"\x06events\x18\x01 \x03(\v2\x1d.payment.v1.SubscriptionEventR\x06events\"\x99\x02\n" +
+	"\x14CreateInvoiceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\tmulti_use\x18\x06 \x01(\bR\bmultiUse\x12\x1d\n" +
+	"\n" +
+	"return_url\x18\a \x01(\tR\treturnUrl\x12\x1b\n" +
+	"\terror_url\x18\b \x01(\tR\berrorUrl\"2\n" +
+	"\x11GetInvoiceRequest\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x01 \x01(\tR\tinvoiceId\"\x82\x01\n" +
+	"\x0eInvoicePayment\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xbf\x03\n" +
+	"\aInvoice\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x01 \x01(\tR\tinvoiceId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\tmulti_use\x18\t \x01(\bR\bmultiUse\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"paid_count\x18\v \x01(\x05R\tpaidCount\x126\n" +
+	"\bpayments\x18\f \x03(\v2\x1a.payment.v1.InvoicePaymentR\bpayments\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"6\n" +
+	"\x17GetSellerBalanceRequest\x12\x1b\n" +
+	"\tseller_id\x18\x01 \x01(\tR\bsellerId\"d\n" +
+	"\rSellerBalance\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\x01R\tavailable\x12\x19\n" +
+	"\bpaid_out\x18\x03 \x01(\x01R\apaidOut\"n\n" +
+	"\x18GetSellerBalanceResponse\x12\x1b\n" +
+	"\tseller_id\x18\x01 \x01(\tR\bsellerId\x125\n" +
+	"\bbalances\x18\x02 \x03(\v2\x19.payment.v1.SellerBalanceR\bbalances\"\x15\n" +
+	"\x13CreatePayoutRequest\"/\n" +
+	"\x10GetPayoutRequest\x12\x1b\n" +
+	"\tpayout_id\x18\x01 \x01(\tR\bpayoutId\"w\n" +
+	"\n" +
+	"PayoutLine\x12\x1b\n" +
+	"\tseller_id\x18\x01 \x01(\tR\bsellerId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x18\n" +
+	"\aentries\x18\x04 \x01(\x05R\aentries\"\x8e\x01\n" +
+	"\x06Payout\x12\x1b\n" +
+	"\tpayout_id\x18\x01 \x01(\tR\bpayoutId\x12,\n" +
+	"\x05lines\x18\x02 \x03(\v2\x16.payment.v1.PayoutLineR\x05lines\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"I\n" +
+	"\x16GetTrialBalanceRequest\x12/\n" +
+	"\x05as_of\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"\xa4\x01\n" +
+	"\x10TrialBalanceLine\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05debit\x18\x04 \x01(\x01R\x05debit\x12\x16\n" +
+	"\x06credit\x18\x05 \x01(\x01R\x06credit\x12\x18\n" +
+	"\abalance\x18\x06 \x01(\x01R\abalance\"\x9a\x01\n" +
+	"\x17GetTrialBalanceResponse\x12/\n" +
+	"\x05as_of\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x12\x1a\n" +
+	"\bbalanced\x18\x02 \x01(\bR\bbalanced\x122\n" +
+	"\x05lines\x18\x03 \x03(\v2\x1c.payment.v1.TrialBalanceLineR\x05lines\"\xd6\x02\n" +
+	"\rColumnMapping\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1c\n" +
+	"\tdelimiter\x18\x06 \x01(\tR\tdelimiter\x12\x1f\n" +
+	"\vminor_units\x18\a \x01(\bR\n" +
+	"minorUnits\x12C\n" +
+	"\bstatuses\x18\b \x03(\v2'.payment.v1.ColumnMapping.StatusesEntryR\bstatuses\x1a;\n" +
+	"\rStatusesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x80\x02\n" +
+	"\x18RunReconciliationRequest\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x123\n" +
+	"\amapping\x18\x03 \x01(\v2\x19.payment.v1.ColumnMappingR\amapping\x12=\n" +
+	"\fperiod_start\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x129\n" +
+	"\n" +
+	"period_end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tperiodEnd\"G\n" +
+	"\x18GetReconciliationRequest\x12+\n" +
+	"\x11reconciliation_id\x18\x01 \x01(\tR\x10reconciliationId\"\xe3\x01\n" +
+	"\vDiscrepancy\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vbank_amount\x18\x04 \x01(\x01R\n" +
+	"bankAmount\x12!\n" +
+	"\flocal_amount\x18\x05 \x01(\x01R\vlocalAmount\x12\x1f\n" +
+	"\vbank_status\x18\x06 \x01(\tR\n" +
+	"bankStatus\x12!\n" +
+	"\flocal_status\x18\a \x01(\tR\vlocalStatus\"\xfe\x02\n" +
+	"\x0eReconciliation\x12+\n" +
+	"\x11reconciliation_id\x18\x01 \x01(\tR\x10reconciliationId\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12=\n" +
+	"\fperiod_start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x129\n" +
+	"\n" +
+	"period_end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tperiodEnd\x12\x14\n" +
+	"\x05lines\x18\x05 \x01(\x05R\x05lines\x12\x18\n" +
+	"\amatched\x18\x06 \x01(\x05R\amatched\x12=\n" +
+	"\rdiscrepancies\x18\a \x03(\v2\x17.payment.v1.DiscrepancyR\rdiscrepancies\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"N\n" +
+	"\aFeeTier\x12\x13\n" +
+	"\x05up_to\x18\x01 \x01(\x01R\x04upTo\x12\x18\n" +
+	"\apercent\x18\x02 \x01(\x01R\apercent\x12\x14\n" +
+	"\x05fixed\x18\x03 \x01(\x01R\x05fixed\"\x9a\x02\n" +
+	"\x18CreateFeeScheduleRequest\x12\x16\n" +
+	"\x06broker\x18\x01 \x01(\tR\x06broker\x12\x1a\n" +
+	"\bmerchant\x18\x02 \x01(\tR\bmerchant\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x18\n" +
+	"\apercent\x18\x04 \x01(\x01R\apercent\x12\x14\n" +
+	"\x05fixed\x18\x05 \x01(\x01R\x05fixed\x12\x17\n" +
+	"\amin_fee\x18\x06 \x01(\x01R\x06minFee\x12\x17\n" +
+	"\amax_fee\x18\a \x01(\x01R\x06maxFee\x12)\n" +
+	"\x05tiers\x18\b \x03(\v2\x13.payment.v1.FeeTierR\x05tiers\x12!\n" +
+	"\frefund_fixed\x18\t \x01(\x01R\vrefundFixed\"D\n" +
+	"\x17ListFeeSchedulesRequest\x12)\n" +
+	"\x10include_inactive\x18\x01 \x01(\bR\x0fincludeInactive\"?\n" +
+	"\x1cDeactivateFeeScheduleRequest\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\"\x81\x03\n" +
+	"\vFeeSchedule\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\x12\x16\n" +
+	"\x06broker\x18\x02 \x01(\tR\x06broker\x12\x1a\n" +
+	"\bmerchant\x18\x03 \x01(\tR\bmerchant\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x18\n" +
+	"\apercent\x18\x05 \x01(\x01R\apercent\x12\x14\n" +
+	"\x05fixed\x18\x06 \x01(\x01R\x05fixed\x12\x17\n" +
+	"\amin_fee\x18\a \x01(\x01R\x06minFee\x12\x17\n" +
+	"\amax_fee\x18\b \x01(\x01R\x06maxFee\x12)\n" +
+	"\x05tiers\x18\t \x03(\v2\x13.payment.v1.FeeTierR\x05tiers\x12!\n" +
+	"\frefund_fixed\x18\n" +
+	" \x01(\x01R\vrefundFixed\x12\x16\n" +
+	"\x06active\x18\v \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"Q\n" +
+	"\x18ListFeeSchedulesResponse\x125\n" +
+	"\tschedules\x18\x01 \x03(\v2\x17.payment.v1.FeeScheduleR\tschedules\"\xaf\x01\n" +
+	"\x12CreateLimitRequest\x12\x14\n" +
+	"\x05scope\x18\x01 \x01(\tR\x05scope\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"max_single\x18\x04 \x01(\x01R\tmaxSingle\x12\x14\n" +
+	"\x05daily\x18\x05 \x01(\x01R\x05daily\x12\x18\n" +
+	"\amonthly\x18\x06 \x01(\x01R\amonthly\">\n" +
+	"\x11ListLimitsRequest\x12)\n" +
+	"\x10include_inactive\x18\x01 \x01(\bR\x0fincludeInactive\"3\n" +
+	"\x16DeactivateLimitRequest\x12\x19\n" +
+	"\blimit_id\x18\x01 \x01(\tR\alimitId\"\x97\x02\n" +
+	"\fPaymentLimit\x12\x19\n" +
+	"\blimit_id\x18\x01 \x01(\tR\alimitId\x12\x14\n" +
+	"\x05scope\x18\x02 \x01(\tR\x05scope\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"max_single\x18\x05 \x01(\x01R\tmaxSingle\x12\x14\n" +
+	"\x05daily\x18\x06 \x01(\x01R\x05daily\x12\x18\n" +
+	"\amonthly\x18\a \x01(\x01R\amonthly\x12\x16\n" +
+	"\x06active\x18\b \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"F\n" +
+	"\x12ListLimitsResponse\x120\n" +
+	"\x06limits\x18\x01 \x03(\v2\x18.payment.v1.PaymentLimitR\x06limits\"y\n" +
+	"\x11GetReceiptRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\"\x96\x01\n" +
+	"\x14ResendReceiptRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x120\n" +
+	"\bcustomer\x18\x04 \x01(\v2\x14.payment.v1.CustomerR\bcustomer\"\xa4\x04\n" +
+	"\aReceipt\x12\x1d\n" +
+	"\n" +
+	"receipt_id\x18\x01 \x01(\tR\treceiptId\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x1a\n" +
+	"\bprovider\x18\a \x01(\tR\bprovider\x12#\n" +
+	"\rfiscal_number\x18\b \x01(\tR\ffiscalNumber\x12\x1d\n" +
+	"\n" +
+	"fiscal_url\x18\t \x01(\tR\tfiscalUrl\x12\x1a\n" +
+	"\battempts\x18\n" +
+	" \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\v \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tissued_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x123\n" +
+	"\asent_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x06sentAt\x127\n" +
+	"\bdocument\x18\x0f \x01(\v2\x1b.payment.v1.ReceiptDocumentR\bdocument\"f\n" +
+	"\x0fReceiptDocument\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"\x17\n" +
+	"\x15ListCurrenciesRequest\"\xa2\x01\n" +
+	"\bCurrency\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12!\n" +
+	"\fnumeric_code\x18\x02 \x01(\tR\vnumericCode\x12\x1a\n" +
+	"\bexponent\x18\x03 \x01(\x05R\bexponent\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x12)\n" +
+	"\x10broker_supported\x18\x05 \x01(\bR\x0fbrokerSupported\"\x7f\n" +
+	"\x16ListCurrenciesResponse\x124\n" +
+	"\n" +
+	"currencies\x18\x01 \x03(\v2\x14.payment.v1.CurrencyR\n" +
+	"currencies\x12/\n" +
+	"\x13settlement_currency\x18\x02 \x01(\tR\x12settlementCurrency\"\x14\n" +
 	"\x12HealthCheckRequest\"\x8e\x01\n" +
 	"\x13HealthCheckResponse\x12\x1f\n" +
 	"\vdatabase_ok\x18\x01 \x01(\bR\n" +
 	"databaseOk\x12\x1b\n" +
 	"\tbroker_ok\x18\x02 \x01(\bR\bbrokerOk\x129\n" +
 	"\n" +
-	"checked_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt2\x95\t\n" +
+	"checked_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt2\x8e\x12\n" +
 	"\aPayment\x12m\n" +
 	"\rCreatePayment\x12 .payment.v1.CreatePaymentRequest\x1a!.payment.v1.CreatePaymentResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/payments\x12l\n" +
 	"\vAuthPayment\x12\x1e.payment.v1.AuthPaymentRequest\x1a\x1f.payment.v1.AuthPaymentResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/payments/auth\x12x\n" +
@@ -1271,12 +7023,56 @@ const file_payment_proto_rawDesc = "" +
 	"\rRefundPayment\x12 .payment.v1.RefundPaymentRequest\x1a!.payment.v1.RefundPaymentResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/payments/refund\x12|\n" +
 	"\x0fReversalPayment\x12\".payment.v1.ReversalPaymentRequest\x1a#.payment.v1.ReversalPaymentResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/payments/reversal\x12n\n" +
 	"\n" +
-	"GetPayment\x12\x1d.payment.v1.GetPaymentRequest\x1a\x1e.payment.v1.GetPaymentResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/payments/{payment_id}\x12\x87\x01\n" +
-	"\x10GetPaymentStatus\x12#.payment.v1.GetPaymentStatusRequest\x1a$.payment.v1.GetPaymentStatusResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/payments/{payment_id}/status\x12x\n" +
+	"GetPayment\x12\x1d.payment.v1.GetPaymentRequest\x1a\x1e.payment.v1.GetPaymentResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/payments/{payment_id}\x12\x84\x01\n" +
+	"\x13GetPaymentByOrderId\x12&.payment.v1.GetPaymentByOrderIdRequest\x1a\x1e.payment.v1.GetPaymentResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/orders/{order_id}/payment\x12\xa7\x01\n" +
+	"\x10GetPaymentStatus\x12#.payment.v1.GetPaymentStatusRequest\x1a$.payment.v1.GetPaymentStatusResponse\"H\x82\xd3\xe4\x93\x02BZ\x1e\x12\x1c/v1/orders/{order_id}/status\x12 /v1/payments/{payment_id}/status\x12\xac\x01\n" +
+	"\x11GetPaymentHistory\x12$.payment.v1.GetPaymentHistoryRequest\x1a%.payment.v1.GetPaymentHistoryResponse\"J\x82\xd3\xe4\x93\x02DZ\x1f\x12\x1d/v1/orders/{order_id}/history\x12!/v1/payments/{payment_id}/history\x12x\n" +
 	"\x0eSuccessPayment\x12!.payment.v1.SuccessPaymentRequest\x1a\".payment.v1.SuccessPaymentResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/payments/success\x12g\n" +
-	"\fListPayments\x12\x1f.payment.v1.ListPaymentsRequest\x1a .payment.v1.ListPaymentsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/payments\x12b\n" +
+	"\fListPayments\x12\x1f.payment.v1.ListPaymentsRequest\x1a .payment.v1.ListPaymentsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/payments\x12w\n" +
+	"\fListBindings\x12\x1f.payment.v1.ListBindingsRequest\x1a .payment.v1.ListBindingsResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/users/{user_id}/bindings\x12\x87\x01\n" +
+	"\rDeleteBinding\x12 .payment.v1.DeleteBindingRequest\x1a!.payment.v1.DeleteBindingResponse\"1\x82\xd3\xe4\x93\x02+*)/v1/users/{user_id}/bindings/{binding_id}\x12u\n" +
+	"\rChargeBinding\x12 .payment.v1.ChargeBindingRequest\x1a!.payment.v1.ChargeBindingResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/payments/binding\x12\x8c\x01\n" +
+	"\n" +
+	"GetReceipt\x12\x1d.payment.v1.GetReceiptRequest\x1a\x13.payment.v1.Receipt\"J\x82\xd3\xe4\x93\x02DZ\x1f\x12\x1d/v1/orders/{order_id}/receipt\x12!/v1/payments/{payment_id}/receipt\x12\xa6\x01\n" +
+	"\rResendReceipt\x12 .payment.v1.ResendReceiptRequest\x1a\x13.payment.v1.Receipt\"^\x82\xd3\xe4\x93\x02X:\x01*Z):\x01*\"$/v1/orders/{order_id}/receipt/resend\"(/v1/payments/{payment_id}/receipt/resend\x12o\n" +
+	"\x0eListCurrencies\x12!.payment.v1.ListCurrenciesRequest\x1a\".payment.v1.ListCurrenciesResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/currencies\x12b\n" +
 	"\vHealthCheck\x12\x1e.payment.v1.HealthCheckRequest\x1a\x1f.payment.v1.HealthCheckResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
-	"/v1/healthB\x16Z\x14payment/v1;paymentv1b\x06proto3"
+	"/v1/health2\xfe\x06\n" +
+	"\rSubscriptions\x12S\n" +
+	"\n" +
+	"CreatePlan\x12\x1d.payment.v1.CreatePlanRequest\x1a\x10.payment.v1.Plan\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/plans\x12[\n" +
+	"\tListPlans\x12\x1c.payment.v1.ListPlansRequest\x1a\x1d.payment.v1.ListPlansResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/plans\x12s\n" +
+	"\x12CreateSubscription\x12%.payment.v1.CreateSubscriptionRequest\x1a\x18.payment.v1.Subscription\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/subscriptions\x12|\n" +
+	"\x0fGetSubscription\x12\".payment.v1.GetSubscriptionRequest\x1a\x18.payment.v1.Subscription\"+\x82\xd3\xe4\x93\x02%\x12#/v1/subscriptions/{subscription_id}\x12\x8c\x01\n" +
+	"\x12CancelSubscription\x12%.payment.v1.CancelSubscriptionRequest\x1a\x18.payment.v1.Subscription\"5\x82\xd3\xe4\x93\x02/:\x01*\"*/v1/subscriptions/{subscription_id}/cancel\x12\x92\x01\n" +
+	"\x16ChangeSubscriptionPlan\x12).payment.v1.ChangeSubscriptionPlanRequest\x1a\x18.payment.v1.Subscription\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/v1/subscriptions/{subscription_id}/plan\x12\xa3\x01\n" +
+	"\x16ListSubscriptionEvents\x12).payment.v1.ListSubscriptionEventsRequest\x1a*.payment.v1.ListSubscriptionEventsResponse\"2\x82\xd3\xe4\x93\x02,\x12*/v1/subscriptions/{subscription_id}/events2\xd0\x01\n" +
+	"\bInvoices\x12_\n" +
+	"\rCreateInvoice\x12 .payment.v1.CreateInvoiceRequest\x1a\x13.payment.v1.Invoice\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/invoices\x12c\n" +
+	"\n" +
+	"GetInvoice\x12\x1d.payment.v1.GetInvoiceRequest\x1a\x13.payment.v1.Invoice\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/invoices/{invoice_id}2\xcc\x05\n" +
+	"\x06Ledger\x12\x86\x01\n" +
+	"\x10GetSellerBalance\x12#.payment.v1.GetSellerBalanceRequest\x1a$.payment.v1.GetSellerBalanceResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v1/sellers/{seller_id}/balance\x12[\n" +
+	"\fCreatePayout\x12\x1f.payment.v1.CreatePayoutRequest\x1a\x12.payment.v1.Payout\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/payouts\x12^\n" +
+	"\tGetPayout\x12\x1c.payment.v1.GetPayoutRequest\x1a\x12.payment.v1.Payout\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/payouts/{payout_id}\x12|\n" +
+	"\x0fGetTrialBalance\x12\".payment.v1.GetTrialBalanceRequest\x1a#.payment.v1.GetTrialBalanceResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/ledger/trial-balance\x12u\n" +
+	"\x11RunReconciliation\x12$.payment.v1.RunReconciliationRequest\x1a\x1a.payment.v1.Reconciliation\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/reconciliations\x12\x86\x01\n" +
+	"\x11GetReconciliation\x12$.payment.v1.GetReconciliationRequest\x1a\x1a.payment.v1.Reconciliation\"/\x82\xd3\xe4\x93\x02)\x12'/v1/reconciliations/{reconciliation_id}2\x7f\n" +
+	"\aExports\x12t\n" +
+	"\x0eExportPayments\x12!.payment.v1.ExportPaymentsRequest\x1a\x1f.payment.v1.ExportPaymentsChunk\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/exports/payments0\x012\xb0\b\n" +
+	"\x05Admin\x12\x83\x01\n" +
+	"\x0eApprovePayment\x12 .payment.v1.ReviewPaymentRequest\x1a!.payment.v1.CreatePaymentResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/payments/{payment_id}/approve\x12\x81\x01\n" +
+	"\rRejectPayment\x12 .payment.v1.ReviewPaymentRequest\x1a!.payment.v1.RejectPaymentResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/payments/{payment_id}/reject\x12d\n" +
+	"\fListAuditLog\x12\x1f.payment.v1.ListAuditLogRequest\x1a .payment.v1.ListAuditLogResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/audit\x12p\n" +
+	"\x11CreateFeeSchedule\x12$.payment.v1.CreateFeeScheduleRequest\x1a\x17.payment.v1.FeeSchedule\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/fee-schedules\x12x\n" +
+	"\x10ListFeeSchedules\x12#.payment.v1.ListFeeSchedulesRequest\x1a$.payment.v1.ListFeeSchedulesResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/fee-schedules\x12\x8e\x01\n" +
+	"\x15DeactivateFeeSchedule\x12(.payment.v1.DeactivateFeeScheduleRequest\x1a\x17.payment.v1.FeeSchedule\"2\x82\xd3\xe4\x93\x02,\"*/v1/fee-schedules/{schedule_id}/deactivate\x12^\n" +
+	"\vCreateLimit\x12\x1e.payment.v1.CreateLimitRequest\x1a\x18.payment.v1.PaymentLimit\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/limits\x12_\n" +
+	"\n" +
+	"ListLimits\x12\x1d.payment.v1.ListLimitsRequest\x1a\x1e.payment.v1.ListLimitsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/limits\x12y\n" +
+	"\x0fDeactivateLimit\x12\".payment.v1.DeactivateLimitRequest\x1a\x18.payment.v1.PaymentLimit\"(\x82\xd3\xe4\x93\x02\"\" /v1/limits/{limit_id}/deactivateB\x16Z\x14payment/v1;paymentv1b\x06proto3"
 
 var (
 	file_payment_proto_rawDescOnce sync.Once
@@ -1290,63 +7086,267 @@ func file_payment_proto_rawDescGZIP() []byte {
 	return file_payment_proto_rawDescData
 }
 
-var file_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 94)
 var file_payment_proto_goTypes = []any{
-	(*CreatePaymentRequest)(nil),     // 0: payment.v1.CreatePaymentRequest
-	(*CreatePaymentResponse)(nil),    // 1: payment.v1.CreatePaymentResponse
-	(*AuthPaymentRequest)(nil),       // 2: payment.v1.AuthPaymentRequest
-	(*AuthPaymentResponse)(nil),      // 3: payment.v1.AuthPaymentResponse
-	(*DepositPaymentRequest)(nil),    // 4: payment.v1.DepositPaymentRequest
-	(*DepositPaymentResponse)(nil),   // 5: payment.v1.DepositPaymentResponse
-	(*RefundPaymentRequest)(nil),     // 6: payment.v1.RefundPaymentRequest
-	(*RefundPaymentResponse)(nil),    // 7: payment.v1.RefundPaymentResponse
-	(*ReversalPaymentRequest)(nil),   // 8: payment.v1.ReversalPaymentRequest
-	(*ReversalPaymentResponse)(nil),  // 9: payment.v1.ReversalPaymentResponse
-	(*GetPaymentRequest)(nil),        // 10: payment.v1.GetPaymentRequest
-	(*GetPaymentResponse)(nil),       // 11: payment.v1.GetPaymentResponse
-	(*GetPaymentStatusRequest)(nil),  // 12: payment.v1.GetPaymentStatusRequest
-	(*GetPaymentStatusResponse)(nil), // 13: payment.v1.GetPaymentStatusResponse
-	(*SuccessPaymentRequest)(nil),    // 14: payment.v1.SuccessPaymentRequest
-	(*SuccessPaymentResponse)(nil),   // 15: payment.v1.SuccessPaymentResponse
-	(*ListPaymentsRequest)(nil),      // 16: payment.v1.ListPaymentsRequest
-	(*ListPaymentsResponse)(nil),     // 17: payment.v1.ListPaymentsResponse
-	(*HealthCheckRequest)(nil),       // 18: payment.v1.HealthCheckRequest
-	(*HealthCheckResponse)(nil),      // 19: payment.v1.HealthCheckResponse
-	(*structpb.Struct)(nil),          // 20: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil),    // 21: google.protobuf.Timestamp
+	(*CreatePaymentRequest)(nil),           // 0: payment.v1.CreatePaymentRequest
+	(*Customer)(nil),                       // 1: payment.v1.Customer
+	(*CartItem)(nil),                       // 2: payment.v1.CartItem
+	(*WalletToken)(nil),                    // 3: payment.v1.WalletToken
+	(*SplitRule)(nil),                      // 4: payment.v1.SplitRule
+	(*CreatePaymentResponse)(nil),          // 5: payment.v1.CreatePaymentResponse
+	(*AuthPaymentRequest)(nil),             // 6: payment.v1.AuthPaymentRequest
+	(*AuthPaymentResponse)(nil),            // 7: payment.v1.AuthPaymentResponse
+	(*DepositPaymentRequest)(nil),          // 8: payment.v1.DepositPaymentRequest
+	(*DepositPaymentResponse)(nil),         // 9: payment.v1.DepositPaymentResponse
+	(*RefundPaymentRequest)(nil),           // 10: payment.v1.RefundPaymentRequest
+	(*RefundPaymentResponse)(nil),          // 11: payment.v1.RefundPaymentResponse
+	(*ReversalPaymentRequest)(nil),         // 12: payment.v1.ReversalPaymentRequest
+	(*ReversalPaymentResponse)(nil),        // 13: payment.v1.ReversalPaymentResponse
+	(*GetPaymentRequest)(nil),              // 14: payment.v1.GetPaymentRequest
+	(*GetPaymentByOrderIdRequest)(nil),     // 15: payment.v1.GetPaymentByOrderIdRequest
+	(*GetPaymentResponse)(nil),             // 16: payment.v1.GetPaymentResponse
+	(*GetPaymentStatusRequest)(nil),        // 17: payment.v1.GetPaymentStatusRequest
+	(*GetPaymentStatusResponse)(nil),       // 18: payment.v1.GetPaymentStatusResponse
+	(*GetPaymentHistoryRequest)(nil),       // 19: payment.v1.GetPaymentHistoryRequest
+	(*PaymentStatusEntry)(nil),             // 20: payment.v1.PaymentStatusEntry
+	(*GetPaymentHistoryResponse)(nil),      // 21: payment.v1.GetPaymentHistoryResponse
+	(*SuccessPaymentRequest)(nil),          // 22: payment.v1.SuccessPaymentRequest
+	(*SuccessPaymentResponse)(nil),         // 23: payment.v1.SuccessPaymentResponse
+	(*ListPaymentsRequest)(nil),            // 24: payment.v1.ListPaymentsRequest
+	(*ListPaymentsResponse)(nil),           // 25: payment.v1.ListPaymentsResponse
+	(*ExportPaymentsRequest)(nil),          // 26: payment.v1.ExportPaymentsRequest
+	(*ExportPaymentsChunk)(nil),            // 27: payment.v1.ExportPaymentsChunk
+	(*ListAuditLogRequest)(nil),            // 28: payment.v1.ListAuditLogRequest
+	(*AuditEntry)(nil),                     // 29: payment.v1.AuditEntry
+	(*ListAuditLogResponse)(nil),           // 30: payment.v1.ListAuditLogResponse
+	(*Binding)(nil),                        // 31: payment.v1.Binding
+	(*ListBindingsRequest)(nil),            // 32: payment.v1.ListBindingsRequest
+	(*ListBindingsResponse)(nil),           // 33: payment.v1.ListBindingsResponse
+	(*DeleteBindingRequest)(nil),           // 34: payment.v1.DeleteBindingRequest
+	(*DeleteBindingResponse)(nil),          // 35: payment.v1.DeleteBindingResponse
+	(*ChargeBindingRequest)(nil),           // 36: payment.v1.ChargeBindingRequest
+	(*ChargeBindingResponse)(nil),          // 37: payment.v1.ChargeBindingResponse
+	(*ReviewPaymentRequest)(nil),           // 38: payment.v1.ReviewPaymentRequest
+	(*RejectPaymentResponse)(nil),          // 39: payment.v1.RejectPaymentResponse
+	(*Plan)(nil),                           // 40: payment.v1.Plan
+	(*CreatePlanRequest)(nil),              // 41: payment.v1.CreatePlanRequest
+	(*ListPlansRequest)(nil),               // 42: payment.v1.ListPlansRequest
+	(*ListPlansResponse)(nil),              // 43: payment.v1.ListPlansResponse
+	(*Subscription)(nil),                   // 44: payment.v1.Subscription
+	(*CreateSubscriptionRequest)(nil),      // 45: payment.v1.CreateSubscriptionRequest
+	(*GetSubscriptionRequest)(nil),         // 46: payment.v1.GetSubscriptionRequest
+	(*CancelSubscriptionRequest)(nil),      // 47: payment.v1.CancelSubscriptionRequest
+	(*ChangeSubscriptionPlanRequest)(nil),  // 48: payment.v1.ChangeSubscriptionPlanRequest
+	(*SubscriptionEvent)(nil),              // 49: payment.v1.SubscriptionEvent
+	(*ListSubscriptionEventsRequest)(nil),  // 50: payment.v1.ListSubscriptionEventsRequest
+	(*ListSubscriptionEventsResponse)(nil), // 51: payment.v1.ListSubscriptionEventsResponse
+	(*CreateInvoiceRequest)(nil),           // 52: payment.v1.CreateInvoiceRequest
+	(*GetInvoiceRequest)(nil),              // 53: payment.v1.GetInvoiceRequest
+	(*InvoicePayment)(nil),                 // 54: payment.v1.InvoicePayment
+	(*Invoice)(nil),                        // 55: payment.v1.Invoice
+	(*GetSellerBalanceRequest)(nil),        // 56: payment.v1.GetSellerBalanceRequest
+	(*SellerBalance)(nil),                  // 57: payment.v1.SellerBalance
+	(*GetSellerBalanceResponse)(nil),       // 58: payment.v1.GetSellerBalanceResponse
+	(*CreatePayoutRequest)(nil),            // 59: payment.v1.CreatePayoutRequest
+	(*GetPayoutRequest)(nil),               // 60: payment.v1.GetPayoutRequest
+	(*PayoutLine)(nil),                     // 61: payment.v1.PayoutLine
+	(*Payout)(nil),                         // 62: payment.v1.Payout
+	(*GetTrialBalanceRequest)(nil),         // 63: payment.v1.GetTrialBalanceRequest
+	(*TrialBalanceLine)(nil),               // 64: payment.v1.TrialBalanceLine
+	(*GetTrialBalanceResponse)(nil),        // 65: payment.v1.GetTrialBalanceResponse
+	(*ColumnMapping)(nil),                  // 66: payment.v1.ColumnMapping
+	(*RunReconciliationRequest)(nil),       // 67: payment.v1.RunReconciliationRequest
+	(*GetReconciliationRequest)(nil),       // 68: payment.v1.GetReconciliationRequest
+	(*Discrepancy)(nil),                    // 69: payment.v1.Discrepancy
+	(*Reconciliation)(nil),                 // 70: payment.v1.Reconciliation
+	(*FeeTier)(nil),                        // 71: payment.v1.FeeTier
+	(*CreateFeeScheduleRequest)(nil),       // 72: payment.v1.CreateFeeScheduleRequest
+	(*ListFeeSchedulesRequest)(nil),        // 73: payment.v1.ListFeeSchedulesRequest
+	(*DeactivateFeeScheduleRequest)(nil),   // 74: payment.v1.DeactivateFeeScheduleRequest
+	(*FeeSchedule)(nil),                    // 75: payment.v1.FeeSchedule
+	(*ListFeeSchedulesResponse)(nil),       // 76: payment.v1.ListFeeSchedulesResponse
+	(*CreateLimitRequest)(nil),             // 77: payment.v1.CreateLimitRequest
+	(*ListLimitsRequest)(nil),              // 78: payment.v1.ListLimitsRequest
+	(*DeactivateLimitRequest)(nil),         // 79: payment.v1.DeactivateLimitRequest
+	(*PaymentLimit)(nil),                   // 80: payment.v1.PaymentLimit
+	(*ListLimitsResponse)(nil),             // 81: payment.v1.ListLimitsResponse
+	(*GetReceiptRequest)(nil),              // 82: payment.v1.GetReceiptRequest
+	(*ResendReceiptRequest)(nil),           // 83: payment.v1.ResendReceiptRequest
+	(*Receipt)(nil),                        // 84: payment.v1.Receipt
+	(*ReceiptDocument)(nil),                // 85: payment.v1.ReceiptDocument
+	(*ListCurrenciesRequest)(nil),          // 86: payment.v1.ListCurrenciesRequest
+	(*Currency)(nil),                       // 87: payment.v1.Currency
+	(*ListCurrenciesResponse)(nil),         // 88: payment.v1.ListCurrenciesResponse
+	(*HealthCheckRequest)(nil),             // 89: payment.v1.HealthCheckRequest
+	(*HealthCheckResponse)(nil),            // 90: payment.v1.HealthCheckResponse
+	nil,                                    // 91: payment.v1.ListPaymentsRequest.MetadataEntry
+	nil,                                    // 92: payment.v1.ExportPaymentsRequest.MetadataEntry
+	nil,                                    // 93: payment.v1.ColumnMapping.StatusesEntry
+	(*structpb.Struct)(nil),                // 94: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),          // 95: google.protobuf.Timestamp
 }
 var file_payment_proto_depIdxs = []int32{
-	20, // 0: payment.v1.CreatePaymentRequest.metadata:type_name -> google.protobuf.Struct
-	20, // 1: payment.v1.AuthPaymentRequest.metadata:type_name -> google.protobuf.Struct
-	21, // 2: payment.v1.GetPaymentResponse.created_at:type_name -> google.protobuf.Timestamp
-	20, // 3: payment.v1.GetPaymentResponse.metadata:type_name -> google.protobuf.Struct
-	11, // 4: payment.v1.ListPaymentsResponse.payments:type_name -> payment.v1.GetPaymentResponse
-	21, // 5: payment.v1.HealthCheckResponse.checked_at:type_name -> google.protobuf.Timestamp
-	0,  // 6: payment.v1.Payment.CreatePayment:input_type -> payment.v1.CreatePaymentRequest
-	2,  // 7: payment.v1.Payment.AuthPayment:input_type -> payment.v1.AuthPaymentRequest
-	4,  // 8: payment.v1.Payment.DepositPayment:input_type -> payment.v1.DepositPaymentRequest
-	6,  // 9: payment.v1.Payment.RefundPayment:input_type -> payment.v1.RefundPaymentRequest
-	8,  // 10: payment.v1.Payment.ReversalPayment:input_type -> payment.v1.ReversalPaymentRequest
-	10, // 11: payment.v1.Payment.GetPayment:input_type -> payment.v1.GetPaymentRequest
-	12, // 12: payment.v1.Payment.GetPaymentStatus:input_type -> payment.v1.GetPaymentStatusRequest
-	14, // 13: payment.v1.Payment.SuccessPayment:input_type -> payment.v1.SuccessPaymentRequest
-	16, // 14: payment.v1.Payment.ListPayments:input_type -> payment.v1.ListPaymentsRequest
-	18, // 15: payment.v1.Payment.HealthCheck:input_type -> payment.v1.HealthCheckRequest
-	1,  // 16: payment.v1.Payment.CreatePayment:output_type -> payment.v1.CreatePaymentResponse
-	3,  // 17: payment.v1.Payment.AuthPayment:output_type -> payment.v1.AuthPaymentResponse
-	5,  // 18: payment.v1.Payment.DepositPayment:output_type -> payment.v1.DepositPaymentResponse
-	7,  // 19: payment.v1.Payment.RefundPayment:output_type -> payment.v1.RefundPaymentResponse
-	9,  // 20: payment.v1.Payment.ReversalPayment:output_type -> payment.v1.ReversalPaymentResponse
-	11, // 21: payment.v1.Payment.GetPayment:output_type -> payment.v1.GetPaymentResponse
-	13, // 22: payment.v1.Payment.GetPaymentStatus:output_type -> payment.v1.GetPaymentStatusResponse
-	15, // 23: payment.v1.Payment.SuccessPayment:output_type -> payment.v1.SuccessPaymentResponse
-	17, // 24: payment.v1.Payment.ListPayments:output_type -> payment.v1.ListPaymentsResponse
-	19, // 25: payment.v1.Payment.HealthCheck:output_type -> payment.v1.HealthCheckResponse
-	16, // [16:26] is the sub-list for method output_type
-	6,  // [6:16] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	94,  // 0: payment.v1.CreatePaymentRequest.metadata:type_name -> google.protobuf.Struct
+	4,   // 1: payment.v1.CreatePaymentRequest.splits:type_name -> payment.v1.SplitRule
+	3,   // 2: payment.v1.CreatePaymentRequest.wallet:type_name -> payment.v1.WalletToken
+	1,   // 3: payment.v1.CreatePaymentRequest.customer:type_name -> payment.v1.Customer
+	2,   // 4: payment.v1.CreatePaymentRequest.items:type_name -> payment.v1.CartItem
+	94,  // 5: payment.v1.AuthPaymentRequest.metadata:type_name -> google.protobuf.Struct
+	1,   // 6: payment.v1.AuthPaymentRequest.customer:type_name -> payment.v1.Customer
+	2,   // 7: payment.v1.AuthPaymentRequest.items:type_name -> payment.v1.CartItem
+	95,  // 8: payment.v1.GetPaymentResponse.created_at:type_name -> google.protobuf.Timestamp
+	94,  // 9: payment.v1.GetPaymentResponse.metadata:type_name -> google.protobuf.Struct
+	95,  // 10: payment.v1.GetPaymentResponse.fx_rate_at:type_name -> google.protobuf.Timestamp
+	1,   // 11: payment.v1.GetPaymentResponse.customer:type_name -> payment.v1.Customer
+	2,   // 12: payment.v1.GetPaymentResponse.items:type_name -> payment.v1.CartItem
+	95,  // 13: payment.v1.PaymentStatusEntry.created_at:type_name -> google.protobuf.Timestamp
+	20,  // 14: payment.v1.GetPaymentHistoryResponse.history:type_name -> payment.v1.PaymentStatusEntry
+	95,  // 15: payment.v1.ListPaymentsRequest.created_from:type_name -> google.protobuf.Timestamp
+	95,  // 16: payment.v1.ListPaymentsRequest.created_to:type_name -> google.protobuf.Timestamp
+	91,  // 17: payment.v1.ListPaymentsRequest.metadata:type_name -> payment.v1.ListPaymentsRequest.MetadataEntry
+	16,  // 18: payment.v1.ListPaymentsResponse.payments:type_name -> payment.v1.GetPaymentResponse
+	95,  // 19: payment.v1.ExportPaymentsRequest.created_from:type_name -> google.protobuf.Timestamp
+	95,  // 20: payment.v1.ExportPaymentsRequest.created_to:type_name -> google.protobuf.Timestamp
+	92,  // 21: payment.v1.ExportPaymentsRequest.metadata:type_name -> payment.v1.ExportPaymentsRequest.MetadataEntry
+	95,  // 22: payment.v1.ListAuditLogRequest.created_from:type_name -> google.protobuf.Timestamp
+	95,  // 23: payment.v1.ListAuditLogRequest.created_to:type_name -> google.protobuf.Timestamp
+	94,  // 24: payment.v1.AuditEntry.params:type_name -> google.protobuf.Struct
+	95,  // 25: payment.v1.AuditEntry.created_at:type_name -> google.protobuf.Timestamp
+	29,  // 26: payment.v1.ListAuditLogResponse.entries:type_name -> payment.v1.AuditEntry
+	95,  // 27: payment.v1.Binding.created_at:type_name -> google.protobuf.Timestamp
+	31,  // 28: payment.v1.ListBindingsResponse.bindings:type_name -> payment.v1.Binding
+	95,  // 29: payment.v1.Plan.created_at:type_name -> google.protobuf.Timestamp
+	40,  // 30: payment.v1.ListPlansResponse.plans:type_name -> payment.v1.Plan
+	95,  // 31: payment.v1.Subscription.current_period_start:type_name -> google.protobuf.Timestamp
+	95,  // 32: payment.v1.Subscription.current_period_end:type_name -> google.protobuf.Timestamp
+	95,  // 33: payment.v1.Subscription.next_charge_at:type_name -> google.protobuf.Timestamp
+	95,  // 34: payment.v1.Subscription.canceled_at:type_name -> google.protobuf.Timestamp
+	95,  // 35: payment.v1.Subscription.created_at:type_name -> google.protobuf.Timestamp
+	95,  // 36: payment.v1.SubscriptionEvent.created_at:type_name -> google.protobuf.Timestamp
+	49,  // 37: payment.v1.ListSubscriptionEventsResponse.events:type_name -> payment.v1.SubscriptionEvent
+	95,  // 38: payment.v1.CreateInvoiceRequest.expires_at:type_name -> google.protobuf.Timestamp
+	95,  // 39: payment.v1.InvoicePayment.created_at:type_name -> google.protobuf.Timestamp
+	95,  // 40: payment.v1.Invoice.expires_at:type_name -> google.protobuf.Timestamp
+	54,  // 41: payment.v1.Invoice.payments:type_name -> payment.v1.InvoicePayment
+	95,  // 42: payment.v1.Invoice.created_at:type_name -> google.protobuf.Timestamp
+	57,  // 43: payment.v1.GetSellerBalanceResponse.balances:type_name -> payment.v1.SellerBalance
+	61,  // 44: payment.v1.Payout.lines:type_name -> payment.v1.PayoutLine
+	95,  // 45: payment.v1.Payout.created_at:type_name -> google.protobuf.Timestamp
+	95,  // 46: payment.v1.GetTrialBalanceRequest.as_of:type_name -> google.protobuf.Timestamp
+	95,  // 47: payment.v1.GetTrialBalanceResponse.as_of:type_name -> google.protobuf.Timestamp
+	64,  // 48: payment.v1.GetTrialBalanceResponse.lines:type_name -> payment.v1.TrialBalanceLine
+	93,  // 49: payment.v1.ColumnMapping.statuses:type_name -> payment.v1.ColumnMapping.StatusesEntry
+	66,  // 50: payment.v1.RunReconciliationRequest.mapping:type_name -> payment.v1.ColumnMapping
+	95,  // 51: payment.v1.RunReconciliationRequest.period_start:type_name -> google.protobuf.Timestamp
+	95,  // 52: payment.v1.RunReconciliationRequest.period_end:type_name -> google.protobuf.Timestamp
+	95,  // 53: payment.v1.Reconciliation.period_start:type_name -> google.protobuf.Timestamp
+	95,  // 54: payment.v1.Reconciliation.period_end:type_name -> google.protobuf.Timestamp
+	69,  // 55: payment.v1.Reconciliation.discrepancies:type_name -> payment.v1.Discrepancy
+	95,  // 56: payment.v1.Reconciliation.created_at:type_name -> google.protobuf.Timestamp
+	71,  // 57: payment.v1.CreateFeeScheduleRequest.tiers:type_name -> payment.v1.FeeTier
+	71,  // 58: payment.v1.FeeSchedule.tiers:type_name -> payment.v1.FeeTier
+	95,  // 59: payment.v1.FeeSchedule.created_at:type_name -> google.protobuf.Timestamp
+	75,  // 60: payment.v1.ListFeeSchedulesResponse.schedules:type_name -> payment.v1.FeeSchedule
+	95,  // 61: payment.v1.PaymentLimit.created_at:type_name -> google.protobuf.Timestamp
+	80,  // 62: payment.v1.ListLimitsResponse.limits:type_name -> payment.v1.PaymentLimit
+	1,   // 63: payment.v1.ResendReceiptRequest.customer:type_name -> payment.v1.Customer
+	95,  // 64: payment.v1.Receipt.created_at:type_name -> google.protobuf.Timestamp
+	95,  // 65: payment.v1.Receipt.issued_at:type_name -> google.protobuf.Timestamp
+	95,  // 66: payment.v1.Receipt.sent_at:type_name -> google.protobuf.Timestamp
+	85,  // 67: payment.v1.Receipt.document:type_name -> payment.v1.ReceiptDocument
+	87,  // 68: payment.v1.ListCurrenciesResponse.currencies:type_name -> payment.v1.Currency
+	95,  // 69: payment.v1.HealthCheckResponse.checked_at:type_name -> google.protobuf.Timestamp
+	0,   // 70: payment.v1.Payment.CreatePayment:input_type -> payment.v1.CreatePaymentRequest
+	6,   // 71: payment.v1.Payment.AuthPayment:input_type -> payment.v1.AuthPaymentRequest
+	8,   // 72: payment.v1.Payment.DepositPayment:input_type -> payment.v1.DepositPaymentRequest
+	10,  // 73: payment.v1.Payment.RefundPayment:input_type -> payment.v1.RefundPaymentRequest
+	12,  // 74: payment.v1.Payment.ReversalPayment:input_type -> payment.v1.ReversalPaymentRequest
+	14,  // 75: payment.v1.Payment.GetPayment:input_type -> payment.v1.GetPaymentRequest
+	15,  // 76: payment.v1.Payment.GetPaymentByOrderId:input_type -> payment.v1.GetPaymentByOrderIdRequest
+	17,  // 77: payment.v1.Payment.GetPaymentStatus:input_type -> payment.v1.GetPaymentStatusRequest
+	19,  // 78: payment.v1.Payment.GetPaymentHistory:input_type -> payment.v1.GetPaymentHistoryRequest
+	22,  // 79: payment.v1.Payment.SuccessPayment:input_type -> payment.v1.SuccessPaymentRequest
+	24,  // 80: payment.v1.Payment.ListPayments:input_type -> payment.v1.ListPaymentsRequest
+	32,  // 81: payment.v1.Payment.ListBindings:input_type -> payment.v1.ListBindingsRequest
+	34,  // 82: payment.v1.Payment.DeleteBinding:input_type -> payment.v1.DeleteBindingRequest
+	36,  // 83: payment.v1.Payment.ChargeBinding:input_type -> payment.v1.ChargeBindingRequest
+	82,  // 84: payment.v1.Payment.GetReceipt:input_type -> payment.v1.GetReceiptRequest
+	83,  // 85: payment.v1.Payment.ResendReceipt:input_type -> payment.v1.ResendReceiptRequest
+	86,  // 86: payment.v1.Payment.ListCurrencies:input_type -> payment.v1.ListCurrenciesRequest
+	89,  // 87: payment.v1.Payment.HealthCheck:input_type -> payment.v1.HealthCheckRequest
+	41,  // 88: payment.v1.Subscriptions.CreatePlan:input_type -> payment.v1.CreatePlanRequest
+	42,  // 89: payment.v1.Subscriptions.ListPlans:input_type -> payment.v1.ListPlansRequest
+	45,  // 90: payment.v1.Subscriptions.CreateSubscription:input_type -> payment.v1.CreateSubscriptionRequest
+	46,  // 91: payment.v1.Subscriptions.GetSubscription:input_type -> payment.v1.GetSubscriptionRequest
+	47,  // 92: payment.v1.Subscriptions.CancelSubscription:input_type -> payment.v1.CancelSubscriptionRequest
+	48,  // 93: payment.v1.Subscriptions.ChangeSubscriptionPlan:input_type -> payment.v1.ChangeSubscriptionPlanRequest
+	50,  // 94: payment.v1.Subscriptions.ListSubscriptionEvents:input_type -> payment.v1.ListSubscriptionEventsRequest
+	52,  // 95: payment.v1.Invoices.CreateInvoice:input_type -> payment.v1.CreateInvoiceRequest
+	53,  // 96: payment.v1.Invoices.GetInvoice:input_type -> payment.v1.GetInvoiceRequest
+	56,  // 97: payment.v1.Ledger.GetSellerBalance:input_type -> payment.v1.GetSellerBalanceRequest
+	59,  // 98: payment.v1.Ledger.CreatePayout:input_type -> payment.v1.CreatePayoutRequest
+	60,  // 99: payment.v1.Ledger.GetPayout:input_type -> payment.v1.GetPayoutRequest
+	63,  // 100: payment.v1.Ledger.GetTrialBalance:input_type -> payment.v1.GetTrialBalanceRequest
+	67,  // 101: payment.v1.Ledger.RunReconciliation:input_type -> payment.v1.RunReconciliationRequest
+	68,  // 102: payment.v1.Ledger.GetReconciliation:input_type -> payment.v1.GetReconciliationRequest
+	26,  // 103: payment.v1.Exports.ExportPayments:input_type -> payment.v1.ExportPaymentsRequest
+	38,  // 104: payment.v1.Admin.ApprovePayment:input_type -> payment.v1.ReviewPaymentRequest
+	38,  // 105: payment.v1.Admin.RejectPayment:input_type -> payment.v1.ReviewPaymentRequest
+	28,  // 106: payment.v1.Admin.ListAuditLog:input_type -> payment.v1.ListAuditLogRequest
+	72,  // 107: payment.v1.Admin.CreateFeeSchedule:input_type -> payment.v1.CreateFeeScheduleRequest
+	73,  // 108: payment.v1.Admin.ListFeeSchedules:input_type -> payment.v1.ListFeeSchedulesRequest
+	74,  // 109: payment.v1.Admin.DeactivateFeeSchedule:input_type -> payment.v1.DeactivateFeeScheduleRequest
+	77,  // 110: payment.v1.Admin.CreateLimit:input_type -> payment.v1.CreateLimitRequest
+	78,  // 111: payment.v1.Admin.ListLimits:input_type -> payment.v1.ListLimitsRequest
+	79,  // 112: payment.v1.Admin.DeactivateLimit:input_type -> payment.v1.DeactivateLimitRequest
+	5,   // 113: payment.v1.Payment.CreatePayment:output_type -> payment.v1.CreatePaymentResponse
+	7,   // 114: payment.v1.Payment.AuthPayment:output_type -> payment.v1.AuthPaymentResponse
+	9,   // 115: payment.v1.Payment.DepositPayment:output_type -> payment.v1.DepositPaymentResponse
+	11,  // 116: payment.v1.Payment.RefundPayment:output_type -> payment.v1.RefundPaymentResponse
+	13,  // 117: payment.v1.Payment.ReversalPayment:output_type -> payment.v1.ReversalPaymentResponse
+	16,  // 118: payment.v1.Payment.GetPayment:output_type -> payment.v1.GetPaymentResponse
+	16,  // 119: payment.v1.Payment.GetPaymentByOrderId:output_type -> payment.v1.GetPaymentResponse
+	18,  // 120: payment.v1.Payment.GetPaymentStatus:output_type -> payment.v1.GetPaymentStatusResponse
+	21,  // 121: payment.v1.Payment.GetPaymentHistory:output_type -> payment.v1.GetPaymentHistoryResponse
+	23,  // 122: payment.v1.Payment.SuccessPayment:output_type -> payment.v1.SuccessPaymentResponse
+	25,  // 123: payment.v1.Payment.ListPayments:output_type -> payment.v1.ListPaymentsResponse
+	33,  // 124: payment.v1.Payment.ListBindings:output_type -> payment.v1.ListBindingsResponse
+	35,  // 125: payment.v1.Payment.DeleteBinding:output_type -> payment.v1.DeleteBindingResponse
+	37,  // 126: payment.v1.Payment.ChargeBinding:output_type -> payment.v1.ChargeBindingResponse
+	84,  // 127: payment.v1.Payment.GetReceipt:output_type -> payment.v1.Receipt
+	84,  // 128: payment.v1.Payment.ResendReceipt:output_type -> payment.v1.Receipt
+	88,  // 129: payment.v1.Payment.ListCurrencies:output_type -> payment.v1.ListCurrenciesResponse
+	90,  // 130: payment.v1.Payment.HealthCheck:output_type -> payment.v1.HealthCheckResponse
+	40,  // 131: payment.v1.Subscriptions.CreatePlan:output_type -> payment.v1.Plan
+	43,  // 132: payment.v1.Subscriptions.ListPlans:output_type -> payment.v1.ListPlansResponse
+	44,  // 133: payment.v1.Subscriptions.CreateSubscription:output_type -> payment.v1.Subscription
+	44,  // 134: payment.v1.Subscriptions.GetSubscription:output_type -> payment.v1.Subscription
+	44,  // 135: payment.v1.Subscriptions.CancelSubscription:output_type -> payment.v1.Subscription
+	44,  // 136: payment.v1.Subscriptions.ChangeSubscriptionPlan:output_type -> payment.v1.Subscription
+	51,  // 137: payment.v1.Subscriptions.ListSubscriptionEvents:output_type -> payment.v1.ListSubscriptionEventsResponse
+	55,  // 138: payment.v1.Invoices.CreateInvoice:output_type -> payment.v1.Invoice
+	55,  // 139: payment.v1.Invoices.GetInvoice:output_type -> payment.v1.Invoice
+	58,  // 140: payment.v1.Ledger.GetSellerBalance:output_type -> payment.v1.GetSellerBalanceResponse
+	62,  // 141: payment.v1.Ledger.CreatePayout:output_type -> payment.v1.Payout
+	62,  // 142: payment.v1.Ledger.GetPayout:output_type -> payment.v1.Payout
+	65,  // 143: payment.v1.Ledger.GetTrialBalance:output_type -> payment.v1.GetTrialBalanceResponse
+	70,  // 144: payment.v1.Ledger.RunReconciliation:output_type -> payment.v1.Reconciliation
+	70,  // 145: payment.v1.Ledger.GetReconciliation:output_type -> payment.v1.Reconciliation
+	27,  // 146: payment.v1.Exports.ExportPayments:output_type -> payment.v1.ExportPaymentsChunk
+	5,   // 147: payment.v1.Admin.ApprovePayment:output_type -> payment.v1.CreatePaymentResponse
+	39,  // 148: payment.v1.Admin.RejectPayment:output_type -> payment.v1.RejectPaymentResponse
+	30,  // 149: payment.v1.Admin.ListAuditLog:output_type -> payment.v1.ListAuditLogResponse
+	75,  // 150: payment.v1.Admin.CreateFeeSchedule:output_type -> payment.v1.FeeSchedule
+	76,  // 151: payment.v1.Admin.ListFeeSchedules:output_type -> payment.v1.ListFeeSchedulesResponse
+	75,  // 152: payment.v1.Admin.DeactivateFeeSchedule:output_type -> payment.v1.FeeSchedule
+	80,  // 153: payment.v1.Admin.CreateLimit:output_type -> payment.v1.PaymentLimit
+	81,  // 154: payment.v1.Admin.ListLimits:output_type -> payment.v1.ListLimitsResponse
+	80,  // 155: payment.v1.Admin.DeactivateLimit:output_type -> payment.v1.PaymentLimit
+	113, // [113:156] is the sub-list for method output_type
+	70,  // [70:113] is the sub-list for method input_type
+	70,  // [70:70] is the sub-list for extension type_name
+	70,  // [70:70] is the sub-list for extension extendee
+	0,   // [0:70] is the sub-list for field type_name
 }
 
 func init() { file_payment_proto_init() }
@@ -1354,15 +7354,17 @@ func file_payment_proto_init() {
 	if File_payment_proto != nil {
 		return
 	}
+	file_payment_proto_msgTypes[24].OneofWrappers = []any{}
+	file_payment_proto_msgTypes[26].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_proto_rawDesc), len(file_payment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   94,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   6,
 		},
 		GoTypes:           file_payment_proto_goTypes,
 		DependencyIndexes: file_payment_proto_depIdxs,
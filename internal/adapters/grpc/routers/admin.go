@@ -0,0 +1,39 @@
+package routers
+
+import (
+	"context"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+)
+
+// AdminServer — операции операторов: решения по проверке риска, журнал аудита, тарифы комиссий и лимиты.
+type AdminServer struct {
+	service ports.PaymentService
+	fees    ports.FeeService
+	limits  ports.LimitService
+	log     logger.Logger
+	paymentv1.UnimplementedAdminServer
+}
+
+func NewAdminServer(service ports.PaymentService, fees ports.FeeService, limits ports.LimitService, log logger.Logger) *AdminServer {
+	return &AdminServer{
+		service: service,
+		fees:    fees,
+		limits:  limits,
+		log:     log,
+	}
+}
+
+func (s *AdminServer) ListAuditLog(ctx context.Context, req *paymentv1.ListAuditLogRequest) (*paymentv1.ListAuditLogResponse, error) {
+	if err := ValidateListAuditLog(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	page, err := s.service.AuditLog(ctx, mapAuditRequestToFilter(req))
+	if err != nil {
+		return nil, grpcError(err, "failed to get audit log")
+	}
+
+	return mapAuditToResponse(page), nil
+}
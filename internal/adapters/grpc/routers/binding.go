@@ -0,0 +1,47 @@
+package routers
+
+import (
+	"context"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+)
+
+func (s *PaymentServer) ListBindings(ctx context.Context, req *paymentv1.ListBindingsRequest) (*paymentv1.ListBindingsResponse, error) {
+	if err := ValidateUserID(req.GetUserId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	bindings, err := s.service.ListBindings(ctx, req.UserId)
+	if err != nil {
+		return nil, grpcError(err, "failed to get bindings")
+	}
+
+	return mapBindingsToResponse(bindings), nil
+}
+
+func (s *PaymentServer) DeleteBinding(ctx context.Context, req *paymentv1.DeleteBindingRequest) (*paymentv1.DeleteBindingResponse, error) {
+	if err := ValidateBindingRef(req.GetUserId(), req.GetBindingId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	if err := s.service.DeleteBinding(ctx, req.UserId, req.BindingId); err != nil {
+		return nil, grpcError(err, "failed to delete binding")
+	}
+
+	return &paymentv1.DeleteBindingResponse{}, nil
+}
+
+func (s *PaymentServer) ChargeBinding(ctx context.Context, req *paymentv1.ChargeBindingRequest) (*paymentv1.ChargeBindingResponse, error) {
+	if err := ValidateChargeBindingReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	payment, err := s.service.ChargeBinding(ctx, req.OrderId, req.UserId, req.BindingId, req.Amount, req.Currency)
+	if err != nil {
+		return nil, grpcError(err, "failed to charge binding")
+	}
+
+	return &paymentv1.ChargeBindingResponse{
+		PaymentId: payment.ID,
+		Status:    string(payment.Status),
+	}, nil
+}
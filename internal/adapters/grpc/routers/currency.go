@@ -0,0 +1,15 @@
+package routers
+
+import (
+	"context"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+)
+
+func (s *PaymentServer) ListCurrencies(ctx context.Context, _ *paymentv1.ListCurrenciesRequest) (*paymentv1.ListCurrenciesResponse, error) {
+	currencies, err := s.currencies.ListCurrencies(ctx)
+	if err != nil {
+		return nil, grpcError(err, "failed to list currencies")
+	}
+
+	return mapCurrenciesToResponse(s.currencies.SettlementCurrency(), currencies), nil
+}
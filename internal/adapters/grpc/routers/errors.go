@@ -0,0 +1,253 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/adapters/repo"
+	"payment/internal/adapters/settlement"
+	"payment/internal/domain/models"
+	"payment/internal/service"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Домен ошибок в errdetails.ErrorInfo
+const errorDomain = "payment.v1"
+
+// Задержка перед повтором для временных ошибок
+const retryDelay = 5 * time.Second
+
+// Стабильные коды причин ошибок (errdetails.ErrorInfo.Reason).
+// Клиенты опираются на эти значения, поэтому их нельзя переименовывать.
+const (
+	ReasonInvalidRequest          = "INVALID_REQUEST"
+	ReasonPaymentNotFound         = "PAYMENT_NOT_FOUND"
+	ReasonPaymentStatusNotFound   = "PAYMENT_STATUS_NOT_FOUND"
+	ReasonOrderIDConflict         = "ORDER_ID_CONFLICT"
+	ReasonUnsupportedCurrency     = "UNSUPPORTED_CURRENCY"
+	ReasonUnsupportedOperation    = "UNSUPPORTED_OPERATION"
+	ReasonCurrencyMismatch        = "CURRENCY_MISMATCH"
+	ReasonInvalidCursor           = "INVALID_CURSOR"
+	ReasonAmountExceedsAuthorized = "AMOUNT_EXCEEDS_AUTHORIZED"
+	ReasonPaymentNotPaid          = "PAYMENT_NOT_PAID"
+	ReasonPaymentNotCapturable    = "PAYMENT_NOT_CAPTURABLE"
+	ReasonPaymentNotReversible    = "PAYMENT_NOT_REVERSIBLE"
+	ReasonPaymentNotRefundable    = "PAYMENT_NOT_REFUNDABLE"
+	ReasonAmountExceedsCaptured   = "AMOUNT_EXCEEDS_CAPTURED"
+	ReasonInsufficientFunds       = "INSUFFICIENT_FUNDS"
+	ReasonPaymentDeclined         = "PAYMENT_DECLINED"
+	ReasonBrokerOrderNotFound     = "BROKER_ORDER_NOT_FOUND"
+	ReasonBrokerDuplicateOrder    = "BROKER_DUPLICATE_ORDER"
+	ReasonBrokerCurrency          = "BROKER_UNSUPPORTED_CURRENCY"
+	ReasonBrokerRejected          = "BROKER_REJECTED_REQUEST"
+	ReasonBrokerOperationDenied   = "BROKER_OPERATION_IMPOSSIBLE"
+	ReasonBrokerOperationFailed   = "BROKER_OPERATION_FAILED"
+	ReasonBrokerUnknownState      = "BROKER_UNKNOWN_STATE"
+	ReasonRefundAmountUnknown     = "REFUND_AMOUNT_UNKNOWN"
+	ReasonBindingNotFound         = "BINDING_NOT_FOUND"
+	ReasonBindingsNotSupported    = "BINDINGS_NOT_SUPPORTED"
+	ReasonOperationNotSupported   = "OPERATION_NOT_SUPPORTED_BY_BROKER"
+	ReasonInvalidPaymentMethod    = "INVALID_PAYMENT_METHOD"
+	ReasonInvalidRedirectURL      = "INVALID_REDIRECT_URL"
+	ReasonRiskDenied              = "RISK_DENIED"
+	ReasonPaymentInReview         = "PAYMENT_IN_REVIEW"
+	ReasonPaymentNotInReview      = "PAYMENT_NOT_IN_REVIEW"
+	ReasonPlanNotFound            = "PLAN_NOT_FOUND"
+	ReasonPlanInactive            = "PLAN_INACTIVE"
+	ReasonSubscriptionNotFound    = "SUBSCRIPTION_NOT_FOUND"
+	ReasonSubscriptionCanceled    = "SUBSCRIPTION_CANCELED"
+	ReasonInvoiceNotFound         = "INVOICE_NOT_FOUND"
+	ReasonInvoicePaid             = "INVOICE_PAID"
+	ReasonInvoiceExpired          = "INVOICE_EXPIRED"
+	ReasonSplitExceedsAmount      = "SPLIT_EXCEEDS_AMOUNT"
+	ReasonCartTotalMismatch       = "CART_TOTAL_MISMATCH"
+	ReasonPayoutNotFound          = "PAYOUT_NOT_FOUND"
+	ReasonNoPayoutDue             = "NO_PAYOUT_DUE"
+	ReasonReconciliationNotFound  = "RECONCILIATION_NOT_FOUND"
+	ReasonSettlementFileInvalid   = "SETTLEMENT_FILE_INVALID"
+	ReasonFeeScheduleNotFound     = "FEE_SCHEDULE_NOT_FOUND"
+	ReasonLimitNotFound           = "LIMIT_NOT_FOUND"
+	ReasonLimitExceeded           = "LIMIT_EXCEEDED"
+	ReasonReceiptNotFound         = "RECEIPT_NOT_FOUND"
+	ReasonReceiptNoContact        = "RECEIPT_NO_CONTACT"
+	ReasonReceiptsDisabled        = "RECEIPTS_DISABLED"
+	ReasonFXRateUnavailable       = "FX_RATE_UNAVAILABLE"
+	ReasonAmountPrecision         = "AMOUNT_PRECISION"
+	ReasonBrokerUnavailable       = "BROKER_UNAVAILABLE"
+	ReasonDatabaseUnavailable     = "DATABASE_UNAVAILABLE"
+	ReasonDeadlineExceeded        = "DEADLINE_EXCEEDED"
+	ReasonCanceled                = "CANCELED"
+	ReasonInternal                = "INTERNAL"
+)
+
+// errorSpec описывает, как доменная ошибка отдаётся клиенту
+type errorSpec struct {
+	err       error
+	code      codes.Code
+	reason    string
+	message   string
+	retryable bool
+}
+
+// Каталог доменных ошибок. Порядок важен: ошибки брокера оборачиваются
+// в service.ErrBrokerOperationFailed, поэтому конкретные причины идут раньше общей.
+var errorCatalogue = []errorSpec{
+	{repo.ErrPaymentNotFound, codes.NotFound, ReasonPaymentNotFound, "payment is not found", false},
+	{repo.ErrPaymentStatusNotFound, codes.NotFound, ReasonPaymentStatusNotFound, "payment status is not found", false},
+	{repo.ErrBindingNotFound, codes.NotFound, ReasonBindingNotFound, "binding is not found", false},
+	{repo.ErrPlanNotFound, codes.NotFound, ReasonPlanNotFound, "plan is not found", false},
+	{repo.ErrSubscriptionNotFound, codes.NotFound, ReasonSubscriptionNotFound, "subscription is not found", false},
+	{repo.ErrInvoiceNotFound, codes.NotFound, ReasonInvoiceNotFound, "invoice is not found", false},
+	{repo.ErrPayoutNotFound, codes.NotFound, ReasonPayoutNotFound, "payout is not found", false},
+	{repo.ErrNoPayoutDue, codes.FailedPrecondition, ReasonNoPayoutDue, "no seller balance is due for payout", false},
+	{repo.ErrReconciliationNotFound, codes.NotFound, ReasonReconciliationNotFound, "reconciliation is not found", false},
+	{repo.ErrFeeScheduleNotFound, codes.NotFound, ReasonFeeScheduleNotFound, "fee schedule is not found", false},
+	{repo.ErrLimitNotFound, codes.NotFound, ReasonLimitNotFound, "payment limit is not found", false},
+	{repo.ErrReceiptNotFound, codes.NotFound, ReasonReceiptNotFound, "receipt is not found", false},
+	{repo.ErrOrderIDConflict, codes.AlreadyExists, ReasonOrderIDConflict, "order ID must be unique", false},
+	{repo.ErrInvalidCursor, codes.InvalidArgument, ReasonInvalidCursor, "page cursor is invalid", false},
+	{repo.ErrCaptureExceedsAuthorized, codes.InvalidArgument, ReasonAmountExceedsAuthorized, "amount exceeds remaining authorized amount", false},
+	{repo.ErrRefundExceedsCaptured, codes.InvalidArgument, ReasonAmountExceedsCaptured, "amount exceeds captured amount not yet refunded", false},
+	{service.ErrUnsupportedCurrency, codes.InvalidArgument, ReasonUnsupportedCurrency, "currency is not supported", false},
+	{service.ErrUnsupportedOperation, codes.InvalidArgument, ReasonUnsupportedOperation, "operation is not supported", false},
+	{models.ErrInvalidPaymentMethod, codes.InvalidArgument, ReasonInvalidPaymentMethod, "payment method does not match operation", false},
+	{models.ErrInvalidRedirectURL, codes.InvalidArgument, ReasonInvalidRedirectURL, "redirect URL is not allowed", false},
+	{service.ErrAmountPrecision, codes.InvalidArgument, ReasonAmountPrecision, "amount has more decimal places than currency allows", false},
+	{service.ErrSplitExceedsAmount, codes.InvalidArgument, ReasonSplitExceedsAmount, "split amounts exceed payment amount", false},
+	{models.ErrCartTotalMismatch, codes.InvalidArgument, ReasonCartTotalMismatch, "cart items total does not match payment amount", false},
+	{settlement.ErrInvalidFile, codes.InvalidArgument, ReasonSettlementFileInvalid, "settlement file cannot be parsed", false},
+	{settlement.ErrInvalidMapping, codes.InvalidArgument, ReasonSettlementFileInvalid, "settlement column mapping is invalid", false},
+	{service.ErrInvalidPeriod, codes.InvalidArgument, ReasonInvalidRequest, "period end must be after period start", false},
+	{service.ErrCurrencyMismatch, codes.InvalidArgument, ReasonCurrencyMismatch, "currency does not match payment currency", false},
+	{models.ErrFXRateNotFound, codes.FailedPrecondition, ReasonFXRateUnavailable, "fx rate is not available for currency", false},
+	{service.ErrFXRateStale, codes.FailedPrecondition, ReasonFXRateUnavailable, "fx rate is stale", false},
+	{models.ErrLimitExceeded, codes.ResourceExhausted, ReasonLimitExceeded, "payment limit exceeded", false},
+	{models.ErrReceiptNoContact, codes.FailedPrecondition, ReasonReceiptNoContact, "customer has no email or phone for receipt", false},
+	{models.ErrReceiptsDisabled, codes.FailedPrecondition, ReasonReceiptsDisabled, "receipts are disabled", false},
+	{service.ErrPaymentDenied, codes.PermissionDenied, ReasonRiskDenied, "payment is denied by risk rules", false},
+	{service.ErrPaymentInReview, codes.FailedPrecondition, ReasonPaymentInReview, "payment is under risk review", false},
+	{service.ErrPaymentNotInReview, codes.FailedPrecondition, ReasonPaymentNotInReview, "payment is not under risk review", false},
+	{repo.ErrReviewNotPending, codes.FailedPrecondition, ReasonPaymentNotInReview, "payment review is already resolved", false},
+	{service.ErrPlanInactive, codes.FailedPrecondition, ReasonPlanInactive, "plan is not active", false},
+	{service.ErrSubscriptionCanceled, codes.FailedPrecondition, ReasonSubscriptionCanceled, "subscription is canceled", false},
+	{service.ErrInvoicePaid, codes.FailedPrecondition, ReasonInvoicePaid, "invoice is already paid", false},
+	{service.ErrInvoiceExpired, codes.FailedPrecondition, ReasonInvoiceExpired, "invoice is expired", false},
+	{service.ErrPaymentNotPaid, codes.FailedPrecondition, ReasonPaymentNotPaid, "payment is not paid yet", false},
+	{service.ErrPaymentNotCapturable, codes.FailedPrecondition, ReasonPaymentNotCapturable, "payment cannot be captured in current state", false},
+	{service.ErrPaymentNotReversible, codes.FailedPrecondition, ReasonPaymentNotReversible, "payment cannot be reversed in current state", false},
+	{service.ErrPaymentNotRefundable, codes.FailedPrecondition, ReasonPaymentNotRefundable, "payment cannot be refunded in current state", false},
+	{models.ErrInsufficientFunds, codes.FailedPrecondition, ReasonInsufficientFunds, "insufficient funds for operation", false},
+	{models.ErrPaymentDeclined, codes.FailedPrecondition, ReasonPaymentDeclined, "payment is declined by bank", false},
+	{models.ErrBrokerOrderNotFound, codes.NotFound, ReasonBrokerOrderNotFound, "order is not found at bank", false},
+	{models.ErrBrokerDuplicateOrder, codes.AlreadyExists, ReasonBrokerDuplicateOrder, "order is already registered at bank", false},
+	{models.ErrBrokerUnsupportedCurrency, codes.InvalidArgument, ReasonBrokerCurrency, "currency is not supported by bank", false},
+	{models.ErrBrokerRejectedRequest, codes.FailedPrecondition, ReasonBrokerRejected, "bank rejected request", false},
+	{models.ErrBrokerOperationImpossible, codes.FailedPrecondition, ReasonBrokerOperationDenied, "operation is impossible for current order state", false},
+	{models.ErrBrokerUnavailable, codes.Unavailable, ReasonBrokerUnavailable, "bank is temporarily unavailable", true},
+	{models.ErrBindingsNotSupported, codes.Unimplemented, ReasonBindingsNotSupported, "card bindings are not supported by bank", false},
+	{models.ErrOperationNotSupported, codes.Unimplemented, ReasonOperationNotSupported, "operation is not supported by bank", false},
+	{service.ErrUnknownBrokerState, codes.Internal, ReasonBrokerUnknownState, "order state at bank is not recognized", false},
+	{service.ErrRefundAmountUnknown, codes.FailedPrecondition, ReasonRefundAmountUnknown, "refunded amount at bank is unknown, sync it manually", false},
+	{service.ErrBrokerOperationFailed, codes.FailedPrecondition, ReasonBrokerOperationFailed, "bank operation failed", false},
+	{service.ErrDBUnavailable, codes.Unavailable, ReasonDatabaseUnavailable, "database is temporarily unavailable", true},
+	{context.DeadlineExceeded, codes.DeadlineExceeded, ReasonDeadlineExceeded, "request deadline exceeded", true},
+	{context.Canceled, codes.Canceled, ReasonCanceled, "request is canceled", false},
+}
+
+// internalError — описание для ошибок вне каталога; текст ошибки клиенту не раскрывается
+var internalError = errorSpec{code: codes.Internal, reason: ReasonInternal, message: "internal error"}
+
+// lookupError находит описание ошибки в каталоге.
+// Ошибки валидации без известной причины отдаются как INVALID_REQUEST с текстом нарушений.
+func lookupError(err error) errorSpec {
+	for _, spec := range errorCatalogue {
+		if errors.Is(err, spec.err) {
+			return spec
+		}
+	}
+
+	var verr *models.ValidationError
+	if errors.As(err, &verr) {
+		return errorSpec{code: codes.InvalidArgument, reason: ReasonInvalidRequest, message: verr.Error()}
+	}
+
+	return internalError
+}
+
+func GetGrpcCode(err error) codes.Code {
+	return lookupError(err).code
+}
+
+// grpcError формирует статус gRPC по ошибке сервиса.
+// Сообщение берётся из каталога, к статусу добавляются ErrorInfo, BadRequest, QuotaFailure и RetryInfo.
+func grpcError(err error, msg string) error {
+	spec := lookupError(err)
+	return withDetails(status.New(spec.code, msg+": "+spec.message), spec, err).Err()
+}
+
+// invalidRequest формирует статус InvalidArgument для невалидного тела запроса.
+// Ошибки валидаторов содержат только описание полей запроса, поэтому текст отдаётся как есть.
+func invalidRequest(err error) error {
+	spec := errorSpec{code: codes.InvalidArgument, reason: ReasonInvalidRequest}
+	return withDetails(status.New(codes.InvalidArgument, "request body is invalid: "+err.Error()), spec, err).Err()
+}
+
+func withDetails(st *status.Status, spec errorSpec, err error) *status.Status {
+	info := &errdetails.ErrorInfo{Reason: spec.reason, Domain: errorDomain}
+	details := []protoadapt.MessageV1{info}
+
+	var verr *models.ValidationError
+	if errors.As(err, &verr) {
+		br := &errdetails.BadRequest{}
+		for _, v := range verr.Violations {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, br)
+	}
+
+	// Превышенный лимит и остаток по нему: в метаданных ErrorInfo и в QuotaFailure
+	var lerr *models.LimitExceededError
+	if errors.As(err, &lerr) {
+		info.Metadata = map[string]string{
+			"limit_id":  lerr.Limit.ID,
+			"scope":     string(lerr.Limit.Scope),
+			"period":    string(lerr.Period),
+			"currency":  lerr.Limit.Currency,
+			"limit":     strconv.FormatFloat(lerr.Max, 'f', 2, 64),
+			"used":      strconv.FormatFloat(lerr.Used, 'f', 2, 64),
+			"remaining": strconv.FormatFloat(lerr.Remaining, 'f', 2, 64),
+		}
+		// логин мерчанта у банка клиенту не раскрывается
+		subject := strings.ToLower(string(lerr.Limit.Scope))
+		if lerr.Limit.Scope == models.LimitScopeUser {
+			subject += ":" + lerr.Subject
+		}
+		details = append(details, &errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject: subject,
+				Description: fmt.Sprintf("%s limit %s %s exceeded, remaining %s",
+					strings.ToLower(string(lerr.Period)), info.Metadata["limit"], lerr.Limit.Currency, info.Metadata["remaining"]),
+			}},
+		})
+	}
+
+	if spec.retryable {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)})
+	}
+
+	if detailed, dErr := st.WithDetails(details...); dErr == nil {
+		return detailed
+	}
+	return st
+}
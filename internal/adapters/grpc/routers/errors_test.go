@@ -0,0 +1,205 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/adapters/repo"
+	"payment/internal/adapters/settlement"
+	"payment/internal/domain/models"
+	"payment/internal/service"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Коды причин — контракт с клиентами, поэтому ожидания записаны строками, а не константами каталога.
+var wantCatalogue = []struct {
+	err    error
+	code   codes.Code
+	reason string
+}{
+	{repo.ErrPaymentNotFound, codes.NotFound, "PAYMENT_NOT_FOUND"},
+	{repo.ErrPaymentStatusNotFound, codes.NotFound, "PAYMENT_STATUS_NOT_FOUND"},
+	{repo.ErrBindingNotFound, codes.NotFound, "BINDING_NOT_FOUND"},
+	{repo.ErrPlanNotFound, codes.NotFound, "PLAN_NOT_FOUND"},
+	{repo.ErrSubscriptionNotFound, codes.NotFound, "SUBSCRIPTION_NOT_FOUND"},
+	{repo.ErrInvoiceNotFound, codes.NotFound, "INVOICE_NOT_FOUND"},
+	{repo.ErrPayoutNotFound, codes.NotFound, "PAYOUT_NOT_FOUND"},
+	{repo.ErrNoPayoutDue, codes.FailedPrecondition, "NO_PAYOUT_DUE"},
+	{repo.ErrReconciliationNotFound, codes.NotFound, "RECONCILIATION_NOT_FOUND"},
+	{repo.ErrFeeScheduleNotFound, codes.NotFound, "FEE_SCHEDULE_NOT_FOUND"},
+	{repo.ErrLimitNotFound, codes.NotFound, "LIMIT_NOT_FOUND"},
+	{repo.ErrReceiptNotFound, codes.NotFound, "RECEIPT_NOT_FOUND"},
+	{repo.ErrOrderIDConflict, codes.AlreadyExists, "ORDER_ID_CONFLICT"},
+	{repo.ErrInvalidCursor, codes.InvalidArgument, "INVALID_CURSOR"},
+	{repo.ErrCaptureExceedsAuthorized, codes.InvalidArgument, "AMOUNT_EXCEEDS_AUTHORIZED"},
+	{repo.ErrRefundExceedsCaptured, codes.InvalidArgument, "AMOUNT_EXCEEDS_CAPTURED"},
+	{service.ErrUnsupportedCurrency, codes.InvalidArgument, "UNSUPPORTED_CURRENCY"},
+	{service.ErrUnsupportedOperation, codes.InvalidArgument, "UNSUPPORTED_OPERATION"},
+	{models.ErrInvalidPaymentMethod, codes.InvalidArgument, "INVALID_PAYMENT_METHOD"},
+	{models.ErrInvalidRedirectURL, codes.InvalidArgument, "INVALID_REDIRECT_URL"},
+	{service.ErrAmountPrecision, codes.InvalidArgument, "AMOUNT_PRECISION"},
+	{service.ErrSplitExceedsAmount, codes.InvalidArgument, "SPLIT_EXCEEDS_AMOUNT"},
+	{models.ErrCartTotalMismatch, codes.InvalidArgument, "CART_TOTAL_MISMATCH"},
+	{settlement.ErrInvalidFile, codes.InvalidArgument, "SETTLEMENT_FILE_INVALID"},
+	{settlement.ErrInvalidMapping, codes.InvalidArgument, "SETTLEMENT_FILE_INVALID"},
+	{service.ErrInvalidPeriod, codes.InvalidArgument, "INVALID_REQUEST"},
+	{service.ErrCurrencyMismatch, codes.InvalidArgument, "CURRENCY_MISMATCH"},
+	{models.ErrFXRateNotFound, codes.FailedPrecondition, "FX_RATE_UNAVAILABLE"},
+	{service.ErrFXRateStale, codes.FailedPrecondition, "FX_RATE_UNAVAILABLE"},
+	{models.ErrLimitExceeded, codes.ResourceExhausted, "LIMIT_EXCEEDED"},
+	{models.ErrReceiptNoContact, codes.FailedPrecondition, "RECEIPT_NO_CONTACT"},
+	{models.ErrReceiptsDisabled, codes.FailedPrecondition, "RECEIPTS_DISABLED"},
+	{service.ErrPaymentDenied, codes.PermissionDenied, "RISK_DENIED"},
+	{service.ErrPaymentInReview, codes.FailedPrecondition, "PAYMENT_IN_REVIEW"},
+	{service.ErrPaymentNotInReview, codes.FailedPrecondition, "PAYMENT_NOT_IN_REVIEW"},
+	{repo.ErrReviewNotPending, codes.FailedPrecondition, "PAYMENT_NOT_IN_REVIEW"},
+	{service.ErrPlanInactive, codes.FailedPrecondition, "PLAN_INACTIVE"},
+	{service.ErrSubscriptionCanceled, codes.FailedPrecondition, "SUBSCRIPTION_CANCELED"},
+	{service.ErrInvoicePaid, codes.FailedPrecondition, "INVOICE_PAID"},
+	{service.ErrInvoiceExpired, codes.FailedPrecondition, "INVOICE_EXPIRED"},
+	{service.ErrPaymentNotPaid, codes.FailedPrecondition, "PAYMENT_NOT_PAID"},
+	{service.ErrPaymentNotCapturable, codes.FailedPrecondition, "PAYMENT_NOT_CAPTURABLE"},
+	{service.ErrPaymentNotReversible, codes.FailedPrecondition, "PAYMENT_NOT_REVERSIBLE"},
+	{service.ErrPaymentNotRefundable, codes.FailedPrecondition, "PAYMENT_NOT_REFUNDABLE"},
+	{models.ErrInsufficientFunds, codes.FailedPrecondition, "INSUFFICIENT_FUNDS"},
+	{models.ErrPaymentDeclined, codes.FailedPrecondition, "PAYMENT_DECLINED"},
+	{models.ErrBrokerOrderNotFound, codes.NotFound, "BROKER_ORDER_NOT_FOUND"},
+	{models.ErrBrokerDuplicateOrder, codes.AlreadyExists, "BROKER_DUPLICATE_ORDER"},
+	{models.ErrBrokerUnsupportedCurrency, codes.InvalidArgument, "BROKER_UNSUPPORTED_CURRENCY"},
+	{models.ErrBrokerRejectedRequest, codes.FailedPrecondition, "BROKER_REJECTED_REQUEST"},
+	{models.ErrBrokerOperationImpossible, codes.FailedPrecondition, "BROKER_OPERATION_IMPOSSIBLE"},
+	{models.ErrBrokerUnavailable, codes.Unavailable, "BROKER_UNAVAILABLE"},
+	{models.ErrBindingsNotSupported, codes.Unimplemented, "BINDINGS_NOT_SUPPORTED"},
+	{models.ErrOperationNotSupported, codes.Unimplemented, "OPERATION_NOT_SUPPORTED_BY_BROKER"},
+	{service.ErrUnknownBrokerState, codes.Internal, "BROKER_UNKNOWN_STATE"},
+	{service.ErrRefundAmountUnknown, codes.FailedPrecondition, "REFUND_AMOUNT_UNKNOWN"},
+	{service.ErrBrokerOperationFailed, codes.FailedPrecondition, "BROKER_OPERATION_FAILED"},
+	{service.ErrDBUnavailable, codes.Unavailable, "DATABASE_UNAVAILABLE"},
+	{context.DeadlineExceeded, codes.DeadlineExceeded, "DEADLINE_EXCEEDED"},
+	{context.Canceled, codes.Canceled, "CANCELED"},
+}
+
+func TestErrorCatalogue(t *testing.T) {
+	if len(errorCatalogue) != len(wantCatalogue) {
+		t.Fatalf("catalogue has %d entries, test pins %d: pin the code and reason of every new entry", len(errorCatalogue), len(wantCatalogue))
+	}
+
+	for _, tt := range wantCatalogue {
+		t.Run(tt.reason+"/"+tt.err.Error(), func(t *testing.T) {
+			err := fmt.Errorf("op: %w", tt.err)
+			if code := GetGrpcCode(err); code != tt.code {
+				t.Fatalf("GetGrpcCode = %v, want %v", code, tt.code)
+			}
+
+			st := status.Convert(grpcError(err, "failed"))
+			if st.Code() != tt.code {
+				t.Fatalf("status code = %v, want %v", st.Code(), tt.code)
+			}
+			info := errorInfo(t, st)
+			if info.GetReason() != tt.reason || info.GetDomain() != "payment.v1" {
+				t.Fatalf("ErrorInfo = %s/%s, want payment.v1/%s", info.GetDomain(), info.GetReason(), tt.reason)
+			}
+
+			retryable := tt.code == codes.Unavailable || tt.code == codes.DeadlineExceeded
+			if hasDetail[*errdetails.RetryInfo](st) != retryable {
+				t.Fatalf("RetryInfo present = %v, want %v", !retryable, retryable)
+			}
+		})
+	}
+}
+
+func TestGrpcErrorDetails(t *testing.T) {
+	t.Run("broker cause wins over generic failure", func(t *testing.T) {
+		err := fmt.Errorf("%w: %w", service.ErrBrokerOperationFailed, models.ErrInsufficientFunds)
+		if reason := errorInfo(t, status.Convert(grpcError(err, "failed"))).GetReason(); reason != "INSUFFICIENT_FUNDS" {
+			t.Fatalf("reason = %s, want INSUFFICIENT_FUNDS", reason)
+		}
+	})
+
+	t.Run("internal error is not disclosed", func(t *testing.T) {
+		st := status.Convert(grpcError(errors.New("pq: relation Transactions does not exist"), "failed to get payment"))
+		if st.Code() != codes.Internal || errorInfo(t, st).GetReason() != "INTERNAL" {
+			t.Fatalf("status = %v %s, want INTERNAL", st.Code(), errorInfo(t, st).GetReason())
+		}
+		if strings.Contains(st.Message(), "Transactions") {
+			t.Fatalf("message %q discloses internal error", st.Message())
+		}
+	})
+
+	t.Run("validation violations", func(t *testing.T) {
+		err := models.NewValidationError(service.ErrCurrencyMismatch, "currency", "USD does not match KZT")
+		st := status.Convert(grpcError(err, "failed"))
+		if st.Code() != codes.InvalidArgument || errorInfo(t, st).GetReason() != "CURRENCY_MISMATCH" {
+			t.Fatalf("status = %v %s, want InvalidArgument CURRENCY_MISMATCH", st.Code(), errorInfo(t, st).GetReason())
+		}
+		br := detail[*errdetails.BadRequest](st)
+		if br == nil || len(br.FieldViolations) != 1 || br.FieldViolations[0].Field != "currency" {
+			t.Fatalf("BadRequest = %v, want currency violation", br)
+		}
+	})
+
+	t.Run("validation without known cause", func(t *testing.T) {
+		verr := &models.ValidationError{}
+		verr.Add("amount", "must be positive")
+		if reason := errorInfo(t, status.Convert(grpcError(verr, "failed"))).GetReason(); reason != "INVALID_REQUEST" {
+			t.Fatalf("reason = %s, want INVALID_REQUEST", reason)
+		}
+	})
+
+	t.Run("limit exceeded", func(t *testing.T) {
+		err := &models.LimitExceededError{
+			Limit:   models.PaymentLimit{ID: "l1", Scope: models.LimitScopeUser, Currency: "KZT"},
+			Subject: "u1", Period: models.LimitDaily, Max: 1000, Used: 800, Remaining: 200,
+		}
+		st := status.Convert(grpcError(err, "failed"))
+		if st.Code() != codes.ResourceExhausted {
+			t.Fatalf("code = %v, want ResourceExhausted", st.Code())
+		}
+		if info := errorInfo(t, st); info.Metadata["remaining"] != "200.00" || info.Metadata["period"] != "DAILY" {
+			t.Fatalf("ErrorInfo metadata = %v", info.Metadata)
+		}
+		if qf := detail[*errdetails.QuotaFailure](st); qf == nil || qf.Violations[0].Subject != "user:u1" {
+			t.Fatalf("QuotaFailure = %v, want user:u1 violation", qf)
+		}
+	})
+
+	t.Run("invalid request body", func(t *testing.T) {
+		st := status.Convert(invalidRequest(errors.New("paymentID field is empty")))
+		if st.Code() != codes.InvalidArgument || errorInfo(t, st).GetReason() != "INVALID_REQUEST" {
+			t.Fatalf("status = %v %s, want InvalidArgument INVALID_REQUEST", st.Code(), errorInfo(t, st).GetReason())
+		}
+	})
+}
+
+func errorInfo(t *testing.T, st *status.Status) *errdetails.ErrorInfo {
+	t.Helper()
+	info := detail[*errdetails.ErrorInfo](st)
+	if info == nil {
+		t.Fatalf("status %v has no ErrorInfo", st)
+	}
+	return info
+}
+
+func detail[T any](st *status.Status) T {
+	var zero T
+	for _, d := range st.Details() {
+		if v, ok := d.(T); ok {
+			return v
+		}
+	}
+	return zero
+}
+
+func hasDetail[T any](st *status.Status) bool {
+	for _, d := range st.Details() {
+		if _, ok := d.(T); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package routers
+
+import (
+	"bufio"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+)
+
+// ExportServer — выгрузки платежей для финансовой отчётности.
+type ExportServer struct {
+	exports ports.ExportService
+	log     logger.Logger
+	paymentv1.UnimplementedExportsServer
+}
+
+func NewExportServer(exports ports.ExportService, log logger.Logger) *ExportServer {
+	return &ExportServer{
+		exports: exports,
+		log:     log,
+	}
+}
+
+// exportChunkSize — размер части выгрузки в потоке.
+const exportChunkSize = 64 << 10
+
+func (s *ExportServer) ExportPayments(req *paymentv1.ExportPaymentsRequest, stream paymentv1.Exports_ExportPaymentsServer) error {
+	if err := ValidateExportPayments(req); err != nil {
+		return invalidRequest(err)
+	}
+
+	format := exportFormat(req.Format)
+	chunks := &chunkWriter{stream: stream, contentType: s.exports.ContentType(format)}
+	buf := bufio.NewWriterSize(chunks, exportChunkSize)
+
+	rows, err := s.exports.ExportPayments(stream.Context(), mapExportRequestToFilter(req), format, buf)
+	if err == nil {
+		err = buf.Flush()
+	}
+	if err != nil {
+		return grpcError(err, "failed to export payments")
+	}
+
+	return stream.Send(&paymentv1.ExportPaymentsChunk{
+		ContentType: chunks.pendingContentType(),
+		Rows:        int32(rows),
+	})
+}
+
+// chunkWriter отправляет данные выгрузки частями; тип содержимого передаётся в первой части.
+type chunkWriter struct {
+	stream      paymentv1.Exports_ExportPaymentsServer
+	contentType string
+	sent        bool
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	chunk := &paymentv1.ExportPaymentsChunk{
+		Data:        append([]byte(nil), p...),
+		ContentType: w.pendingContentType(),
+	}
+	if err := w.stream.Send(chunk); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// pendingContentType — тип содержимого, если он ещё не отправлен.
+func (w *chunkWriter) pendingContentType() string {
+	if w.sent {
+		return ""
+	}
+	w.sent = true
+	return w.contentType
+}
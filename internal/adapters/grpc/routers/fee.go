@@ -0,0 +1,47 @@
+package routers
+
+import (
+	"context"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+)
+
+func (s *AdminServer) CreateFeeSchedule(ctx context.Context, req *paymentv1.CreateFeeScheduleRequest) (*paymentv1.FeeSchedule, error) {
+	if err := ValidateCreateFeeScheduleReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	schedule, err := s.fees.CreateFeeSchedule(ctx, mapFeeScheduleFromRequest(req))
+	if err != nil {
+		return nil, grpcError(err, "failed to create fee schedule")
+	}
+
+	return mapFeeScheduleToResponse(schedule), nil
+}
+
+func (s *AdminServer) ListFeeSchedules(ctx context.Context, req *paymentv1.ListFeeSchedulesRequest) (*paymentv1.ListFeeSchedulesResponse, error) {
+	schedules, err := s.fees.ListFeeSchedules(ctx, req.GetIncludeInactive())
+	if err != nil {
+		return nil, grpcError(err, "failed to list fee schedules")
+	}
+
+	resp := &paymentv1.ListFeeSchedulesResponse{
+		Schedules: make([]*paymentv1.FeeSchedule, 0, len(schedules)),
+	}
+	for _, schedule := range schedules {
+		resp.Schedules = append(resp.Schedules, mapFeeScheduleToResponse(schedule))
+	}
+	return resp, nil
+}
+
+func (s *AdminServer) DeactivateFeeSchedule(ctx context.Context, req *paymentv1.DeactivateFeeScheduleRequest) (*paymentv1.FeeSchedule, error) {
+	if err := ValidateFeeScheduleID(req.GetScheduleId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	schedule, err := s.fees.DeactivateFeeSchedule(ctx, req.ScheduleId)
+	if err != nil {
+		return nil, grpcError(err, "failed to deactivate fee schedule")
+	}
+
+	return mapFeeScheduleToResponse(schedule), nil
+}
@@ -1,51 +1,635 @@
 package routers
 
 import (
-	"errors"
-	"payment/internal/adapters/broker/bereke"
 	paymentv1 "payment/internal/adapters/grpc/payment/v1"
-	"payment/internal/adapters/repo"
 	"payment/internal/domain/models"
-	"payment/internal/service"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
-	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func GetGrpcCode(err error) codes.Code {
-	switch {
-	case errors.Is(err, repo.ErrPaymentNotFound), errors.Is(err, repo.ErrPaymentStatusNotFound), errors.Is(err, bereke.ErrNoSuchOrder):
-		return codes.NotFound
-	case errors.Is(err, repo.ErrOrderIDConflict):
-		return codes.AlreadyExists
-	case errors.Is(err, service.ErrUnsupportedCurrency), errors.Is(err, service.ErrPaymentNotPaid):
-		return codes.InvalidArgument
-	case errors.Is(err, service.ErrBrokerOperationFailed):
-		return codes.FailedPrecondition
-	default:
-		return codes.Internal
+func mapListRequestToFilter(req *paymentv1.ListPaymentsRequest) models.PaymentFilter {
+	filter := models.PaymentFilter{
+		UserID:        req.UserId,
+		Currencies:    req.Currencies,
+		Broker:        req.Broker,
+		OrderIDPrefix: req.OrderIdPrefix,
+		AmountMin:     req.AmountMin,
+		AmountMax:     req.AmountMax,
+		SortBy:        models.SortField(req.SortBy),
+		SortDesc:      req.SortOrder != sortOrderAsc,
+		Cursor:        req.Cursor,
+		Limit:         int(req.PageSize),
+		Metadata:      req.Metadata,
 	}
+
+	for _, st := range req.Statuses {
+		filter.Statuses = append(filter.Statuses, models.StatusType(st))
+	}
+	if req.CreatedFrom != nil {
+		filter.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		filter.CreatedTo = req.CreatedTo.AsTime()
+	}
+
+	return filter
+}
+
+// mapExportRequestToFilter — фильтр выгрузки; по умолчанию платежи от старых к новым.
+func mapExportRequestToFilter(req *paymentv1.ExportPaymentsRequest) models.PaymentFilter {
+	filter := models.PaymentFilter{
+		UserID:        req.UserId,
+		Currencies:    req.Currencies,
+		Broker:        req.Broker,
+		OrderIDPrefix: req.OrderIdPrefix,
+		AmountMin:     req.AmountMin,
+		AmountMax:     req.AmountMax,
+		SortBy:        models.SortField(req.SortBy),
+		SortDesc:      req.SortOrder == sortOrderDesc,
+		Metadata:      req.Metadata,
+	}
+
+	for _, st := range req.Statuses {
+		filter.Statuses = append(filter.Statuses, models.StatusType(st))
+	}
+	if req.CreatedFrom != nil {
+		filter.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		filter.CreatedTo = req.CreatedTo.AsTime()
+	}
+
+	return filter
+}
+
+// exportFormat — формат выгрузки из запроса, по умолчанию CSV.
+func exportFormat(format string) models.ExportFormat {
+	if format == "" {
+		return models.ExportCSV
+	}
+	return models.ExportFormat(strings.ToUpper(format))
 }
 
-func mapPaymentsToResponse(payments []models.Payment) *paymentv1.ListPaymentsResponse {
+func mapPaymentsToResponse(page models.PaymentPage) *paymentv1.ListPaymentsResponse {
 	resp := &paymentv1.ListPaymentsResponse{
-		Payments: make([]*paymentv1.GetPaymentResponse, 0, len(payments)),
-		Total:    int32(len(payments)),
+		Payments:   make([]*paymentv1.GetPaymentResponse, 0, len(page.Payments)),
+		Total:      int32(page.Total),
+		NextCursor: page.NextCursor,
+	}
+
+	for _, p := range page.Payments {
+		resp.Payments = append(resp.Payments, mapPaymentToResponse(p))
 	}
 
-	for _, p := range payments {
-		resp.Payments = append(resp.Payments, &paymentv1.GetPaymentResponse{
-			PaymentId: p.ID,
-			OrderId:   p.OrderID,
-			UserId:    p.UserID,
-			Amount:    p.Amount,
-			Currency:  p.Currency,
+	return resp
+}
+
+func mapPaymentToResponse(p models.Payment) *paymentv1.GetPaymentResponse {
+	metadata, _ := structpb.NewStruct(p.Metadata)
+	customer, items := mapCartToResponse(p.Customer, p.Items)
+
+	return &paymentv1.GetPaymentResponse{
+		PaymentId: p.ID,
+		OrderId:   p.OrderID,
+		UserId:    p.UserID,
+		Amount:    p.Amount,
+		Currency:  p.Currency,
+		Status:    string(p.Status),
+		CreatedAt: timestamppb.New(p.CreatedAt),
+		Operation: string(p.Operation),
+		Broker:    p.Broker,
+
+		AuthorizedAmount: p.Amount,
+		CapturedAmount:   p.CapturedAmount,
+		ReleasedAmount:   p.ReleasedAmount,
+		RemainingAmount:  p.RemainingAmount(),
+
+		FeeAmount:      p.FeeAmount,
+		RefundedAmount: p.RefundedAmount,
+		NetAmount:      p.NetAmount(),
+
+		PresentmentAmount:   p.PresentmentAmount,
+		PresentmentCurrency: p.PresentmentCurrency,
+		FxRate:              p.FXRate,
+		FxRateSource:        p.FXSource,
+		FxRateAt:            timestamppb.New(p.FXRateAt),
+
+		RiskDecision: string(p.RiskDecision),
+		RiskRules:    p.RiskRules,
+		ClientIp:     p.ClientIP,
+
+		Description: p.Description,
+		Metadata:    metadata,
+		Customer:    customer,
+		Items:       items,
+	}
+}
+
+func mapPaymentDetailsFromRequest(
+	description string,
+	metadata *structpb.Struct,
+	customer *paymentv1.Customer,
+	items []*paymentv1.CartItem,
+) models.PaymentDetails {
+	details := models.PaymentDetails{
+		Description: description,
+		Metadata:    metadata.AsMap(),
+		Customer: models.Customer{
+			Email: customer.GetEmail(),
+			Phone: customer.GetPhone(),
+		},
+	}
+	for _, item := range items {
+		details.Items = append(details.Items, models.CartItem{
+			Name:     strings.TrimSpace(item.GetName()),
+			Quantity: item.GetQuantity(),
+			Price:    item.GetPrice(),
+			TaxRate:  item.GetTaxRate(),
+		})
+	}
+	return details
+}
+
+func mapCartToResponse(customer models.Customer, items []models.CartItem) (*paymentv1.Customer, []*paymentv1.CartItem) {
+	var respCustomer *paymentv1.Customer
+	if !customer.IsZero() {
+		respCustomer = &paymentv1.Customer{Email: customer.Email, Phone: customer.Phone}
+	}
+
+	respItems := make([]*paymentv1.CartItem, 0, len(items))
+	for _, item := range items {
+		respItems = append(respItems, &paymentv1.CartItem{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+			TaxRate:  item.TaxRate,
+			Amount:   item.Amount(),
+		})
+	}
+	return respCustomer, respItems
+}
+
+// mapCreatePaymentResponse возвращает URL формы или QR код в поле своей операции.
+func mapCreatePaymentResponse(p models.Payment, next string) *paymentv1.CreatePaymentResponse {
+	resp := &paymentv1.CreatePaymentResponse{
+		PaymentId: p.ID,
+		Status:    string(p.Status),
+	}
+	switch p.Operation {
+	case models.URLpayment:
+		resp.PaymentUrl = next
+	case models.QRPayment:
+		resp.QrPayload = next
+	}
+	return resp
+}
+
+func mapHistoryToResponse(paymentID string, history []models.PaymentStatus) *paymentv1.GetPaymentHistoryResponse {
+	resp := &paymentv1.GetPaymentHistoryResponse{
+		PaymentId: paymentID,
+		History:   make([]*paymentv1.PaymentStatusEntry, 0, len(history)),
+	}
+
+	for _, st := range history {
+		resp.History = append(resp.History, &paymentv1.PaymentStatusEntry{
+			Status:     st.Status,
+			CreatedAt:  timestamppb.New(st.CreatedAt),
+			Source:     string(st.Source),
+			BrokerCode: st.BrokerCode,
+		})
+	}
+
+	return resp
+}
+
+func mapBindingsToResponse(bindings []models.Binding) *paymentv1.ListBindingsResponse {
+	resp := &paymentv1.ListBindingsResponse{
+		Bindings: make([]*paymentv1.Binding, 0, len(bindings)),
+	}
+
+	for _, b := range bindings {
+		resp.Bindings = append(resp.Bindings, &paymentv1.Binding{
+			BindingId: b.ID,
+			UserId:    b.UserID,
+			Broker:    b.Broker,
+			MaskedPan: b.MaskedPan,
+			Expiry:    b.Expiry,
+			PaymentId: b.PaymentID,
+			CreatedAt: timestamppb.New(b.CreatedAt),
+		})
+	}
+
+	return resp
+}
+
+func mapPlanToResponse(p models.Plan) *paymentv1.Plan {
+	return &paymentv1.Plan{
+		PlanId:         p.ID,
+		Name:           p.Name,
+		Amount:         p.Amount,
+		Currency:       p.Currency,
+		IntervalMonths: int32(p.IntervalMonths),
+		TrialDays:      int32(p.TrialDays),
+		Active:         p.Active,
+		CreatedAt:      timestamppb.New(p.CreatedAt),
+	}
+}
+
+func mapSubscriptionToResponse(s models.Subscription) *paymentv1.Subscription {
+	resp := &paymentv1.Subscription{
+		SubscriptionId:     s.ID,
+		UserId:             s.UserID,
+		PlanId:             s.PlanID,
+		BindingId:          s.BindingID,
+		Status:             string(s.Status),
+		CurrentPeriodStart: timestamppb.New(s.CurrentPeriodStart),
+		CurrentPeriodEnd:   timestamppb.New(s.CurrentPeriodEnd),
+		Cycle:              int32(s.Cycle),
+		FailedAttempts:     int32(s.FailedAttempts),
+		Credit:             s.Credit,
+		CancelAtPeriodEnd:  s.CancelAtPeriodEnd,
+		CreatedAt:          timestamppb.New(s.CreatedAt),
+	}
+
+	if s.Status != models.SubscriptionCanceled {
+		resp.NextChargeAt = timestamppb.New(s.NextChargeAt)
+	}
+	if s.CanceledAt != nil {
+		resp.CanceledAt = timestamppb.New(*s.CanceledAt)
+	}
+
+	return resp
+}
+
+func mapSubscriptionEventsToResponse(events []models.SubscriptionEvent) *paymentv1.ListSubscriptionEventsResponse {
+	resp := &paymentv1.ListSubscriptionEventsResponse{
+		Events: make([]*paymentv1.SubscriptionEvent, 0, len(events)),
+	}
+
+	for _, e := range events {
+		resp.Events = append(resp.Events, &paymentv1.SubscriptionEvent{
+			Id:             e.ID,
+			SubscriptionId: e.SubscriptionID,
+			Type:           string(e.Type),
+			PaymentId:      e.PaymentID,
+			Amount:         e.Amount,
+			Reason:         e.Reason,
+			CreatedAt:      timestamppb.New(e.CreatedAt),
+		})
+	}
+
+	return resp
+}
+
+func mapInvoiceToResponse(inv models.Invoice, url string) *paymentv1.Invoice {
+	resp := &paymentv1.Invoice{
+		InvoiceId:   inv.ID,
+		Code:        inv.Code,
+		Url:         url,
+		UserId:      inv.UserID,
+		Amount:      inv.Amount,
+		Currency:    inv.Currency,
+		Description: inv.Description,
+		ExpiresAt:   timestamppb.New(inv.ExpiresAt),
+		MultiUse:    inv.MultiUse,
+		Status:      string(inv.StatusAt(time.Now())),
+		PaidCount:   int32(inv.PaidCount()),
+		Payments:    make([]*paymentv1.InvoicePayment, 0, len(inv.Payments)),
+		CreatedAt:   timestamppb.New(inv.CreatedAt),
+	}
+
+	for _, p := range inv.Payments {
+		resp.Payments = append(resp.Payments, &paymentv1.InvoicePayment{
+			PaymentId: p.PaymentID,
 			Status:    string(p.Status),
 			CreatedAt: timestamppb.New(p.CreatedAt),
-			Operation: string(p.Operation),
-			Broker:    p.Broker,
 		})
 	}
 
 	return resp
 }
+
+func mapPaymentMethodFromRequest(req *paymentv1.CreatePaymentRequest) models.PaymentMethod {
+	return models.PaymentMethod{
+		ReturnURL:   req.GetReturnUrl(),
+		FailURL:     req.GetErrorUrl(),
+		BindingID:   req.GetBindingId(),
+		CardToken:   req.GetCardToken(),
+		WalletType:  req.GetWallet().GetType(),
+		WalletToken: req.GetWallet().GetToken(),
+	}
+}
+
+func mapSplitsFromRequest(splits []*paymentv1.SplitRule) []models.SplitRule {
+	if len(splits) == 0 {
+		return nil
+	}
+
+	rules := make([]models.SplitRule, 0, len(splits))
+	for _, split := range splits {
+		rules = append(rules, models.SplitRule{SellerID: split.SellerId, Amount: split.Amount})
+	}
+	return rules
+}
+
+func mapSellerBalanceToResponse(sellerID string, balances []models.SellerBalance) *paymentv1.GetSellerBalanceResponse {
+	resp := &paymentv1.GetSellerBalanceResponse{
+		SellerId: sellerID,
+		Balances: make([]*paymentv1.SellerBalance, 0, len(balances)),
+	}
+
+	for _, b := range balances {
+		resp.Balances = append(resp.Balances, &paymentv1.SellerBalance{
+			Currency:  b.Currency,
+			Available: b.Available,
+			PaidOut:   b.PaidOut,
+		})
+	}
+
+	return resp
+}
+
+func mapPayoutToResponse(p models.Payout) *paymentv1.Payout {
+	resp := &paymentv1.Payout{
+		PayoutId:  p.ID,
+		Lines:     make([]*paymentv1.PayoutLine, 0, len(p.Lines)),
+		CreatedAt: timestamppb.New(p.CreatedAt),
+	}
+
+	for _, l := range p.Lines {
+		resp.Lines = append(resp.Lines, &paymentv1.PayoutLine{
+			SellerId: l.SellerID,
+			Currency: l.Currency,
+			Amount:   l.Amount,
+			Entries:  int32(l.Entries),
+		})
+	}
+
+	return resp
+}
+
+func mapTrialBalanceToResponse(tb models.TrialBalance) *paymentv1.GetTrialBalanceResponse {
+	resp := &paymentv1.GetTrialBalanceResponse{
+		AsOf:     timestamppb.New(tb.AsOf),
+		Balanced: tb.Balanced(),
+		Lines:    make([]*paymentv1.TrialBalanceLine, 0, len(tb.Lines)),
+	}
+
+	for _, l := range tb.Lines {
+		resp.Lines = append(resp.Lines, &paymentv1.TrialBalanceLine{
+			Account:  l.Account,
+			Type:     string(l.Type),
+			Currency: l.Currency,
+			Debit:    l.Debit,
+			Credit:   l.Credit,
+			Balance:  l.Balance(),
+		})
+	}
+
+	return resp
+}
+
+// mapColumnMapping дополняет сопоставление колонок из запроса значениями по умолчанию.
+func mapColumnMapping(m *paymentv1.ColumnMapping) models.ColumnMapping {
+	mapping := models.DefaultColumnMapping()
+	if m == nil {
+		return mapping
+	}
+
+	if m.PaymentId != "" {
+		mapping.PaymentID = m.PaymentId
+	}
+	if m.OrderId != "" {
+		mapping.OrderID = m.OrderId
+	}
+	if m.Amount != "" {
+		mapping.Amount = m.Amount
+	}
+	if m.Currency != "" {
+		mapping.Currency = m.Currency
+	}
+	if m.Status != "" {
+		mapping.Status = m.Status
+	}
+	if m.Delimiter != "" {
+		mapping.Delimiter, _ = utf8.DecodeRuneInString(m.Delimiter)
+	}
+	mapping.MinorUnits = m.MinorUnits
+
+	if len(m.Statuses) > 0 {
+		mapping.Statuses = make(map[string]models.StatusType, len(m.Statuses))
+		for bankStatus, st := range m.Statuses {
+			mapping.Statuses[bankStatus] = models.StatusType(st)
+		}
+	}
+	return mapping
+}
+
+func mapReconciliationToResponse(rec models.Reconciliation) *paymentv1.Reconciliation {
+	resp := &paymentv1.Reconciliation{
+		ReconciliationId: rec.ID,
+		FileName:         rec.FileName,
+		PeriodStart:      timestamppb.New(rec.PeriodStart),
+		PeriodEnd:        timestamppb.New(rec.PeriodEnd),
+		Lines:            int32(rec.Lines),
+		Matched:          int32(rec.Matched),
+		Discrepancies:    make([]*paymentv1.Discrepancy, 0, len(rec.Discrepancies)),
+		CreatedAt:        timestamppb.New(rec.CreatedAt),
+	}
+
+	for _, d := range rec.Discrepancies {
+		resp.Discrepancies = append(resp.Discrepancies, &paymentv1.Discrepancy{
+			Kind:        string(d.Kind),
+			PaymentId:   d.PaymentID,
+			OrderId:     d.OrderID,
+			BankAmount:  d.BankAmount,
+			LocalAmount: d.LocalAmount,
+			BankStatus:  string(d.BankStatus),
+			LocalStatus: string(d.LocalStatus),
+		})
+	}
+
+	return resp
+}
+
+func mapAuditRequestToFilter(req *paymentv1.ListAuditLogRequest) models.AuditFilter {
+	filter := models.AuditFilter{
+		PaymentID: req.PaymentId,
+		Actor:     req.Actor,
+		Method:    req.Method,
+		Result:    models.AuditResult(req.Result),
+		Limit:     int(req.PageSize),
+	}
+
+	// Курсор проверен в ValidateListAuditLog
+	filter.AfterID, _ = strconv.ParseInt(req.Cursor, 10, 64)
+	if req.CreatedFrom != nil {
+		filter.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		filter.CreatedTo = req.CreatedTo.AsTime()
+	}
+
+	return filter
+}
+
+func mapAuditToResponse(page models.AuditPage) *paymentv1.ListAuditLogResponse {
+	resp := &paymentv1.ListAuditLogResponse{
+		Entries: make([]*paymentv1.AuditEntry, 0, len(page.Entries)),
+	}
+	if page.NextID > 0 {
+		resp.NextCursor = strconv.FormatInt(page.NextID, 10)
+	}
+
+	for _, e := range page.Entries {
+		// Параметры прочитаны из JSONB, поэтому всегда конвертируются в Struct
+		params, _ := structpb.NewStruct(e.Params)
+		resp.Entries = append(resp.Entries, &paymentv1.AuditEntry{
+			Id:           e.ID,
+			Actor:        e.Actor,
+			ClaimedActor: e.ClaimedActor,
+			Method:       e.Method,
+			PaymentId:    e.PaymentID,
+			Params:       params,
+			Result:       string(e.Result),
+			Error:        e.Error,
+			BrokerError:  e.BrokerError,
+			CreatedAt:    timestamppb.New(e.CreatedAt),
+		})
+	}
+
+	return resp
+}
+
+func mapFeeScheduleFromRequest(req *paymentv1.CreateFeeScheduleRequest) models.FeeSchedule {
+	schedule := models.FeeSchedule{
+		Broker:      req.Broker,
+		Merchant:    req.Merchant,
+		Currency:    strings.ToUpper(req.Currency),
+		Percent:     req.Percent,
+		Fixed:       req.Fixed,
+		Min:         req.MinFee,
+		Max:         req.MaxFee,
+		RefundFixed: req.RefundFixed,
+	}
+	for _, tier := range req.Tiers {
+		schedule.Tiers = append(schedule.Tiers, models.FeeTier{
+			UpTo:    tier.UpTo,
+			Percent: tier.Percent,
+			Fixed:   tier.Fixed,
+		})
+	}
+	return schedule
+}
+
+func mapFeeScheduleToResponse(s models.FeeSchedule) *paymentv1.FeeSchedule {
+	resp := &paymentv1.FeeSchedule{
+		ScheduleId:  s.ID,
+		Broker:      s.Broker,
+		Merchant:    s.Merchant,
+		Currency:    s.Currency,
+		Percent:     s.Percent,
+		Fixed:       s.Fixed,
+		MinFee:      s.Min,
+		MaxFee:      s.Max,
+		Tiers:       make([]*paymentv1.FeeTier, 0, len(s.Tiers)),
+		RefundFixed: s.RefundFixed,
+		Active:      s.Active,
+		CreatedAt:   timestamppb.New(s.CreatedAt),
+	}
+	for _, tier := range s.Tiers {
+		resp.Tiers = append(resp.Tiers, &paymentv1.FeeTier{
+			UpTo:    tier.UpTo,
+			Percent: tier.Percent,
+			Fixed:   tier.Fixed,
+		})
+	}
+	return resp
+}
+
+func mapLimitFromRequest(req *paymentv1.CreateLimitRequest) models.PaymentLimit {
+	return models.PaymentLimit{
+		Scope:     models.LimitScope(strings.ToUpper(req.Scope)),
+		Subject:   req.Subject,
+		Currency:  strings.ToUpper(req.Currency),
+		MaxSingle: req.MaxSingle,
+		Daily:     req.Daily,
+		Monthly:   req.Monthly,
+	}
+}
+
+func mapLimitToResponse(l models.PaymentLimit) *paymentv1.PaymentLimit {
+	return &paymentv1.PaymentLimit{
+		LimitId:   l.ID,
+		Scope:     string(l.Scope),
+		Subject:   l.Subject,
+		Currency:  l.Currency,
+		MaxSingle: l.MaxSingle,
+		Daily:     l.Daily,
+		Monthly:   l.Monthly,
+		Active:    l.Active,
+		CreatedAt: timestamppb.New(l.CreatedAt),
+	}
+}
+
+// receiptKind — вид чека из запроса, по умолчанию приход.
+func receiptKind(kind string) models.ReceiptKind {
+	if kind == "" {
+		return models.ReceiptSale
+	}
+	return models.ReceiptKind(strings.ToUpper(kind))
+}
+
+// receiptFormat — формат документа чека из запроса, по умолчанию HTML.
+func receiptFormat(format string) models.ReceiptFormat {
+	if format == "" {
+		return models.ReceiptHTML
+	}
+	return models.ReceiptFormat(strings.ToUpper(format))
+}
+
+func mapReceiptToResponse(r models.Receipt) *paymentv1.Receipt {
+	resp := &paymentv1.Receipt{
+		ReceiptId:    r.ID,
+		PaymentId:    r.PaymentID,
+		Kind:         string(r.Kind),
+		Status:       string(r.Status),
+		Amount:       r.Amount,
+		Currency:     r.Currency,
+		Provider:     r.Provider,
+		FiscalNumber: r.FiscalNumber,
+		FiscalUrl:    r.FiscalURL,
+		Attempts:     int32(r.Attempts),
+		LastError:    r.LastError,
+		CreatedAt:    timestamppb.New(r.CreatedAt),
+	}
+	if r.IssuedAt != nil {
+		resp.IssuedAt = timestamppb.New(*r.IssuedAt)
+	}
+	if r.SentAt != nil {
+		resp.SentAt = timestamppb.New(*r.SentAt)
+	}
+	return resp
+}
+
+func mapCurrenciesToResponse(settlement string, currencies []models.Currency) *paymentv1.ListCurrenciesResponse {
+	resp := &paymentv1.ListCurrenciesResponse{
+		Currencies:         make([]*paymentv1.Currency, 0, len(currencies)),
+		SettlementCurrency: settlement,
+	}
+	for _, c := range currencies {
+		resp.Currencies = append(resp.Currencies, &paymentv1.Currency{
+			Code:            c.Code,
+			NumericCode:     c.Numeric,
+			Exponent:        int32(c.Exponent),
+			Enabled:         c.Enabled,
+			BrokerSupported: c.BrokerSupported,
+		})
+	}
+	return resp
+}
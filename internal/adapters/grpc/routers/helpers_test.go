@@ -0,0 +1,35 @@
+package routers
+
+import (
+	"payment/internal/domain/models"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestPaymentDetailsMapping(t *testing.T) {
+	metadata, err := structpb.NewStruct(map[string]any{"cart_id": "42", "qty": 3, "tags": []any{"gift"}})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	details := mapPaymentDetailsFromRequest("Чай", metadata, nil, nil)
+	want := map[string]any{"cart_id": "42", "qty": float64(3), "tags": []any{"gift"}}
+	if details.Description != "Чай" || !reflect.DeepEqual(details.Metadata, want) {
+		t.Fatalf("details = %q %#v, want Чай %#v", details.Description, details.Metadata, want)
+	}
+
+	// Без metadata сохраняется пустой объект, а не null
+	if empty := mapPaymentDetailsFromRequest("", nil, nil, nil); empty.Metadata == nil || len(empty.Metadata) != 0 {
+		t.Fatalf("metadata without request field = %#v, want empty map", empty.Metadata)
+	}
+
+	resp := mapPaymentToResponse(models.Payment{ID: "bank-1", Description: "Чай", Metadata: details.Metadata})
+	if resp.GetDescription() != "Чай" || !reflect.DeepEqual(resp.GetMetadata().AsMap(), want) {
+		t.Fatalf("response = %q %v, want Чай %v", resp.GetDescription(), resp.GetMetadata().AsMap(), want)
+	}
+	if resp := mapPaymentToResponse(models.Payment{ID: "bank-2"}); len(resp.GetMetadata().GetFields()) != 0 {
+		t.Fatalf("response metadata of payment without metadata = %v, want empty", resp.GetMetadata())
+	}
+}
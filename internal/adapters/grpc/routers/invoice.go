@@ -0,0 +1,58 @@
+package routers
+
+import (
+	"context"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+)
+
+// InvoiceServer — счета с публичной ссылкой на оплату.
+type InvoiceServer struct {
+	invoices ports.InvoiceService
+	log      logger.Logger
+	paymentv1.UnimplementedInvoicesServer
+}
+
+func NewInvoiceServer(invoices ports.InvoiceService, log logger.Logger) *InvoiceServer {
+	return &InvoiceServer{
+		invoices: invoices,
+		log:      log,
+	}
+}
+
+func (s *InvoiceServer) CreateInvoice(ctx context.Context, req *paymentv1.CreateInvoiceRequest) (*paymentv1.Invoice, error) {
+	if err := ValidateCreateInvoiceReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	invoice, err := s.invoices.CreateInvoice(ctx, models.Invoice{
+		UserID:      req.UserId,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Description: req.Description,
+		ReturnURL:   req.ReturnUrl,
+		FailURL:     req.ErrorUrl,
+		MultiUse:    req.MultiUse,
+		ExpiresAt:   req.ExpiresAt.AsTime(),
+	})
+	if err != nil {
+		return nil, grpcError(err, "failed to create invoice")
+	}
+
+	return mapInvoiceToResponse(invoice, s.invoices.InvoiceURL(invoice.Code)), nil
+}
+
+func (s *InvoiceServer) GetInvoice(ctx context.Context, req *paymentv1.GetInvoiceRequest) (*paymentv1.Invoice, error) {
+	if err := ValidateInvoiceID(req.GetInvoiceId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	invoice, err := s.invoices.GetInvoice(ctx, req.InvoiceId)
+	if err != nil {
+		return nil, grpcError(err, "failed to get invoice")
+	}
+
+	return mapInvoiceToResponse(invoice, s.invoices.InvoiceURL(invoice.Code)), nil
+}
@@ -0,0 +1,74 @@
+package routers
+
+import (
+	"context"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+	"time"
+)
+
+// LedgerServer — балансы продавцов, выплаты, оборотно-сальдовая ведомость и сверка с выпиской банка.
+type LedgerServer struct {
+	ledger      ports.LedgerService
+	settlements ports.SettlementService
+	log         logger.Logger
+	paymentv1.UnimplementedLedgerServer
+}
+
+func NewLedgerServer(ledger ports.LedgerService, settlements ports.SettlementService, log logger.Logger) *LedgerServer {
+	return &LedgerServer{
+		ledger:      ledger,
+		settlements: settlements,
+		log:         log,
+	}
+}
+
+func (s *LedgerServer) GetSellerBalance(ctx context.Context, req *paymentv1.GetSellerBalanceRequest) (*paymentv1.GetSellerBalanceResponse, error) {
+	if err := ValidateSellerID(req.GetSellerId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	balances, err := s.ledger.SellerBalances(ctx, req.SellerId)
+	if err != nil {
+		return nil, grpcError(err, "failed to get seller balance")
+	}
+
+	return mapSellerBalanceToResponse(req.SellerId, balances), nil
+}
+
+func (s *LedgerServer) CreatePayout(ctx context.Context, _ *paymentv1.CreatePayoutRequest) (*paymentv1.Payout, error) {
+	payout, err := s.ledger.CreatePayout(ctx)
+	if err != nil {
+		return nil, grpcError(err, "failed to create payout")
+	}
+
+	return mapPayoutToResponse(payout), nil
+}
+
+func (s *LedgerServer) GetPayout(ctx context.Context, req *paymentv1.GetPayoutRequest) (*paymentv1.Payout, error) {
+	if err := ValidatePayoutID(req.GetPayoutId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	payout, err := s.ledger.GetPayout(ctx, req.PayoutId)
+	if err != nil {
+		return nil, grpcError(err, "failed to get payout")
+	}
+
+	return mapPayoutToResponse(payout), nil
+}
+
+func (s *LedgerServer) GetTrialBalance(ctx context.Context, req *paymentv1.GetTrialBalanceRequest) (*paymentv1.GetTrialBalanceResponse, error) {
+	asOf := time.Now()
+	if req.AsOf != nil {
+		asOf = req.AsOf.AsTime()
+	}
+
+	tb, err := s.ledger.TrialBalance(ctx, asOf)
+	if err != nil {
+		return nil, grpcError(err, "failed to get trial balance")
+	}
+
+	return mapTrialBalanceToResponse(tb), nil
+}
@@ -0,0 +1,47 @@
+package routers
+
+import (
+	"context"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+)
+
+func (s *AdminServer) CreateLimit(ctx context.Context, req *paymentv1.CreateLimitRequest) (*paymentv1.PaymentLimit, error) {
+	if err := ValidateCreateLimitReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	limit, err := s.limits.CreateLimit(ctx, mapLimitFromRequest(req))
+	if err != nil {
+		return nil, grpcError(err, "failed to create limit")
+	}
+
+	return mapLimitToResponse(limit), nil
+}
+
+func (s *AdminServer) ListLimits(ctx context.Context, req *paymentv1.ListLimitsRequest) (*paymentv1.ListLimitsResponse, error) {
+	limits, err := s.limits.ListLimits(ctx, req.GetIncludeInactive())
+	if err != nil {
+		return nil, grpcError(err, "failed to list limits")
+	}
+
+	resp := &paymentv1.ListLimitsResponse{
+		Limits: make([]*paymentv1.PaymentLimit, 0, len(limits)),
+	}
+	for _, limit := range limits {
+		resp.Limits = append(resp.Limits, mapLimitToResponse(limit))
+	}
+	return resp, nil
+}
+
+func (s *AdminServer) DeactivateLimit(ctx context.Context, req *paymentv1.DeactivateLimitRequest) (*paymentv1.PaymentLimit, error) {
+	if err := ValidateLimitID(req.GetLimitId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	limit, err := s.limits.DeactivateLimit(ctx, req.LimitId)
+	if err != nil {
+		return nil, grpcError(err, "failed to deactivate limit")
+	}
+
+	return mapLimitToResponse(limit), nil
+}
@@ -8,102 +8,120 @@ import (
 	"payment/internal/service"
 	"payment/pkg/logger"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// PaymentServer — платежи мерчанта, сохранённые карты, чеки и реестр валют.
 type PaymentServer struct {
-	service ports.PaymentService
-	log     logger.Logger
+	service    ports.PaymentService
+	receipts   ports.ReceiptService
+	currencies ports.CurrencyService
+	log        logger.Logger
 	paymentv1.UnimplementedPaymentServer
 }
 
-func NewPaymentServer(service ports.PaymentService, log logger.Logger) *PaymentServer {
+func NewPaymentServer(service ports.PaymentService, receipts ports.ReceiptService, currencies ports.CurrencyService, log logger.Logger) *PaymentServer {
 	return &PaymentServer{
-		service: service,
-		log:     log,
+		service:    service,
+		receipts:   receipts,
+		currencies: currencies,
+		log:        log,
 	}
 }
 
 func (s *PaymentServer) CreatePayment(ctx context.Context, req *paymentv1.CreatePaymentRequest) (*paymentv1.CreatePaymentResponse, error) {
 	if err := ValidateCreateOrderReq(req); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "request body is invalid: %v", err)
+		return nil, invalidRequest(err)
 	}
 
-	payment, paymentUrl, err := s.service.CreatePayment(ctx, req.OrderId, req.UserId, req.Amount, req.Currency, req.Operation, req.ReturnUrl, req.ErrorUrl)
+	payment, next, err := s.service.CreatePayment(ctx, req.OrderId, req.UserId, req.Amount, req.Currency, req.Operation, mapPaymentMethodFromRequest(req), mapSplitsFromRequest(req.Splits),
+		mapPaymentDetailsFromRequest(req.Description, req.Metadata, req.Customer, req.Items))
 	if err != nil {
-		return nil, status.Errorf(GetGrpcCode(err), "failed to create payment: %v", err)
+		return nil, grpcError(err, "failed to create payment")
 	}
 
-	return &paymentv1.CreatePaymentResponse{
-		PaymentId:  payment.ID,
-		PaymentUrl: paymentUrl,
-	}, nil
+	return mapCreatePaymentResponse(payment, next), nil
 }
 
 func (s *PaymentServer) AuthPayment(ctx context.Context, req *paymentv1.AuthPaymentRequest) (*paymentv1.AuthPaymentResponse, error) {
 	if err := ValidateAuthOrderReq(req); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "request body is invalid: %v", err)
+		return nil, invalidRequest(err)
 	}
 
-	payment, paymentUrl, err := s.service.AuthPayment(ctx, req.OrderId, req.UserId, req.Amount, req.Currency, req.ReturnUrl, req.ErrorUrl)
+	payment, paymentUrl, err := s.service.AuthPayment(ctx, req.OrderId, req.UserId, req.Amount, req.Currency, req.ReturnUrl, req.ErrorUrl,
+		mapPaymentDetailsFromRequest(req.Description, req.Metadata, req.Customer, req.Items))
 	if err != nil {
-		return nil, status.Errorf(GetGrpcCode(err), "failed to auth payment: %v", err)
+		return nil, grpcError(err, "failed to auth payment")
 	}
 
 	return &paymentv1.AuthPaymentResponse{
 		PaymentId:  payment.ID,
 		PaymentUrl: paymentUrl,
+		Status:     string(payment.Status),
 	}, nil
 }
 
 func (s *PaymentServer) DepositPayment(ctx context.Context, req *paymentv1.DepositPaymentRequest) (*paymentv1.DepositPaymentResponse, error) {
 	if err := ValidateDepositOrderReq(req); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "request body is invalid: %v", err)
+		return nil, invalidRequest(err)
+	}
+
+	paymentID, err := s.resolvePaymentID(ctx, req.PaymentId, req.OrderId)
+	if err != nil {
+		return nil, err
 	}
 
-	state, err := s.service.DepositPayment(ctx, req.PaymentId, req.Amount, req.Currency)
+	payment, err := s.service.DepositPayment(ctx, paymentID, req.Amount, req.Currency, req.Final)
 	if err != nil {
-		return nil, status.Errorf(GetGrpcCode(err), "failed to deposit payment: %v", err)
+		return nil, grpcError(err, "failed to deposit payment")
 	}
 
 	return &paymentv1.DepositPaymentResponse{
-		Status: string(state),
+		Status:          string(payment.Status),
+		CapturedAmount:  payment.CapturedAmount,
+		RemainingAmount: payment.RemainingAmount(),
 	}, nil
 }
 
 func (s *PaymentServer) GetPayment(ctx context.Context, req *paymentv1.GetPaymentRequest) (*paymentv1.GetPaymentResponse, error) {
 	if err := ValidatePaymentID(req.GetPaymentId()); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "request body is invalid: %v", err)
+		return nil, invalidRequest(err)
 	}
 
 	payment, err := s.service.GetPayment(ctx, req.PaymentId)
 	if err != nil {
-		return nil, status.Errorf(GetGrpcCode(err), "failed to get payment: %v", err)
+		return nil, grpcError(err, "failed to get payment")
 	}
 
-	return &paymentv1.GetPaymentResponse{
-		PaymentId: payment.ID,
-		OrderId:   payment.OrderID,
-		UserId:    payment.UserID,
-		Amount:    payment.Amount,
-		Currency:  payment.Currency,
-		Status:    string(payment.Status),
-		CreatedAt: timestamppb.New(payment.CreatedAt),
-		Operation: string(payment.Operation),
-		Broker:    payment.Broker,
-	}, nil
+	return mapPaymentToResponse(payment), nil
+}
+
+func (s *PaymentServer) GetPaymentByOrderId(ctx context.Context, req *paymentv1.GetPaymentByOrderIdRequest) (*paymentv1.GetPaymentResponse, error) {
+	if err := ValidateOrderID(req.GetOrderId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	payment, err := s.service.GetPaymentByOrderID(ctx, req.OrderId)
+	if err != nil {
+		return nil, grpcError(err, "failed to get payment")
+	}
+
+	return mapPaymentToResponse(payment), nil
 }
 
 func (s *PaymentServer) GetPaymentStatus(ctx context.Context, req *paymentv1.GetPaymentStatusRequest) (*paymentv1.GetPaymentStatusResponse, error) {
-	if err := ValidatePaymentID(req.GetPaymentId()); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "request body is invalid: %v", err)
+	if err := ValidatePaymentRef(req.GetPaymentId(), req.GetOrderId()); err != nil {
+		return nil, invalidRequest(err)
 	}
 
-	paymentStatus, err := s.service.GetPaymentStatus(ctx, req.PaymentId)
+	paymentID, err := s.resolvePaymentID(ctx, req.PaymentId, req.OrderId)
 	if err != nil {
-		return nil, status.Errorf(GetGrpcCode(err), "failed to get payment status: %v", err)
+		return nil, err
+	}
+
+	paymentStatus, err := s.service.GetPaymentStatus(ctx, paymentID)
+	if err != nil {
+		return nil, grpcError(err, "failed to get payment status")
 	}
 
 	return &paymentv1.GetPaymentStatusResponse{
@@ -111,14 +129,37 @@ func (s *PaymentServer) GetPaymentStatus(ctx context.Context, req *paymentv1.Get
 	}, nil
 }
 
+func (s *PaymentServer) GetPaymentHistory(ctx context.Context, req *paymentv1.GetPaymentHistoryRequest) (*paymentv1.GetPaymentHistoryResponse, error) {
+	if err := ValidatePaymentRef(req.GetPaymentId(), req.GetOrderId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	paymentID, err := s.resolvePaymentID(ctx, req.PaymentId, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.service.GetPaymentHistory(ctx, paymentID)
+	if err != nil {
+		return nil, grpcError(err, "failed to get payment history")
+	}
+
+	return mapHistoryToResponse(paymentID, history), nil
+}
+
 func (s *PaymentServer) RefundPayment(ctx context.Context, req *paymentv1.RefundPaymentRequest) (*paymentv1.RefundPaymentResponse, error) {
-	if err := ValidatePaymentID(req.GetPaymentId()); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "request body is invalid: %v", err)
+	if err := ValidateRefundOrderReq(req); err != nil {
+		return nil, invalidRequest(err)
 	}
 
-	stat, err := s.service.RefundPayment(ctx, req.PaymentId, req.Reason)
+	paymentID, err := s.resolvePaymentID(ctx, req.PaymentId, req.OrderId)
 	if err != nil {
-		return nil, status.Errorf(GetGrpcCode(err), "failed to refund payment: %v", err)
+		return nil, err
+	}
+
+	stat, err := s.service.RefundPayment(ctx, paymentID, req.Reason, req.Amount, req.Currency)
+	if err != nil {
+		return nil, grpcError(err, "failed to refund payment")
 	}
 
 	return &paymentv1.RefundPaymentResponse{
@@ -127,13 +168,18 @@ func (s *PaymentServer) RefundPayment(ctx context.Context, req *paymentv1.Refund
 }
 
 func (s *PaymentServer) SuccessPayment(ctx context.Context, req *paymentv1.SuccessPaymentRequest) (*paymentv1.SuccessPaymentResponse, error) {
-	if err := ValidatePaymentID(req.GetPaymentId()); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "request body is invalid: %v", err)
+	if err := ValidatePaymentRef(req.GetPaymentId(), req.GetOrderId()); err != nil {
+		return nil, invalidRequest(err)
 	}
 
-	stat, err := s.service.SuccessPayment(ctx, req.PaymentId)
+	paymentID, err := s.resolvePaymentID(ctx, req.PaymentId, req.OrderId)
 	if err != nil {
-		return nil, status.Errorf(GetGrpcCode(err), "failed to set payment status to success: %v", err)
+		return nil, err
+	}
+
+	stat, err := s.service.SuccessPayment(ctx, paymentID)
+	if err != nil {
+		return nil, grpcError(err, "failed to set payment status to success")
 	}
 
 	return &paymentv1.SuccessPaymentResponse{
@@ -153,27 +199,42 @@ func (s *PaymentServer) HealthCheck(ctx context.Context, _ *paymentv1.HealthChec
 
 func (s *PaymentServer) ListPayments(ctx context.Context, req *paymentv1.ListPaymentsRequest) (*paymentv1.ListPaymentsResponse, error) {
 	if err := ValidateListPayments(req); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "request body is invalid: %v", err)
+		return nil, invalidRequest(err)
 	}
 
-	paymentList, err := s.service.PaymentsList(ctx, req.UserId, int(req.Page), int(req.PageSize))
+	page, err := s.service.PaymentsList(ctx, mapListRequestToFilter(req), int(req.Page))
 	if err != nil {
-		return nil, status.Errorf(GetGrpcCode(err), "failed to get payments list: %v", err)
+		return nil, grpcError(err, "failed to get payments list")
 	}
 
-	return mapPaymentsToResponse(paymentList), nil
+	return mapPaymentsToResponse(page), nil
 }
+
 func (s *PaymentServer) ReversalPayment(ctx context.Context, req *paymentv1.ReversalPaymentRequest) (*paymentv1.ReversalPaymentResponse, error) {
 	if err := ValidateReversalOrderReq(req); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "request body is invalid: %v", err)
+		return nil, invalidRequest(err)
 	}
 
-	state, err := s.service.ReversalPayment(ctx, req.PaymentId, req.Amount, req.Currency)
+	paymentID, err := s.resolvePaymentID(ctx, req.PaymentId, req.OrderId)
 	if err != nil {
-		return nil, status.Errorf(GetGrpcCode(err), "failed to deposit payment: %v", err)
+		return nil, err
+	}
+
+	state, err := s.service.ReversalPayment(ctx, paymentID, req.Amount, req.Currency)
+	if err != nil {
+		return nil, grpcError(err, "failed to reverse payment")
 	}
 
 	return &paymentv1.ReversalPaymentResponse{
 		Status: string(state),
 	}, nil
 }
+
+// resolvePaymentID находит ID платежа по payment_id или order_id из запроса.
+func (s *PaymentServer) resolvePaymentID(ctx context.Context, paymentID, orderID string) (string, error) {
+	id, err := s.service.ResolvePaymentID(ctx, paymentID, orderID)
+	if err != nil {
+		return "", grpcError(err, "failed to find payment")
+	}
+	return id, nil
+}
@@ -0,0 +1,50 @@
+package routers
+
+import (
+	"context"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+	"payment/internal/domain/models"
+)
+
+func (s *PaymentServer) GetReceipt(ctx context.Context, req *paymentv1.GetReceiptRequest) (*paymentv1.Receipt, error) {
+	if err := ValidateGetReceiptReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	paymentID, err := s.resolvePaymentID(ctx, req.PaymentId, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, document, err := s.receipts.GetReceipt(ctx, paymentID, receiptKind(req.Kind), receiptFormat(req.Format))
+	if err != nil {
+		return nil, grpcError(err, "failed to get receipt")
+	}
+
+	resp := mapReceiptToResponse(receipt)
+	resp.Document = &paymentv1.ReceiptDocument{
+		Format:      string(document.Format),
+		ContentType: document.ContentType,
+		Content:     document.Content,
+	}
+	return resp, nil
+}
+
+func (s *PaymentServer) ResendReceipt(ctx context.Context, req *paymentv1.ResendReceiptRequest) (*paymentv1.Receipt, error) {
+	if err := ValidateResendReceiptReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	paymentID, err := s.resolvePaymentID(ctx, req.PaymentId, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	customer := models.Customer{Email: req.GetCustomer().GetEmail(), Phone: req.GetCustomer().GetPhone()}
+	receipt, err := s.receipts.ResendReceipt(ctx, paymentID, receiptKind(req.Kind), customer)
+	if err != nil {
+		return nil, grpcError(err, "failed to resend receipt")
+	}
+
+	return mapReceiptToResponse(receipt), nil
+}
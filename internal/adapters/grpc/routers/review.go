@@ -0,0 +1,35 @@
+package routers
+
+import (
+	"context"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+)
+
+func (s *AdminServer) ApprovePayment(ctx context.Context, req *paymentv1.ReviewPaymentRequest) (*paymentv1.CreatePaymentResponse, error) {
+	if err := ValidateReviewPaymentReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	payment, next, err := s.service.ApprovePayment(ctx, req.PaymentId, req.Note)
+	if err != nil {
+		return nil, grpcError(err, "failed to approve payment")
+	}
+
+	return mapCreatePaymentResponse(payment, next), nil
+}
+
+func (s *AdminServer) RejectPayment(ctx context.Context, req *paymentv1.ReviewPaymentRequest) (*paymentv1.RejectPaymentResponse, error) {
+	if err := ValidateReviewPaymentReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	payment, err := s.service.RejectPayment(ctx, req.PaymentId, req.Note)
+	if err != nil {
+		return nil, grpcError(err, "failed to reject payment")
+	}
+
+	return &paymentv1.RejectPaymentResponse{
+		PaymentId: payment.ID,
+		Status:    string(payment.Status),
+	}, nil
+}
@@ -0,0 +1,34 @@
+package routers
+
+import (
+	"bytes"
+	"context"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+)
+
+func (s *LedgerServer) RunReconciliation(ctx context.Context, req *paymentv1.RunReconciliationRequest) (*paymentv1.Reconciliation, error) {
+	if err := ValidateRunReconciliationReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	rec, err := s.settlements.Reconcile(ctx, req.FileName, bytes.NewReader(req.Content), mapColumnMapping(req.Mapping),
+		req.PeriodStart.AsTime(), req.PeriodEnd.AsTime())
+	if err != nil {
+		return nil, grpcError(err, "failed to reconcile settlement file")
+	}
+
+	return mapReconciliationToResponse(rec), nil
+}
+
+func (s *LedgerServer) GetReconciliation(ctx context.Context, req *paymentv1.GetReconciliationRequest) (*paymentv1.Reconciliation, error) {
+	if err := ValidateReconciliationID(req.GetReconciliationId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	rec, err := s.settlements.GetReconciliation(ctx, req.ReconciliationId)
+	if err != nil {
+		return nil, grpcError(err, "failed to get reconciliation")
+	}
+
+	return mapReconciliationToResponse(rec), nil
+}
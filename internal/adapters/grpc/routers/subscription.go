@@ -0,0 +1,120 @@
+package routers
+
+import (
+	"context"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+)
+
+// SubscriptionServer — тарифы и подписки.
+type SubscriptionServer struct {
+	subscriptions ports.SubscriptionService
+	log           logger.Logger
+	paymentv1.UnimplementedSubscriptionsServer
+}
+
+func NewSubscriptionServer(subscriptions ports.SubscriptionService, log logger.Logger) *SubscriptionServer {
+	return &SubscriptionServer{
+		subscriptions: subscriptions,
+		log:           log,
+	}
+}
+
+func (s *SubscriptionServer) CreatePlan(ctx context.Context, req *paymentv1.CreatePlanRequest) (*paymentv1.Plan, error) {
+	if err := ValidateCreatePlanReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	plan, err := s.subscriptions.CreatePlan(ctx, models.Plan{
+		Name:           req.Name,
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+		IntervalMonths: int(req.IntervalMonths),
+		TrialDays:      int(req.TrialDays),
+	})
+	if err != nil {
+		return nil, grpcError(err, "failed to create plan")
+	}
+
+	return mapPlanToResponse(plan), nil
+}
+
+func (s *SubscriptionServer) ListPlans(ctx context.Context, req *paymentv1.ListPlansRequest) (*paymentv1.ListPlansResponse, error) {
+	plans, err := s.subscriptions.ListPlans(ctx, req.IncludeInactive)
+	if err != nil {
+		return nil, grpcError(err, "failed to get plans")
+	}
+
+	resp := &paymentv1.ListPlansResponse{Plans: make([]*paymentv1.Plan, 0, len(plans))}
+	for _, p := range plans {
+		resp.Plans = append(resp.Plans, mapPlanToResponse(p))
+	}
+	return resp, nil
+}
+
+func (s *SubscriptionServer) CreateSubscription(ctx context.Context, req *paymentv1.CreateSubscriptionRequest) (*paymentv1.Subscription, error) {
+	if err := ValidateCreateSubscriptionReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	sub, err := s.subscriptions.Subscribe(ctx, req.UserId, req.PlanId, req.BindingId)
+	if err != nil {
+		return nil, grpcError(err, "failed to create subscription")
+	}
+
+	return mapSubscriptionToResponse(sub), nil
+}
+
+func (s *SubscriptionServer) GetSubscription(ctx context.Context, req *paymentv1.GetSubscriptionRequest) (*paymentv1.Subscription, error) {
+	if err := ValidateSubscriptionID(req.GetSubscriptionId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	sub, err := s.subscriptions.GetSubscription(ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, grpcError(err, "failed to get subscription")
+	}
+
+	return mapSubscriptionToResponse(sub), nil
+}
+
+func (s *SubscriptionServer) CancelSubscription(ctx context.Context, req *paymentv1.CancelSubscriptionRequest) (*paymentv1.Subscription, error) {
+	if err := ValidateSubscriptionID(req.GetSubscriptionId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	sub, err := s.subscriptions.CancelSubscription(ctx, req.SubscriptionId, req.AtPeriodEnd)
+	if err != nil {
+		return nil, grpcError(err, "failed to cancel subscription")
+	}
+
+	return mapSubscriptionToResponse(sub), nil
+}
+
+func (s *SubscriptionServer) ChangeSubscriptionPlan(ctx context.Context, req *paymentv1.ChangeSubscriptionPlanRequest) (*paymentv1.Subscription, error) {
+	if err := ValidateChangePlanReq(req); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	sub, err := s.subscriptions.ChangePlan(ctx, req.SubscriptionId, req.PlanId)
+	if err != nil {
+		return nil, grpcError(err, "failed to change subscription plan")
+	}
+
+	return mapSubscriptionToResponse(sub), nil
+}
+
+func (s *SubscriptionServer) ListSubscriptionEvents(ctx context.Context, req *paymentv1.ListSubscriptionEventsRequest) (*paymentv1.ListSubscriptionEventsResponse, error) {
+	if err := ValidateSubscriptionID(req.GetSubscriptionId()); err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	events, err := s.subscriptions.SubscriptionEvents(ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, grpcError(err, "failed to get subscription events")
+	}
+
+	return mapSubscriptionEventsToResponse(events), nil
+}
@@ -3,7 +3,23 @@ package routers
 import (
 	"errors"
 	"fmt"
+	"net/mail"
 	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+	"payment/internal/domain/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Порядок сортировки списка платежей
+const (
+	sortOrderAsc  = "asc"
+	sortOrderDesc = "desc"
 )
 
 func ValidateCreateOrderReq(req *paymentv1.CreatePaymentRequest) error {
@@ -15,18 +31,11 @@ func ValidateCreateOrderReq(req *paymentv1.CreatePaymentRequest) error {
 		return errors.New("currency field is empty")
 	}
 
-	if req.GetErrorUrl() == "" {
-		return errors.New("errorUrl field is empty")
-	}
-
+	// Способ оплаты проверяется по правилам операции в сервисе
 	if req.GetOrderId() == "" {
 		return errors.New("orderID field is empty")
 	}
 
-	if req.GetReturnUrl() == "" {
-		return errors.New("returnUrl field is empty")
-	}
-
 	if req.GetUserId() == "" {
 		return errors.New("userID field is empty")
 	}
@@ -36,6 +45,116 @@ func ValidateCreateOrderReq(req *paymentv1.CreatePaymentRequest) error {
 
 	}
 
+	if err := validatePaymentDetails(req.GetDescription(), req.GetMetadata()); err != nil {
+		return err
+	}
+
+	if err := validateCart(req.GetCustomer(), req.GetItems()); err != nil {
+		return err
+	}
+
+	return validateSplits(req.GetSplits())
+}
+
+// Ограничения описания и metadata платежа
+const (
+	maxDescriptionLength = 255
+	maxMetadataKeys      = 50
+	maxMetadataKeyLength = 40
+	maxMetadataSizeBytes = 8 << 10
+)
+
+// validatePaymentDetails проверяет размер описания и metadata платежа.
+func validatePaymentDetails(description string, metadata *structpb.Struct) error {
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return fmt.Errorf("description must not exceed %d characters", maxDescriptionLength)
+	}
+
+	fields := metadata.GetFields()
+	if len(fields) > maxMetadataKeys {
+		return fmt.Errorf("metadata must not contain more than %d keys", maxMetadataKeys)
+	}
+	for key := range fields {
+		if key == "" || utf8.RuneCountInString(key) > maxMetadataKeyLength {
+			return fmt.Errorf("metadata key %q must be 1 to %d characters", key, maxMetadataKeyLength)
+		}
+	}
+	if metadata != nil {
+		raw, err := protojson.Marshal(metadata)
+		if err != nil {
+			return fmt.Errorf("metadata is invalid: %w", err)
+		}
+		if len(raw) > maxMetadataSizeBytes {
+			return fmt.Errorf("metadata must not exceed %d bytes", maxMetadataSizeBytes)
+		}
+	}
+
+	return nil
+}
+
+// Ограничения корзины
+const (
+	maxCartItems        = 100
+	maxCartItemName     = 128
+	maxCartItemQuantity = 1_000_000
+)
+
+// e164Phone — номер телефона в формате E.164.
+var e164Phone = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// validateCart проверяет контакты покупателя и позиции корзины.
+// Совпадение суммы позиций с суммой платежа проверяется в сервисе.
+func validateCart(customer *paymentv1.Customer, items []*paymentv1.CartItem) error {
+	if email := customer.GetEmail(); email != "" {
+		addr, err := mail.ParseAddress(email)
+		if err != nil || addr.Address != email {
+			return fmt.Errorf("customer email %q is invalid", email)
+		}
+	}
+	if phone := customer.GetPhone(); phone != "" && !e164Phone.MatchString(phone) {
+		return fmt.Errorf("customer phone %q must be in E.164 format", phone)
+	}
+
+	if len(items) > maxCartItems {
+		return fmt.Errorf("cart must not contain more than %d items", maxCartItems)
+	}
+	for i, item := range items {
+		if name := strings.TrimSpace(item.GetName()); name == "" || utf8.RuneCountInString(name) > maxCartItemName {
+			return fmt.Errorf("item %d name must be 1 to %d characters", i+1, maxCartItemName)
+		}
+		if item.GetQuantity() <= 0 || item.GetQuantity() > maxCartItemQuantity {
+			return fmt.Errorf("item %d quantity %v must be greater than 0 and not exceed %d", i+1, item.GetQuantity(), maxCartItemQuantity)
+		}
+		if item.GetPrice() < 0 {
+			return fmt.Errorf("item %d price %.2f must not be negative", i+1, item.GetPrice())
+		}
+		if item.GetTaxRate() < 0 || item.GetTaxRate() >= 100 {
+			return fmt.Errorf("item %d tax rate %v must be at least 0 and less than 100", i+1, item.GetTaxRate())
+		}
+	}
+
+	return nil
+}
+
+// validateSplits проверяет правила распределения: у каждого продавца одна положительная доля.
+func validateSplits(splits []*paymentv1.SplitRule) error {
+	sellers := make(map[string]struct{}, len(splits))
+	for _, split := range splits {
+		if split.GetSellerId() == "" {
+			return errors.New("split sellerID field is empty")
+		}
+		if split.GetSellerId() == models.PlatformParty {
+			return fmt.Errorf("split sellerID %q is reserved", models.PlatformParty)
+		}
+		if split.GetAmount() <= 0 {
+			return fmt.Errorf("split amount %.2f must be greater than 0", split.GetAmount())
+		}
+		if _, ok := sellers[split.GetSellerId()]; ok {
+			return fmt.Errorf("split sellerID %q is duplicated", split.GetSellerId())
+		}
+		sellers[split.GetSellerId()] = struct{}{}
+	}
+
 	return nil
 }
 
@@ -48,47 +167,52 @@ func ValidateAuthOrderReq(req *paymentv1.AuthPaymentRequest) error {
 		return errors.New("currency field is empty")
 	}
 
-	if req.GetErrorUrl() == "" {
-		return errors.New("errorUrl field is empty")
-	}
-
+	// Адреса возврата необязательны: по умолчанию берутся из конфигурации и проверяются в сервисе
 	if req.GetOrderId() == "" {
 		return errors.New("orderID field is empty")
 	}
 
-	if req.GetReturnUrl() == "" {
-		return errors.New("returnUrl field is empty")
-	}
-
 	if req.GetUserId() == "" {
 		return errors.New("userID field is empty")
 	}
 
-	return nil
+	if err := validatePaymentDetails(req.GetDescription(), req.GetMetadata()); err != nil {
+		return err
+	}
+
+	return validateCart(req.GetCustomer(), req.GetItems())
 }
 
 func ValidateDepositOrderReq(req *paymentv1.DepositPaymentRequest) error {
-	if req.GetAmount() < 0 {
-		return fmt.Errorf("amount %.2f must be positive", req.Amount)
-	}
-
-	if req.GetCurrency() == "" {
-		return errors.New("currency field is empty")
-	}
+	// Валюта необязательна: по умолчанию используется валюта платежа
+	return validateAmountAndRef(req.GetAmount(), req.GetCurrency(), req.GetPaymentId(), req.GetOrderId())
+}
 
-	return ValidatePaymentID(req.GetPaymentId())
+func ValidateRefundOrderReq(req *paymentv1.RefundPaymentRequest) error {
+	// Сумма и валюта необязательны: по умолчанию возвращается весь остаток в валюте платежа
+	return validateAmountAndRef(req.GetAmount(), req.GetCurrency(), req.GetPaymentId(), req.GetOrderId())
 }
 
 func ValidateReversalOrderReq(req *paymentv1.ReversalPaymentRequest) error {
-	if req.GetAmount() < 0 {
-		return fmt.Errorf("amount %.2f must be positive", req.Amount)
+	return validateAmountAndRef(req.GetAmount(), req.GetCurrency(), req.GetPaymentId(), req.GetOrderId())
+}
+
+func validateAmountAndRef(amount float64, currency, paymentID, orderID string) error {
+	var verr models.ValidationError
+
+	if amount < 0 {
+		verr.Add("amount", fmt.Sprintf("amount %.2f must be positive", amount))
 	}
 
-	if req.GetCurrency() == "" {
-		return errors.New("currency field is empty")
+	if currency != "" && len(currency) != 3 {
+		verr.Add("currency", fmt.Sprintf("currency %q must be an ISO 4217 code", currency))
+	}
+
+	if err := ValidatePaymentRef(paymentID, orderID); err != nil {
+		verr.Add("payment_id", err.Error())
 	}
 
-	return ValidatePaymentID(req.GetPaymentId())
+	return verr.Err()
 }
 
 func ValidateListPayments(req *paymentv1.ListPaymentsRequest) error {
@@ -100,10 +224,59 @@ func ValidateListPayments(req *paymentv1.ListPaymentsRequest) error {
 		return errors.New("page size must be greater than 0")
 	}
 
+	if req.GetPageSize() > models.MaxPageSize {
+		return fmt.Errorf("page size must not exceed %d", models.MaxPageSize)
+	}
+
 	if req.GetUserId() == "" {
 		return errors.New("userID field is empty")
 	}
 
+	return validatePaymentFilter(mapListRequestToFilter(req), req.GetSortOrder())
+}
+
+func ValidateExportPayments(req *paymentv1.ExportPaymentsRequest) error {
+	if format := exportFormat(req.GetFormat()); !models.IsExportFormatSupported(format) {
+		return fmt.Errorf("format %q must be CSV, JSONL or PARQUET", req.GetFormat())
+	}
+
+	return validatePaymentFilter(mapExportRequestToFilter(req), req.GetSortOrder())
+}
+
+// validatePaymentFilter проверяет фильтры списка и выгрузки платежей.
+func validatePaymentFilter(f models.PaymentFilter, sortOrder string) error {
+	for _, st := range f.Statuses {
+		if !models.IsStatusSupported(st) {
+			return fmt.Errorf("status %q is not supported", st)
+		}
+	}
+
+	if f.SortBy != "" && !models.IsSortFieldSupported(f.SortBy) {
+		return fmt.Errorf("sort field %q is not supported", f.SortBy)
+	}
+
+	if sortOrder != "" && sortOrder != sortOrderAsc && sortOrder != sortOrderDesc {
+		return fmt.Errorf("sort order %q must be %q or %q", sortOrder, sortOrderAsc, sortOrderDesc)
+	}
+
+	if !f.CreatedFrom.IsZero() && !f.CreatedTo.IsZero() && !f.CreatedFrom.Before(f.CreatedTo) {
+		return errors.New("created_from must be before created_to")
+	}
+
+	if f.AmountMin != nil && *f.AmountMin < 0 {
+		return fmt.Errorf("amount_min %.2f must be positive", *f.AmountMin)
+	}
+
+	if f.AmountMin != nil && f.AmountMax != nil && *f.AmountMin > *f.AmountMax {
+		return errors.New("amount_min must not exceed amount_max")
+	}
+
+	for key := range f.Metadata {
+		if key == "" {
+			return errors.New("metadata filter key is empty")
+		}
+	}
+
 	return nil
 }
 
@@ -114,3 +287,381 @@ func ValidatePaymentID(paymentID string) error {
 
 	return nil
 }
+
+// Максимальная длина комментария к ручной проверке платежа
+const maxReviewNoteLength = 1000
+
+func ValidateReviewPaymentReq(req *paymentv1.ReviewPaymentRequest) error {
+	var verr models.ValidationError
+
+	if req.GetPaymentId() == "" {
+		verr.Add("payment_id", "paymentID field is empty")
+	}
+
+	if utf8.RuneCountInString(req.GetNote()) > maxReviewNoteLength {
+		verr.Add("note", fmt.Sprintf("note must not exceed %d characters", maxReviewNoteLength))
+	}
+
+	return verr.Err()
+}
+
+func ValidateUserID(userID string) error {
+	if userID == "" {
+		return errors.New("userID field is empty")
+	}
+
+	return nil
+}
+
+func ValidateOrderID(orderID string) error {
+	if orderID == "" {
+		return errors.New("orderID field is empty")
+	}
+
+	return nil
+}
+
+// ValidatePaymentRef проверяет, что платёж задан ровно одним из идентификаторов.
+func ValidatePaymentRef(paymentID, orderID string) error {
+	if paymentID == "" && orderID == "" {
+		return errors.New("paymentID or orderID field must be set")
+	}
+
+	if paymentID != "" && orderID != "" {
+		return errors.New("only one of paymentID and orderID fields must be set")
+	}
+
+	return nil
+}
+
+func ValidateListAuditLog(req *paymentv1.ListAuditLogRequest) error {
+	if req.GetPageSize() < 0 || req.GetPageSize() > models.MaxPageSize {
+		return fmt.Errorf("page size must be between 0 and %d", models.MaxPageSize)
+	}
+
+	if res := models.AuditResult(req.GetResult()); res != "" && res != models.AuditSuccess && res != models.AuditFailure {
+		return fmt.Errorf("result %q must be %q or %q", res, models.AuditSuccess, models.AuditFailure)
+	}
+
+	if req.CreatedFrom != nil && req.CreatedTo != nil &&
+		!req.CreatedFrom.AsTime().Before(req.CreatedTo.AsTime()) {
+		return errors.New("created_from must be before created_to")
+	}
+
+	if req.GetCursor() != "" {
+		if id, err := strconv.ParseInt(req.GetCursor(), 10, 64); err != nil || id <= 0 {
+			return errors.New("cursor is invalid")
+		}
+	}
+
+	return nil
+}
+
+func ValidateChargeBindingReq(req *paymentv1.ChargeBindingRequest) error {
+	var verr models.ValidationError
+
+	if req.GetOrderId() == "" {
+		verr.Add("order_id", "orderID field is empty")
+	}
+
+	if req.GetUserId() == "" {
+		verr.Add("user_id", "userID field is empty")
+	}
+
+	if req.GetBindingId() == "" {
+		verr.Add("binding_id", "bindingID field is empty")
+	}
+
+	if req.GetAmount() <= 0 {
+		verr.Add("amount", fmt.Sprintf("amount %.2f must be greater than 0", req.GetAmount()))
+	}
+
+	if len(req.GetCurrency()) != 3 {
+		verr.Add("currency", fmt.Sprintf("currency %q must be an ISO 4217 code", req.GetCurrency()))
+	}
+
+	return verr.Err()
+}
+
+func ValidateBindingRef(userID, bindingID string) error {
+	if err := ValidateUserID(userID); err != nil {
+		return err
+	}
+
+	if bindingID == "" {
+		return errors.New("bindingID field is empty")
+	}
+
+	return nil
+}
+
+func ValidateCreatePlanReq(req *paymentv1.CreatePlanRequest) error {
+	var verr models.ValidationError
+
+	if req.GetName() == "" {
+		verr.Add("name", "name field is empty")
+	}
+
+	if req.GetAmount() <= 0 {
+		verr.Add("amount", fmt.Sprintf("amount %.2f must be greater than 0", req.GetAmount()))
+	}
+
+	if len(req.GetCurrency()) != 3 {
+		verr.Add("currency", fmt.Sprintf("currency %q must be an ISO 4217 code", req.GetCurrency()))
+	}
+
+	if req.GetIntervalMonths() <= 0 {
+		verr.Add("interval_months", "interval must be at least one month")
+	}
+
+	if req.GetTrialDays() < 0 {
+		verr.Add("trial_days", "trial days must not be negative")
+	}
+
+	return verr.Err()
+}
+
+func ValidateCreateSubscriptionReq(req *paymentv1.CreateSubscriptionRequest) error {
+	var verr models.ValidationError
+
+	if req.GetUserId() == "" {
+		verr.Add("user_id", "userID field is empty")
+	}
+
+	if req.GetPlanId() == "" {
+		verr.Add("plan_id", "planID field is empty")
+	}
+
+	if req.GetBindingId() == "" {
+		verr.Add("binding_id", "bindingID field is empty")
+	}
+
+	return verr.Err()
+}
+
+func ValidateChangePlanReq(req *paymentv1.ChangeSubscriptionPlanRequest) error {
+	if err := ValidateSubscriptionID(req.GetSubscriptionId()); err != nil {
+		return err
+	}
+
+	if req.GetPlanId() == "" {
+		return errors.New("planID field is empty")
+	}
+
+	return nil
+}
+
+func ValidateSubscriptionID(subscriptionID string) error {
+	if subscriptionID == "" {
+		return errors.New("subscriptionID field is empty")
+	}
+
+	return nil
+}
+
+func ValidateCreateInvoiceReq(req *paymentv1.CreateInvoiceRequest) error {
+	var verr models.ValidationError
+
+	if req.GetAmount() <= 0 {
+		verr.Add("amount", fmt.Sprintf("amount %.2f must be greater than 0", req.GetAmount()))
+	}
+
+	if len(req.GetCurrency()) != 3 {
+		verr.Add("currency", fmt.Sprintf("currency %q must be an ISO 4217 code", req.GetCurrency()))
+	}
+
+	if req.ExpiresAt == nil {
+		verr.Add("expires_at", "expires_at field is empty")
+	} else if !req.ExpiresAt.AsTime().After(time.Now()) {
+		verr.Add("expires_at", "expires_at must be in the future")
+	}
+
+	return verr.Err()
+}
+
+func ValidateInvoiceID(invoiceID string) error {
+	if invoiceID == "" {
+		return errors.New("invoiceID field is empty")
+	}
+
+	return nil
+}
+
+func ValidateSellerID(sellerID string) error {
+	if sellerID == "" {
+		return errors.New("sellerID field is empty")
+	}
+
+	return nil
+}
+
+func ValidatePayoutID(payoutID string) error {
+	if payoutID == "" {
+		return errors.New("payoutID field is empty")
+	}
+
+	return nil
+}
+
+func ValidateRunReconciliationReq(req *paymentv1.RunReconciliationRequest) error {
+	var verr models.ValidationError
+
+	if len(req.GetContent()) == 0 {
+		verr.Add("content", "content field is empty")
+	}
+
+	if req.PeriodStart == nil {
+		verr.Add("period_start", "period_start field is empty")
+	}
+
+	if req.PeriodEnd == nil {
+		verr.Add("period_end", "period_end field is empty")
+	} else if req.PeriodStart != nil && !req.PeriodEnd.AsTime().After(req.PeriodStart.AsTime()) {
+		verr.Add("period_end", "period_end must be after period_start")
+	}
+
+	if d := req.GetMapping().GetDelimiter(); utf8.RuneCountInString(d) > 1 {
+		verr.Add("mapping.delimiter", fmt.Sprintf("delimiter %q must be a single character", d))
+	}
+
+	for bankStatus, st := range req.GetMapping().GetStatuses() {
+		if !models.IsStatusSupported(models.StatusType(st)) {
+			verr.Add("mapping.statuses", fmt.Sprintf("status %q for %q is not supported", st, bankStatus))
+		}
+	}
+
+	return verr.Err()
+}
+
+func ValidateReconciliationID(reconciliationID string) error {
+	if reconciliationID == "" {
+		return errors.New("reconciliationID field is empty")
+	}
+
+	return nil
+}
+
+func ValidateCreateFeeScheduleReq(req *paymentv1.CreateFeeScheduleRequest) error {
+	var verr models.ValidationError
+
+	if c := req.GetCurrency(); c != "" && len(c) != 3 {
+		verr.Add("currency", fmt.Sprintf("currency %q must be an ISO 4217 code", c))
+	}
+
+	if req.GetPercent() < 0 || req.GetPercent() >= 100 {
+		verr.Add("percent", fmt.Sprintf("percent %.4f must be in [0, 100)", req.GetPercent()))
+	}
+	if req.GetFixed() < 0 {
+		verr.Add("fixed", fmt.Sprintf("fixed %.2f must be positive", req.GetFixed()))
+	}
+	if req.GetMinFee() < 0 {
+		verr.Add("min_fee", fmt.Sprintf("min_fee %.2f must be positive", req.GetMinFee()))
+	}
+	if req.GetMaxFee() < 0 {
+		verr.Add("max_fee", fmt.Sprintf("max_fee %.2f must be positive", req.GetMaxFee()))
+	} else if req.GetMaxFee() > 0 && req.GetMaxFee() < req.GetMinFee() {
+		verr.Add("max_fee", fmt.Sprintf("max_fee %.2f must not be less than min_fee %.2f", req.GetMaxFee(), req.GetMinFee()))
+	}
+	if req.GetRefundFixed() < 0 {
+		verr.Add("refund_fixed", fmt.Sprintf("refund_fixed %.2f must be positive", req.GetRefundFixed()))
+	}
+
+	// Ставки упорядочены по возрастанию up_to, безлимитной может быть только последняя
+	var prevUpTo float64
+	for i, tier := range req.GetTiers() {
+		field := fmt.Sprintf("tiers[%d]", i)
+		switch {
+		case tier.GetUpTo() < 0:
+			verr.Add(field+".up_to", fmt.Sprintf("up_to %.2f must be positive", tier.GetUpTo()))
+		case tier.GetUpTo() == 0 && i != len(req.GetTiers())-1:
+			verr.Add(field+".up_to", "only the last tier may be unlimited")
+		case tier.GetUpTo() != 0 && tier.GetUpTo() <= prevUpTo:
+			verr.Add(field+".up_to", fmt.Sprintf("up_to %.2f must be greater than previous tier", tier.GetUpTo()))
+		}
+		prevUpTo = tier.GetUpTo()
+
+		if tier.GetPercent() < 0 || tier.GetPercent() >= 100 {
+			verr.Add(field+".percent", fmt.Sprintf("percent %.4f must be in [0, 100)", tier.GetPercent()))
+		}
+		if tier.GetFixed() < 0 {
+			verr.Add(field+".fixed", fmt.Sprintf("fixed %.2f must be positive", tier.GetFixed()))
+		}
+	}
+
+	return verr.Err()
+}
+
+func ValidateCreateLimitReq(req *paymentv1.CreateLimitRequest) error {
+	var verr models.ValidationError
+
+	if !models.IsLimitScopeSupported(models.LimitScope(strings.ToUpper(req.GetScope()))) {
+		verr.Add("scope", fmt.Sprintf("scope %q must be USER or MERCHANT", req.GetScope()))
+	}
+	if c := req.GetCurrency(); len(c) != 3 {
+		verr.Add("currency", fmt.Sprintf("currency %q must be an ISO 4217 code", c))
+	}
+
+	for _, f := range []struct {
+		field string
+		value float64
+	}{{"max_single", req.GetMaxSingle()}, {"daily", req.GetDaily()}, {"monthly", req.GetMonthly()}} {
+		if f.value < 0 {
+			verr.Add(f.field, fmt.Sprintf("%s %.2f must be positive", f.field, f.value))
+		}
+	}
+	if req.GetMaxSingle() == 0 && req.GetDaily() == 0 && req.GetMonthly() == 0 {
+		verr.Add("max_single", "at least one of max_single, daily or monthly must be set")
+	}
+
+	return verr.Err()
+}
+
+func ValidateLimitID(limitID string) error {
+	if limitID == "" {
+		return errors.New("limitID field is empty")
+	}
+
+	return nil
+}
+
+func ValidateGetReceiptReq(req *paymentv1.GetReceiptRequest) error {
+	var verr models.ValidationError
+
+	if err := ValidatePaymentRef(req.GetPaymentId(), req.GetOrderId()); err != nil {
+		verr.Add("payment_id", err.Error())
+	}
+	validateReceiptKind(req.GetKind(), &verr)
+	if !models.IsReceiptFormatSupported(receiptFormat(req.GetFormat())) {
+		verr.Add("format", fmt.Sprintf("format %q must be HTML or PDF", req.GetFormat()))
+	}
+
+	return verr.Err()
+}
+
+func ValidateResendReceiptReq(req *paymentv1.ResendReceiptRequest) error {
+	var verr models.ValidationError
+
+	if err := ValidatePaymentRef(req.GetPaymentId(), req.GetOrderId()); err != nil {
+		verr.Add("payment_id", err.Error())
+	}
+	validateReceiptKind(req.GetKind(), &verr)
+	if err := validateCart(req.GetCustomer(), nil); err != nil {
+		verr.Add("customer", err.Error())
+	}
+
+	return verr.Err()
+}
+
+func validateReceiptKind(kind string, verr *models.ValidationError) {
+	if !models.IsReceiptKindSupported(receiptKind(kind)) {
+		verr.Add("kind", fmt.Sprintf("kind %q must be SALE or REFUND", kind))
+	}
+}
+
+func ValidateFeeScheduleID(scheduleID string) error {
+	if scheduleID == "" {
+		return errors.New("scheduleID field is empty")
+	}
+
+	return nil
+}
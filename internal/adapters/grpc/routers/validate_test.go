@@ -0,0 +1,152 @@
+package routers
+
+import (
+	"fmt"
+	paymentv1 "payment/internal/adapters/grpc/payment/v1"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestValidatePaymentRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *paymentv1.RefundPaymentRequest
+		wantErr bool
+	}{
+		{"payment id", &paymentv1.RefundPaymentRequest{PaymentId: "bank-1"}, false},
+		{"order id", &paymentv1.RefundPaymentRequest{OrderId: "order-1"}, false},
+		{"neither", &paymentv1.RefundPaymentRequest{}, true},
+		{"both", &paymentv1.RefundPaymentRequest{PaymentId: "bank-1", OrderId: "order-1"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRefundOrderReq(tt.req); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateRefundOrderReq error = %v, want error = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePaymentDetails(t *testing.T) {
+	metadata := func(fields map[string]any) *structpb.Struct {
+		t.Helper()
+		s, err := structpb.NewStruct(fields)
+		if err != nil {
+			t.Fatalf("NewStruct: %v", err)
+		}
+		return s
+	}
+	manyKeys := make(map[string]any, maxMetadataKeys+1)
+	for i := range maxMetadataKeys + 1 {
+		manyKeys[fmt.Sprintf("key_%d", i)] = i
+	}
+	maxKeys := make(map[string]any, maxMetadataKeys)
+	for i := range maxMetadataKeys {
+		maxKeys[fmt.Sprintf("key_%d", i)] = i
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		metadata    *structpb.Struct
+		wantErr     string
+	}{
+		{"empty", "", nil, ""},
+		{"description in characters", strings.Repeat("ж", maxDescriptionLength), nil, ""},
+		{"long description", strings.Repeat("a", maxDescriptionLength+1), nil, "description"},
+		{"nested metadata", "", metadata(map[string]any{"cart_id": "42", "items": []any{"sku-1", 2}, "source": map[string]any{"app": "ios"}}), ""},
+		{"max keys", "", metadata(maxKeys), ""},
+		{"too many keys", "", metadata(manyKeys), "keys"},
+		{"empty key", "", metadata(map[string]any{"": "x"}), "metadata key"},
+		{"long key", "", metadata(map[string]any{strings.Repeat("k", maxMetadataKeyLength+1): "x"}), "metadata key"},
+		{"key in characters", "", metadata(map[string]any{strings.Repeat("ключ", maxMetadataKeyLength/4): "x"}), ""},
+		{"too large", "", metadata(map[string]any{"blob": strings.Repeat("x", maxMetadataSizeBytes)}), "bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePaymentDetails(tt.description, tt.metadata)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validatePaymentDetails: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validatePaymentDetails error = %v, want error about %s", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Описание и metadata проверяются и при создании, и при авторизации платежа
+	long := strings.Repeat("a", maxDescriptionLength+1)
+	create := &paymentv1.CreatePaymentRequest{Amount: 100, Currency: "KZT", OrderId: "order-1", UserId: "u1", Operation: "URL_payment", Description: long}
+	if err := ValidateCreateOrderReq(create); err == nil {
+		t.Error("ValidateCreateOrderReq with long description error = nil")
+	}
+	auth := &paymentv1.AuthPaymentRequest{Amount: 100, Currency: "KZT", OrderId: "order-1", UserId: "u1", Metadata: metadata(map[string]any{"": "x"})}
+	if err := ValidateAuthOrderReq(auth); err == nil {
+		t.Error("ValidateAuthOrderReq with empty metadata key error = nil")
+	}
+}
+
+func TestValidateListPaymentsMetadata(t *testing.T) {
+	req := &paymentv1.ListPaymentsRequest{UserId: "u1", Metadata: map[string]string{"cart_id": "42"}}
+	if err := ValidateListPayments(req); err != nil {
+		t.Fatalf("ValidateListPayments: %v", err)
+	}
+
+	req.Metadata[""] = "x"
+	if err := ValidateListPayments(req); err == nil || !strings.Contains(err.Error(), "metadata") {
+		t.Fatalf("ValidateListPayments with empty key error = %v, want metadata error", err)
+	}
+}
+
+func TestValidateCartRequest(t *testing.T) {
+	item := func(name string, quantity, price, taxRate float64) *paymentv1.CartItem {
+		return &paymentv1.CartItem{Name: name, Quantity: quantity, Price: price, TaxRate: taxRate}
+	}
+	tooMany := make([]*paymentv1.CartItem, maxCartItems+1)
+	for i := range tooMany {
+		tooMany[i] = item("Чай", 1, 1, 0)
+	}
+
+	tests := []struct {
+		name     string
+		customer *paymentv1.Customer
+		items    []*paymentv1.CartItem
+		wantErr  string
+	}{
+		{"no cart", nil, nil, ""},
+		{"valid cart", &paymentv1.Customer{Email: "buyer@example.com", Phone: "+77011234567"},
+			[]*paymentv1.CartItem{item("Чай", 2, 150, 12), item("Пакет", 1, 0, 0), item("Сыр", 0.347, 1290, 0)}, ""},
+		{"invalid email", &paymentv1.Customer{Email: "Buyer <buyer@example.com>"}, nil, "email"},
+		{"phone not in E.164", &paymentv1.Customer{Phone: "87011234567"}, nil, "E.164"},
+		{"too many items", nil, tooMany, "items"},
+		{"empty name", nil, []*paymentv1.CartItem{item("  ", 1, 100, 0)}, "item 1 name"},
+		{"zero quantity", nil, []*paymentv1.CartItem{item("Чай", 1, 100, 0), item("Сахар", 0, 100, 0)}, "item 2 quantity"},
+		{"negative quantity", nil, []*paymentv1.CartItem{item("Чай", -1, 100, 0)}, "item 1 quantity"},
+		{"quantity too large", nil, []*paymentv1.CartItem{item("Чай", maxCartItemQuantity+1, 1, 0)}, "item 1 quantity"},
+		{"negative price", nil, []*paymentv1.CartItem{item("Скидка", 1, -10, 0)}, "item 1 price"},
+		{"negative tax rate", nil, []*paymentv1.CartItem{item("Чай", 1, 100, -12)}, "item 1 tax rate"},
+		{"tax rate 100", nil, []*paymentv1.CartItem{item("Чай", 1, 100, 100)}, "item 1 tax rate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCart(tt.customer, tt.items)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateCart: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateCart error = %v, want error about %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,49 +2,138 @@ package grpcserver
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"payment/config"
 	paymentv1 "payment/internal/adapters/grpc/payment/v1"
 	"payment/internal/adapters/grpc/routers"
 	"payment/internal/domain/action"
 	"payment/internal/domain/ports"
 	"payment/pkg/logger"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
+const gatewayShutdownTimeout = 10 * time.Second
+
 type API struct {
-	server *grpc.Server
-	cfg    config.GRPCServer
+	server  *grpc.Server
+	gateway *http.Server // REST шлюз сервисов мерчанта и операторов
+	links   *http.Server // Публичные ссылки на оплату счетов
+	cfg     config.Server
 
 	log logger.Logger
 }
 
-func New(ctx context.Context, cfg config.GRPCServer, paymentService ports.PaymentService, log logger.Logger) *API {
-	mux := runtime.NewServeMux()
-	server := grpc.NewServer(GetOptions(cfg, log)...)
+// Services — сервисы, которые обслуживает API.
+type Services struct {
+	Payments      ports.PaymentService
+	Subscriptions ports.SubscriptionService
+	Invoices      ports.InvoiceService
+	Ledger        ports.LedgerService
+	Settlements   ports.SettlementService
+	Fees          ports.FeeService
+	Limits        ports.LimitService
+	Currencies    ports.CurrencyService
+	Receipts      ports.ReceiptService
+	Exports       ports.ExportService
+}
 
-	paymentv1.RegisterPaymentServer(server, routers.NewPaymentServer(paymentService, log))
-	paymentv1.RegisterPaymentHandlerFromEndpoint(ctx, mux, "localhost:7000", nil)
+func New(ctx context.Context, cfg config.Server, services Services, log logger.Logger) *API {
+	auth, err := NewAuthenticator(cfg.Auth)
+	if err != nil {
+		log.Fatal(ctx, action.ServerStartFail, err, "Failed to load API keys")
+	}
+	if cfg.Auth.AllowAnonymous {
+		log.Warn(ctx, action.ServiceSetup, "merchant services accept calls without API key (API_ALLOW_ANONYMOUS)")
+	} else if cfg.Auth.APIKeys == "" {
+		log.Warn(ctx, action.ServiceSetup, "API_KEYS is empty: merchant services reject calls without operator key")
+	}
+	proxies, err := ParseTrustedProxies(cfg.HTTPServer.TrustedProxies)
+	if err != nil {
+		log.Fatal(ctx, action.ServerStartFail, err, "Failed to load trusted proxies")
+	}
+	gatewayToken, err := newGatewayToken()
+	if err != nil {
+		log.Fatal(ctx, action.ServerStartFail, err, "Failed to generate REST gateway token")
+	}
+
+	// REST шлюз подписывает вызовы секретом, чтобы gRPC сервер доверял дописанному им адресу клиента
+	mux := runtime.NewServeMux(runtime.WithMetadata(func(context.Context, *http.Request) metadata.MD {
+		return metadata.Pairs(gatewayTokenHeader, gatewayToken)
+	}))
+	server := grpc.NewServer(GetOptions(cfg.GRPCServer, auth, proxies.withGateway(gatewayToken), log)...)
+
+	paymentv1.RegisterPaymentServer(server, routers.NewPaymentServer(services.Payments, services.Receipts, services.Currencies, log))
+	paymentv1.RegisterSubscriptionsServer(server, routers.NewSubscriptionServer(services.Subscriptions, log))
+	paymentv1.RegisterInvoicesServer(server, routers.NewInvoiceServer(services.Invoices, log))
+	paymentv1.RegisterLedgerServer(server, routers.NewLedgerServer(services.Ledger, services.Settlements, log))
+	paymentv1.RegisterExportsServer(server, routers.NewExportServer(services.Exports, log))
+	paymentv1.RegisterAdminServer(server, routers.NewAdminServer(services.Payments, services.Fees, services.Limits, log))
+
+	// REST шлюз для всех сервисов
+	endpoint := "localhost:" + cfg.GRPCServer.Port
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	for _, register := range []func(context.Context, *runtime.ServeMux, string, []grpc.DialOption) error{
+		paymentv1.RegisterPaymentHandlerFromEndpoint,
+		paymentv1.RegisterSubscriptionsHandlerFromEndpoint,
+		paymentv1.RegisterInvoicesHandlerFromEndpoint,
+		paymentv1.RegisterLedgerHandlerFromEndpoint,
+		paymentv1.RegisterExportsHandlerFromEndpoint,
+		paymentv1.RegisterAdminHandlerFromEndpoint,
+	} {
+		if err := register(ctx, mux, endpoint, opts); err != nil {
+			log.Error(ctx, action.ServerStartFail, err, "Failed to register REST gateway")
+		}
+	}
 
 	return &API{
 		server: server,
-		cfg:    cfg,
-		log:    log,
+		gateway: &http.Server{
+			Addr:              ":" + cfg.HTTPServer.Port,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		links: &http.Server{
+			Addr:              ":" + cfg.HTTPServer.PublicPort,
+			Handler:           newInvoiceLinks(services.Invoices, proxies, log),
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		cfg: cfg,
+		log: log,
 	}
 }
 
+// newGatewayToken — случайный секрет REST шлюза на время жизни процесса.
+func newGatewayToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
 func (a *API) Start(ctx context.Context, errCh chan error) {
-	l, err := net.Listen("tcp", ":"+a.cfg.Port)
+	go a.startGateway(ctx, errCh)
+	go a.startLinks(ctx, errCh)
+
+	port := a.cfg.GRPCServer.Port
+	l, err := net.Listen("tcp", ":"+port)
 	if err != nil {
-		a.log.Error(ctx, action.ServerStartFail, err, "Failed to listen on port", "port", a.cfg.Port)
-		errCh <- fmt.Errorf("failed to listen on port %s: %w", a.cfg.Port, err)
+		a.log.Error(ctx, action.ServerStartFail, err, "Failed to listen on port", "port", port)
+		errCh <- fmt.Errorf("failed to listen on port %s: %w", port, err)
 		return
 	}
 
-	a.log.Info(ctx, action.ServerStarted, "Server has been started", "port", a.cfg.Port)
+	a.log.Info(ctx, action.ServerStarted, "Server has been started", "port", port)
 	if err := a.server.Serve(l); err != nil {
 		a.log.Error(ctx, action.ServerStartFail, err, "Failed to start gRPC server")
 		errCh <- fmt.Errorf("failed to start gRPC server: %w", err)
@@ -54,6 +143,36 @@ func (a *API) Start(ctx context.Context, errCh chan error) {
 	a.log.Info(ctx, action.ServerClosed, "Server has been stopped")
 }
 
+func (a *API) startGateway(ctx context.Context, errCh chan error) {
+	a.log.Info(ctx, action.ServerStarted, "HTTP gateway has been started", "port", a.cfg.HTTPServer.Port)
+	if err := a.gateway.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		a.log.Error(ctx, action.ServerStartFail, err, "Failed to start HTTP gateway")
+		errCh <- fmt.Errorf("failed to start HTTP gateway: %w", err)
+		return
+	}
+
+	a.log.Info(ctx, action.ServerClosed, "HTTP gateway has been stopped")
+}
+
+func (a *API) startLinks(ctx context.Context, errCh chan error) {
+	a.log.Info(ctx, action.ServerStarted, "Invoice links server has been started", "port", a.cfg.HTTPServer.PublicPort)
+	if err := a.links.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		a.log.Error(ctx, action.ServerStartFail, err, "Failed to start invoice links server")
+		errCh <- fmt.Errorf("failed to start invoice links server: %w", err)
+		return
+	}
+
+	a.log.Info(ctx, action.ServerClosed, "Invoice links server has been stopped")
+}
+
 func (a *API) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), gatewayShutdownTimeout)
+	defer cancel()
+	if err := a.links.Shutdown(ctx); err != nil {
+		a.log.Error(ctx, action.GracefulShutdown, err, "Failed to stop invoice links server")
+	}
+	if err := a.gateway.Shutdown(ctx); err != nil {
+		a.log.Error(ctx, action.GracefulShutdown, err, "Failed to stop HTTP gateway")
+	}
 	a.server.GracefulStop()
 }
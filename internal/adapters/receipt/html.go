@@ -0,0 +1,76 @@
+package receipt
+
+import (
+	"bytes"
+	"html/template"
+	"payment/internal/domain/models"
+	"time"
+)
+
+var htmlTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"amount":   formatAmount,
+	"quantity": formatQuantity,
+	"rate":     formatRate,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}} {{.Receipt.OrderID}}</title>
+<style>
+body { font-family: sans-serif; max-width: 480px; margin: 24px auto; color: #222; }
+table { width: 100%; border-collapse: collapse; }
+td, th { padding: 4px 0; text-align: left; vertical-align: top; }
+.num { text-align: right; white-space: nowrap; }
+.total td { border-top: 1px solid #999; font-weight: bold; }
+.muted { color: #777; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h2>{{.Title}}</h2>
+{{if .Seller}}<p>{{.Seller}}{{if .SellerTIN}}<br><span class="muted">TIN {{.SellerTIN}}</span>{{end}}</p>{{end}}
+<p class="muted">
+Order {{.Receipt.OrderID}}<br>
+Payment {{.Receipt.PaymentID}}<br>
+{{.Date.Format "2006-01-02 15:04:05"}} UTC
+</p>
+<table>
+<tr><th>Item</th><th class="num">Qty</th><th class="num">Price</th><th class="num">Amount</th></tr>
+{{range .Receipt.Items}}<tr>
+<td>{{.Name}}<br><span class="muted">{{rate .TaxRate}}</span></td>
+<td class="num">{{quantity .Quantity}}</td>
+<td class="num">{{amount .Price}}</td>
+<td class="num">{{amount .Amount}}</td>
+</tr>
+{{end}}<tr class="total"><td colspan="3">Total, {{.Receipt.Currency}}</td><td class="num">{{amount .Receipt.Amount}}</td></tr>
+<tr><td colspan="3" class="muted">incl. VAT</td><td class="num muted">{{amount .Receipt.TaxTotal}}</td></tr>
+</table>
+{{if .Receipt.FiscalNumber}}<p class="muted">
+Fiscal sign {{.Receipt.FiscalNumber}}{{if .Receipt.Provider}} ({{.Receipt.Provider}}){{end}}
+{{if .Receipt.FiscalURL}}<br><a href="{{.Receipt.FiscalURL}}">Check the receipt</a>{{end}}
+</p>{{else}}<p class="muted">The receipt is not fiscalized yet.</p>{{end}}
+</body>
+</html>
+`))
+
+type htmlData struct {
+	Title     string
+	Seller    string
+	SellerTIN string
+	Date      time.Time
+	Receipt   models.Receipt
+}
+
+func (r *Renderer) html(receipt models.Receipt) ([]byte, error) {
+	var buf bytes.Buffer
+	err := htmlTemplate.Execute(&buf, htmlData{
+		Title:     titles[receipt.Kind],
+		Seller:    r.seller,
+		SellerTIN: r.sellerTIN,
+		Date:      receiptDate(receipt),
+		Receipt:   receipt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
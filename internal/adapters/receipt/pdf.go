@@ -0,0 +1,164 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"payment/internal/domain/models"
+	"strings"
+	"unicode/utf8"
+)
+
+// Чек печатается на ленте 80 мм моноширинным шрифтом Courier.
+// Стандартные шрифты PDF не содержат кириллицы, поэтому текст транслитерируется (pdfText).
+const (
+	pdfPageWidth  = 226 // 80 мм в пунктах
+	pdfMargin     = 12
+	pdfFontSize   = 8
+	pdfLineHeight = 10
+	pdfLineWidth  = 42 // Символов Courier 8pt в строке: (226 - 2*12) / 4.8
+)
+
+func (r *Renderer) pdf(receipt models.Receipt) ([]byte, error) {
+	lines := r.textLines(receipt)
+	height := 2*pdfMargin + len(lines)*pdfLineHeight
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLineHeight, pdfMargin, height-pdfMargin-pdfFontSize)
+	for _, line := range lines {
+		fmt.Fprintf(&content, "(%s) Tj T*\n", pdfText(line))
+	}
+	content.WriteString("ET\n")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>", pdfPageWidth, height),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return out.Bytes(), nil
+}
+
+// textLines — строки чека шириной pdfLineWidth.
+func (r *Renderer) textLines(receipt models.Receipt) []string {
+	separator := strings.Repeat("-", pdfLineWidth)
+
+	var lines []string
+	lines = append(lines, wrap(titles[receipt.Kind])...)
+	if r.seller != "" {
+		lines = append(lines, wrap(r.seller)...)
+	}
+	if r.sellerTIN != "" {
+		lines = append(lines, "TIN "+r.sellerTIN)
+	}
+	lines = append(lines,
+		separator,
+		"Order "+receipt.OrderID,
+		"Payment "+receipt.PaymentID,
+		receiptDate(receipt).Format("2006-01-02 15:04:05")+" UTC",
+		separator,
+	)
+
+	for _, item := range receipt.Items {
+		lines = append(lines, wrap(item.Name)...)
+		lines = append(lines, columns(
+			"  "+formatQuantity(item.Quantity)+" x "+formatAmount(item.Price),
+			formatAmount(item.Amount())))
+		lines = append(lines, "  "+formatRate(item.TaxRate))
+	}
+
+	lines = append(lines,
+		separator,
+		columns("TOTAL, "+receipt.Currency, formatAmount(receipt.Amount)),
+		columns("incl. VAT", formatAmount(receipt.TaxTotal())),
+		separator,
+	)
+	if receipt.FiscalNumber != "" {
+		lines = append(lines, wrap("Fiscal sign "+receipt.FiscalNumber)...)
+		if receipt.FiscalURL != "" {
+			lines = append(lines, wrap(receipt.FiscalURL)...)
+		}
+	} else {
+		lines = append(lines, "Not fiscalized yet")
+	}
+	return lines
+}
+
+// columns выравнивает left по левому краю, right — по правому.
+func columns(left, right string) string {
+	gap := pdfLineWidth - utf8.RuneCountInString(left) - utf8.RuneCountInString(right)
+	if gap < 1 {
+		gap = 1
+	}
+	return left + strings.Repeat(" ", gap) + right
+}
+
+// wrap разбивает строку на части не длиннее pdfLineWidth символов.
+func wrap(s string) []string {
+	runes := []rune(s)
+	var lines []string
+	for len(runes) > pdfLineWidth {
+		lines = append(lines, string(runes[:pdfLineWidth]))
+		runes = runes[pdfLineWidth:]
+	}
+	return append(lines, string(runes))
+}
+
+// pdfText переводит строку в WinAnsi: кириллица транслитерируется, остальные символы вне Latin-1
+// заменяются на '?', спецсимволы строк PDF экранируются.
+func pdfText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if lat, ok := translit[r]; ok {
+			b.WriteString(lat)
+			continue
+		}
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+var translit = func() map[rune]string {
+	pairs := map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+		'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+		'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+		'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+		// Казахский алфавит
+		'ә': "a", 'ғ': "g", 'қ': "q", 'ң': "n", 'ө': "o", 'ұ': "u", 'ү': "u", 'һ': "h", 'і': "i",
+	}
+	m := make(map[rune]string, 2*len(pairs))
+	for r, lat := range pairs {
+		m[r] = lat
+		if upper := []rune(strings.ToUpper(string(r))); len(upper) == 1 && upper[0] != r {
+			m[upper[0]] = strings.ToUpper(lat[:min(1, len(lat))]) + lat[min(1, len(lat)):]
+		}
+	}
+	return m
+}()
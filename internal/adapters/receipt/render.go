@@ -0,0 +1,79 @@
+// Package receipt — отрисовка чеков покупателю в HTML и PDF.
+package receipt
+
+import (
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+	"strconv"
+	"time"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported receipt format")
+
+// Renderer отрисовывает чек. Реквизиты продавца печатаются в шапке каждого чека.
+type Renderer struct {
+	seller    string // Наименование продавца
+	sellerTIN string // БИН/ИИН продавца
+}
+
+func NewRenderer(seller, sellerTIN string) *Renderer {
+	return &Renderer{seller: seller, sellerTIN: sellerTIN}
+}
+
+func (r *Renderer) Render(receipt models.Receipt, format models.ReceiptFormat) (models.ReceiptDocument, error) {
+	var (
+		content []byte
+		err     error
+	)
+	switch format {
+	case models.ReceiptHTML:
+		content, err = r.html(receipt)
+	case models.ReceiptPDF:
+		content, err = r.pdf(receipt)
+	default:
+		return models.ReceiptDocument{}, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return models.ReceiptDocument{}, err
+	}
+
+	return models.ReceiptDocument{
+		Format:      format,
+		ContentType: contentTypes[format],
+		Content:     content,
+	}, nil
+}
+
+var contentTypes = map[models.ReceiptFormat]string{
+	models.ReceiptHTML: "text/html; charset=utf-8",
+	models.ReceiptPDF:  "application/pdf",
+}
+
+var titles = map[models.ReceiptKind]string{
+	models.ReceiptSale:   "Sale receipt",
+	models.ReceiptRefund: "Refund receipt",
+}
+
+// receiptDate — дата чека: момент регистрации, а до неё — момент постановки в очередь.
+func receiptDate(receipt models.Receipt) time.Time {
+	if receipt.IssuedAt != nil {
+		return receipt.IssuedAt.UTC()
+	}
+	return receipt.CreatedAt.UTC()
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+func formatQuantity(quantity float64) string {
+	return strconv.FormatFloat(quantity, 'f', -1, 64)
+}
+
+func formatRate(rate float64) string {
+	if rate == 0 {
+		return "no VAT"
+	}
+	return "VAT " + strconv.FormatFloat(rate, 'f', -1, 64) + "%"
+}
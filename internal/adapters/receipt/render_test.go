@@ -0,0 +1,191 @@
+package receipt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func testReceipt() models.Receipt {
+	issued := time.Date(2025, 3, 1, 9, 30, 0, 0, time.FixedZone("ALMT", 5*3600))
+	return models.Receipt{
+		ID:           "r-1",
+		PaymentID:    "bank-1",
+		Kind:         models.ReceiptSale,
+		Provider:     "local",
+		FiscalNumber: "FN-42",
+		FiscalURL:    "https://ofd.example.com/r-1",
+		CreatedAt:    issued.Add(-time.Minute),
+		IssuedAt:     &issued,
+		OrderID:      "order-1",
+		Amount:       2240,
+		Currency:     "KZT",
+		Items: []models.CartItem{
+			{Name: "Чай <зелёный>", Quantity: 2, Price: 560, TaxRate: 12},
+			{Name: "Доставка (курьер)", Quantity: 1, Price: 1120},
+		},
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	doc, err := NewRenderer("ТОО Магазин", "123456789012").Render(testReceipt(), models.ReceiptHTML)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if doc.Format != models.ReceiptHTML || doc.ContentType != "text/html; charset=utf-8" {
+		t.Fatalf("document = %s %s, want HTML", doc.Format, doc.ContentType)
+	}
+
+	html := string(doc.Content)
+	for _, want := range []string{
+		"<h2>Sale receipt</h2>",
+		"ТОО Магазин",
+		"TIN 123456789012",
+		"Order order-1",
+		"Payment bank-1",
+		"2025-03-01 04:30:00 UTC", // Дата регистрации в UTC
+		"Чай &lt;зелёный&gt;",     // Название экранируется
+		"VAT 12%",
+		"no VAT",
+		`<td class="num">1120.00</td>`, // 2 x 560
+		"Total, KZT</td><td class=\"num\">2240.00</td>",
+		`<td class="num muted">120.00</td>`, // НДС 12% в 1120
+		"Fiscal sign FN-42 (local)",
+		`href="https://ofd.example.com/r-1"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML does not contain %q", want)
+		}
+	}
+	if strings.Contains(html, "<зелёный>") {
+		t.Error("HTML contains unescaped item name")
+	}
+}
+
+func TestRenderHTMLNotFiscalized(t *testing.T) {
+	receipt := testReceipt()
+	receipt.Kind, receipt.FiscalNumber, receipt.FiscalURL, receipt.IssuedAt = models.ReceiptRefund, "", "", nil
+
+	doc, err := NewRenderer("", "").Render(receipt, models.ReceiptHTML)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	html := string(doc.Content)
+	for _, want := range []string{"<h2>Refund receipt</h2>", "2025-03-01 04:29:00 UTC", "The receipt is not fiscalized yet."} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML does not contain %q", want)
+		}
+	}
+	if strings.Contains(html, "TIN") || strings.Contains(html, "Fiscal sign") {
+		t.Error("HTML contains seller or fiscal data that is not set")
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	doc, err := NewRenderer("ТОО Магазин", "123456789012").Render(testReceipt(), models.ReceiptPDF)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if doc.Format != models.ReceiptPDF || doc.ContentType != "application/pdf" {
+		t.Fatalf("document = %s %s, want PDF", doc.Format, doc.ContentType)
+	}
+
+	pdf := doc.Content
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("PDF has no header or trailer")
+	}
+	checkPDFStructure(t, pdf)
+
+	for _, want := range []string{
+		"(Sale receipt) Tj",
+		"(TOO Magazin) Tj",
+		"(Chay <zelenyy>) Tj",
+		`(Dostavka \(kurer\)) Tj`,
+		"(  2 x 560.00" + strings.Repeat(" ", 23) + "1120.00) Tj", // Колонки на ширину ленты
+		"(TOTAL, KZT" + strings.Repeat(" ", 25) + "2240.00) Tj",
+		"(Fiscal sign FN-42) Tj",
+	} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("PDF does not contain %q", want)
+		}
+	}
+}
+
+// checkPDFStructure проверяет, что таблица xref указывает на начала объектов,
+// startxref — на таблицу, а длина потока совпадает с его содержимым.
+func checkPDFStructure(t *testing.T, pdf []byte) {
+	t.Helper()
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	if startxref == nil {
+		t.Fatal("PDF has no startxref")
+	}
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(pdf[xref:], []byte("xref\n0 6\n")) {
+		t.Fatalf("startxref %d does not point to xref table of 6 entries", xref)
+	}
+
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	if len(offsets) != 5 {
+		t.Fatalf("xref entries = %d, want 5", len(offsets))
+	}
+	for i, m := range offsets {
+		offset, _ := strconv.Atoi(string(m[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points to %q, want %q", i+1, pdf[offset:min(offset+10, len(pdf))], want)
+		}
+	}
+
+	stream := regexp.MustCompile(`(?s)/Length (\d+) >>\nstream\n(.*)endstream`).FindSubmatch(pdf)
+	if stream == nil {
+		t.Fatal("PDF has no content stream")
+	}
+	if length, _ := strconv.Atoi(string(stream[1])); length != len(stream[2]) {
+		t.Errorf("stream /Length = %d, content is %d bytes", length, len(stream[2]))
+	}
+}
+
+func TestRenderUnsupportedFormat(t *testing.T) {
+	if _, err := NewRenderer("", "").Render(testReceipt(), "XML"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Fatalf("Render(XML) error = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+func TestPDFText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Order 1", "Order 1"},
+		{"Щётка", "Shchetka"},
+		{"ЖЁЛТЫЙ", "ZhELTYY"},
+		{"Қазақстан", "Qazaqstan"},
+		{`a(b)\c`, `a\(b\)\\c`},
+		{"café", `caf\351`},
+		{"€5", "?5"},
+	}
+
+	for _, tt := range tests {
+		if got := pdfText(tt.in); got != tt.want {
+			t.Errorf("pdfText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTextLinesFitWidth(t *testing.T) {
+	receipt := testReceipt()
+	receipt.Items[0].Name = strings.Repeat("Очень длинное название ", 5)
+
+	for _, line := range NewRenderer(strings.Repeat("Продавец ", 10), "").textLines(receipt) {
+		if n := utf8.RuneCountInString(line); n > pdfLineWidth {
+			t.Errorf("line %q has %d characters, want at most %d", line, n, pdfLineWidth)
+		}
+	}
+}
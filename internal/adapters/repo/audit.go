@@ -0,0 +1,116 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"payment/internal/domain/models"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// insertAudit добавляет запись в журнал аудита.
+// Вызывается внутри транзакции изменения состояния платежа, чтобы запись и изменение фиксировались вместе.
+func insertAudit(ctx context.Context, db execer, entry models.AuditEntry) error {
+	params := entry.Params
+	if params == nil {
+		params = map[string]any{}
+	}
+
+	query := `
+		INSERT INTO Audit_log(Actor, Claimed_actor, Method, Payment_id, Params, Result, Error, Broker_error)
+		VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''), NULLIF($8, ''));`
+	_, err := db.Exec(ctx, query,
+		entry.Actor, entry.ClaimedActor, entry.Method, entry.PaymentID, params,
+		entry.Result, entry.Error, entry.BrokerError)
+	return err
+}
+
+// Добавляет запись в журнал аудита вне транзакции изменения состояния (например, для неуспешных операций)
+func (repo *PostgresPaymentRepo) SaveAudit(ctx context.Context, entry models.AuditEntry) error {
+	const op = "PostgresPaymentRepo.SaveAudit"
+
+	if err := insertAudit(ctx, repo.pool, entry); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Возвращает записи журнала аудита по фильтру (от новых к старым)
+func (repo *PostgresPaymentRepo) ListAudit(ctx context.Context, f models.AuditFilter) (models.AuditPage, error) {
+	const op = "PostgresPaymentRepo.ListAudit"
+
+	var (
+		args  queryArgs
+		conds []string
+	)
+	if f.PaymentID != "" {
+		conds = append(conds, "Payment_id = "+args.add(f.PaymentID))
+	}
+	if f.Actor != "" {
+		conds = append(conds, "Actor = "+args.add(f.Actor))
+	}
+	if f.Method != "" {
+		conds = append(conds, "Method = "+args.add(f.Method))
+	}
+	if f.Result != "" {
+		conds = append(conds, "Result::text = "+args.add(string(f.Result)))
+	}
+	if !f.CreatedFrom.IsZero() {
+		conds = append(conds, "Created_at >= "+args.add(f.CreatedFrom))
+	}
+	if !f.CreatedTo.IsZero() {
+		conds = append(conds, "Created_at < "+args.add(f.CreatedTo))
+	}
+	if f.AfterID > 0 {
+		conds = append(conds, "Id < "+args.add(f.AfterID))
+	}
+
+	query := `
+		SELECT 
+			Id, 
+			Actor, 
+			COALESCE(Claimed_actor, ''), 
+			Method, 
+			COALESCE(Payment_id, ''), 
+			Params, 
+			Result, 
+			COALESCE(Error, ''), 
+			COALESCE(Broker_error, ''), 
+			Created_at
+		FROM 
+			Audit_log
+		` + whereClause(conds) + `
+		ORDER BY 
+			Id DESC
+		LIMIT ` + strconv.Itoa(f.Limit+1) + `;`
+
+	rows, err := repo.pool.Query(ctx, query, args...)
+	if err != nil {
+		return models.AuditPage{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.AuditEntry, error) {
+		var e models.AuditEntry
+		err := row.Scan(&e.ID, &e.Actor, &e.ClaimedActor, &e.Method, &e.PaymentID, &e.Params,
+			&e.Result, &e.Error, &e.BrokerError, &e.CreatedAt)
+		return e, err
+	})
+	if err != nil {
+		return models.AuditPage{}, fmt.Errorf("%s: failed to collect rows: %w", op, err)
+	}
+
+	page := models.AuditPage{Entries: entries}
+	if len(entries) > f.Limit {
+		page.Entries = entries[:f.Limit]
+		page.NextID = page.Entries[f.Limit-1].ID
+	}
+
+	return page, nil
+}
@@ -0,0 +1,124 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrBindingNotFound = errors.New("binding is not found")
+
+// Сохраняет связку карты. Повторное сохранение той же связки игнорируется.
+func (repo *PostgresPaymentRepo) SaveBinding(ctx context.Context, binding models.Binding) error {
+	const op = "PostgresPaymentRepo.SaveBinding"
+	query := `
+		INSERT INTO Bindings(Binding_id, User_id, Broker, Masked_pan, Expiry, Payment_id)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+		ON CONFLICT (Binding_id) DO NOTHING;`
+
+	_, err := repo.pool.Exec(ctx, query,
+		binding.ID, binding.UserID, binding.Broker,
+		binding.MaskedPan, binding.Expiry, binding.PaymentID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Получает связку по ID
+func (repo *PostgresPaymentRepo) GetBinding(ctx context.Context, bindingID string) (*models.Binding, error) {
+	const op = "PostgresPaymentRepo.GetBinding"
+	query := `
+		SELECT 
+			Binding_id, 
+			User_id, 
+			Broker, 
+			Masked_pan, 
+			Expiry, 
+			COALESCE(Payment_id, ''), 
+			Created_at
+		FROM 
+			Bindings
+		WHERE 
+			Binding_id = $1;`
+
+	var b models.Binding
+	if err := repo.pool.QueryRow(ctx, query, bindingID).
+		Scan(&b.ID, &b.UserID, &b.Broker, &b.MaskedPan, &b.Expiry, &b.PaymentID, &b.CreatedAt); err != nil {
+
+		if err == pgx.ErrNoRows {
+			return nil, ErrBindingNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &b, nil
+}
+
+// Возвращает связки пользователя (от новых к старым)
+func (repo *PostgresPaymentRepo) ListBindings(ctx context.Context, userID string) ([]models.Binding, error) {
+	const op = "PostgresPaymentRepo.ListBindings"
+	query := `
+		SELECT 
+			Binding_id, 
+			User_id, 
+			Broker, 
+			Masked_pan, 
+			Expiry, 
+			COALESCE(Payment_id, ''), 
+			Created_at
+		FROM 
+			Bindings
+		WHERE 
+			User_id = $1
+		ORDER BY 
+			Created_at DESC;`
+
+	rows, err := repo.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	bindings, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Binding, error) {
+		var b models.Binding
+		err := row.Scan(&b.ID, &b.UserID, &b.Broker, &b.MaskedPan, &b.Expiry, &b.PaymentID, &b.CreatedAt)
+		return b, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to collect rows: %w", op, err)
+	}
+
+	return bindings, nil
+}
+
+// Удаляет связку и записывает аудит в одной транзакции
+func (repo *PostgresPaymentRepo) DeleteBinding(ctx context.Context, bindingID string, audit models.AuditEntry) (err error) {
+	const op = "PostgresPaymentRepo.DeleteBinding"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	res, err := tx.Exec(ctx, `DELETE FROM Bindings WHERE Binding_id = $1;`, bindingID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrBindingNotFound
+	}
+
+	if err = insertAudit(ctx, tx, audit); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,125 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrCaptureExceedsAuthorized = errors.New("amount exceeds remaining authorized amount")
+
+// Capture — фиксирует списание средств по авторизованному платежу.
+// В транзакции:
+//  1. Увеличивает списанную сумму в Transactions (с проверкой остатка),
+//  2. Сохраняет запись в Captures,
+//  3. Обновляет статус и историю статусов,
+//  4. Добавляет запись в журнал аудита.
+//
+// При ошибке выполняется rollback.
+func (repo *PostgresPaymentRepo) Capture(ctx context.Context, capture models.Capture, change models.StatusChange, audit models.AuditEntry) (err error) {
+	const op = "PostgresPaymentRepo.Capture"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// 1) Увеличиваем списанную сумму, если остаток позволяет
+	query := `
+		UPDATE Transactions
+		SET Captured_amount = Captured_amount + $1
+		WHERE Payment_id = $2 AND Captured_amount + Released_amount + $1 <= Amount;`
+
+	res, err := tx.Exec(ctx, query, capture.Amount, capture.PaymentID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrCaptureExceedsAuthorized
+	}
+
+	// 2) Сохраняем списание
+	query = `
+		INSERT INTO Captures(Payment_id, Amount, Is_final, Broker_code)
+		VALUES ($1, $2, $3, NULLIF($4, ''));`
+
+	if _, err = tx.Exec(ctx, query, capture.PaymentID, capture.Amount, capture.Final, capture.BrokerCode); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// 3) Обновляем статус
+	if err = markStatusTx(ctx, tx, capture.PaymentID, change); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// 4) Фиксируем операцию в журнале аудита
+	if err = insertAudit(ctx, tx, audit); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Release — фиксирует разблокировку остатка авторизованной суммы после финального списания.
+func (repo *PostgresPaymentRepo) Release(ctx context.Context, paymentID string, amount float64, change models.StatusChange, audit models.AuditEntry) (err error) {
+	const op = "PostgresPaymentRepo.Release"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		UPDATE Transactions
+		SET Released_amount = Released_amount + $1
+		WHERE Payment_id = $2 AND Captured_amount + Released_amount + $1 <= Amount;`
+
+	res, err := tx.Exec(ctx, query, amount, paymentID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrCaptureExceedsAuthorized
+	}
+
+	if err = markStatusTx(ctx, tx, paymentID, change); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = insertAudit(ctx, tx, audit); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// captureInFullTx фиксирует списание всей суммы одностадийного платежа: банк списывает её
+// без отдельного capture, поэтому списанная сумма заполняется при первом переходе в списанный статус.
+// Для двухстадийных платежей списанная или разблокированная сумма уже сохранена, и запрос ничего не меняет.
+func captureInFullTx(ctx context.Context, tx pgx.Tx, paymentID string, status models.StatusType) error {
+	switch status {
+	case models.OrderDeposited, models.OrderPartiallyRefunded, models.OrderRefunded:
+	default:
+		return nil
+	}
+
+	query := `
+		UPDATE Transactions
+		SET Captured_amount = Amount
+		WHERE Payment_id = $1 AND Captured_amount = 0 AND Released_amount = 0;`
+	_, err := tx.Exec(ctx, query, paymentID)
+	return err
+}
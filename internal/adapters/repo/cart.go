@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"payment/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// insertCartTx сохраняет контакты покупателя и позиции корзины платежа.
+func insertCartTx(ctx context.Context, tx pgx.Tx, transaction models.Payment) error {
+	if !transaction.Customer.IsZero() {
+		query := `
+			INSERT INTO Payment_customers(Payment_id, Email, Phone)
+			VALUES ($1, NULLIF($2, ''), NULLIF($3, ''));`
+		if _, err := tx.Exec(ctx, query, transaction.ID,
+			transaction.Customer.Email, transaction.Customer.Phone); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO Payment_items(Payment_id, Position, Name, Quantity, Price, Tax_rate, Amount)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);`
+	for i, item := range transaction.Items {
+		if _, err := tx.Exec(ctx, query, transaction.ID, i+1,
+			item.Name, item.Quantity, item.Price, item.TaxRate, item.Amount()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Возвращает контакты покупателя и позиции корзины платежа
+func (repo *PostgresPaymentRepo) GetPaymentCart(ctx context.Context, paymentID string) (models.Customer, []models.CartItem, error) {
+	const op = "PostgresPaymentRepo.GetPaymentCart"
+
+	var customer models.Customer
+	query := `
+		SELECT COALESCE(Email, ''), COALESCE(Phone, '')
+		FROM Payment_customers
+		WHERE Payment_id = $1;`
+	err := repo.pool.QueryRow(ctx, query, paymentID).Scan(&customer.Email, &customer.Phone)
+	if err != nil && err != pgx.ErrNoRows {
+		return models.Customer{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = `
+		SELECT Name, Quantity, Price, Tax_rate
+		FROM Payment_items
+		WHERE Payment_id = $1
+		ORDER BY Position;`
+	rows, err := repo.pool.Query(ctx, query, paymentID)
+	if err != nil {
+		return models.Customer{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var items []models.CartItem
+	for rows.Next() {
+		var item models.CartItem
+		if err := rows.Scan(&item.Name, &item.Quantity, &item.Price, &item.TaxRate); err != nil {
+			return models.Customer{}, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Customer{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return customer, items, nil
+}
@@ -0,0 +1,130 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+	"payment/pkg/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresCurrencyRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresCurrencyRepo(pool *pgxpool.Pool) *PostgresCurrencyRepo {
+	return &PostgresCurrencyRepo{pool: pool}
+}
+
+var ErrCurrencyNotFound = errors.New("currency is not found")
+
+// Валюта доступна мерчанту, если она включена и мерчант не ограничен списком валют или она в этом списке
+const currencyColumns = `
+	c.Code,
+	c.Numeric_code,
+	c.Exponent,
+	c.Broker_supported,
+	c.Enabled AND (
+		NOT EXISTS (SELECT 1 FROM Merchant_currencies m WHERE m.Merchant = $1)
+		OR EXISTS (SELECT 1 FROM Merchant_currencies m WHERE m.Merchant = $1 AND m.Currency = c.Code)
+	)`
+
+func scanCurrency(row pgx.Row) (models.Currency, error) {
+	var c models.Currency
+	err := row.Scan(&c.Code, &c.Numeric, &c.Exponent, &c.BrokerSupported, &c.Enabled)
+	return c, err
+}
+
+// Получает валюту из реестра с доступностью для мерчанта
+func (repo *PostgresCurrencyRepo) Currency(ctx context.Context, code, merchant string) (*models.Currency, error) {
+	const op = "PostgresCurrencyRepo.Currency"
+	query := `
+		SELECT ` + currencyColumns + `
+		FROM Currencies c
+		WHERE c.Code = $2;`
+
+	currency, err := scanCurrency(repo.pool.QueryRow(ctx, query, merchant, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCurrencyNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &currency, nil
+}
+
+// Возвращает реестр валют с доступностью для мерчанта
+func (repo *PostgresCurrencyRepo) ListCurrencies(ctx context.Context, merchant string) ([]models.Currency, error) {
+	const op = "PostgresCurrencyRepo.ListCurrencies"
+	query := `
+		SELECT ` + currencyColumns + `
+		FROM Currencies c
+		ORDER BY c.Code;`
+
+	rows, err := repo.pool.Query(ctx, query, merchant)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	currencies, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Currency, error) {
+		return scanCurrency(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return currencies, nil
+}
+
+// Rate — последний загруженный курс пары валют. Реализует поставщика курсов из БД.
+func (repo *PostgresCurrencyRepo) Rate(ctx context.Context, base, quote string) (models.FXRate, error) {
+	const op = "PostgresCurrencyRepo.Rate"
+	query := `
+		SELECT Base, Quote, Rate, Source, As_of
+		FROM Fx_rates
+		WHERE Base = $1 AND Quote = $2 AND As_of <= NOW()
+		ORDER BY As_of DESC
+		LIMIT 1;`
+
+	var rate models.FXRate
+	if err := repo.pool.QueryRow(ctx, query, base, quote).
+		Scan(&rate.Base, &rate.Quote, &rate.Rate, &rate.Source, &rate.AsOf); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.FXRate{}, models.ErrFXRateNotFound
+		}
+		return models.FXRate{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return rate, nil
+}
+
+// SaveRates — сохраняет курсы. Курс пары на тот же момент перезаписывается.
+func (repo *PostgresCurrencyRepo) SaveRates(ctx context.Context, rates []models.FXRate) (err error) {
+	const op = "PostgresCurrencyRepo.SaveRates"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO Fx_rates(Base, Quote, Rate, Source, As_of)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (Base, Quote, As_of) DO UPDATE
+		SET Rate = EXCLUDED.Rate, Source = EXCLUDED.Source;`
+	for _, r := range rates {
+		if _, err = tx.Exec(ctx, query, r.Base, r.Quote, r.Rate, r.Source, r.AsOf); err != nil {
+			if postgres.IsForeignKeyViolation(err) {
+				return fmt.Errorf("%w: %s/%s", ErrCurrencyNotFound, r.Base, r.Quote)
+			}
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
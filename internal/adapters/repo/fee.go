@@ -0,0 +1,214 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresFeeRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresFeeRepo(pool *pgxpool.Pool) *PostgresFeeRepo {
+	return &PostgresFeeRepo{pool: pool}
+}
+
+var ErrFeeScheduleNotFound = errors.New("fee schedule is not found")
+
+const feeScheduleColumns = `
+	Schedule_id,
+	COALESCE(Broker, ''),
+	COALESCE(Merchant, ''),
+	COALESCE(Currency, ''),
+	Percent,
+	Fixed,
+	Min_fee,
+	Max_fee,
+	Tiers,
+	Refund_fixed,
+	Active,
+	Created_at`
+
+func scanFeeSchedule(row pgx.Row) (models.FeeSchedule, error) {
+	var s models.FeeSchedule
+	err := row.Scan(&s.ID, &s.Broker, &s.Merchant, &s.Currency, &s.Percent, &s.Fixed,
+		&s.Min, &s.Max, &s.Tiers, &s.RefundFixed, &s.Active, &s.CreatedAt)
+	return s, err
+}
+
+// postFeesTx начисляет комиссии банка по платежу после смены статуса:
+// за списание — по тарифу от списанной суммы, за каждый возврат — фиксированную.
+// Начисляется только разница с уже начисленным, поэтому повторный вызов ничего не добавляет.
+// Итог сохраняется в Transactions.Fee_amount и попадает в журнал на счёт FEES.
+func postFeesTx(ctx context.Context, tx pgx.Tx, paymentID string, status models.StatusType) error {
+	switch status {
+	case models.OrderDeposited, models.OrderPartiallyDeposited, models.OrderPartiallyRefunded, models.OrderRefunded:
+	default:
+		return nil
+	}
+
+	var amount, captured float64
+	var currency, broker, merchant string
+	query := `
+		SELECT Amount, Captured_amount, Currency, Broker, COALESCE(Merchant, '')
+		FROM Transactions
+		WHERE Payment_id = $1;`
+	if err := tx.QueryRow(ctx, query, paymentID).Scan(&amount, &captured, &currency, &broker, &merchant); err != nil {
+		return err
+	}
+
+	schedule, err := paymentFeeScheduleTx(ctx, tx, paymentID, broker, merchant, currency)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Одностадийный платёж списывается целиком без отдельного capture
+	deposited := captured
+	if deposited == 0 {
+		deposited = amount
+	}
+
+	var posted float64
+	query = `
+		SELECT COALESCE(SUM(Amount), 0)
+		FROM Payment_fees
+		WHERE Payment_id = $1 AND Kind = $2;`
+	if err := tx.QueryRow(ctx, query, paymentID, models.FeeDeposit).Scan(&posted); err != nil {
+		return err
+	}
+
+	if delta := models.RoundAmount(schedule.DepositFee(deposited) - posted); delta != 0 {
+		query = `
+			INSERT INTO Payment_fees(Payment_id, Schedule_id, Kind, Base_amount, Amount, Currency)
+			VALUES ($1, $2, $3, $4, $5, $6);`
+		if _, err := tx.Exec(ctx, query, paymentID, schedule.ID, models.FeeDeposit, deposited, delta, currency); err != nil {
+			return err
+		}
+	}
+
+	if schedule.RefundFixed > 0 {
+		query = `
+			INSERT INTO Payment_fees(Payment_id, Schedule_id, Refund_id, Kind, Base_amount, Amount, Currency)
+			SELECT r.Payment_id, $2, r.Refund_id, $3, r.Amount, $4, $5
+			FROM Refunds r
+			WHERE r.Payment_id = $1
+				AND NOT EXISTS (SELECT 1 FROM Payment_fees f WHERE f.Refund_id = r.Refund_id);`
+		if _, err := tx.Exec(ctx, query, paymentID, schedule.ID, models.FeeRefund, schedule.RefundFixed, currency); err != nil {
+			return err
+		}
+	}
+
+	query = `
+		UPDATE Transactions
+		SET Fee_amount = (SELECT COALESCE(SUM(Amount), 0) FROM Payment_fees WHERE Payment_id = $1)
+		WHERE Payment_id = $1;`
+	_, err = tx.Exec(ctx, query, paymentID)
+	return err
+}
+
+// paymentFeeScheduleTx возвращает тариф платежа: уже применённый к нему, иначе самый конкретный
+// из активных (совпадение мерчанта важнее банка, банк важнее валюты).
+func paymentFeeScheduleTx(ctx context.Context, tx pgx.Tx, paymentID, broker, merchant, currency string) (models.FeeSchedule, error) {
+	query := `
+		SELECT ` + feeScheduleColumns + `
+		FROM Fee_schedules
+		WHERE Schedule_id = (
+			SELECT Schedule_id
+			FROM Payment_fees
+			WHERE Payment_id = $1
+			ORDER BY Fee_id
+			LIMIT 1
+		);`
+	schedule, err := scanFeeSchedule(tx.QueryRow(ctx, query, paymentID))
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return schedule, err
+	}
+
+	query = `
+		SELECT ` + feeScheduleColumns + `
+		FROM Fee_schedules
+		WHERE Active
+			AND (Broker IS NULL OR Broker = $1)
+			AND (Merchant IS NULL OR Merchant = $2)
+			AND (Currency IS NULL OR Currency = $3)
+		ORDER BY
+			Merchant IS NULL,
+			Broker IS NULL,
+			Currency IS NULL,
+			Created_at DESC
+		LIMIT 1;`
+	return scanFeeSchedule(tx.QueryRow(ctx, query, broker, merchant, currency))
+}
+
+// Добавляет тариф комиссии
+func (repo *PostgresFeeRepo) CreateFeeSchedule(ctx context.Context, schedule models.FeeSchedule) (models.FeeSchedule, error) {
+	const op = "PostgresFeeRepo.CreateFeeSchedule"
+	if schedule.Tiers == nil {
+		schedule.Tiers = []models.FeeTier{}
+	}
+
+	query := `
+		INSERT INTO Fee_schedules(Broker, Merchant, Currency, Percent, Fixed, Min_fee, Max_fee, Tiers, Refund_fixed)
+		VALUES (NULLIF($1, ''), NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6, $7, $8, $9)
+		RETURNING ` + feeScheduleColumns + `;`
+	created, err := scanFeeSchedule(repo.pool.QueryRow(ctx, query,
+		schedule.Broker, schedule.Merchant, schedule.Currency, schedule.Percent, schedule.Fixed,
+		schedule.Min, schedule.Max, schedule.Tiers, schedule.RefundFixed))
+	if err != nil {
+		return models.FeeSchedule{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return created, nil
+}
+
+// Возвращает тарифы комиссий (от новых к старым)
+func (repo *PostgresFeeRepo) ListFeeSchedules(ctx context.Context, includeInactive bool) ([]models.FeeSchedule, error) {
+	const op = "PostgresFeeRepo.ListFeeSchedules"
+	query := `
+		SELECT ` + feeScheduleColumns + `
+		FROM Fee_schedules
+		WHERE Active OR $1
+		ORDER BY Created_at DESC;`
+
+	rows, err := repo.pool.Query(ctx, query, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	schedules, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.FeeSchedule, error) {
+		return scanFeeSchedule(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return schedules, nil
+}
+
+// Отключает тариф. Уже начисленные по нему комиссии не меняются,
+// а платежи, по которым он применялся, продолжают начислять по нему.
+func (repo *PostgresFeeRepo) DeactivateFeeSchedule(ctx context.Context, scheduleID string) (models.FeeSchedule, error) {
+	const op = "PostgresFeeRepo.DeactivateFeeSchedule"
+	if !validUUID(scheduleID) {
+		return models.FeeSchedule{}, ErrFeeScheduleNotFound
+	}
+
+	query := `
+		UPDATE Fee_schedules
+		SET Active = FALSE
+		WHERE Schedule_id = $1
+		RETURNING ` + feeScheduleColumns + `;`
+	schedule, err := scanFeeSchedule(repo.pool.QueryRow(ctx, query, scheduleID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.FeeSchedule{}, ErrFeeScheduleNotFound
+		}
+		return models.FeeSchedule{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return schedule, nil
+}
@@ -0,0 +1,149 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+	"payment/pkg/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresInvoiceRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresInvoiceRepo(pool *pgxpool.Pool) *PostgresInvoiceRepo {
+	return &PostgresInvoiceRepo{pool: pool}
+}
+
+var (
+	ErrInvoiceNotFound     = errors.New("invoice is not found")
+	ErrInvoiceCodeConflict = errors.New("invoice code must be unique")
+)
+
+const invoiceColumns = `
+	Invoice_id, 
+	Code, 
+	User_id, 
+	Amount, 
+	Currency, 
+	Description,
+	Return_url, 
+	Fail_url, 
+	Multi_use, 
+	Expires_at, 
+	Created_at`
+
+// Добавляет счёт
+func (repo *PostgresInvoiceRepo) CreateInvoice(ctx context.Context, invoice models.Invoice) (models.Invoice, error) {
+	const op = "PostgresInvoiceRepo.CreateInvoice"
+	query := `
+		INSERT INTO Invoices(Code, User_id, Amount, Currency, Description, Return_url, Fail_url, Multi_use, Expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING Invoice_id, Created_at;`
+
+	if err := repo.pool.QueryRow(ctx, query,
+		invoice.Code, invoice.UserID, invoice.Amount, invoice.Currency, invoice.Description,
+		invoice.ReturnURL, invoice.FailURL, invoice.MultiUse, invoice.ExpiresAt).
+		Scan(&invoice.ID, &invoice.CreatedAt); err != nil {
+
+		if postgres.IsUniqueViolation(err) {
+			return models.Invoice{}, ErrInvoiceCodeConflict
+		}
+		return models.Invoice{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return invoice, nil
+}
+
+// Получает счёт по ID вместе с платежами по нему
+func (repo *PostgresInvoiceRepo) GetInvoice(ctx context.Context, invoiceID string) (*models.Invoice, error) {
+	const op = "PostgresInvoiceRepo.GetInvoice"
+	if !validUUID(invoiceID) {
+		return nil, ErrInvoiceNotFound
+	}
+
+	query := `SELECT ` + invoiceColumns + ` FROM Invoices WHERE Invoice_id = $1;`
+	invoice, err := repo.getInvoice(ctx, query, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return invoice, nil
+}
+
+// Получает счёт по коду публичной ссылки вместе с платежами по нему
+func (repo *PostgresInvoiceRepo) GetInvoiceByCode(ctx context.Context, code string) (*models.Invoice, error) {
+	const op = "PostgresInvoiceRepo.GetInvoiceByCode"
+
+	query := `SELECT ` + invoiceColumns + ` FROM Invoices WHERE Code = $1;`
+	invoice, err := repo.getInvoice(ctx, query, code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return invoice, nil
+}
+
+func (repo *PostgresInvoiceRepo) getInvoice(ctx context.Context, query string, arg string) (*models.Invoice, error) {
+	var inv models.Invoice
+	if err := repo.pool.QueryRow(ctx, query, arg).
+		Scan(&inv.ID, &inv.Code, &inv.UserID, &inv.Amount, &inv.Currency, &inv.Description,
+			&inv.ReturnURL, &inv.FailURL, &inv.MultiUse, &inv.ExpiresAt, &inv.CreatedAt); err != nil {
+
+		if err == pgx.ErrNoRows {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+
+	// Статус платежа берётся из Transactions, поэтому статус счёта всегда соответствует платежам
+	query = `
+		SELECT 
+			ip.Payment_id, 
+			ip.Payment_url, 
+			t.Current_status, 
+			ip.Created_at
+		FROM 
+			Invoice_payments ip
+		INNER JOIN 
+			Transactions t ON t.Payment_id = ip.Payment_id
+		WHERE 
+			ip.Invoice_id = $1
+		ORDER BY 
+			ip.Created_at ASC;`
+
+	rows, err := repo.pool.Query(ctx, query, inv.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	inv.Payments, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.InvoicePayment, error) {
+		var p models.InvoicePayment
+		err := row.Scan(&p.PaymentID, &p.PaymentURL, &p.Status, &p.CreatedAt)
+		return p, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	return &inv, nil
+}
+
+// Привязывает к счёту платёж, созданный по ссылке.
+// Повторная привязка того же платежа не ошибка: она только дописывает URL формы, если его не было.
+func (repo *PostgresInvoiceRepo) AddInvoicePayment(ctx context.Context, invoiceID string, payment models.InvoicePayment) error {
+	const op = "PostgresInvoiceRepo.AddInvoicePayment"
+	query := `
+		INSERT INTO Invoice_payments(Invoice_id, Payment_id, Payment_url)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (Invoice_id, Payment_id) DO UPDATE
+		SET Payment_url = EXCLUDED.Payment_url
+		WHERE Invoice_payments.Payment_url = '';`
+
+	if _, err := repo.pool.Exec(ctx, query, invoiceID, payment.PaymentID, payment.PaymentURL); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
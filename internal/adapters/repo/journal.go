@@ -0,0 +1,131 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"payment/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// postJournalTx проводит по двойной записи изменение денежного состояния платежа после смены статуса.
+// Проводка — разница между сальдо счетов в новом состоянии и уже проведённым по платежу,
+// поэтому она всегда сходится и не дублируется при повторной синхронизации того же статуса.
+func postJournalTx(ctx context.Context, tx pgx.Tx, paymentID string, status models.StatusType) error {
+	position := models.PaymentPosition{Status: status}
+	var currency string
+	query := `
+		SELECT
+			t.Amount,
+			t.Captured_amount,
+			t.Released_amount,
+			t.Currency,
+			t.Refunded_amount,
+			t.Fee_amount
+		FROM Transactions t
+		WHERE t.Payment_id = $1;`
+	if err := tx.QueryRow(ctx, query, paymentID).Scan(
+		&position.Amount, &position.Captured, &position.Released, &currency, &position.Refunded, &position.Fees); err != nil {
+		return err
+	}
+
+	query = `
+		SELECT a.Code, SUM(p.Amount)
+		FROM Postings p
+		JOIN Journal_entries j ON j.Entry_id = p.Entry_id
+		JOIN Accounts a ON a.Account_id = p.Account_id
+		WHERE j.Payment_id = $1
+		GROUP BY a.Code;`
+	rows, err := tx.Query(ctx, query, paymentID)
+	if err != nil {
+		return err
+	}
+	posted, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Posting, error) {
+		var p models.Posting
+		err := row.Scan(&p.Account, &p.Amount)
+		return p, err
+	})
+	if err != nil {
+		return err
+	}
+
+	postings := position.Postings(posted)
+	if len(postings) == 0 {
+		return nil
+	}
+
+	var entryID int64
+	query = `
+		INSERT INTO Journal_entries(Payment_id, Kind, Status)
+		VALUES ($1, $2, $3)
+		RETURNING Entry_id;`
+	if err := tx.QueryRow(ctx, query, paymentID, models.JournalKindFor(status), status).Scan(&entryID); err != nil {
+		return err
+	}
+
+	for _, p := range postings {
+		accountID, err := accountIDTx(ctx, tx, p.Account, currency)
+		if err != nil {
+			return err
+		}
+
+		query = `
+			INSERT INTO Postings(Entry_id, Account_id, Amount)
+			VALUES ($1, $2, $3);`
+		if _, err := tx.Exec(ctx, query, entryID, accountID, p.Amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accountIDTx возвращает счёт плана счетов в валюте, создавая его при первой проводке.
+func accountIDTx(ctx context.Context, tx pgx.Tx, code, currency string) (int, error) {
+	accountType, ok := models.ChartOfAccounts[code]
+	if !ok {
+		return 0, fmt.Errorf("account %q is not in chart of accounts", code)
+	}
+
+	var id int
+	query := `
+		INSERT INTO Accounts(Code, Type, Currency)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (Code, Currency) DO UPDATE SET Code = EXCLUDED.Code
+		RETURNING Account_id;`
+	err := tx.QueryRow(ctx, query, code, accountType, currency).Scan(&id)
+	return id, err
+}
+
+// Оборотно-сальдовая ведомость по проводкам, созданным до asOf
+func (repo *PostgresLedgerRepo) TrialBalance(ctx context.Context, asOf time.Time) (models.TrialBalance, error) {
+	const op = "PostgresLedgerRepo.TrialBalance"
+	query := `
+		SELECT
+			a.Code,
+			a.Type,
+			a.Currency,
+			COALESCE(SUM(p.Amount) FILTER (WHERE p.Amount > 0), 0),
+			COALESCE(-SUM(p.Amount) FILTER (WHERE p.Amount < 0), 0)
+		FROM Accounts a
+		JOIN Postings p ON p.Account_id = a.Account_id
+		JOIN Journal_entries j ON j.Entry_id = p.Entry_id
+		WHERE j.Created_at < $1
+		GROUP BY a.Code, a.Type, a.Currency
+		ORDER BY a.Currency, a.Type, a.Code;`
+
+	rows, err := repo.pool.Query(ctx, query, asOf)
+	if err != nil {
+		return models.TrialBalance{}, fmt.Errorf("%s: %w", op, err)
+	}
+	lines, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.TrialBalanceLine, error) {
+		var l models.TrialBalanceLine
+		err := row.Scan(&l.Account, &l.Type, &l.Currency, &l.Debit, &l.Credit)
+		return l, err
+	})
+	if err != nil {
+		return models.TrialBalance{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return models.TrialBalance{AsOf: asOf, Lines: lines}, nil
+}
@@ -0,0 +1,339 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresLedgerRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresLedgerRepo(pool *pgxpool.Pool) *PostgresLedgerRepo {
+	return &PostgresLedgerRepo{pool: pool}
+}
+
+var (
+	ErrPayoutNotFound = errors.New("payout is not found")
+	ErrNoPayoutDue    = errors.New("no seller balance is due for payout")
+)
+
+// postLedgerTx проводит доли участников платежа с правилами распределения:
+// при списании начисляет доли от списанной суммы, при частичном возврате уменьшает их
+// пропорционально возвращённой сумме, при полном возврате и реверсе сторнирует начисленное.
+// Проводки считаются от текущего состояния платежа, поэтому повторный вызов ничего не добавляет.
+func postLedgerTx(ctx context.Context, tx pgx.Tx, paymentID string, status models.StatusType) error {
+	switch status {
+	case models.OrderDeposited, models.OrderPartiallyDeposited:
+		return postDepositTx(ctx, tx, paymentID)
+	case models.OrderPartiallyRefunded:
+		return postPartialRefundTx(ctx, tx, paymentID)
+	case models.OrderRefunded:
+		return reverseLedgerTx(ctx, tx, paymentID, models.LedgerRefund)
+	case models.OrderReversed:
+		return reverseLedgerTx(ctx, tx, paymentID, models.LedgerReversal)
+	default:
+		return nil
+	}
+}
+
+func postDepositTx(ctx context.Context, tx pgx.Tx, paymentID string) error {
+	rules, err := splitsTx(ctx, tx, paymentID)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+
+	var amount, captured float64
+	var currency string
+	query := `
+		SELECT Amount, Captured_amount, Currency
+		FROM Transactions
+		WHERE Payment_id = $1;`
+	if err := tx.QueryRow(ctx, query, paymentID).Scan(&amount, &captured, &currency); err != nil {
+		return err
+	}
+
+	// Одностадийный платёж списывается целиком без отдельного capture
+	deposited := captured
+	if deposited == 0 {
+		deposited = amount
+	}
+
+	query = `
+		SELECT Party, COALESCE(SUM(Amount), 0)
+		FROM Ledger_entries
+		WHERE Payment_id = $1 AND Type = $2
+		GROUP BY Party;`
+	rows, err := tx.Query(ctx, query, paymentID, models.LedgerDeposit)
+	if err != nil {
+		return err
+	}
+	posted, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.LedgerShare, error) {
+		var s models.LedgerShare
+		err := row.Scan(&s.Party, &s.Amount)
+		return s, err
+	})
+	if err != nil {
+		return err
+	}
+
+	postedByParty := make(map[string]float64, len(posted))
+	for _, s := range posted {
+		postedByParty[s.Party] = s.Amount
+	}
+
+	for _, delta := range models.LedgerDeltas(models.AllocateSplits(rules, amount, deposited), postedByParty, false) {
+		if err := insertLedgerEntry(ctx, tx, paymentID, delta.Party, models.LedgerDeposit, delta.Amount, currency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postPartialRefundTx уменьшает доли участников до распределения невозвращённой части списания.
+func postPartialRefundTx(ctx context.Context, tx pgx.Tx, paymentID string) error {
+	rules, err := splitsTx(ctx, tx, paymentID)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+
+	var amount, captured, refunded float64
+	var currency string
+	query := `
+		SELECT Amount, Captured_amount, Refunded_amount, Currency
+		FROM Transactions
+		WHERE Payment_id = $1;`
+	if err := tx.QueryRow(ctx, query, paymentID).Scan(&amount, &captured, &refunded, &currency); err != nil {
+		return err
+	}
+
+	query = `
+		SELECT Party, COALESCE(SUM(Amount), 0)
+		FROM Ledger_entries
+		WHERE Payment_id = $1
+		GROUP BY Party;`
+	rows, err := tx.Query(ctx, query, paymentID)
+	if err != nil {
+		return err
+	}
+	posted, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.LedgerShare, error) {
+		var s models.LedgerShare
+		err := row.Scan(&s.Party, &s.Amount)
+		return s, err
+	})
+	if err != nil {
+		return err
+	}
+
+	postedByParty := make(map[string]float64, len(posted))
+	for _, s := range posted {
+		postedByParty[s.Party] = s.Amount
+	}
+
+	shares := models.AllocateSplits(rules, amount, models.RoundAmount(captured-refunded))
+	for _, delta := range models.LedgerDeltas(shares, postedByParty, true) {
+		if err := insertLedgerEntry(ctx, tx, paymentID, delta.Party, models.LedgerRefund, delta.Amount, currency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func reverseLedgerTx(ctx context.Context, tx pgx.Tx, paymentID string, entryType models.LedgerEntryType) error {
+	query := `
+		SELECT Party, Currency, SUM(Amount)
+		FROM Ledger_entries
+		WHERE Payment_id = $1
+		GROUP BY Party, Currency
+		HAVING SUM(Amount) <> 0;`
+	rows, err := tx.Query(ctx, query, paymentID)
+	if err != nil {
+		return err
+	}
+	lines, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.PayoutLine, error) {
+		var l models.PayoutLine
+		err := row.Scan(&l.SellerID, &l.Currency, &l.Amount)
+		return l, err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		if err := insertLedgerEntry(ctx, tx, paymentID, l.SellerID, entryType, -l.Amount, l.Currency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitsTx(ctx context.Context, tx pgx.Tx, paymentID string) ([]models.SplitRule, error) {
+	query := `
+		SELECT Seller_id, Amount
+		FROM Payment_splits
+		WHERE Payment_id = $1
+		ORDER BY Seller_id;`
+	rows, err := tx.Query(ctx, query, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.SplitRule, error) {
+		var r models.SplitRule
+		err := row.Scan(&r.SellerID, &r.Amount)
+		return r, err
+	})
+}
+
+func insertLedgerEntry(ctx context.Context, db execer, paymentID, party string, entryType models.LedgerEntryType, amount float64, currency string) error {
+	query := `
+		INSERT INTO Ledger_entries(Payment_id, Party, Type, Amount, Currency)
+		VALUES ($1, $2, $3, $4, $5);`
+	_, err := db.Exec(ctx, query, paymentID, party, entryType, amount, currency)
+	return err
+}
+
+// Возвращает балансы продавца по валютам
+func (repo *PostgresLedgerRepo) SellerBalances(ctx context.Context, sellerID string) ([]models.SellerBalance, error) {
+	const op = "PostgresLedgerRepo.SellerBalances"
+	query := `
+		SELECT
+			Currency,
+			COALESCE(SUM(Amount) FILTER (WHERE Payout_id IS NULL), 0),
+			COALESCE(SUM(Amount) FILTER (WHERE Payout_id IS NOT NULL), 0)
+		FROM Ledger_entries
+		WHERE Party = $1
+		GROUP BY Currency
+		ORDER BY Currency;`
+
+	rows, err := repo.pool.Query(ctx, query, sellerID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	balances, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.SellerBalance, error) {
+		b := models.SellerBalance{SellerID: sellerID}
+		err := row.Scan(&b.Currency, &b.Available, &b.PaidOut)
+		return b, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return balances, nil
+}
+
+// CreatePayout — формирует пакет выплат.
+// В транзакции:
+//  1. Создаёт запись в Payouts,
+//  2. Привязывает к ней невыплаченные проводки продавцов с положительным балансом,
+//  3. Возвращает строки выплаты по продавцам и валютам.
+//
+// Отрицательный баланс продавца (возвраты после выплаты) переносится на следующий пакет.
+func (repo *PostgresLedgerRepo) CreatePayout(ctx context.Context) (payout *models.Payout, err error) {
+	const op = "PostgresLedgerRepo.CreatePayout"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// Пакеты формируются по одному, чтобы проводки не попали в две выплаты
+	if _, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('payouts'));`); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	payout = &models.Payout{}
+	query := `
+		INSERT INTO Payouts DEFAULT VALUES
+		RETURNING Payout_id, Created_at;`
+	if err = tx.QueryRow(ctx, query).Scan(&payout.ID, &payout.CreatedAt); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = `
+		WITH due AS (
+			SELECT Party, Currency
+			FROM Ledger_entries
+			WHERE Payout_id IS NULL AND Party <> $2
+			GROUP BY Party, Currency
+			HAVING SUM(Amount) > 0
+		)
+		UPDATE Ledger_entries e
+		SET Payout_id = $1
+		FROM due
+		WHERE e.Payout_id IS NULL AND e.Party = due.Party AND e.Currency = due.Currency;`
+	res, err := tx.Exec(ctx, query, payout.ID, models.PlatformParty)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if res.RowsAffected() == 0 {
+		err = ErrNoPayoutDue
+		return nil, err
+	}
+
+	if payout.Lines, err = payoutLines(ctx, tx, payout.ID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return payout, nil
+}
+
+// Получает пакет выплат по ID
+func (repo *PostgresLedgerRepo) GetPayout(ctx context.Context, payoutID string) (*models.Payout, error) {
+	const op = "PostgresLedgerRepo.GetPayout"
+	if !validUUID(payoutID) {
+		return nil, ErrPayoutNotFound
+	}
+
+	payout := &models.Payout{}
+	query := `
+		SELECT Payout_id, Created_at
+		FROM Payouts
+		WHERE Payout_id = $1;`
+	if err := repo.pool.QueryRow(ctx, query, payoutID).Scan(&payout.ID, &payout.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPayoutNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	lines, err := payoutLines(ctx, repo.pool, payout.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	payout.Lines = lines
+	return payout, nil
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func payoutLines(ctx context.Context, db querier, payoutID string) ([]models.PayoutLine, error) {
+	query := `
+		SELECT Party, Currency, SUM(Amount), COUNT(*)
+		FROM Ledger_entries
+		WHERE Payout_id = $1
+		GROUP BY Party, Currency
+		ORDER BY Party, Currency;`
+	rows, err := db.Query(ctx, query, payoutID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.PayoutLine, error) {
+		var l models.PayoutLine
+		err := row.Scan(&l.SellerID, &l.Currency, &l.Amount, &l.Entries)
+		return l, err
+	})
+}
@@ -0,0 +1,209 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresLimitRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresLimitRepo(pool *pgxpool.Pool) *PostgresLimitRepo {
+	return &PostgresLimitRepo{pool: pool}
+}
+
+var ErrLimitNotFound = errors.New("payment limit is not found")
+
+const limitColumns = `
+	Limit_id,
+	Scope::TEXT,
+	COALESCE(Subject, ''),
+	Currency,
+	Max_single,
+	Daily,
+	Monthly,
+	Active,
+	Created_at`
+
+func scanLimit(row pgx.Row) (models.PaymentLimit, error) {
+	var l models.PaymentLimit
+	err := row.Scan(&l.ID, &l.Scope, &l.Subject, &l.Currency, &l.MaxSingle, &l.Daily, &l.Monthly, &l.Active, &l.CreatedAt)
+	return l, err
+}
+
+// limitQuerier — пул или транзакция
+type limitQuerier interface {
+	querier
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Добавляет лимит
+func (repo *PostgresLimitRepo) CreateLimit(ctx context.Context, limit models.PaymentLimit) (models.PaymentLimit, error) {
+	const op = "PostgresLimitRepo.CreateLimit"
+	query := `
+		INSERT INTO Payment_limits(Scope, Subject, Currency, Max_single, Daily, Monthly)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6)
+		RETURNING ` + limitColumns + `;`
+	created, err := scanLimit(repo.pool.QueryRow(ctx, query,
+		limit.Scope, limit.Subject, limit.Currency, limit.MaxSingle, limit.Daily, limit.Monthly))
+	if err != nil {
+		return models.PaymentLimit{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return created, nil
+}
+
+// Возвращает лимиты (от новых к старым)
+func (repo *PostgresLimitRepo) ListLimits(ctx context.Context, includeInactive bool) ([]models.PaymentLimit, error) {
+	const op = "PostgresLimitRepo.ListLimits"
+	query := `
+		SELECT ` + limitColumns + `
+		FROM Payment_limits
+		WHERE Active OR $1
+		ORDER BY Created_at DESC;`
+
+	rows, err := repo.pool.Query(ctx, query, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	limits, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.PaymentLimit, error) {
+		return scanLimit(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return limits, nil
+}
+
+// Отключает лимит для новых платежей
+func (repo *PostgresLimitRepo) DeactivateLimit(ctx context.Context, limitID string) (models.PaymentLimit, error) {
+	const op = "PostgresLimitRepo.DeactivateLimit"
+	if !validUUID(limitID) {
+		return models.PaymentLimit{}, ErrLimitNotFound
+	}
+
+	query := `
+		UPDATE Payment_limits
+		SET Active = FALSE
+		WHERE Limit_id = $1
+		RETURNING ` + limitColumns + `;`
+	limit, err := scanLimit(repo.pool.QueryRow(ctx, query, limitID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PaymentLimit{}, ErrLimitNotFound
+		}
+		return models.PaymentLimit{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return limit, nil
+}
+
+// CheckLimits проверяет по лимитам уже сохранённый платёж, например, одобренный после ручной проверки.
+// Сам платёж в использованной части лимита не учитывается.
+func (repo *PostgresPaymentRepo) CheckLimits(ctx context.Context, transaction models.Payment) error {
+	const op = "PostgresPaymentRepo.CheckLimits"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := checkLimitsTx(ctx, tx, transaction); err != nil {
+		if errors.Is(err, models.ErrLimitExceeded) {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// checkLimitsTx проверяет платёж по лимитам в транзакции, которая сохраняет его до обращения к банку.
+// Блокировка пользователя и мерчанта (advisory lock до конца транзакции) не даёт параллельным
+// платежам одновременно израсходовать один и тот же остаток лимита.
+func checkLimitsTx(ctx context.Context, tx pgx.Tx, payment models.Payment) error {
+	limits, err := paymentLimits(ctx, tx, payment)
+	if err != nil || len(limits) == 0 {
+		return err
+	}
+
+	// порядок блокировок одинаковый для всех платежей: сначала пользователь, затем мерчант
+	for _, scope := range []models.LimitScope{models.LimitScopeUser, models.LimitScopeMerchant} {
+		subject := payment.LimitSubject(scope)
+		if subject == "" {
+			continue
+		}
+		query := `SELECT pg_advisory_xact_lock(hashtextextended('payment_limit:' || $1 || ':' || $2 || ':' || $3, 0));`
+		if _, err := tx.Exec(ctx, query, scope, subject, payment.Currency); err != nil {
+			return err
+		}
+	}
+
+	return checkLimits(ctx, tx, payment, limits)
+}
+
+// paymentLimits — активные лимиты в валюте платежа для его пользователя и мерчанта.
+func paymentLimits(ctx context.Context, db limitQuerier, payment models.Payment) ([]models.PaymentLimit, error) {
+	query := `
+		SELECT ` + limitColumns + `
+		FROM Payment_limits
+		WHERE Active
+			AND Currency = $1
+			AND ((Scope = 'USER' AND (Subject IS NULL OR Subject = $2))
+				OR (Scope = 'MERCHANT' AND $3 <> '' AND (Subject IS NULL OR Subject = $3)))
+		ORDER BY Scope, Subject NULLS LAST, Created_at;`
+	rows, err := db.Query(ctx, query, payment.Currency, payment.UserID, payment.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.PaymentLimit, error) {
+		return scanLimit(row)
+	})
+}
+
+// checkLimits суммирует платежи пользователя или мерчанта за текущие календарный день и месяц (UTC)
+// и проверяет по каждому лимиту. Учитываются платежи, которые держат лимит (models.Payment.HoldsLimit):
+// отклонённые и отменённые не учитываются, неоплаченные — только models.PendingLimitTTL после
+// последней смены статуса. Разблокированная часть авторизации и возвращённые суммы возвращаются в лимит.
+func checkLimits(ctx context.Context, db limitQuerier, payment models.Payment, limits []models.PaymentLimit) error {
+	usage := make(map[models.LimitScope]*models.LimitUsage)
+	for _, limit := range limits {
+		subject := payment.LimitSubject(limit.Scope)
+
+		if usage[limit.Scope] == nil {
+			column := "t.User_id"
+			if limit.Scope == models.LimitScopeMerchant {
+				column = "t.Merchant"
+			}
+			query := `
+				SELECT
+					COALESCE(SUM(t.Amount - t.Released_amount - t.Refunded_amount)
+						FILTER (WHERE t.Created_at >= date_trunc('day', NOW() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'), 0),
+					COALESCE(SUM(t.Amount - t.Released_amount - t.Refunded_amount), 0)
+				FROM Transactions t
+				WHERE ` + column + ` = $1
+					AND t.Currency = $2
+					AND t.Payment_id <> $3
+					AND t.Current_status NOT IN ('DECLINED', 'REVERSED')
+					AND (t.Current_status NOT IN ('CREATED', 'PENDING_3DS', 'REVIEW')
+						OR COALESCE((SELECT MAX(s.Created_at) FROM TransactionStatus s WHERE s.Payment_id = t.Payment_id),
+							t.Created_at) > NOW() - make_interval(secs => $4))
+					AND t.Created_at >= date_trunc('month', NOW() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC';`
+
+			var u models.LimitUsage
+			if err := db.QueryRow(ctx, query, subject, payment.Currency, payment.ID, models.PendingLimitTTL.Seconds()).Scan(&u.Daily, &u.Monthly); err != nil {
+				return err
+			}
+			usage[limit.Scope] = &u
+		}
+
+		if err := limit.Check(subject, payment.Amount, *usage[limit.Scope]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package repo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrInvalidCursor = errors.New("pagination cursor is invalid")
+
+// paymentCursor — позиция последнего элемента страницы для keyset пагинации.
+// Клиенту передаётся в виде непрозрачной base64 строки.
+type paymentCursor struct {
+	SortBy    models.SortField `json:"s"`
+	Desc      bool             `json:"d"`
+	Value     string           `json:"v"`
+	PaymentID string           `json:"id"`
+}
+
+func encodeCursor(c paymentCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (paymentCursor, error) {
+	var c paymentCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil || c.PaymentID == "" {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// queryArgs накапливает аргументы запроса и выдаёт плейсхолдеры $N.
+type queryArgs []any
+
+func (a *queryArgs) add(v any) string {
+	*a = append(*a, v)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+// sortColumn возвращает колонку сортировки и приведение типа для значения курсора.
+func sortColumn(field models.SortField) (column, cast string) {
+	if field == models.SortByAmount {
+		return "Amount", "numeric"
+	}
+	return "Created_at", "timestamptz"
+}
+
+func cursorValue(field models.SortField, p models.Payment) string {
+	if field == models.SortByAmount {
+		return strconv.FormatFloat(p.Amount, 'f', -1, 64)
+	}
+	return p.CreatedAt.Format(time.RFC3339Nano)
+}
+
+// paymentConditions строит условия WHERE по фильтру (без учёта курсора).
+func paymentConditions(f models.PaymentFilter, args *queryArgs) []string {
+	var conds []string
+
+	if f.UserID != "" {
+		conds = append(conds, "User_id = "+args.add(f.UserID))
+	}
+	if len(f.Statuses) != 0 {
+		statuses := make([]string, 0, len(f.Statuses))
+		for _, s := range f.Statuses {
+			statuses = append(statuses, string(s))
+		}
+		conds = append(conds, "Current_status::text = ANY("+args.add(statuses)+")")
+	}
+	if len(f.Currencies) != 0 {
+		conds = append(conds, "Currency = ANY("+args.add(f.Currencies)+")")
+	}
+	if f.Broker != "" {
+		conds = append(conds, "Broker = "+args.add(f.Broker))
+	}
+	if f.OrderIDPrefix != "" {
+		conds = append(conds, "starts_with(Order_id, "+args.add(f.OrderIDPrefix)+")")
+	}
+	if !f.CreatedFrom.IsZero() {
+		conds = append(conds, "Created_at >= "+args.add(f.CreatedFrom))
+	}
+	if !f.CreatedTo.IsZero() {
+		conds = append(conds, "Created_at < "+args.add(f.CreatedTo))
+	}
+	if f.AmountMin != nil {
+		conds = append(conds, "Amount >= "+args.add(*f.AmountMin))
+	}
+	if f.AmountMax != nil {
+		conds = append(conds, "Amount <= "+args.add(*f.AmountMax))
+	}
+	if len(f.Metadata) != 0 {
+		conds = append(conds, "Metadata @> "+args.add(f.Metadata)+"::jsonb")
+	}
+
+	return conds
+}
+
+func whereClause(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conds, " AND ")
+}
+
+// listColumns — колонки Transactions для списка и выгрузки платежей (порядок scanListPayment).
+const listColumns = `
+			Payment_id, 
+			User_id, 
+			Order_id, 
+			Amount, 
+			Currency,
+			Broker, 
+			Operation, 
+			Current_status, 
+			Created_at,
+			Captured_amount,
+			Released_amount,
+			Refunded_amount,
+			Fee_amount,
+			COALESCE(Merchant, ''),
+			Presentment_amount,
+			Presentment_currency,
+			Fx_rate,
+			Fx_source,
+			Fx_rate_at,
+			COALESCE(Client_ip, ''),
+			COALESCE(Risk_decision::TEXT, ''),
+			Risk_rules,
+			COALESCE(Description, ''),
+			Metadata`
+
+func scanListPayment(row pgx.Row) (models.Payment, error) {
+	var p models.Payment
+	err := row.Scan(&p.ID, &p.UserID, &p.OrderID, &p.Amount, &p.Currency,
+		&p.Broker, &p.Operation, &p.Status, &p.CreatedAt,
+		&p.CapturedAmount, &p.ReleasedAmount,
+		&p.RefundedAmount, &p.FeeAmount, &p.Merchant,
+		&p.PresentmentAmount, &p.PresentmentCurrency,
+		&p.FXRate, &p.FXSource, &p.FXRateAt,
+		&p.ClientIP, &p.RiskDecision, &p.RiskRules,
+		&p.Description, &p.Metadata)
+	return p, err
+}
+
+// ListPayments — возвращает страницу платежей по фильтру и общее количество подходящих платежей.
+// Сортировка стабильна: при равенстве значений порядок определяется Payment_id.
+func (repo *PostgresPaymentRepo) ListPayments(ctx context.Context, f models.PaymentFilter) (page models.PaymentPage, err error) {
+	const op = "PostgresPaymentRepo.ListPayments"
+
+	if f.SortBy == "" {
+		f.SortBy = models.SortByCreatedAt
+	}
+	column, cast := sortColumn(f.SortBy)
+	direction, cmp := "ASC", ">"
+	if f.SortDesc {
+		direction, cmp = "DESC", "<"
+	}
+
+	// Подсчёт общего количества
+	var countArgs queryArgs
+	countQuery := `SELECT COUNT(*) FROM Transactions ` + whereClause(paymentConditions(f, &countArgs)) + `;`
+
+	// Выборка страницы
+	var args queryArgs
+	conds := paymentConditions(f, &args)
+	if f.Cursor != "" {
+		c, err := decodeCursor(f.Cursor)
+		if err != nil {
+			return page, err
+		}
+		if c.SortBy != f.SortBy || c.Desc != f.SortDesc {
+			return page, ErrInvalidCursor
+		}
+		conds = append(conds, fmt.Sprintf("(%s, Payment_id) %s (%s::%s, %s)",
+			column, cmp, args.add(c.Value), cast, args.add(c.PaymentID)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT`+listColumns+`
+		FROM 
+			Transactions
+		%s
+		ORDER BY 
+			%s %s, Payment_id %s
+		LIMIT %s`, whereClause(conds), column, direction, direction, args.add(f.Limit+1))
+	if f.Cursor == "" && f.Offset > 0 {
+		query += " OFFSET " + args.add(f.Offset)
+	}
+
+	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return page, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := tx.QueryRow(ctx, countQuery, countArgs...).Scan(&page.Total); err != nil {
+		return page, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return page, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	paymentList, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Payment, error) {
+		return scanListPayment(row)
+	})
+	if err != nil {
+		return page, fmt.Errorf("%s: failed to collect rows: %w", op, err)
+	}
+
+	if len(paymentList) > f.Limit {
+		paymentList = paymentList[:f.Limit]
+		last := paymentList[len(paymentList)-1]
+		page.NextCursor = encodeCursor(paymentCursor{
+			SortBy:    f.SortBy,
+			Desc:      f.SortDesc,
+			Value:     cursorValue(f.SortBy, last),
+			PaymentID: last.ID,
+		})
+	}
+
+	page.Payments = paymentList
+	return page, nil
+}
+
+// exportFetchSize — число строк, которое выгрузка забирает из курсора за раз.
+const exportFetchSize = 500
+
+// ExportPayments — передаёт в fn все платежи по фильтру в порядке сортировки.
+// Строки читаются серверным курсором порциями по exportFetchSize, поэтому память не зависит от объёма выгрузки.
+// Курсор, Offset и Limit фильтра не используются. Ошибка fn прерывает выгрузку и возвращается без обёртки.
+func (repo *PostgresPaymentRepo) ExportPayments(ctx context.Context, f models.PaymentFilter, fn func(models.Payment) error) (err error) {
+	const op = "PostgresPaymentRepo.ExportPayments"
+
+	if f.SortBy == "" {
+		f.SortBy = models.SortByCreatedAt
+	}
+	column, _ := sortColumn(f.SortBy)
+	direction := "ASC"
+	if f.SortDesc {
+		direction = "DESC"
+	}
+
+	var args queryArgs
+	query := fmt.Sprintf(`
+		DECLARE payments_export NO SCROLL CURSOR FOR
+		SELECT`+listColumns+`
+		FROM 
+			Transactions
+		%s
+		ORDER BY 
+			%s %s, Payment_id %s`, whereClause(paymentConditions(f, &args)), column, direction, direction)
+
+	// Курсор живёт до конца транзакции; снимок данных один на всю выгрузку
+	tx, err := repo.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM payments_export;", exportFetchSize)
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		fetched := 0
+		for rows.Next() {
+			p, err := scanListPayment(rows)
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			fetched++
+
+			if err := fn(p); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if fetched < exportFetchSize {
+			return nil
+		}
+	}
+}
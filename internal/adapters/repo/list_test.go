@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"encoding/base64"
+	"errors"
+	"payment/internal/domain/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	want := paymentCursor{
+		SortBy:    models.SortByAmount,
+		Desc:      true,
+		Value:     "1500.5",
+		PaymentID: "b7c0a1d2-payment",
+	}
+
+	encoded := encodeCursor(want)
+	if strings.ContainsAny(encoded, "+/=") {
+		t.Fatalf("cursor %q is not URL safe", encoded)
+	}
+
+	got, err := decodeCursor(encoded)
+	if err != nil {
+		t.Fatalf("decodeCursor: %v", err)
+	}
+	if got != want {
+		t.Fatalf("decodeCursor = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	tests := map[string]string{
+		"not base64":       "%%%",
+		"not json":         encodeRaw("not json"),
+		"no payment id":    encodeRaw(`{"s":"amount","d":false,"v":"10"}`),
+		"wrong value type": encodeRaw(`{"s":"amount","v":10,"id":"p1"}`),
+	}
+
+	for name, cursor := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decodeCursor(cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("decodeCursor(%q) error = %v, want ErrInvalidCursor", cursor, err)
+			}
+		})
+	}
+}
+
+func TestCursorValue(t *testing.T) {
+	createdAt := time.Date(2025, 3, 4, 5, 6, 7, 890, time.UTC)
+	p := models.Payment{Amount: 1234.56, CreatedAt: createdAt}
+
+	if got := cursorValue(models.SortByAmount, p); got != "1234.56" {
+		t.Errorf("cursorValue(amount) = %q, want 1234.56", got)
+	}
+	if got := cursorValue(models.SortByCreatedAt, p); got != createdAt.Format(time.RFC3339Nano) {
+		t.Errorf("cursorValue(created_at) = %q, want %q", got, createdAt.Format(time.RFC3339Nano))
+	}
+}
+
+func TestPaymentConditions(t *testing.T) {
+	amountMin, amountMax := 100.0, 500.0
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name   string
+		filter models.PaymentFilter
+		conds  []string
+		args   []any
+	}{
+		{
+			name: "empty filter",
+		},
+		{
+			name: "all filters",
+			filter: models.PaymentFilter{
+				UserID:        "u1",
+				Statuses:      []models.StatusType{models.OrderDeposited, models.OrderRefunded},
+				Currencies:    []string{"KZT"},
+				Broker:        "BEREKE",
+				OrderIDPrefix: "shop-",
+				CreatedFrom:   from,
+				CreatedTo:     to,
+				AmountMin:     &amountMin,
+				AmountMax:     &amountMax,
+				Metadata:      map[string]string{"cart_id": "42"},
+			},
+			conds: []string{
+				"User_id = $1",
+				"Current_status::text = ANY($2)",
+				"Currency = ANY($3)",
+				"Broker = $4",
+				"starts_with(Order_id, $5)",
+				"Created_at >= $6",
+				"Created_at < $7",
+				"Amount >= $8",
+				"Amount <= $9",
+				"Metadata @> $10::jsonb",
+			},
+			args: []any{
+				"u1",
+				[]string{"DEPOSITED", "REFUNDED"},
+				[]string{"KZT"},
+				"BEREKE",
+				"shop-",
+				from,
+				to,
+				amountMin,
+				amountMax,
+				map[string]string{"cart_id": "42"},
+			},
+		},
+		{
+			name:   "amount range only",
+			filter: models.PaymentFilter{AmountMax: &amountMax},
+			conds:  []string{"Amount <= $1"},
+			args:   []any{amountMax},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var args queryArgs
+			conds := paymentConditions(tt.filter, &args)
+
+			if !reflect.DeepEqual(conds, tt.conds) {
+				t.Errorf("conditions = %q, want %q", conds, tt.conds)
+			}
+			if len(args) != len(tt.args) || (len(args) > 0 && !reflect.DeepEqual([]any(args), tt.args)) {
+				t.Errorf("args = %#v, want %#v", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestWhereClause(t *testing.T) {
+	if got := whereClause(nil); got != "" {
+		t.Errorf("whereClause(nil) = %q, want empty", got)
+	}
+	if got := whereClause([]string{"A = $1", "B = $2"}); got != "WHERE A = $1 AND B = $2" {
+		t.Errorf("whereClause = %q", got)
+	}
+}
+
+func encodeRaw(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
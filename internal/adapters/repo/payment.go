@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"payment/internal/domain/models"
 	"payment/pkg/postgres"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -25,9 +26,41 @@ var (
 	ErrPaymentStatusNotFound = errors.New("payment status info is not found")
 )
 
-// Добавляет информацию о платеже в БД
-func (repo *PostgresPaymentRepo) Create(ctx context.Context, transaction models.Payment) (err error) {
-	const op = "PostgresPaymentRepo.Create"
+// Резервирует лимит под платёж до обращения к банку: сохраняет платёж в статусе CREATED под
+// временным ID. Лимиты проверяются под блокировкой пользователя и мерчанта, поэтому параллельные
+// платежи не могут одновременно пройти проверку на один и тот же остаток лимита.
+func (repo *PostgresPaymentRepo) Reserve(ctx context.Context, transaction models.Payment) (err error) {
+	const op = "PostgresPaymentRepo.Reserve"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = insertPaymentTx(ctx, tx, transaction); err != nil {
+		if errors.Is(err, ErrOrderIDConflict) || errors.Is(err, models.ErrLimitExceeded) {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Подтверждает резерв после ответа банка: платёж получает ID заказа у банка и статус из ответа.
+func (repo *PostgresPaymentRepo) ConfirmReservation(
+	ctx context.Context,
+	reservationID string,
+	transaction models.Payment,
+	change models.StatusChange,
+	audit models.AuditEntry,
+) (err error) {
+	const op = "PostgresPaymentRepo.ConfirmReservation"
 
 	tx, err := repo.pool.Begin(ctx)
 	if err != nil {
@@ -40,28 +73,105 @@ func (repo *PostgresPaymentRepo) Create(ctx context.Context, transaction models.
 	}()
 
 	query := `
-		INSERT INTO Transactions(Payment_id, User_id, Order_id, Amount, Currency, Broker, Operation)
-		VALUES ($1, $2, $3, $4, $5, $6, $7);`
-	_, err = tx.Exec(ctx, query,
+		UPDATE Transactions
+		SET Payment_id = $2, Broker = $3, Merchant = NULLIF($4, '')
+		WHERE Payment_id = $1 AND Current_status = 'CREATED';`
+	res, err := tx.Exec(ctx, query, reservationID, transaction.ID, transaction.Broker, transaction.Merchant)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrPaymentNotFound
+	}
+
+	// прямые операции (оплата по связке) сразу возвращают итоговый статус
+	if change.Status != models.OrderCreated {
+		if err = markStatusTx(ctx, tx, transaction.ID, change); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = insertAudit(ctx, tx, audit); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Снимает резерв, если заказ у банка не создан: платёж удаляется, лимит и ID заказа освобождаются
+func (repo *PostgresPaymentRepo) ReleaseReservation(ctx context.Context, reservationID string) error {
+	const op = "PostgresPaymentRepo.ReleaseReservation"
+	query := `DELETE FROM Transactions WHERE Payment_id = $1 AND Current_status = 'CREATED';`
+
+	res, err := repo.pool.Exec(ctx, query, reservationID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrPaymentNotFound
+	}
+	return nil
+}
+
+// insertPaymentTx проверяет лимиты и сохраняет платёж, его начальный статус, правила распределения
+// между продавцами и данные для чека. Платёж сохраняется под ID у мерчанта, который остаётся в Local_id
+// после замены Payment_id на ID заказа у банка.
+func insertPaymentTx(ctx context.Context, tx pgx.Tx, transaction models.Payment) error {
+	if err := checkLimitsTx(ctx, tx, transaction); err != nil {
+		return err
+	}
+
+	metadata := transaction.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	query := `
+		INSERT INTO Transactions(Payment_id, Local_id, User_id, Order_id, Amount, Currency, Broker, Operation, Current_status, Merchant,
+			Presentment_amount, Presentment_currency, Fx_rate, Fx_source, Fx_rate_at,
+			Client_ip, Risk_decision, Risk_rules, Description, Metadata)
+		VALUES ($1, $1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12, $13, $14,
+			NULLIF($15, ''), NULLIF($16, '')::risk_decision_enum, COALESCE($17::TEXT[], '{}'),
+			NULLIF($18, ''), $19);`
+	_, err := tx.Exec(ctx, query,
 		transaction.ID, transaction.UserID, transaction.OrderID,
-		transaction.Amount, transaction.Currency, transaction.Broker, transaction.Operation)
+		transaction.Amount, transaction.Currency, transaction.Broker, transaction.Operation,
+		transaction.Status, transaction.Merchant,
+		transaction.PresentmentAmount, transaction.PresentmentCurrency,
+		transaction.FXRate, transaction.FXSource, transaction.FXRateAt,
+		transaction.ClientIP, transaction.RiskDecision, transaction.RiskRules,
+		transaction.Description, metadata)
 	if err != nil {
 		if postgres.IsUniqueViolation(err) {
 			return ErrOrderIDConflict
 		}
-		return fmt.Errorf("%s: %w", op, err)
+		return err
 	}
 
 	// сохраняем статус по Payment_id
 	query = `
-		INSERT INTO TransactionStatus(Payment_id, Status)
-		VALUES ($1, $2);`
-	_, err = tx.Exec(ctx, query, transaction.ID, transaction.Status)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		INSERT INTO TransactionStatus(Payment_id, Status, Source)
+		VALUES ($1, $2, $3);`
+	if _, err = tx.Exec(ctx, query, transaction.ID, transaction.Status, models.SourceAPI); err != nil {
+		return err
 	}
 
-	return tx.Commit(ctx)
+	// сохраняем правила распределения платежа между продавцами
+	query = `
+		INSERT INTO Payment_splits(Payment_id, Seller_id, Amount)
+		VALUES ($1, $2, $3);`
+	for _, split := range transaction.Splits {
+		if _, err = tx.Exec(ctx, query, transaction.ID, split.SellerID, split.Amount); err != nil {
+			return err
+		}
+	}
+
+	if err = insertCartTx(ctx, tx, transaction); err != nil {
+		return err
+	}
+
+	// прямые операции (оплата по связке) могут сразу вернуть итоговый статус
+	return postStatusTx(ctx, tx, transaction.ID, transaction.Status)
 }
 
 // Проверяет уникальность OrderID
@@ -92,7 +202,22 @@ func (repo *PostgresPaymentRepo) GetTransactionByOrderID(ctx context.Context, or
 		    f.Broker, 
 			f.Operation, 
 			s.Status, 
-			s.Created_at
+			s.Created_at,
+			f.Captured_amount,
+			f.Released_amount,
+			f.Refunded_amount,
+			f.Fee_amount,
+			COALESCE(f.Merchant, ''),
+			f.Presentment_amount,
+			f.Presentment_currency,
+			f.Fx_rate,
+			f.Fx_source,
+			f.Fx_rate_at,
+			COALESCE(f.Client_ip, ''),
+			COALESCE(f.Risk_decision::TEXT, ''),
+			f.Risk_rules,
+			COALESCE(f.Description, ''),
+			f.Metadata
 		FROM 
 			Transactions f
 		INNER JOIN 
@@ -107,7 +232,13 @@ func (repo *PostgresPaymentRepo) GetTransactionByOrderID(ctx context.Context, or
 	if err := repo.pool.QueryRow(ctx, query, orderID).
 		Scan(&payment.ID, &payment.UserID, &payment.OrderID,
 			&payment.Amount, &payment.Currency, &payment.Broker,
-			&payment.Operation, &payment.Status, &payment.CreatedAt); err != nil {
+			&payment.Operation, &payment.Status, &payment.CreatedAt,
+			&payment.CapturedAmount, &payment.ReleasedAmount,
+			&payment.RefundedAmount, &payment.FeeAmount, &payment.Merchant,
+			&payment.PresentmentAmount, &payment.PresentmentCurrency,
+			&payment.FXRate, &payment.FXSource, &payment.FXRateAt,
+			&payment.ClientIP, &payment.RiskDecision, &payment.RiskRules,
+			&payment.Description, &payment.Metadata); err != nil {
 
 		if err == pgx.ErrNoRows {
 			return nil, ErrPaymentNotFound
@@ -117,6 +248,24 @@ func (repo *PostgresPaymentRepo) GetTransactionByOrderID(ctx context.Context, or
 	return &payment, nil
 }
 
+// Возвращает текущий ID платежа по ID, под которым платёж был создан у мерчанта
+func (repo *PostgresPaymentRepo) PaymentIDByLocalID(ctx context.Context, localID string) (string, error) {
+	const op = "PostgresPaymentRepo.PaymentIDByLocalID"
+	query := `
+		SELECT Payment_id
+		FROM Transactions
+		WHERE Local_id = $1;`
+
+	var paymentID string
+	if err := repo.pool.QueryRow(ctx, query, localID).Scan(&paymentID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrPaymentNotFound
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	return paymentID, nil
+}
+
 // Получает транзакцию по PaymentID
 func (repo *PostgresPaymentRepo) GetTransactionByPaymentID(ctx context.Context, paymentID string) (*models.Payment, error) {
 	const op = "PostgresPaymentRepo.GetTransactionByPaymentID"
@@ -130,7 +279,22 @@ func (repo *PostgresPaymentRepo) GetTransactionByPaymentID(ctx context.Context,
 			f.Broker, 
 			f.Operation, 
 			s.Status, 
-			s.Created_at
+			s.Created_at,
+			f.Captured_amount,
+			f.Released_amount,
+			f.Refunded_amount,
+			f.Fee_amount,
+			COALESCE(f.Merchant, ''),
+			f.Presentment_amount,
+			f.Presentment_currency,
+			f.Fx_rate,
+			f.Fx_source,
+			f.Fx_rate_at,
+			COALESCE(f.Client_ip, ''),
+			COALESCE(f.Risk_decision::TEXT, ''),
+			f.Risk_rules,
+			COALESCE(f.Description, ''),
+			f.Metadata
 		FROM 
 			Transactions f
 		INNER JOIN TransactionStatus s ON s.Payment_id = f.Payment_id
@@ -144,7 +308,13 @@ func (repo *PostgresPaymentRepo) GetTransactionByPaymentID(ctx context.Context,
 	if err := repo.pool.QueryRow(ctx, query, paymentID).
 		Scan(&payment.ID, &payment.UserID, &payment.OrderID,
 			&payment.Amount, &payment.Currency, &payment.Broker,
-			&payment.Operation, &payment.Status, &payment.CreatedAt); err != nil {
+			&payment.Operation, &payment.Status, &payment.CreatedAt,
+			&payment.CapturedAmount, &payment.ReleasedAmount,
+			&payment.RefundedAmount, &payment.FeeAmount, &payment.Merchant,
+			&payment.PresentmentAmount, &payment.PresentmentCurrency,
+			&payment.FXRate, &payment.FXSource, &payment.FXRateAt,
+			&payment.ClientIP, &payment.RiskDecision, &payment.RiskRules,
+			&payment.Description, &payment.Metadata); err != nil {
 
 		if err == pgx.ErrNoRows {
 			return nil, ErrPaymentNotFound
@@ -154,9 +324,9 @@ func (repo *PostgresPaymentRepo) GetTransactionByPaymentID(ctx context.Context,
 	return &payment, nil
 }
 
-// Возвращает список платежей пользователя
-func (repo *PostgresPaymentRepo) UserPaymentsList(ctx context.Context, userID string, offset, limit int) ([]models.Payment, error) {
-	const op = "PostgresPaymentRepo.UserPaymentsList"
+// Возвращает платежи, созданные начиная с since (от старых к новым)
+func (repo *PostgresPaymentRepo) PaymentsSince(ctx context.Context, since time.Time) ([]models.Payment, error) {
+	const op = "PostgresPaymentRepo.PaymentsSince"
 	query := `
 		SELECT 
 			Payment_id, 
@@ -167,14 +337,30 @@ func (repo *PostgresPaymentRepo) UserPaymentsList(ctx context.Context, userID st
 		    Broker, 
 			Operation, 
 			Current_status, 
-			Created_at
+			Created_at,
+			Captured_amount,
+			Released_amount,
+			Refunded_amount,
+			Fee_amount,
+			COALESCE(Merchant, ''),
+			Presentment_amount,
+			Presentment_currency,
+			Fx_rate,
+			Fx_source,
+			Fx_rate_at,
+			COALESCE(Client_ip, ''),
+			COALESCE(Risk_decision::TEXT, ''),
+			Risk_rules,
+			COALESCE(Description, ''),
+			Metadata
 		FROM 
 			Transactions
 		WHERE 
-			User_id = $1
-		OFFSET $2 LIMIT $3;`
+			Created_at >= $1
+		ORDER BY 
+			Created_at ASC;`
 
-	rows, err := repo.pool.Query(ctx, query, userID, offset, limit)
+	rows, err := repo.pool.Query(ctx, query, since)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -183,17 +369,19 @@ func (repo *PostgresPaymentRepo) UserPaymentsList(ctx context.Context, userID st
 	paymentList, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Payment, error) {
 		var p models.Payment
 		err := row.Scan(&p.ID, &p.UserID, &p.OrderID, &p.Amount, &p.Currency,
-			&p.Broker, &p.Operation, &p.Status, &p.CreatedAt)
+			&p.Broker, &p.Operation, &p.Status, &p.CreatedAt,
+			&p.CapturedAmount, &p.ReleasedAmount,
+			&p.RefundedAmount, &p.FeeAmount, &p.Merchant,
+			&p.PresentmentAmount, &p.PresentmentCurrency,
+			&p.FXRate, &p.FXSource, &p.FXRateAt,
+			&p.ClientIP, &p.RiskDecision, &p.RiskRules,
+			&p.Description, &p.Metadata)
 		return p, err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to collect rows: %w", op, err)
 	}
 
-	if len(paymentList) == 0 {
-		return nil, ErrPaymentNotFound
-	}
-
 	return paymentList, nil
 }
 
@@ -204,7 +392,9 @@ func (repo *PostgresPaymentRepo) GetStatus(ctx context.Context, paymentID string
 		SELECT 
 			s.Status, 
 			s.Payment_id, 
-			s.Created_at
+			s.Created_at,
+			s.Source,
+			COALESCE(s.Broker_code, '')
 		FROM 
 			TransactionStatus s
 		WHERE 
@@ -215,7 +405,8 @@ func (repo *PostgresPaymentRepo) GetStatus(ctx context.Context, paymentID string
 
 	var status models.PaymentStatus
 	if err := repo.pool.QueryRow(ctx, query, paymentID).
-		Scan(&status.Status, &status.PaymentID, &status.CreatedAt); err != nil {
+		Scan(&status.Status, &status.PaymentID, &status.CreatedAt,
+			&status.Source, &status.BrokerCode); err != nil {
 
 		if err == pgx.ErrNoRows {
 			return nil, ErrPaymentStatusNotFound
@@ -225,6 +416,45 @@ func (repo *PostgresPaymentRepo) GetStatus(ctx context.Context, paymentID string
 	return &status, nil
 }
 
+// Возвращает историю статусов заказа (от старых к новым)
+func (repo *PostgresPaymentRepo) GetStatusHistory(ctx context.Context, paymentID string) ([]models.PaymentStatus, error) {
+	const op = "PostgresPaymentRepo.GetStatusHistory"
+	query := `
+		SELECT 
+			s.Status, 
+			s.Payment_id, 
+			s.Created_at,
+			s.Source,
+			COALESCE(s.Broker_code, '')
+		FROM 
+			TransactionStatus s
+		WHERE 
+			s.Payment_id = $1
+		ORDER BY 
+			s.Created_at ASC;`
+
+	rows, err := repo.pool.Query(ctx, query, paymentID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	history, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.PaymentStatus, error) {
+		var st models.PaymentStatus
+		err := row.Scan(&st.Status, &st.PaymentID, &st.CreatedAt, &st.Source, &st.BrokerCode)
+		return st, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to collect rows: %w", op, err)
+	}
+
+	if len(history) == 0 {
+		return nil, ErrPaymentStatusNotFound
+	}
+
+	return history, nil
+}
+
 // Обновляет заказ по OrderID
 func (repo *PostgresPaymentRepo) UpdateByOrderID(ctx context.Context, transaction models.Payment) error {
 	const op = "PostgresPaymentRepo.UpdateByOrderID"
@@ -269,7 +499,7 @@ func (repo *PostgresPaymentRepo) Delete(ctx context.Context, paymentID string) e
 }
 
 // Проставляет новый статус заказа
-func (repo *PostgresPaymentRepo) MarkStatus(ctx context.Context, paymentID string, status models.StatusType) (err error) {
+func (repo *PostgresPaymentRepo) MarkStatus(ctx context.Context, paymentID string, change models.StatusChange, audit models.AuditEntry) (err error) {
 	const op = "PostgresPaymentRepo.MarkStatus"
 
 	tx, err := repo.pool.Begin(ctx)
@@ -282,15 +512,31 @@ func (repo *PostgresPaymentRepo) MarkStatus(ctx context.Context, paymentID strin
 		}
 	}()
 
+	if err = markStatusTx(ctx, tx, paymentID, change); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = insertAudit(ctx, tx, audit); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// markStatusTx обновляет текущий статус заказа, добавляет запись в историю статусов,
+// начисляет комиссии, проводит изменение денежного состояния в журнал и доли продавцов по новому статусу
+// и ставит в очередь чек для покупателя.
+// Вызывается внутри транзакции изменения состояния платежа.
+func markStatusTx(ctx context.Context, db pgx.Tx, paymentID string, change models.StatusChange) error {
 	// обновляем текущий статус
 	query := `
 		UPDATE Transactions
 		SET Current_status = $1
 		WHERE Payment_id = $2;`
 
-	res, err := tx.Exec(ctx, query, status, paymentID)
+	res, err := db.Exec(ctx, query, change.Status, paymentID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return err
 	}
 	if res.RowsAffected() == 0 {
 		return ErrPaymentNotFound
@@ -298,12 +544,34 @@ func (repo *PostgresPaymentRepo) MarkStatus(ctx context.Context, paymentID strin
 
 	// вставляем историю по Payment_id
 	query = `
-		INSERT INTO TransactionStatus(Payment_id, Status)
-		VALUES ($1, $2);`
-	_, err = tx.Exec(ctx, query, paymentID, status)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		INSERT INTO TransactionStatus(Payment_id, Status, Source, Broker_code)
+		VALUES ($1, $2, $3, NULLIF($4, ''));`
+	if _, err = db.Exec(ctx, query, paymentID, change.Status, change.Source, change.BrokerCode); err != nil {
+		return err
 	}
 
-	return tx.Commit(ctx)
+	return postStatusTx(ctx, db, paymentID, change.Status)
+}
+
+// postStatusTx проводит последствия перехода платежа в статус: списанную сумму одностадийного платежа,
+// комиссии, журнал, доли продавцов и чек. Вызывается и при сохранении платежа, который банк
+// сразу вернул в итоговом статусе.
+func postStatusTx(ctx context.Context, tx pgx.Tx, paymentID string, status models.StatusType) error {
+	if err := captureInFullTx(ctx, tx, paymentID, status); err != nil {
+		return err
+	}
+
+	if err := postFeesTx(ctx, tx, paymentID, status); err != nil {
+		return err
+	}
+
+	if err := postJournalTx(ctx, tx, paymentID, status); err != nil {
+		return err
+	}
+
+	if err := postLedgerTx(ctx, tx, paymentID, status); err != nil {
+		return err
+	}
+
+	return enqueueReceiptTx(ctx, tx, paymentID, status)
 }
@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresReceiptRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresReceiptRepo(pool *pgxpool.Pool) *PostgresReceiptRepo {
+	return &PostgresReceiptRepo{pool: pool}
+}
+
+var ErrReceiptNotFound = errors.New("receipt is not found")
+
+// enqueueReceiptTx ставит в очередь чек, если платёж перешёл в статус, по которому он формируется.
+// Повторный переход в тот же статус второй чек не создаёт.
+func enqueueReceiptTx(ctx context.Context, tx pgx.Tx, paymentID string, status models.StatusType) error {
+	kind, ok := models.ReceiptKindFor(status)
+	if !ok {
+		return nil
+	}
+
+	query := `
+		INSERT INTO Receipts(Payment_id, Kind)
+		VALUES ($1, $2)
+		ON CONFLICT (Payment_id, Kind) DO NOTHING;`
+	_, err := tx.Exec(ctx, query, paymentID, kind)
+	return err
+}
+
+const receiptColumns = `
+	Receipt_id,
+	Payment_id,
+	Kind,
+	Status,
+	COALESCE(Provider, ''),
+	COALESCE(Fiscal_number, ''),
+	COALESCE(Fiscal_url, ''),
+	Attempts,
+	COALESCE(Last_error, ''),
+	Created_at,
+	Issued_at,
+	Sent_at`
+
+func scanReceipt(row pgx.Row) (models.Receipt, error) {
+	var r models.Receipt
+	if err := row.Scan(&r.ID, &r.PaymentID, &r.Kind, &r.Status,
+		&r.Provider, &r.FiscalNumber, &r.FiscalURL, &r.Attempts, &r.LastError,
+		&r.CreatedAt, &r.IssuedAt, &r.SentAt); err != nil {
+		return models.Receipt{}, err
+	}
+	return r, nil
+}
+
+// Возвращает чек платежа указанного вида
+func (repo *PostgresReceiptRepo) GetReceipt(ctx context.Context, paymentID string, kind models.ReceiptKind) (*models.Receipt, error) {
+	const op = "PostgresReceiptRepo.GetReceipt"
+	query := `SELECT` + receiptColumns + `
+		FROM Receipts
+		WHERE Payment_id = $1 AND Kind = $2;`
+
+	receipt, err := scanReceipt(repo.pool.QueryRow(ctx, query, paymentID, kind))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReceiptNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &receipt, nil
+}
+
+// Возвращает чеки, ожидающие регистрации (от старых к новым)
+func (repo *PostgresReceiptRepo) PendingReceipts(ctx context.Context, limit int) ([]models.Receipt, error) {
+	const op = "PostgresReceiptRepo.PendingReceipts"
+	query := `SELECT` + receiptColumns + `
+		FROM Receipts
+		WHERE Status = 'PENDING'
+		ORDER BY Created_at
+		LIMIT $1;`
+
+	rows, err := repo.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	receipts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Receipt, error) {
+		return scanReceipt(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return receipts, nil
+}
+
+// Сохраняет фискальные данные зарегистрированного чека
+func (repo *PostgresReceiptRepo) MarkReceiptIssued(ctx context.Context, receiptID string, fiscal models.Fiscalization) (models.Receipt, error) {
+	const op = "PostgresReceiptRepo.MarkReceiptIssued"
+	query := `
+		UPDATE Receipts
+		SET Status = 'ISSUED',
+			Provider = $2,
+			Fiscal_number = $3,
+			Fiscal_url = NULLIF($4, ''),
+			Attempts = Attempts + 1,
+			Last_error = NULL,
+			Issued_at = NOW()
+		WHERE Receipt_id = $1
+		RETURNING` + receiptColumns + `;`
+
+	receipt, err := scanReceipt(repo.pool.QueryRow(ctx, query, receiptID, fiscal.Provider, fiscal.Number, fiscal.URL))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Receipt{}, ErrReceiptNotFound
+		}
+		return models.Receipt{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return receipt, nil
+}
+
+// Фиксирует неудачную попытку регистрации. После maxAttempts попыток чек переходит в FAILED
+func (repo *PostgresReceiptRepo) MarkReceiptFailed(ctx context.Context, receiptID, reason string, maxAttempts int) error {
+	const op = "PostgresReceiptRepo.MarkReceiptFailed"
+	query := `
+		UPDATE Receipts
+		SET Attempts = Attempts + 1,
+			Last_error = $2,
+			Status = CASE WHEN Attempts + 1 >= $3 THEN 'FAILED'::receipt_status_enum ELSE Status END
+		WHERE Receipt_id = $1 AND Status <> 'ISSUED';`
+
+	if _, err := repo.pool.Exec(ctx, query, receiptID, reason, maxAttempts); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Фиксирует отправку чека покупателю
+func (repo *PostgresReceiptRepo) MarkReceiptSent(ctx context.Context, receiptID string) error {
+	const op = "PostgresReceiptRepo.MarkReceiptSent"
+	query := `
+		UPDATE Receipts
+		SET Sent_at = NOW()
+		WHERE Receipt_id = $1;`
+
+	res, err := repo.pool.Exec(ctx, query, receiptID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrReceiptNotFound
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresRedirectRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresRedirectRepo(pool *pgxpool.Pool) *PostgresRedirectRepo {
+	return &PostgresRedirectRepo{pool: pool}
+}
+
+// Возвращает хосты, на которые разрешён возврат покупателя для мерчанта
+func (repo *PostgresRedirectRepo) MerchantRedirectHosts(ctx context.Context, merchant string) ([]string, error) {
+	const op = "PostgresRedirectRepo.MerchantRedirectHosts"
+	query := `
+		SELECT Host
+		FROM Merchant_redirect_hosts
+		WHERE Merchant = $1
+		ORDER BY Host;`
+
+	rows, err := repo.pool.Query(ctx, query, merchant)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	hosts, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return hosts, nil
+}
@@ -2,18 +2,23 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"payment/internal/domain/models"
 )
 
+var ErrRefundExceedsCaptured = errors.New("amount exceeds captured amount not yet refunded")
+
 // Refund — выполняет возврат средств по платежу.
 // В транзакции:
-//  1. Сохраняет запись в Refunds,
+//  1. Сохраняет запись в Refunds и увеличивает сумму возвратов платежа (не больше списанной;
+//     одностадийный платёж, возвращённый до отметки о списании, сначала считается списанным целиком),
 //  2. Обновляет статус в Transactions,
-//  3. Логирует новый статус в TransactionStatus.
+//  3. Логирует новый статус в TransactionStatus,
+//  4. Добавляет запись в журнал аудита.
 //
 // При ошибке выполняется rollback.
-func (repo *PostgresPaymentRepo) Refund(ctx context.Context, paymentID, reason string, amount float64) error {
+func (repo *PostgresPaymentRepo) Refund(ctx context.Context, paymentID, reason string, amount float64, change models.StatusChange, audit models.AuditEntry) (err error) {
 	const op = "PostgresPaymentRepo.Refund"
 
 	tx, err := repo.pool.Begin(ctx)
@@ -35,26 +40,30 @@ func (repo *PostgresPaymentRepo) Refund(ctx context.Context, paymentID, reason s
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	// 2) Обновляем статус заказа
+	// Одностадийный платёж, возвращённый до сверки, ещё не отмечен списанным
+	if err = captureInFullTx(ctx, tx, paymentID, change.Status); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	query = `
 		UPDATE Transactions
-		SET Current_status = $1
-		WHERE Payment_id = $2;`
-
-	res, err := tx.Exec(ctx, query, models.OrderRefunded, paymentID)
+		SET Refunded_amount = Refunded_amount + $1
+		WHERE Payment_id = $2 AND Refunded_amount + $1 <= Captured_amount;`
+	res, err := tx.Exec(ctx, query, amount, paymentID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	if res.RowsAffected() == 0 {
-		return ErrPaymentNotFound
+		return ErrRefundExceedsCaptured
 	}
 
-	// 3) Добавляем новую запись о статусе заказа
-	query = `
-		INSERT INTO TransactionStatus(Payment_id, Status)
-		VALUES ($1, $2);`
+	// 2) Обновляем статус заказа и 3) добавляем новую запись о статусе заказа
+	if err = markStatusTx(ctx, tx, paymentID, change); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 
-	if _, err = tx.Exec(ctx, query, paymentID, models.OrderRefunded); err != nil {
+	// 4) Фиксируем операцию в журнале аудита
+	if err = insertAudit(ctx, tx, audit); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -0,0 +1,141 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrReviewNotFound   = errors.New("payment review is not found")
+	ErrReviewNotPending = errors.New("payment review is already resolved")
+)
+
+// Сохраняет платёж, отложенный проверкой риска, вместе с данными способа оплаты для проведения после одобрения
+func (repo *PostgresPaymentRepo) CreateReview(ctx context.Context, transaction models.Payment, method models.PaymentMethod, audit models.AuditEntry) (err error) {
+	const op = "PostgresPaymentRepo.CreateReview"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = insertPaymentTx(ctx, tx, transaction); err != nil {
+		if errors.Is(err, ErrOrderIDConflict) || errors.Is(err, models.ErrLimitExceeded) {
+			return err
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `
+		INSERT INTO Payment_reviews(Payment_id, Review_id, Method)
+		VALUES ($1, $1, $2);`
+	if _, err = tx.Exec(ctx, query, transaction.ID, method); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = insertAudit(ctx, tx, audit); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Получает ручную проверку платежа по текущему ID платежа или ID, под которым он был создан
+func (repo *PostgresPaymentRepo) GetReview(ctx context.Context, paymentID string) (*models.PaymentReview, error) {
+	const op = "PostgresPaymentRepo.GetReview"
+	query := `
+		SELECT
+			Review_id,
+			Payment_id,
+			Status,
+			COALESCE(Method, '{}'),
+			COALESCE(Reviewer, ''),
+			COALESCE(Note, ''),
+			Created_at,
+			COALESCE(Resolved_at, 'epoch')
+		FROM Payment_reviews
+		WHERE Payment_id = $1 OR Review_id = $1;`
+
+	var review models.PaymentReview
+	if err := repo.pool.QueryRow(ctx, query, paymentID).Scan(&review.ReviewID, &review.PaymentID, &review.Status,
+		&review.Method, &review.Reviewer, &review.Note, &review.CreatedAt, &review.ResolvedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &review, nil
+}
+
+// Фиксирует решение по проверке: одобренный платёж получает ID заказа у банка (ссылки обновляются каскадно),
+// данные способа оплаты очищаются, статус платежа меняется на change.
+func (repo *PostgresPaymentRepo) ResolveReview(
+	ctx context.Context,
+	review models.PaymentReview,
+	payment models.Payment,
+	paymentURL string,
+	change models.StatusChange,
+	audit models.AuditEntry,
+) (err error) {
+	const op = "PostgresPaymentRepo.ResolveReview"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		UPDATE Transactions
+		SET Payment_id = $2, Broker = $3, Merchant = NULLIF($4, '')
+		WHERE Payment_id = $1 AND Current_status = 'REVIEW';`
+	res, err := tx.Exec(ctx, query, review.ReviewID, payment.ID, payment.Broker, payment.Merchant)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrReviewNotPending
+	}
+
+	query = `
+		UPDATE Payment_reviews
+		SET Status = $2, Reviewer = $3, Note = NULLIF($4, ''), Method = NULL, Resolved_at = NOW()
+		WHERE Payment_id = $1;`
+	if _, err = tx.Exec(ctx, query, payment.ID, review.Status, review.Reviewer, review.Note); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// платёж по счёту получает ссылку на форму, которой у него не было до одобрения
+	if paymentURL != "" {
+		query = `
+			UPDATE Invoice_payments
+			SET Payment_url = $2
+			WHERE Payment_id = $1;`
+		if _, err = tx.Exec(ctx, query, payment.ID, paymentURL); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = markStatusTx(ctx, tx, payment.ID, change); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = insertAudit(ctx, tx, audit); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,189 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+	"payment/pkg/postgres"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresRiskRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresRiskRepo(pool *pgxpool.Pool) *PostgresRiskRepo {
+	return &PostgresRiskRepo{pool: pool}
+}
+
+var (
+	ErrRiskRuleNotFound      = errors.New("risk rule is not found")
+	ErrRiskRuleConflict      = errors.New("risk rule name must be unique")
+	ErrRiskListEntryNotFound = errors.New("risk list entry is not found")
+)
+
+const riskRuleColumns = `
+	Rule_id,
+	Name,
+	Decision,
+	Expression,
+	Enabled,
+	Created_at`
+
+func scanRiskRule(row pgx.Row) (models.RiskRule, error) {
+	rule := models.RiskRule{Source: models.RiskSourceDB}
+	err := row.Scan(&rule.ID, &rule.Name, &rule.Decision, &rule.Expression, &rule.Enabled, &rule.CreatedAt)
+	return rule, err
+}
+
+// Возвращает правила риска из БД (по имени)
+func (repo *PostgresRiskRepo) ListRiskRules(ctx context.Context, includeDisabled bool) ([]models.RiskRule, error) {
+	const op = "PostgresRiskRepo.ListRiskRules"
+	query := `
+		SELECT ` + riskRuleColumns + `
+		FROM Risk_rules
+		WHERE Enabled OR $1
+		ORDER BY Name;`
+
+	rows, err := repo.pool.Query(ctx, query, includeDisabled)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	rules, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.RiskRule, error) {
+		return scanRiskRule(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return rules, nil
+}
+
+// Добавляет правило риска
+func (repo *PostgresRiskRepo) CreateRiskRule(ctx context.Context, rule models.RiskRule) (models.RiskRule, error) {
+	const op = "PostgresRiskRepo.CreateRiskRule"
+	query := `
+		INSERT INTO Risk_rules(Name, Decision, Expression)
+		VALUES ($1, $2, $3)
+		RETURNING ` + riskRuleColumns + `;`
+
+	created, err := scanRiskRule(repo.pool.QueryRow(ctx, query, rule.Name, rule.Decision, rule.Expression))
+	if err != nil {
+		if postgres.IsUniqueViolation(err) {
+			return models.RiskRule{}, ErrRiskRuleConflict
+		}
+		return models.RiskRule{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return created, nil
+}
+
+// Включает или отключает правило риска по имени
+func (repo *PostgresRiskRepo) SetRiskRuleEnabled(ctx context.Context, name string, enabled bool) (models.RiskRule, error) {
+	const op = "PostgresRiskRepo.SetRiskRuleEnabled"
+	query := `
+		UPDATE Risk_rules
+		SET Enabled = $2
+		WHERE Name = $1
+		RETURNING ` + riskRuleColumns + `;`
+
+	rule, err := scanRiskRule(repo.pool.QueryRow(ctx, query, name, enabled))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.RiskRule{}, ErrRiskRuleNotFound
+		}
+		return models.RiskRule{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return rule, nil
+}
+
+// Добавляет значение в именованный список. Повторное добавление ничего не меняет.
+func (repo *PostgresRiskRepo) AddListEntry(ctx context.Context, list, value string) error {
+	const op = "PostgresRiskRepo.AddListEntry"
+	query := `
+		INSERT INTO Risk_list_entries(List_name, Value)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING;`
+
+	if _, err := repo.pool.Exec(ctx, query, list, value); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Удаляет значение из именованного списка
+func (repo *PostgresRiskRepo) RemoveListEntry(ctx context.Context, list, value string) error {
+	const op = "PostgresRiskRepo.RemoveListEntry"
+	query := `
+		DELETE FROM Risk_list_entries
+		WHERE List_name = $1 AND Value = $2;`
+
+	res, err := repo.pool.Exec(ctx, query, list, value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrRiskListEntryNotFound
+	}
+	return nil
+}
+
+// Возвращает, какие из значений values входят в списки lists: имя списка -> найденные значения
+func (repo *PostgresRiskRepo) ListMatches(ctx context.Context, lists, values []string) (map[string]map[string]bool, error) {
+	const op = "PostgresRiskRepo.ListMatches"
+	matches := make(map[string]map[string]bool, len(lists))
+	if len(lists) == 0 || len(values) == 0 {
+		return matches, nil
+	}
+
+	query := `
+		SELECT List_name, Value
+		FROM Risk_list_entries
+		WHERE List_name = ANY($1) AND Value = ANY($2);`
+
+	rows, err := repo.pool.Query(ctx, query, lists, values)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var list, value string
+		if err := rows.Scan(&list, &value); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if matches[list] == nil {
+			matches[list] = make(map[string]bool)
+		}
+		matches[list][value] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return matches, nil
+}
+
+// Считает платежи пользователя и адреса за последний час и сутки до now.
+// Сумма за сутки учитывает только платежи в валюте currency (валюте покупателя).
+func (repo *PostgresRiskRepo) Velocity(ctx context.Context, userID, clientIP, currency string, now time.Time) (models.RiskVelocity, error) {
+	const op = "PostgresRiskRepo.Velocity"
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE User_id = $1 AND Created_at >= $4 - INTERVAL '1 hour'),
+			COUNT(*) FILTER (WHERE User_id = $1),
+			COALESCE(SUM(Presentment_amount) FILTER (WHERE User_id = $1 AND Presentment_currency = $3), 0),
+			COUNT(*) FILTER (WHERE Client_ip = $2 AND Created_at >= $4 - INTERVAL '1 hour'),
+			COUNT(*) FILTER (WHERE Client_ip = $2)
+		FROM Transactions
+		WHERE (User_id = $1 OR Client_ip = NULLIF($2, ''))
+			AND Created_at >= $4 - INTERVAL '24 hours';`
+
+	var v models.RiskVelocity
+	if err := repo.pool.QueryRow(ctx, query, userID, clientIP, currency, now).
+		Scan(&v.UserCount1h, &v.UserCount24h, &v.UserAmount24h, &v.IPCount1h, &v.IPCount24h); err != nil {
+		return models.RiskVelocity{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,180 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSettlementRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresSettlementRepo(pool *pgxpool.Pool) *PostgresSettlementRepo {
+	return &PostgresSettlementRepo{pool: pool}
+}
+
+var ErrReconciliationNotFound = errors.New("reconciliation is not found")
+
+const settlementColumns = `
+	t.Payment_id,
+	t.Order_id,
+	t.Currency,
+	t.Current_status,
+	t.Amount,
+	t.Captured_amount,
+	t.Released_amount,
+	t.Refunded_amount,
+	t.Fee_amount`
+
+func scanSettlement(row pgx.CollectableRow) (models.LocalSettlement, error) {
+	var (
+		s        models.LocalSettlement
+		position models.PaymentPosition
+	)
+	err := row.Scan(&s.PaymentID, &s.OrderID, &s.Currency, &s.Status,
+		&position.Amount, &position.Captured, &position.Released, &position.Refunded, &position.Fees)
+	position.Status = s.Status
+	s.Settled = position.Settled()
+	return s, err
+}
+
+// Возвращает платежи, на которые ссылаются строки выписки
+func (repo *PostgresSettlementRepo) LocalSettlements(ctx context.Context, paymentIDs, orderIDs []string) ([]models.LocalSettlement, error) {
+	const op = "PostgresSettlementRepo.LocalSettlements"
+	query := `
+		SELECT ` + settlementColumns + `
+		FROM Transactions t
+		WHERE t.Payment_id = ANY($1) OR t.Order_id = ANY($2);`
+
+	rows, err := repo.pool.Query(ctx, query, paymentIDs, orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	settlements, err := pgx.CollectRows(rows, scanSettlement)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return settlements, nil
+}
+
+// Возвращает платежи за период, по которым банк должен был перечислить деньги
+func (repo *PostgresSettlementRepo) SettledPayments(ctx context.Context, from, to time.Time) ([]models.LocalSettlement, error) {
+	const op = "PostgresSettlementRepo.SettledPayments"
+	query := `
+		SELECT ` + settlementColumns + `
+		FROM Transactions t
+		WHERE t.Created_at >= $1 AND t.Created_at < $2
+			AND t.Current_status IN ('PARTIALLY_DEPOSITED', 'DEPOSITED', 'PARTIALLY_REFUNDED', 'REFUNDED')
+		ORDER BY t.Created_at;`
+
+	rows, err := repo.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	settlements, err := pgx.CollectRows(rows, scanSettlement)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return settlements, nil
+}
+
+// SaveReconciliation — сохраняет результат сверки вместе с расхождениями.
+func (repo *PostgresSettlementRepo) SaveReconciliation(ctx context.Context, rec models.Reconciliation) (_ models.Reconciliation, err error) {
+	const op = "PostgresSettlementRepo.SaveReconciliation"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return models.Reconciliation{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO Reconciliations(File_name, Period_start, Period_end, Lines, Matched)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING Reconciliation_id, Created_at;`
+	if err = tx.QueryRow(ctx, query, rec.FileName, rec.PeriodStart, rec.PeriodEnd, rec.Lines, rec.Matched).
+		Scan(&rec.ID, &rec.CreatedAt); err != nil {
+		return models.Reconciliation{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = `
+		INSERT INTO Reconciliation_discrepancies(Reconciliation_id, Kind, Payment_id, Order_id,
+			Bank_amount, Local_amount, Bank_status, Local_status)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, NULLIF($7, ''), NULLIF($8, ''));`
+	for _, d := range rec.Discrepancies {
+		if _, err = tx.Exec(ctx, query, rec.ID, d.Kind, d.PaymentID, d.OrderID,
+			d.BankAmount, d.LocalAmount, d.BankStatus, d.LocalStatus); err != nil {
+			return models.Reconciliation{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Reconciliation{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return rec, nil
+}
+
+// Получает результат сверки по ID
+func (repo *PostgresSettlementRepo) GetReconciliation(ctx context.Context, reconciliationID string) (*models.Reconciliation, error) {
+	const op = "PostgresSettlementRepo.GetReconciliation"
+	if !validUUID(reconciliationID) {
+		return nil, ErrReconciliationNotFound
+	}
+
+	rec := &models.Reconciliation{}
+	query := `
+		SELECT
+			Reconciliation_id,
+			File_name,
+			Period_start,
+			Period_end,
+			Lines,
+			Matched,
+			Created_at
+		FROM Reconciliations
+		WHERE Reconciliation_id = $1;`
+	if err := repo.pool.QueryRow(ctx, query, reconciliationID).Scan(
+		&rec.ID, &rec.FileName, &rec.PeriodStart, &rec.PeriodEnd, &rec.Lines, &rec.Matched, &rec.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReconciliationNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = `
+		SELECT
+			Kind,
+			COALESCE(Payment_id, ''),
+			COALESCE(Order_id, ''),
+			COALESCE(Bank_amount, 0),
+			COALESCE(Local_amount, 0),
+			COALESCE(Bank_status, ''),
+			COALESCE(Local_status, '')
+		FROM Reconciliation_discrepancies
+		WHERE Reconciliation_id = $1
+		ORDER BY Discrepancy_id;`
+	rows, err := repo.pool.Query(ctx, query, reconciliationID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	rec.Discrepancies, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Discrepancy, error) {
+		var d models.Discrepancy
+		err := row.Scan(&d.Kind, &d.PaymentID, &d.OrderID, &d.BankAmount, &d.LocalAmount, &d.BankStatus, &d.LocalStatus)
+		return d, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rec, nil
+}
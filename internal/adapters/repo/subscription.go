@@ -0,0 +1,360 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSubscriptionRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresSubscriptionRepo(pool *pgxpool.Pool) *PostgresSubscriptionRepo {
+	return &PostgresSubscriptionRepo{pool: pool}
+}
+
+var (
+	ErrPlanNotFound         = errors.New("plan is not found")
+	ErrSubscriptionNotFound = errors.New("subscription is not found")
+)
+
+const subscriptionColumns = `
+	Subscription_id, 
+	User_id, 
+	Plan_id, 
+	Binding_id, 
+	Status,
+	Current_period_start, 
+	Current_period_end, 
+	Next_charge_at,
+	Cycle, 
+	Failed_attempts, 
+	Credit, 
+	Cancel_at_period_end, 
+	Canceled_at, 
+	Created_at`
+
+func scanSubscription(row pgx.Row) (models.Subscription, error) {
+	var s models.Subscription
+	err := row.Scan(&s.ID, &s.UserID, &s.PlanID, &s.BindingID, &s.Status,
+		&s.CurrentPeriodStart, &s.CurrentPeriodEnd, &s.NextChargeAt,
+		&s.Cycle, &s.FailedAttempts, &s.Credit, &s.CancelAtPeriodEnd,
+		&s.CanceledAt, &s.CreatedAt)
+	return s, err
+}
+
+// Добавляет тарифный план
+func (repo *PostgresSubscriptionRepo) CreatePlan(ctx context.Context, plan models.Plan) (models.Plan, error) {
+	const op = "PostgresSubscriptionRepo.CreatePlan"
+	query := `
+		INSERT INTO Plans(Name, Amount, Currency, Interval_months, Trial_days, Active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING Plan_id, Created_at;`
+
+	if err := repo.pool.QueryRow(ctx, query,
+		plan.Name, plan.Amount, plan.Currency, plan.IntervalMonths, plan.TrialDays, plan.Active).
+		Scan(&plan.ID, &plan.CreatedAt); err != nil {
+		return models.Plan{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return plan, nil
+}
+
+// Получает тарифный план по ID
+func (repo *PostgresSubscriptionRepo) GetPlan(ctx context.Context, planID string) (*models.Plan, error) {
+	const op = "PostgresSubscriptionRepo.GetPlan"
+	if !validUUID(planID) {
+		return nil, ErrPlanNotFound
+	}
+	query := `
+		SELECT 
+			Plan_id, 
+			Name, 
+			Amount, 
+			Currency, 
+			Interval_months, 
+			Trial_days, 
+			Active, 
+			Created_at
+		FROM 
+			Plans
+		WHERE 
+			Plan_id = $1;`
+
+	var p models.Plan
+	if err := repo.pool.QueryRow(ctx, query, planID).
+		Scan(&p.ID, &p.Name, &p.Amount, &p.Currency, &p.IntervalMonths,
+			&p.TrialDays, &p.Active, &p.CreatedAt); err != nil {
+
+		if err == pgx.ErrNoRows {
+			return nil, ErrPlanNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &p, nil
+}
+
+// Возвращает тарифные планы (от старых к новым)
+func (repo *PostgresSubscriptionRepo) ListPlans(ctx context.Context, includeInactive bool) ([]models.Plan, error) {
+	const op = "PostgresSubscriptionRepo.ListPlans"
+	query := `
+		SELECT 
+			Plan_id, 
+			Name, 
+			Amount, 
+			Currency, 
+			Interval_months, 
+			Trial_days, 
+			Active, 
+			Created_at
+		FROM 
+			Plans
+		WHERE 
+			Active OR $1
+		ORDER BY 
+			Created_at ASC;`
+
+	rows, err := repo.pool.Query(ctx, query, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	plans, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Plan, error) {
+		var p models.Plan
+		err := row.Scan(&p.ID, &p.Name, &p.Amount, &p.Currency, &p.IntervalMonths,
+			&p.TrialDays, &p.Active, &p.CreatedAt)
+		return p, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to collect rows: %w", op, err)
+	}
+
+	return plans, nil
+}
+
+// Создаёт подписку и записывает событие о её создании в одной транзакции
+func (repo *PostgresSubscriptionRepo) CreateSubscription(ctx context.Context, sub models.Subscription, event models.SubscriptionEvent) (_ models.Subscription, err error) {
+	const op = "PostgresSubscriptionRepo.CreateSubscription"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		INSERT INTO Subscriptions(User_id, Plan_id, Binding_id, Status,
+			Current_period_start, Current_period_end, Next_charge_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING Subscription_id, Created_at;`
+	if err = tx.QueryRow(ctx, query,
+		sub.UserID, sub.PlanID, sub.BindingID, sub.Status,
+		sub.CurrentPeriodStart, sub.CurrentPeriodEnd, sub.NextChargeAt).
+		Scan(&sub.ID, &sub.CreatedAt); err != nil {
+		return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	event.SubscriptionID = sub.ID
+	if err = insertSubscriptionEvent(ctx, tx, event); err != nil {
+		return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return sub, nil
+}
+
+// Получает подписку по ID
+func (repo *PostgresSubscriptionRepo) GetSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
+	const op = "PostgresSubscriptionRepo.GetSubscription"
+	if !validUUID(subscriptionID) {
+		return nil, ErrSubscriptionNotFound
+	}
+	query := `SELECT ` + subscriptionColumns + `
+		FROM 
+			Subscriptions
+		WHERE 
+			Subscription_id = $1;`
+
+	sub, err := scanSubscription(repo.pool.QueryRow(ctx, query, subscriptionID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &sub, nil
+}
+
+// Сохраняет состояние подписки, попытку списания (если была) и событие в одной транзакции
+func (repo *PostgresSubscriptionRepo) UpdateSubscription(ctx context.Context, sub models.Subscription, charge *models.SubscriptionCharge, event models.SubscriptionEvent) (err error) {
+	const op = "PostgresSubscriptionRepo.UpdateSubscription"
+
+	tx, err := repo.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		UPDATE Subscriptions
+		SET 
+			Plan_id = $2,
+			Status = $3,
+			Current_period_start = $4,
+			Current_period_end = $5,
+			Next_charge_at = $6,
+			Cycle = $7,
+			Failed_attempts = $8,
+			Credit = $9,
+			Cancel_at_period_end = $10,
+			Canceled_at = $11
+		WHERE 
+			Subscription_id = $1;`
+	res, err := tx.Exec(ctx, query,
+		sub.ID, sub.PlanID, sub.Status,
+		sub.CurrentPeriodStart, sub.CurrentPeriodEnd, sub.NextChargeAt,
+		sub.Cycle, sub.FailedAttempts, sub.Credit,
+		sub.CancelAtPeriodEnd, sub.CanceledAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrSubscriptionNotFound
+	}
+
+	if charge != nil {
+		query = `
+			INSERT INTO Subscription_charges(Subscription_id, Payment_id, Order_id, Cycle, Attempt, Amount, Succeeded, Error)
+			VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, NULLIF($8, ''));`
+		_, err = tx.Exec(ctx, query,
+			charge.SubscriptionID, charge.PaymentID, charge.OrderID,
+			charge.Cycle, charge.Attempt, charge.Amount, charge.Succeeded, charge.Error)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = insertSubscriptionEvent(ctx, tx, event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Захватывает подписки, по которым наступил срок списания.
+// Срок следующего списания сдвигается на leaseUntil, поэтому параллельные
+// обработчики не получат те же подписки, пока текущий не сохранит результат.
+func (repo *PostgresSubscriptionRepo) ClaimDueSubscriptions(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.Subscription, error) {
+	const op = "PostgresSubscriptionRepo.ClaimDueSubscriptions"
+	query := `
+		UPDATE Subscriptions
+		SET Next_charge_at = $2
+		WHERE Subscription_id IN (
+			SELECT Subscription_id
+			FROM Subscriptions
+			WHERE Status <> 'CANCELED' AND Next_charge_at <= $1
+			ORDER BY Next_charge_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + subscriptionColumns + `;`
+
+	rows, err := repo.pool.Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	subs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Subscription, error) {
+		return scanSubscription(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to collect rows: %w", op, err)
+	}
+
+	return subs, nil
+}
+
+// Возвращает события подписки (от старых к новым)
+func (repo *PostgresSubscriptionRepo) ListSubscriptionEvents(ctx context.Context, subscriptionID string) ([]models.SubscriptionEvent, error) {
+	const op = "PostgresSubscriptionRepo.ListSubscriptionEvents"
+	if !validUUID(subscriptionID) {
+		return nil, ErrSubscriptionNotFound
+	}
+	query := `
+		SELECT 
+			Event_id, 
+			Subscription_id, 
+			Type, 
+			COALESCE(Payment_id, ''), 
+			Amount, 
+			COALESCE(Reason, ''), 
+			Created_at
+		FROM 
+			Subscription_events
+		WHERE 
+			Subscription_id = $1
+		ORDER BY 
+			Event_id ASC;`
+
+	rows, err := repo.pool.Query(ctx, query, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.SubscriptionEvent, error) {
+		var e models.SubscriptionEvent
+		err := row.Scan(&e.ID, &e.SubscriptionID, &e.Type, &e.PaymentID, &e.Amount, &e.Reason, &e.CreatedAt)
+		return e, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to collect rows: %w", op, err)
+	}
+
+	return events, nil
+}
+
+func insertSubscriptionEvent(ctx context.Context, db execer, event models.SubscriptionEvent) error {
+	query := `
+		INSERT INTO Subscription_events(Subscription_id, Type, Payment_id, Amount, Reason)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, ''));`
+	_, err := db.Exec(ctx, query,
+		event.SubscriptionID, event.Type, event.PaymentID, event.Amount, event.Reason)
+	return err
+}
+
+// validUUID проверяет формат UUID: некорректный ID означает «не найдено», а не ошибку БД
+func validUUID(id string) bool {
+	if len(id) != 36 {
+		return false
+	}
+	for i, c := range id {
+		switch {
+		case i == 8 || i == 13 || i == 18 || i == 23:
+			if c != '-' {
+				return false
+			}
+		case '0' <= c && c <= '9', 'a' <= c && c <= 'f', 'A' <= c && c <= 'F':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,496 @@
+// Package risk — язык правил проверки риска платежей.
+//
+// Правило — логическое выражение над фактами платежа:
+//
+//	currency == "KZT" and amount >= 500000
+//	user_count_1h >= 5 or ip_count_24h > 20
+//	user_id in list("blocked_users") or operation in ("CARD_payment", "WALLET_payment")
+//	not (ip in list("trusted_ips"))
+//
+// Поддерживаются сравнения ==, !=, <, <=, >, >=, проверка вхождения в именованный список
+// (list), в перечень значений, связки and, or, not и скобки. Типы фактов проверяются при компиляции.
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidExpression = errors.New("invalid risk rule expression")
+
+// Факты платежа, доступные в выражениях
+const (
+	FactAmount        = "amount"
+	FactCurrency      = "currency"
+	FactUserID        = "user_id"
+	FactIP            = "ip"
+	FactOperation     = "operation"
+	FactOrderID       = "order_id"
+	FactUserCount1h   = "user_count_1h"
+	FactUserCount24h  = "user_count_24h"
+	FactUserAmount24h = "user_amount_24h"
+	FactIPCount1h     = "ip_count_1h"
+	FactIPCount24h    = "ip_count_24h"
+)
+
+type valueType int
+
+const (
+	typeNumber valueType = iota
+	typeString
+)
+
+func (t valueType) String() string {
+	if t == typeNumber {
+		return "number"
+	}
+	return "string"
+}
+
+// factTypes — типы фактов платежа
+var factTypes = map[string]valueType{
+	FactAmount:        typeNumber,
+	FactCurrency:      typeString,
+	FactUserID:        typeString,
+	FactIP:            typeString,
+	FactOperation:     typeString,
+	FactOrderID:       typeString,
+	FactUserCount1h:   typeNumber,
+	FactUserCount24h:  typeNumber,
+	FactUserAmount24h: typeNumber,
+	FactIPCount1h:     typeNumber,
+	FactIPCount24h:    typeNumber,
+}
+
+// Env — факты проверяемого платежа и содержимое именованных списков.
+type Env struct {
+	Numbers map[string]float64
+	Strings map[string]string
+	Lists   map[string]map[string]bool // Имя списка -> значения, найденные среди фактов платежа
+}
+
+// Program — скомпилированное выражение правила.
+type Program struct {
+	root  node
+	lists []string
+}
+
+// Lists — имена списков, на которые ссылается выражение.
+func (p *Program) Lists() []string {
+	return p.lists
+}
+
+// Eval вычисляет выражение для фактов платежа.
+func (p *Program) Eval(env Env) bool {
+	return p.root.eval(env)
+}
+
+// Compile разбирает выражение правила и проверяет типы фактов.
+func Compile(expr string) (*Program, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, lists: map[string]bool{}}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	}
+
+	prog := &Program{root: root}
+	for name := range p.lists {
+		prog.lists = append(prog.lists, name)
+	}
+	return prog, nil
+}
+
+// Лексемы
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokComma, ",", i})
+			i++
+
+		case strings.ContainsRune("=!<>", r):
+			start := i
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+			op := string(runes[start:i])
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("%w: position %d: unknown operator %q", ErrInvalidExpression, start+1, op)
+			}
+			tokens = append(tokens, token{tokOp, op, start})
+
+		case r == '"':
+			start := i
+			i++
+			var sb strings.Builder
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("%w: position %d: unterminated string", ErrInvalidExpression, start+1)
+			}
+			i++
+			tokens = append(tokens, token{tokString, sb.String(), start})
+
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokNumber, string(runes[start:i]), start})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, string(runes[start:i]), start})
+
+		default:
+			return nil, fmt.Errorf("%w: position %d: unexpected character %q", ErrInvalidExpression, i+1, r)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, text: "end of expression", pos: len(runes)}), nil
+}
+
+// Разбор
+
+type parser struct {
+	tokens []token
+	pos    int
+	lists  map[string]bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) keyword(word string) bool {
+	tok := p.peek()
+	if tok.kind == tokIdent && strings.EqualFold(tok.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, p.errorf(tok, "expected %s, got %q", what, tok.text)
+	}
+	return tok, nil
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return fmt.Errorf("%w: position %d: %s", ErrInvalidExpression, tok.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.keyword("not") {
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	}
+
+	if p.peek().kind == tokLParen {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	}
+
+	return p.parseCondition()
+}
+
+// parseCondition разбирает сравнение или проверку вхождения.
+func (p *parser) parseCondition() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.keyword("in") {
+		if p.keyword("list") {
+			return p.parseInList(left)
+		}
+		return p.parseInSet(left)
+	}
+
+	tok := p.next()
+	if tok.kind != tokOp {
+		return nil, p.errorf(tok, "expected comparison operator, got %q", tok.text)
+	}
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if left.typ != right.typ {
+		return nil, p.errorf(tok, "cannot compare %s with %s", left.typ, right.typ)
+	}
+	if left.typ == typeString && tok.text != "==" && tok.text != "!=" {
+		return nil, p.errorf(tok, "operator %q is not defined for strings", tok.text)
+	}
+	return compareNode{op: tok.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseInList(left operand) (node, error) {
+	if _, err := p.expect(tokLParen, `"("`); err != nil {
+		return nil, err
+	}
+	name, err := p.expect(tokString, "list name")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokRParen, `")"`); err != nil {
+		return nil, err
+	}
+	if left.fact == "" || left.typ != typeString {
+		return nil, p.errorf(name, "left side of list check must be a string fact")
+	}
+
+	p.lists[name.text] = true
+	return inListNode{value: left, list: name.text}, nil
+}
+
+func (p *parser) parseInSet(left operand) (node, error) {
+	if _, err := p.expect(tokLParen, `"(" or list(...)`); err != nil {
+		return nil, err
+	}
+
+	var values []operand
+	for {
+		tok := p.peek()
+		value, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if value.fact != "" {
+			return nil, p.errorf(tok, "set must contain literals only")
+		}
+		if value.typ != left.typ {
+			return nil, p.errorf(tok, "cannot compare %s with %s", left.typ, value.typ)
+		}
+		values = append(values, value)
+
+		if p.peek().kind != tokComma {
+			break
+		}
+		p.next()
+	}
+	if _, err := p.expect(tokRParen, `")"`); err != nil {
+		return nil, err
+	}
+	return inSetNode{value: left, set: values}, nil
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokIdent:
+		name := strings.ToLower(tok.text)
+		typ, ok := factTypes[name]
+		if !ok {
+			return operand{}, p.errorf(tok, "unknown fact %q", tok.text)
+		}
+		return operand{fact: name, typ: typ}, nil
+
+	case tokNumber:
+		num, err := strconv.ParseFloat(strings.ReplaceAll(tok.text, "_", ""), 64)
+		if err != nil {
+			return operand{}, p.errorf(tok, "invalid number %q", tok.text)
+		}
+		return operand{typ: typeNumber, num: num}, nil
+
+	case tokString:
+		return operand{typ: typeString, str: tok.text}, nil
+
+	default:
+		return operand{}, p.errorf(tok, "expected fact or value, got %q", tok.text)
+	}
+}
+
+// Вычисление
+
+type node interface {
+	eval(env Env) bool
+}
+
+// operand — факт платежа или литерал
+type operand struct {
+	fact string
+	typ  valueType
+	num  float64
+	str  string
+}
+
+func (o operand) number(env Env) float64 {
+	if o.fact != "" {
+		return env.Numbers[o.fact]
+	}
+	return o.num
+}
+
+func (o operand) string(env Env) string {
+	if o.fact != "" {
+		return env.Strings[o.fact]
+	}
+	return o.str
+}
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(env Env) bool { return n.left.eval(env) || n.right.eval(env) }
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(env Env) bool { return n.left.eval(env) && n.right.eval(env) }
+
+type notNode struct{ inner node }
+
+func (n notNode) eval(env Env) bool { return !n.inner.eval(env) }
+
+type compareNode struct {
+	op          string
+	left, right operand
+}
+
+func (n compareNode) eval(env Env) bool {
+	if n.left.typ == typeString {
+		equal := n.left.string(env) == n.right.string(env)
+		return equal == (n.op == "==")
+	}
+
+	l, r := n.left.number(env), n.right.number(env)
+	switch n.op {
+	case "==":
+		return l == r
+	case "!=":
+		return l != r
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+type inListNode struct {
+	value operand
+	list  string
+}
+
+func (n inListNode) eval(env Env) bool {
+	return env.Lists[n.list][n.value.string(env)]
+}
+
+type inSetNode struct {
+	value operand
+	set   []operand
+}
+
+func (n inSetNode) eval(env Env) bool {
+	for _, item := range n.set {
+		if (compareNode{op: "==", left: n.value, right: item}).eval(env) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package risk
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func testEnv() Env {
+	return Env{
+		Numbers: map[string]float64{
+			FactAmount:        750000,
+			FactUserCount1h:   5,
+			FactUserCount24h:  12,
+			FactUserAmount24h: 1200000,
+			FactIPCount1h:     1,
+			FactIPCount24h:    21,
+		},
+		Strings: map[string]string{
+			FactCurrency:  "KZT",
+			FactUserID:    "u1",
+			FactIP:        "203.0.113.7",
+			FactOperation: "CARD_payment",
+			FactOrderID:   "order-1",
+		},
+		Lists: map[string]map[string]bool{
+			"blocked_users": {"u1": true},
+			"trusted_ips":   {"198.51.100.1": true},
+		},
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`currency == "KZT" and amount >= 500000`, true},
+		{`currency == "KZT" and amount >= 1_000_000`, false},
+		{`currency != "KZT"`, false},
+		{`amount > 750000`, false},
+		{`amount < 750000.01`, true},
+		{`amount <= 750000`, true},
+		{`amount == 750000`, true},
+		{`amount != 750000`, false},
+		{`user_count_1h >= 5 or ip_count_24h > 20`, true},
+		{`user_count_1h > 5 or ip_count_24h > 21`, false},
+		{`user_id in list("blocked_users")`, true},
+		{`ip in list("trusted_ips")`, false},
+		{`not (ip in list("trusted_ips"))`, true},
+		{`user_id in list("unknown_list")`, false},
+		{`operation in ("CARD_payment", "WALLET_payment")`, true},
+		{`operation in ("URL_payment")`, false},
+		{`user_count_24h in (10, 12)`, true},
+		{`"KZT" == currency`, true},
+		{`1 < 2`, true},
+		// and связывает сильнее or
+		{`amount > 1 or amount > 1000000 and currency == "USD"`, true},
+		{`(amount > 1 or amount > 1000000) and currency == "USD"`, false},
+		{`not not amount > 1`, true},
+		{`NOT currency == "USD" AND user_id IN LIST("blocked_users")`, true},
+		{`CURRENCY == "KZT"`, true},
+		{`order_id == "order-\"1"`, false},
+		{`user_amount_24h >= 1200000 and ip_count_1h == 1`, true},
+	}
+
+	env := testEnv()
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			prog, err := Compile(tt.expr)
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+			if got := prog.Eval(env); got != tt.want {
+				t.Fatalf("Eval = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvalMissingFacts(t *testing.T) {
+	prog, err := Compile(`amount == 0 and currency == "" and not (user_id in list("blocked_users"))`)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	if !prog.Eval(Env{}) {
+		t.Fatal("Eval with empty environment = false, want zero values")
+	}
+}
+
+func TestCompileLists(t *testing.T) {
+	prog, err := Compile(`user_id in list("blocked_users") or ip in list("bad_ips") or user_id in list("blocked_users")`)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	lists := prog.Lists()
+	slices.Sort(lists)
+	if !slices.Equal(lists, []string{"bad_ips", "blocked_users"}) {
+		t.Fatalf("Lists = %v, want [bad_ips blocked_users]", lists)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"empty", ``},
+		{"unknown fact", `country == "KZ"`},
+		{"type mismatch", `amount == "100"`},
+		{"string ordering", `currency > "KZT"`},
+		{"single equals", `amount = 100`},
+		{"bang", `! amount > 1`},
+		{"unterminated string", `currency == "KZT`},
+		{"unexpected character", `amount > 1 && amount < 2`},
+		{"trailing tokens", `amount > 1 amount`},
+		{"missing operand", `amount >`},
+		{"missing operator", `amount`},
+		{"unbalanced paren", `(amount > 1`},
+		{"extra paren", `amount > 1)`},
+		{"bad number", `amount > 1.2.3`},
+		{"list of number", `amount in list("amounts")`},
+		{"list of literal", `"u1" in list("blocked_users")`},
+		{"list name not a string", `user_id in list(blocked_users)`},
+		{"set with fact", `currency in (currency)`},
+		{"set type mismatch", `currency in ("KZT", 398)`},
+		{"empty set", `currency in ()`},
+		{"dangling and", `amount > 1 and`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Compile(tt.expr); !errors.Is(err, ErrInvalidExpression) {
+				t.Fatalf("Compile(%q) error = %v, want ErrInvalidExpression", tt.expr, err)
+			}
+		})
+	}
+}
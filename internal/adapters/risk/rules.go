@@ -0,0 +1,79 @@
+package risk
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"payment/internal/domain/models"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidRules = errors.New("invalid risk rules file")
+
+// ruleName — допустимое имя правила
+var ruleName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]{0,99}$`)
+
+// ParseRules читает правила из файла конфигурации, по одному на строку:
+//
+//	# решение имя: выражение
+//	deny   blocked_user: user_id in list("blocked_users")
+//	review large_kzt:    currency == "KZT" and amount >= 500000
+//	allow  trusted_user: user_id in list("trusted_users")
+//
+// Пустые строки и строки, начинающиеся с #, пропускаются.
+func ParseRules(r io.Reader) ([]models.RiskRule, error) {
+	var rules []models.RiskRule
+	names := make(map[string]bool)
+
+	sc := bufio.NewScanner(r)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		head, expr, ok := strings.Cut(line, ":")
+		fields := strings.Fields(head)
+		if !ok || len(fields) != 2 {
+			return nil, fmt.Errorf("%w: line %d: expected \"<decision> <name>: <expression>\"", ErrInvalidRules, lineNo)
+		}
+
+		rule := models.RiskRule{
+			Name:       fields[1],
+			Decision:   models.RiskDecision(strings.ToUpper(fields[0])),
+			Expression: strings.TrimSpace(expr),
+			Enabled:    true,
+			Source:     models.RiskSourceConfig,
+		}
+		if err := ValidateRule(rule); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidRules, lineNo, err)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("%w: line %d: duplicate rule %q", ErrInvalidRules, lineNo, rule.Name)
+		}
+		names[rule.Name] = true
+
+		rules = append(rules, rule)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRules, err)
+	}
+
+	return rules, nil
+}
+
+// ValidateRule проверяет имя, решение и выражение правила.
+func ValidateRule(rule models.RiskRule) error {
+	if !ruleName.MatchString(rule.Name) {
+		return fmt.Errorf("%w: rule name %q must start with a letter and contain letters, digits, '_', '.', '-'", ErrInvalidExpression, rule.Name)
+	}
+	if !models.IsRiskDecisionSupported(rule.Decision) {
+		return fmt.Errorf("%w: decision %q must be allow, review or deny", ErrInvalidExpression, rule.Decision)
+	}
+	if _, err := Compile(rule.Expression); err != nil {
+		return err
+	}
+	return nil
+}
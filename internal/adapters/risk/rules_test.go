@@ -0,0 +1,62 @@
+package risk
+
+import (
+	"errors"
+	"payment/internal/domain/models"
+	"strings"
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+	input := `
+# решение имя: выражение
+deny   blocked_user: user_id in list("blocked_users")
+
+review large_kzt:    currency == "KZT" and amount >= 500000
+ALLOW  trusted.user-1: user_id in list("trusted_users")
+`
+	rules, err := ParseRules(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+
+	want := []models.RiskRule{
+		{Name: "blocked_user", Decision: models.RiskDeny, Expression: `user_id in list("blocked_users")`},
+		{Name: "large_kzt", Decision: models.RiskReview, Expression: `currency == "KZT" and amount >= 500000`},
+		{Name: "trusted.user-1", Decision: models.RiskAllow, Expression: `user_id in list("trusted_users")`},
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("rules = %+v, want %d rules", rules, len(want))
+	}
+	for i, w := range want {
+		r := rules[i]
+		if r.Name != w.Name || r.Decision != w.Decision || r.Expression != w.Expression {
+			t.Fatalf("rule %d = %+v, want %+v", i, r, w)
+		}
+		if !r.Enabled || r.Source != models.RiskSourceConfig {
+			t.Fatalf("rule %d = %+v, want enabled config rule", i, r)
+		}
+	}
+}
+
+func TestParseRulesInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"no colon", `deny blocked user_id in list("x")`},
+		{"no name", `deny: amount > 1`},
+		{"unknown decision", `block big: amount > 1`},
+		{"bad name", `deny 1st: amount > 1`},
+		{"bad expression", `deny big: amount > "1"`},
+		{"duplicate", "deny big: amount > 1\nreview big: amount > 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRules(strings.NewReader(tt.input)); !errors.Is(err, ErrInvalidRules) {
+				t.Fatalf("ParseRules error = %v, want ErrInvalidRules", err)
+			}
+		})
+	}
+}
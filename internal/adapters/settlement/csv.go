@@ -0,0 +1,195 @@
+// Package settlement разбирает файлы выписок банка (settlement reports) для сверки.
+package settlement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"payment/internal/domain/models"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidMapping = errors.New("invalid settlement column mapping")
+	ErrInvalidFile    = errors.New("invalid settlement file")
+)
+
+// Поля сопоставления колонок в спецификации "поле=Заголовок,..."
+const (
+	fieldPaymentID = "payment_id"
+	fieldOrderID   = "order_id"
+	fieldAmount    = "amount"
+	fieldCurrency  = "currency"
+	fieldStatus    = "status"
+)
+
+// ParseCSV читает выписку с заголовком в первой строке.
+func ParseCSV(r io.Reader, mapping models.ColumnMapping) ([]models.SettlementLine, error) {
+	if mapping.Amount == "" || (mapping.PaymentID == "" && mapping.OrderID == "") {
+		return nil, fmt.Errorf("%w: amount and payment_id or order_id columns are required", ErrInvalidMapping)
+	}
+
+	cr := csv.NewReader(r)
+	if mapping.Delimiter != 0 {
+		cr.Comma = mapping.Delimiter
+	}
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrInvalidFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+
+	// Отсутствующая в файле колонка пропускается, кроме суммы и ссылки на платёж
+	index := func(name string) int {
+		if i, ok := columns[name]; ok && name != "" {
+			return i
+		}
+		return -1
+	}
+
+	paymentIdx, orderIdx := index(mapping.PaymentID), index(mapping.OrderID)
+	amountIdx, currencyIdx, statusIdx := index(mapping.Amount), index(mapping.Currency), index(mapping.Status)
+	if amountIdx < 0 {
+		return nil, fmt.Errorf("%w: column %q is not found", ErrInvalidFile, mapping.Amount)
+	}
+	if paymentIdx < 0 && orderIdx < 0 {
+		return nil, fmt.Errorf("%w: columns %q and %q are not found", ErrInvalidFile, mapping.PaymentID, mapping.OrderID)
+	}
+
+	var lines []models.SettlementLine
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// csv.ParseError уже содержит номер строки
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+		}
+		if isBlank(record) {
+			continue
+		}
+		// Номер строки в файле: пустые строки csv.Reader пропускает сам
+		lineNo, _ := cr.FieldPos(0)
+
+		field := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		line := models.SettlementLine{
+			Line:      lineNo,
+			PaymentID: field(paymentIdx),
+			OrderID:   field(orderIdx),
+			Currency:  strings.ToUpper(field(currencyIdx)),
+		}
+		if line.PaymentID == "" && line.OrderID == "" {
+			return nil, fmt.Errorf("%w: line %d: payment_id and order_id are empty", ErrInvalidFile, lineNo)
+		}
+
+		if line.Amount, err = parseAmount(field(amountIdx), mapping.MinorUnits); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidFile, lineNo, err)
+		}
+
+		if status := field(statusIdx); status != "" {
+			if mapped, ok := mapping.Statuses[status]; ok {
+				line.Status = mapped
+			} else {
+				line.Status = models.StatusType(strings.ToUpper(status))
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// ParseColumnMapping разбирает спецификацию "поле=Заголовок,..." поверх сопоставления по умолчанию.
+// Пустой заголовок ("status=") отключает колонку.
+func ParseColumnMapping(spec string) (models.ColumnMapping, error) {
+	mapping := models.DefaultColumnMapping()
+	for _, pair := range splitSpec(spec) {
+		field, column, ok := strings.Cut(pair, "=")
+		if !ok {
+			return models.ColumnMapping{}, fmt.Errorf("%w: %q must be field=column", ErrInvalidMapping, pair)
+		}
+
+		switch strings.TrimSpace(field) {
+		case fieldPaymentID:
+			mapping.PaymentID = strings.TrimSpace(column)
+		case fieldOrderID:
+			mapping.OrderID = strings.TrimSpace(column)
+		case fieldAmount:
+			mapping.Amount = strings.TrimSpace(column)
+		case fieldCurrency:
+			mapping.Currency = strings.TrimSpace(column)
+		case fieldStatus:
+			mapping.Status = strings.TrimSpace(column)
+		default:
+			return models.ColumnMapping{}, fmt.Errorf("%w: unknown field %q", ErrInvalidMapping, field)
+		}
+	}
+	return mapping, nil
+}
+
+// ParseStatusMap разбирает спецификацию "СТАТУС_БАНКА=СТАТУС,..." для сопоставления статусов выписки.
+func ParseStatusMap(spec string) (map[string]models.StatusType, error) {
+	statuses := make(map[string]models.StatusType)
+	for _, pair := range splitSpec(spec) {
+		bankStatus, status, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q must be bank_status=status", ErrInvalidMapping, pair)
+		}
+
+		st := models.StatusType(strings.ToUpper(strings.TrimSpace(status)))
+		if !models.IsStatusSupported(st) {
+			return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidMapping, status)
+		}
+		statuses[strings.TrimSpace(bankStatus)] = st
+	}
+	return statuses, nil
+}
+
+// parseAmount разбирает сумму с точкой или запятой в качестве десятичного разделителя.
+func parseAmount(value string, minorUnits bool) (float64, error) {
+	value = strings.ReplaceAll(strings.ReplaceAll(value, " ", ""), ",", ".")
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount %q is not a number", value)
+	}
+	if minorUnits {
+		amount /= 100
+	}
+	return models.RoundAmount(amount), nil
+}
+
+func splitSpec(spec string) []string {
+	var parts []string
+	for _, part := range strings.Split(spec, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func isBlank(record []string) bool {
+	for _, f := range record {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package settlement
+
+import (
+	"errors"
+	"payment/internal/domain/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	custom := models.ColumnMapping{
+		PaymentID:  "Номер заказа",
+		Amount:     "Сумма",
+		Currency:   "Валюта",
+		Status:     "Статус",
+		Delimiter:  ';',
+		MinorUnits: true,
+		Statuses:   map[string]models.StatusType{"Оплачен": models.OrderDeposited},
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		mapping models.ColumnMapping
+		want    []models.SettlementLine
+		wantErr error
+	}{
+		{
+			name: "default columns",
+			file: "payment_id,order_id,amount,currency,status\n" +
+				"bank-1,order-1,1000.50,kzt,deposited\n" +
+				"bank-1,order-1,-200,KZT,refunded\n",
+			mapping: models.DefaultColumnMapping(),
+			want: []models.SettlementLine{
+				{Line: 2, PaymentID: "bank-1", OrderID: "order-1", Amount: 1000.5, Currency: "KZT", Status: models.OrderDeposited},
+				{Line: 3, PaymentID: "bank-1", OrderID: "order-1", Amount: -200, Currency: "KZT", Status: models.OrderRefunded},
+			},
+		},
+		{
+			name: "custom headers, delimiter, minor units and status map",
+			file: "\ufeffНомер заказа;Сумма;Валюта;Статус\n" +
+				"bank-1;100050;KZT;Оплачен\n" +
+				"\n" +
+				"bank-2;1 000;KZT;Declined\n",
+			mapping: custom,
+			want: []models.SettlementLine{
+				{Line: 2, PaymentID: "bank-1", Amount: 1000.5, Currency: "KZT", Status: models.OrderDeposited},
+				{Line: 4, PaymentID: "bank-2", Amount: 10, Currency: "KZT", Status: models.OrderDeclined},
+			},
+		},
+		{
+			name:    "decimal comma and rounding",
+			file:    "order_id;amount\norder-1;\"12,345\"\norder-2;0,005\n",
+			mapping: models.ColumnMapping{OrderID: "order_id", Amount: "amount", Delimiter: ';'},
+			want: []models.SettlementLine{
+				{Line: 2, OrderID: "order-1", Amount: 12.35},
+				{Line: 3, OrderID: "order-2", Amount: 0.01},
+			},
+		},
+		{
+			name:    "missing optional columns",
+			file:    "order_id,amount\norder-1,5\n",
+			mapping: models.DefaultColumnMapping(),
+			want:    []models.SettlementLine{{Line: 2, OrderID: "order-1", Amount: 5}},
+		},
+		{
+			name:    "mapping without amount",
+			file:    "payment_id\nbank-1\n",
+			mapping: models.ColumnMapping{PaymentID: "payment_id"},
+			wantErr: ErrInvalidMapping,
+		},
+		{
+			name:    "amount column is absent",
+			file:    "payment_id,sum\nbank-1,5\n",
+			mapping: models.DefaultColumnMapping(),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "payment reference columns are absent",
+			file:    "id,amount\nbank-1,5\n",
+			mapping: models.DefaultColumnMapping(),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "row without payment reference",
+			file:    "payment_id,order_id,amount\n,,5\n",
+			mapping: models.DefaultColumnMapping(),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "amount is not a number",
+			file:    "payment_id,amount\nbank-1,ten\n",
+			mapping: models.DefaultColumnMapping(),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "broken quoting",
+			file:    "payment_id,amount\nbank-1,\"5\n",
+			mapping: models.DefaultColumnMapping(),
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "empty file",
+			mapping: models.DefaultColumnMapping(),
+			wantErr: ErrInvalidFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCSV(strings.NewReader(tt.file), tt.mapping)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCSV error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseCSV = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseColumnMapping(t *testing.T) {
+	mapping, err := ParseColumnMapping("payment_id=ID, amount = Сумма, status=")
+	if err != nil {
+		t.Fatalf("ParseColumnMapping: %v", err)
+	}
+	want := models.DefaultColumnMapping()
+	want.PaymentID, want.Amount, want.Status = "ID", "Сумма", ""
+	if !reflect.DeepEqual(mapping, want) {
+		t.Fatalf("ParseColumnMapping = %+v, want %+v", mapping, want)
+	}
+
+	for _, spec := range []string{"payment_id", "fee=Комиссия"} {
+		if _, err := ParseColumnMapping(spec); !errors.Is(err, ErrInvalidMapping) {
+			t.Errorf("ParseColumnMapping(%q) error = %v, want ErrInvalidMapping", spec, err)
+		}
+	}
+}
+
+func TestParseStatusMap(t *testing.T) {
+	statuses, err := ParseStatusMap("Оплачен=deposited, Возврат = REFUNDED")
+	if err != nil {
+		t.Fatalf("ParseStatusMap: %v", err)
+	}
+	want := map[string]models.StatusType{"Оплачен": models.OrderDeposited, "Возврат": models.OrderRefunded}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Fatalf("ParseStatusMap = %v, want %v", statuses, want)
+	}
+
+	for _, spec := range []string{"Оплачен", "Оплачен=PAID"} {
+		if _, err := ParseStatusMap(spec); !errors.Is(err, ErrInvalidMapping) {
+			t.Errorf("ParseStatusMap(%q) error = %v, want ErrInvalidMapping", spec, err)
+		}
+	}
+}
@@ -2,13 +2,22 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"payment/config"
 	"payment/internal/adapters/broker/bereke"
+	"payment/internal/adapters/export"
+	"payment/internal/adapters/fiscal"
+	"payment/internal/adapters/fx"
 	grpcserver "payment/internal/adapters/grpc"
+	"payment/internal/adapters/receipt"
 	"payment/internal/adapters/repo"
+	"payment/internal/adapters/risk"
 	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
 	"payment/internal/service"
 	"payment/pkg/logger"
 	"payment/pkg/postgres"
@@ -18,46 +27,203 @@ import (
 )
 
 type App struct {
-	postgresDB *postgres.API
-	gRPC       *grpcserver.API
-	log        logger.Logger
+	postgresDB    *postgres.API
+	gRPC          *grpcserver.API
+	subscriptions *service.SubscriptionService
+	billing       config.Billing
+	stopBilling   context.CancelFunc
+	receipts      *service.ReceiptService
+	receiptsCfg   config.Receipts
+	stopReceipts  context.CancelFunc
+	log           logger.Logger
+}
+
+// Core — общие зависимости, используемые gRPC сервером и административным CLI.
+type Core struct {
+	DB            *postgres.API
+	Repo          ports.PaymentRepo
+	Service       ports.PaymentService
+	Subscriptions *service.SubscriptionService
+	Invoices      *service.InvoiceService
+	Ledger        *service.LedgerService
+	Settlements   *service.SettlementService
+	Fees          *service.FeeService
+	Limits        *service.LimitService
+	Currencies    *service.CurrencyService
+	Risk          *service.RiskService
+	Receipts      *service.ReceiptService
+	Exports       *service.ExportService
 }
 
 func New(ctx context.Context, cfg config.Config, log logger.Logger) *App {
+	core := NewCore(ctx, cfg, log)
+	gRPCserver := grpcserver.New(ctx, cfg.Server, grpcserver.Services{
+		Payments:      core.Service,
+		Subscriptions: core.Subscriptions,
+		Invoices:      core.Invoices,
+		Ledger:        core.Ledger,
+		Settlements:   core.Settlements,
+		Fees:          core.Fees,
+		Limits:        core.Limits,
+		Currencies:    core.Currencies,
+		Receipts:      core.Receipts,
+		Exports:       core.Exports,
+	}, log)
+
+	return &App{
+		log:           log,
+		postgresDB:    core.DB,
+		gRPC:          gRPCserver,
+		subscriptions: core.Subscriptions,
+		billing:       cfg.Billing,
+		receipts:      core.Receipts,
+		receiptsCfg:   cfg.Receipts,
+	}
+}
+
+func NewCore(ctx context.Context, cfg config.Config, log logger.Logger) *Core {
 	db, err := postgres.New(ctx, cfg.Postgres)
 	if err != nil {
 		log.Fatal(ctx, action.ServiceStartFail, err, "Failed to connect to the database")
 	}
 	log.Info(ctx, action.DbConnected, "Database connection has been estabilished")
 
-	brokerMerchant, err := bereke.NewClient(cfg.Broker.Login, cfg.Broker.Password, types.Mode(cfg.Broker.Mode))
+	brokerMerchant, err := bereke.NewClient(cfg.Broker.Login, cfg.Broker.Password, types.Mode(cfg.Broker.Mode), bereke.Options{
+		APIURL:           cfg.Broker.APIURL,
+		BindingReturnURL: cfg.Broker.BindingReturnURL,
+	})
 	if err != nil {
 		log.Fatal(ctx, action.ServiceStartFail, err, "Failed to create broker merchant")
 	}
 	log.Info(ctx, action.DbConnected, "Merchant broker has been created")
 
+	currencyRepo := repo.NewPostgresCurrencyRepo(db.Pool)
+	var rates ports.RateProvider = currencyRepo
+	if cfg.FX.RatesFile != "" {
+		if rates, err = fx.NewFileProvider(cfg.FX.RatesFile); err != nil {
+			log.Fatal(ctx, action.ServiceStartFail, err, "Failed to load fx rates file")
+		}
+	}
+	currencyService := service.NewCurrencyService(currencyRepo, rates, cfg.Broker.Login, cfg.FX.SettlementCurrency, cfg.FX.MaxRateAge, log)
+
+	var riskRules []models.RiskRule
+	if cfg.Risk.RulesFile != "" {
+		if riskRules, err = loadRiskRules(cfg.Risk.RulesFile); err != nil {
+			log.Fatal(ctx, action.ServiceStartFail, err, "Failed to load risk rules file")
+		}
+	}
+	riskService := service.NewRiskService(repo.NewPostgresRiskRepo(db.Pool), riskRules, log)
+
+	redirectHosts, err := models.ParseRedirectHosts(cfg.Redirect.AllowedHosts)
+	if err != nil {
+		log.Fatal(ctx, action.ServiceStartFail, err, "Failed to parse REDIRECT_ALLOWED_HOSTS")
+	}
+	if len(redirectHosts) == 0 {
+		log.Warn(ctx, action.ServiceSetup, "REDIRECT_ALLOWED_HOSTS is empty: payment page redirects are allowed only to hosts from Merchant_redirect_hosts")
+	}
+	redirectService := service.NewRedirectService(repo.NewPostgresRedirectRepo(db.Pool), cfg.Broker.Login,
+		cfg.Redirect.ReturnURL, cfg.Redirect.FailURL, redirectHosts, cfg.Redirect.AllowHTTP, log)
+
 	paymentRepo := repo.NewPostgresPaymentRepo(db.Pool)
-	paymentService := service.NewPaymentService(brokerMerchant, paymentRepo, log)
-	gRPCserver := grpcserver.New(ctx, cfg.Server.GRPCServer, paymentService, log)
+	paymentService := service.NewPaymentService(brokerMerchant, paymentRepo, currencyService, riskService, redirectService, log)
+	subscriptionRepo := repo.NewPostgresSubscriptionRepo(db.Pool)
+	subscriptionService := service.NewSubscriptionService(paymentService, currencyService, subscriptionRepo, log)
+	invoiceRepo := repo.NewPostgresInvoiceRepo(db.Pool)
+	invoiceService := service.NewInvoiceService(paymentService, currencyService, redirectService, invoiceRepo, cfg.Server.HTTPServer.PublicURL, log)
+	ledgerRepo := repo.NewPostgresLedgerRepo(db.Pool)
+	ledgerService := service.NewLedgerService(ledgerRepo, log)
+	settlementRepo := repo.NewPostgresSettlementRepo(db.Pool)
+	settlementService := service.NewSettlementService(settlementRepo, log)
+	feeRepo := repo.NewPostgresFeeRepo(db.Pool)
+	feeService := service.NewFeeService(feeRepo, currencyService, log)
+	limitRepo := repo.NewPostgresLimitRepo(db.Pool)
+	limitService := service.NewLimitService(limitRepo, currencyService, log)
+	fiscalProvider, err := newFiscalProvider(cfg.Receipts, log)
+	if err != nil {
+		log.Fatal(ctx, action.ServiceStartFail, err, "Failed to create fiscal provider")
+	}
+	receiptService := service.NewReceiptService(paymentRepo, repo.NewPostgresReceiptRepo(db.Pool),
+		receipt.NewRenderer(cfg.Receipts.Seller, cfg.Receipts.SellerTIN), fiscalProvider, log)
+	exportService := service.NewExportService(paymentRepo, export.NewExporter(), log)
 
-	return &App{
-		log:        log,
-		postgresDB: db,
-		gRPC:       gRPCserver,
+	return &Core{
+		DB:            db,
+		Repo:          paymentRepo,
+		Service:       paymentService,
+		Subscriptions: subscriptionService,
+		Invoices:      invoiceService,
+		Ledger:        ledgerService,
+		Settlements:   settlementService,
+		Fees:          feeService,
+		Limits:        limitService,
+		Currencies:    currencyService,
+		Risk:          riskService,
+		Receipts:      receiptService,
+		Exports:       exportService,
 	}
 }
 
+// newFiscalProvider — фискальный провайдер из конфигурации; nil, если чеки отключены.
+func newFiscalProvider(cfg config.Receipts, log logger.Logger) (ports.FiscalProvider, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	switch cfg.Provider {
+	case "":
+		return nil, errors.New("FISCAL_PROVIDER is required when RECEIPTS_ENABLED=true")
+	case fiscal.LocalProviderName:
+		log.Warn(context.Background(), action.ServiceSetup, "local fiscal provider issues non-fiscal receipts and must not be used in production")
+		return fiscal.NewLocalProvider(cfg.Dir, log)
+	default:
+		return nil, fmt.Errorf("unknown fiscal provider %q", cfg.Provider)
+	}
+}
+
+// loadRiskRules читает правила риска из файла конфигурации.
+func loadRiskRules(path string) ([]models.RiskRule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return risk.ParseRules(file)
+}
+
+func (c *Core) Close() {
+	c.DB.Pool.Close()
+}
+
 func (a *App) Start(ctx context.Context) {
 	a.log.Info(ctx, action.ServiceStarted, "Starting application...")
 
 	errCh := make(chan error, 1)
 	go a.gRPC.Start(ctx, errCh)
 
+	if a.billing.Enabled && a.billing.Interval > 0 {
+		billingCtx, cancel := context.WithCancel(ctx)
+		a.stopBilling = cancel
+		go a.subscriptions.RunBilling(billingCtx, a.billing.Interval)
+	}
+
+	if a.receiptsCfg.Enabled && a.receiptsCfg.Interval > 0 {
+		receiptsCtx, cancel := context.WithCancel(ctx)
+		a.stopReceipts = cancel
+		go a.receipts.RunReceipts(receiptsCtx, a.receiptsCfg.Interval)
+	}
+
 	ListenShutdown(ctx, errCh, a.log)
 }
 
 func (a *App) Stop(ctx context.Context) {
 	a.log.Info(ctx, action.GracefulShutdown, "Closing application...")
+	if a.stopBilling != nil {
+		a.stopBilling()
+	}
+	if a.stopReceipts != nil {
+		a.stopReceipts()
+	}
 	a.postgresDB.Pool.Close()
 	a.gRPC.Stop()
 	a.log.Info(ctx, action.GracefulShutdown, "Application has been closed...")
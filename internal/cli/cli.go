@@ -0,0 +1,294 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"payment/config"
+	"payment/internal/app"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/migrations"
+	"payment/pkg/logger"
+	"payment/pkg/postgres"
+	"strings"
+	"time"
+)
+
+// Команды CLI
+const (
+	CmdServe     = "serve"
+	CmdMigrate   = "migrate"
+	CmdPayment   = "payment"
+	CmdReconcile = "reconcile"
+	CmdExport    = "export"
+	CmdBill      = "bill"
+	CmdPayout    = "payout"
+	CmdBalance   = "trial-balance"
+	CmdSettle    = "settlement"
+	CmdFX        = "fx"
+	CmdRisk      = "risk"
+	CmdHelp      = "help"
+)
+
+const usage = `Usage: payment <command> [flags] [args]
+
+Commands:
+  serve                                 start gRPC server (default)
+  migrate                               apply database migrations
+  payment get [-o format] <id>          show payment
+  payment sync [-o format] <id>         sync payment status with the broker
+  payment refund [-reason r] [-amount n] [-o format] <id>
+                                        refund payment
+  payment approve [-note n] [-o format] <id>
+                                        approve payment held for risk review
+  payment reject [-note n] [-o format] <id>
+                                        reject payment held for risk review
+  reconcile -since <time> [-o format]   sync statuses of payments created since <time>
+  export -since <time> [-until <time>] [-user id] [-status list] [-currency list] [-broker b]
+         [-order-prefix p] [-min amount] [-max amount] [-meta k=v,...] [-desc] [-out path] [-o format]
+                                        dump payments created since <time>
+  bill [-o format]                      charge subscriptions due now
+  payout [-id payout] [-o format]       create payout batch for sellers (or show existing one)
+  trial-balance [-at <time>] [-o format]
+                                        show ledger trial balance (now by default)
+  settlement run -file <path> -from <time> -to <time> [-columns spec] [-statuses spec]
+                 [-delimiter c] [-minor] [-o format]
+                                        reconcile bank settlement file with payments
+  settlement get [-o format] <id>       show reconciliation result
+  fx load -file <path> [-source name]   load fx rates into the database
+  fx currencies [-o format]             show currency registry
+  risk rules [-all] [-o format]         show risk rules (config and database)
+  risk add -name n -decision d -expr e  add risk rule to the database
+  risk enable <name>, risk disable <name>
+                                        enable or disable database risk rule
+  risk list-add -list l <value>, risk list-remove -list l <value>
+                                        add or remove value of a named risk list
+
+Payment commands accept -order to address a payment by merchant order ID.
+Formats: table (default), json; export, payout, trial-balance and settlement also support csv.
+Export also supports jsonl and parquet; all formats except table are streamed from the database,
+table output is refused above 1000 payments.
+Settlement columns: payment_id=<header>,order_id=..,amount=..,currency=..,status=..
+Settlement statuses: <bank status>=<payment status>,...
+FX rates file: CSV with base,quote,rate[,as_of] header; rate is quote units per base unit.
+Risk decisions: allow, review, deny. Expression example: currency == "KZT" and amount >= 500000
+Time: RFC3339 (2025-01-02T15:04:05Z), date (2025-01-02) or duration back from now (24h).
+`
+
+var (
+	ErrUnknownCommand = errors.New("unknown command")
+	ErrMissingArg     = errors.New("missing argument")
+	ErrUnbalanced     = errors.New("trial balance does not balance")
+	ErrTooManyRows    = errors.New("too many rows for table output")
+)
+
+type CLI struct {
+	core *app.Core
+	log  logger.Logger
+	out  io.Writer
+}
+
+// Run — выполняет административную команду args и пишет результат в out.
+func Run(ctx context.Context, cfg config.Config, log logger.Logger, args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] == CmdHelp || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(out, usage)
+		return nil
+	}
+
+	if args[0] == CmdMigrate {
+		return migrate(ctx, cfg, log, out)
+	}
+
+	ctx = models.WithCaller(ctx, models.Caller{
+		Actor:  "cli:" + currentUser(),
+		Method: "cli/" + strings.Join(commandPath(args), " "),
+	})
+
+	c := &CLI{log: log, out: out}
+	switch args[0] {
+	case CmdPayment, CmdReconcile, CmdExport, CmdBill, CmdPayout, CmdBalance, CmdSettle, CmdFX, CmdRisk:
+		c.core = app.NewCore(ctx, cfg, log)
+		defer c.core.Close()
+	default:
+		return fmt.Errorf("%w %q\n\n%s", ErrUnknownCommand, args[0], usage)
+	}
+
+	switch args[0] {
+	case CmdPayment:
+		return c.payment(ctx, args[1:])
+	case CmdReconcile:
+		return c.reconcile(ctx, args[1:])
+	case CmdBill:
+		return c.bill(ctx, args[1:])
+	case CmdPayout:
+		return c.payout(ctx, args[1:])
+	case CmdBalance:
+		return c.trialBalance(ctx, args[1:])
+	case CmdSettle:
+		return c.settlement(ctx, args[1:])
+	case CmdFX:
+		return c.fx(ctx, args[1:])
+	case CmdRisk:
+		return c.risk(ctx, args[1:])
+	default:
+		return c.export(ctx, args[1:])
+	}
+}
+
+func migrate(ctx context.Context, cfg config.Config, log logger.Logger, out io.Writer) error {
+	db, err := postgres.New(ctx, cfg.Postgres)
+	if err != nil {
+		return err
+	}
+	defer db.Pool.Close()
+
+	applied, err := postgres.Migrate(ctx, db.Pool, migrations.FS)
+	for _, version := range applied {
+		fmt.Fprintln(out, "applied", version)
+	}
+	if err != nil {
+		log.Error(ctx, action.MigrateDatabase, err, "failed to apply migrations")
+		return err
+	}
+
+	if len(applied) == 0 {
+		fmt.Fprintln(out, "database is up to date")
+	}
+	log.Info(ctx, action.MigrateDatabase, "success", "applied", len(applied))
+	return nil
+}
+
+func (c *CLI) reconcile(ctx context.Context, args []string) error {
+	fs, format := newFlagSet(CmdReconcile)
+	since := fs.String("since", "", "reconcile payments created since this time")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	from, err := parseTime("since", *since)
+	if err != nil {
+		return err
+	}
+
+	results, err := c.core.Service.ReconcilePayments(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	return printSyncResults(c.out, *format, results)
+}
+
+func (c *CLI) bill(ctx context.Context, args []string) error {
+	fs, format := newFlagSet(CmdBill)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	results, err := c.core.Subscriptions.BillDueSubscriptions(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return printBillingResults(c.out, *format, results)
+}
+
+func (c *CLI) payout(ctx context.Context, args []string) error {
+	fs, format := newFlagSet(CmdPayout)
+	payoutID := fs.String("id", "", "show existing payout instead of creating a new one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		payout models.Payout
+		err    error
+	)
+	if *payoutID != "" {
+		payout, err = c.core.Ledger.GetPayout(ctx, *payoutID)
+	} else {
+		payout, err = c.core.Ledger.CreatePayout(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	return printPayout(c.out, *format, payout)
+}
+
+func (c *CLI) trialBalance(ctx context.Context, args []string) error {
+	fs, format := newFlagSet(CmdBalance)
+	at := fs.String("at", "", "show balances as of this time")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	asOf := time.Now()
+	if *at != "" {
+		var err error
+		if asOf, err = parseTime("at", *at); err != nil {
+			return err
+		}
+	}
+
+	tb, err := c.core.Ledger.TrialBalance(ctx, asOf)
+	if err != nil {
+		return err
+	}
+
+	if err := printTrialBalance(c.out, *format, tb); err != nil {
+		return err
+	}
+	if !tb.Balanced() {
+		return ErrUnbalanced
+	}
+	return nil
+}
+
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	format := fs.String("o", formatTable, "output format: table, json or csv")
+	return fs, format
+}
+
+// parseTime разбирает значение флага name в формате RFC3339, даты или длительности назад от текущего момента.
+func parseTime(name, value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("%w: -%s", ErrMissingArg, name)
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid -%s value %q", name, value)
+}
+
+// commandPath возвращает имя команды без флагов и аргументов.
+func commandPath(args []string) []string {
+	if (args[0] == CmdPayment || args[0] == CmdSettle || args[0] == CmdFX || args[0] == CmdRisk) && len(args) > 1 {
+		return args[:2]
+	}
+	return args[:1]
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
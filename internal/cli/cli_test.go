@@ -0,0 +1,270 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"payment/config"
+	"payment/internal/app"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunHelp(t *testing.T) {
+	for _, args := range [][]string{nil, {CmdHelp}, {"-h"}, {"--help"}} {
+		var out bytes.Buffer
+		if err := Run(context.Background(), config.Config{}, logger.NewWithWriter("error", io.Discard), args, &out); err != nil {
+			t.Fatalf("Run(%v): %v", args, err)
+		}
+		if out.String() != usage {
+			t.Errorf("Run(%v) did not print usage", args)
+		}
+	}
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	var out bytes.Buffer
+	err := Run(context.Background(), config.Config{}, logger.NewWithWriter("error", io.Discard), []string{"refund", "bank-1"}, &out)
+	if !errors.Is(err, ErrUnknownCommand) || !strings.Contains(err.Error(), `"refund"`) {
+		t.Fatalf("Run(refund) error = %v, want ErrUnknownCommand", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("Run(refund) wrote %q, want nothing", out.String())
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		approx  bool // Длительность отсчитывается от текущего момента
+		wantErr bool
+	}{
+		{value: "2025-01-02T15:04:05Z", want: time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{value: " 2025-01-02 ", want: time.Date(2025, 1, 2, 0, 0, 0, 0, time.Local)},
+		{value: "24h", want: now.Add(-24 * time.Hour), approx: true},
+		{value: "", wantErr: true},
+		{value: "yesterday", wantErr: true},
+		{value: "2025-13-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTime("since", tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseTime(%q) = %v, want error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTime(%q): %v", tt.value, err)
+			continue
+		}
+		if diff := got.Sub(tt.want); (tt.approx && diff.Abs() > time.Minute) || (!tt.approx && diff != 0) {
+			t.Errorf("parseTime(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	if _, err := parseTime("since", ""); !errors.Is(err, ErrMissingArg) {
+		t.Fatalf("parseTime(\"\") error = %v, want ErrMissingArg", err)
+	}
+}
+
+func TestCommandPath(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"payment", "refund", "-amount", "5", "bank-1"}, []string{"payment", "refund"}},
+		{[]string{"settlement", "run", "-file", "x.csv"}, []string{"settlement", "run"}},
+		{[]string{"risk", "enable", "big"}, []string{"risk", "enable"}},
+		{[]string{"fx", "load"}, []string{"fx", "load"}},
+		{[]string{"payment"}, []string{"payment"}},
+		{[]string{"export", "-since", "24h"}, []string{"export"}},
+	}
+
+	for _, tt := range tests {
+		if got := commandPath(tt.args); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("commandPath(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestExportFlags(t *testing.T) {
+	if got, want := splitList(" KZT, ,usd,"), []string{"KZT", "usd"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("splitList = %v, want %v", got, want)
+	}
+
+	if amount, err := parseAmount("min", "10.5"); err != nil || *amount != 10.5 {
+		t.Errorf("parseAmount(10.5) = %v, %v", amount, err)
+	}
+	if amount, err := parseAmount("min", ""); err != nil || amount != nil {
+		t.Errorf("parseAmount(\"\") = %v, %v; want no filter", amount, err)
+	}
+	for _, value := range []string{"-1", "ten"} {
+		if _, err := parseAmount("min", value); err == nil {
+			t.Errorf("parseAmount(%q) error = nil", value)
+		}
+	}
+
+	metadata, err := parseMetadata("cart_id=42, source = app")
+	if want := map[string]string{"cart_id": "42", "source": "app"}; err != nil || !reflect.DeepEqual(metadata, want) {
+		t.Errorf("parseMetadata = %v, %v; want %v", metadata, err, want)
+	}
+	for _, value := range []string{"cart_id", "=42"} {
+		if _, err := parseMetadata(value); err == nil {
+			t.Errorf("parseMetadata(%q) error = nil", value)
+		}
+	}
+}
+
+func TestPrintPayments(t *testing.T) {
+	payments := []models.Payment{{
+		ID: "bank-1", OrderID: "order-1", UserID: "u1", Amount: 1000, CapturedAmount: 1000, RefundedAmount: 250,
+		FeeAmount: 20, Currency: "KZT", Status: models.OrderPartiallyRefunded, Operation: models.URLpayment,
+		Broker: "BEREKE", CreatedAt: time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC),
+	}}
+
+	t.Run("csv", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := printPayments(&out, formatCSV, payments); err != nil {
+			t.Fatalf("printPayments: %v", err)
+		}
+		records, err := csv.NewReader(&out).ReadAll()
+		if err != nil {
+			t.Fatalf("read csv: %v", err)
+		}
+		want := [][]string{paymentHeader, {"bank-1", "order-1", "u1", "1000.00", "1000.00", "0.00", "250.00", "20.00", "730.00",
+			"KZT", "PARTIALLY_REFUNDED", string(models.URLpayment), "BEREKE", "2025-01-02T15:04:05Z"}}
+		if !reflect.DeepEqual(records, want) {
+			t.Fatalf("csv = %v, want %v", records, want)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := printPayments(&out, formatJSON, payments); err != nil {
+			t.Fatalf("printPayments: %v", err)
+		}
+		var views []paymentView
+		if err := json.Unmarshal(out.Bytes(), &views); err != nil {
+			t.Fatalf("decode json: %v", err)
+		}
+		if len(views) != 1 || views[0].PaymentID != "bank-1" || views[0].NetAmount != 730 {
+			t.Fatalf("json = %+v, want bank-1 with net amount 730", views)
+		}
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := printPayments(&out, formatTable, payments); err != nil {
+			t.Fatalf("printPayments: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if len(lines) != 2 || !strings.HasPrefix(lines[0], "PAYMENT_ID") || !strings.HasPrefix(lines[1], "bank-1") {
+			t.Fatalf("table = %q, want header and one row", out.String())
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		if err := printPayments(io.Discard, "xml", payments); err == nil {
+			t.Fatal("printPayments(xml) error = nil")
+		}
+	})
+}
+
+func TestPaymentJSONStream(t *testing.T) {
+	payment := func(i int) models.Payment {
+		return models.Payment{ID: fmt.Sprintf("bank-%d", i), Amount: 100, Currency: "KZT", Description: "<Чай & сахар>",
+			Metadata: map[string]any{"cart_id": "42"}, CreatedAt: time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)}
+	}
+
+	for _, n := range []int{0, 1, 3} {
+		payments := make([]models.Payment, n)
+		for i := range payments {
+			payments[i] = payment(i)
+		}
+
+		var streamed bytes.Buffer
+		stream := &paymentJSONStream{w: &streamed}
+		for _, p := range payments {
+			if err := stream.Write(p); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+		}
+		if err := stream.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		// Поток совпадает с выводом всего списка сразу
+		var want bytes.Buffer
+		if err := printPayments(&want, formatJSON, payments); err != nil {
+			t.Fatalf("printPayments: %v", err)
+		}
+		if streamed.String() != want.String() {
+			t.Fatalf("%d payments streamed as\n%s\nwant\n%s", n, streamed.String(), want.String())
+		}
+	}
+}
+
+// exportRepo отдаёт выгрузке заданное число платежей.
+type exportRepo struct {
+	ports.PaymentRepo
+	rows int
+	read int // Сколько платежей прочитано до остановки
+}
+
+func (r *exportRepo) ExportPayments(ctx context.Context, filter models.PaymentFilter, fn func(models.Payment) error) error {
+	for i := range r.rows {
+		r.read++
+		if err := fn(models.Payment{ID: fmt.Sprintf("bank-%d", i), Amount: 1, Currency: "KZT"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportOutputLimits(t *testing.T) {
+	run := func(rows int, format string) (*exportRepo, string, error) {
+		repo := &exportRepo{rows: rows}
+		var out bytes.Buffer
+		c := &CLI{core: &app.Core{Repo: repo}, log: logger.NewWithWriter("error", io.Discard), out: &out}
+		err := c.export(context.Background(), []string{"-since", "24h", "-o", format})
+		return repo, out.String(), err
+	}
+
+	if _, out, err := run(maxTableRows, formatTable); err != nil || strings.Count(out, "\n") != maxTableRows+1 {
+		t.Fatalf("table of %d payments: %d lines, %v; want header and all rows", maxTableRows, strings.Count(out, "\n"), err)
+	}
+
+	// Таблица больше лимита отклоняется, чтение останавливается на первой лишней строке
+	repo, out, err := run(maxTableRows*5, formatTable)
+	if !errors.Is(err, ErrTooManyRows) || out != "" || repo.read != maxTableRows+1 {
+		t.Fatalf("table over limit: error = %v, output %d bytes, read %d; want ErrTooManyRows after %d rows", err, len(out), repo.read, maxTableRows+1)
+	}
+
+	// JSON выгружается потоком без ограничения
+	_, out, err = run(maxTableRows*5, formatJSON)
+	if err != nil {
+		t.Fatalf("json export: %v", err)
+	}
+	var views []paymentView
+	if err := json.Unmarshal([]byte(out), &views); err != nil || len(views) != maxTableRows*5 {
+		t.Fatalf("json export = %d payments, %v; want %d", len(views), err, maxTableRows*5)
+	}
+
+	if _, _, err := run(1, "xml"); err == nil {
+		t.Fatal("export -o xml error = nil")
+	}
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"payment/internal/domain/models"
+	"strconv"
+	"strings"
+)
+
+func (c *CLI) export(ctx context.Context, args []string) error {
+	fs, format := newFlagSet(CmdExport)
+	since := fs.String("since", "", "export payments created since this time")
+	until := fs.String("until", "", "export payments created before this time")
+	userID := fs.String("user", "", "user ID")
+	statuses := fs.String("status", "", "comma-separated payment statuses")
+	currencies := fs.String("currency", "", "comma-separated currencies")
+	broker := fs.String("broker", "", "broker name")
+	orderPrefix := fs.String("order-prefix", "", "merchant order ID prefix")
+	amountMin := fs.String("min", "", "minimum amount")
+	amountMax := fs.String("max", "", "maximum amount")
+	metadata := fs.String("meta", "", "metadata filter: key=value,...")
+	desc := fs.Bool("desc", false, "newest payments first")
+	path := fs.String("out", "", "write export to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	filter := models.PaymentFilter{
+		UserID:        *userID,
+		Currencies:    splitList(*currencies),
+		Broker:        *broker,
+		OrderIDPrefix: *orderPrefix,
+		SortDesc:      *desc,
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseTime("since", *since); err != nil {
+		return err
+	}
+	if *until != "" {
+		if filter.CreatedTo, err = parseTime("until", *until); err != nil {
+			return err
+		}
+	}
+	for _, st := range splitList(*statuses) {
+		filter.Statuses = append(filter.Statuses, models.StatusType(strings.ToUpper(st)))
+	}
+	if filter.AmountMin, err = parseAmount("min", *amountMin); err != nil {
+		return err
+	}
+	if filter.AmountMax, err = parseAmount("max", *amountMax); err != nil {
+		return err
+	}
+	if filter.Metadata, err = parseMetadata(*metadata); err != nil {
+		return err
+	}
+
+	out := c.out
+	if *path != "" {
+		file, err := os.Create(*path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if exportFormat := models.ExportFormat(strings.ToUpper(*format)); models.IsExportFormatSupported(exportFormat) {
+		rows, err := c.core.Exports.ExportPayments(ctx, filter, exportFormat, out)
+		if err != nil {
+			return err
+		}
+		if *path != "" {
+			fmt.Fprintf(c.out, "exported %d payments to %s\n", rows, *path)
+		}
+		return nil
+	}
+
+	switch *format {
+	case formatJSON:
+		stream := &paymentJSONStream{w: out}
+		if err := c.core.Repo.ExportPayments(ctx, filter, stream.Write); err != nil {
+			return err
+		}
+		return stream.Close()
+	case formatTable:
+		// Ширина колонок таблицы считается по всем строкам, поэтому строки держатся в памяти
+		var payments []models.Payment
+		err = c.core.Repo.ExportPayments(ctx, filter, func(p models.Payment) error {
+			if len(payments) == maxTableRows {
+				return fmt.Errorf("%w: more than %d payments, use -o csv, json, jsonl or parquet", ErrTooManyRows, maxTableRows)
+			}
+			payments = append(payments, p)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return printPayments(out, *format, payments)
+	default:
+		return unknownFormat(*format)
+	}
+}
+
+// splitList разбирает список значений через запятую.
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// parseAmount разбирает необязательную сумму из флага name.
+func parseAmount(name, value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount < 0 {
+		return nil, fmt.Errorf("invalid -%s value %q", name, value)
+	}
+	return &amount, nil
+}
+
+// parseMetadata разбирает фильтр по метаданным вида key=value,...
+func parseMetadata(value string) (map[string]string, error) {
+	list := splitList(value)
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	metadata := make(map[string]string, len(list))
+	for _, pair := range list {
+		key, val, ok := strings.Cut(pair, "=")
+		if key = strings.TrimSpace(key); !ok || key == "" {
+			return nil, fmt.Errorf("invalid -meta pair %q", pair)
+		}
+		metadata[key] = strings.TrimSpace(val)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"payment/internal/adapters/fx"
+	"time"
+)
+
+// Подкоманды fx
+const (
+	fxLoad       = "load"
+	fxCurrencies = "currencies"
+)
+
+func (c *CLI) fx(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: fx subcommand\n\n%s", ErrMissingArg, usage)
+	}
+
+	switch args[0] {
+	case fxLoad:
+		return c.fxLoad(ctx, args[1:])
+
+	case fxCurrencies:
+		fs, format := newFlagSet(CmdFX + " " + fxCurrencies)
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		currencies, err := c.core.Currencies.ListCurrencies(ctx)
+		if err != nil {
+			return err
+		}
+		return printCurrencies(c.out, *format, c.core.Currencies.SettlementCurrency(), currencies)
+
+	default:
+		return fmt.Errorf("%w \"fx %s\"\n\n%s", ErrUnknownCommand, args[0], usage)
+	}
+}
+
+// fxLoad загружает курсы из CSV файла в БД для поставщика курсов из БД.
+func (c *CLI) fxLoad(ctx context.Context, args []string) error {
+	fs, _ := newFlagSet(CmdFX + " " + fxLoad)
+	path := fs.String("file", "", "CSV file with base,quote,rate[,as_of] columns")
+	source := fs.String("source", "", "rate source name (file name by default)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *path == "" {
+		return fmt.Errorf("%w: -file", ErrMissingArg)
+	}
+	if *source == "" {
+		*source = "file:" + filepath.Base(*path)
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rates, err := fx.ParseRates(file, *source, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := c.core.Currencies.LoadRates(ctx, rates); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.out, "loaded", len(rates), "rates")
+	return nil
+}
@@ -0,0 +1,539 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"payment/internal/domain/models"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// Форматы вывода
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+type paymentView struct {
+	PaymentID string    `json:"payment_id"`
+	OrderID   string    `json:"order_id"`
+	UserID    string    `json:"user_id"`
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency"`
+	Status    string    `json:"status"`
+	Operation string    `json:"operation"`
+	Broker    string    `json:"broker"`
+	CreatedAt time.Time `json:"created_at"`
+
+	CapturedAmount  float64 `json:"captured_amount"`
+	RemainingAmount float64 `json:"remaining_amount"`
+	RefundedAmount  float64 `json:"refunded_amount"`
+	FeeAmount       float64 `json:"fee_amount"`
+	NetAmount       float64 `json:"net_amount"`
+
+	RiskDecision string   `json:"risk_decision,omitempty"`
+	RiskRules    []string `json:"risk_rules,omitempty"`
+
+	Description string         `json:"description,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+	Items       []cartItemView `json:"items,omitempty"`
+}
+
+type cartItemView struct {
+	Name     string  `json:"name"`
+	Quantity float64 `json:"quantity"`
+	Price    float64 `json:"price"`
+	TaxRate  float64 `json:"tax_rate"`
+	Amount   float64 `json:"amount"`
+}
+
+type syncView struct {
+	PaymentID string `json:"payment_id"`
+	Previous  string `json:"previous_status"`
+	Current   string `json:"current_status"`
+	Changed   bool   `json:"changed"`
+	Error     string `json:"error,omitempty"`
+}
+
+type billingView struct {
+	SubscriptionID string `json:"subscription_id"`
+	PaymentID      string `json:"payment_id,omitempty"`
+	Status         string `json:"status"`
+	Error          string `json:"error,omitempty"`
+}
+
+type payoutLineView struct {
+	PayoutID string  `json:"payout_id"`
+	SellerID string  `json:"seller_id"`
+	Currency string  `json:"currency"`
+	Amount   float64 `json:"amount"`
+	Entries  int     `json:"entries"`
+}
+
+type trialBalanceView struct {
+	Account  string  `json:"account"`
+	Type     string  `json:"type"`
+	Currency string  `json:"currency"`
+	Debit    float64 `json:"debit"`
+	Credit   float64 `json:"credit"`
+	Balance  float64 `json:"balance"`
+}
+
+type currencyView struct {
+	Code            string `json:"code"`
+	NumericCode     string `json:"numeric_code"`
+	Exponent        int    `json:"exponent"`
+	Enabled         bool   `json:"enabled"`
+	BrokerSupported bool   `json:"broker_supported"`
+	Settlement      bool   `json:"settlement"`
+}
+
+type reconciliationView struct {
+	ReconciliationID string            `json:"reconciliation_id"`
+	FileName         string            `json:"file_name"`
+	PeriodStart      time.Time         `json:"period_start"`
+	PeriodEnd        time.Time         `json:"period_end"`
+	Lines            int               `json:"lines"`
+	Matched          int               `json:"matched"`
+	Discrepancies    []discrepancyView `json:"discrepancies"`
+	CreatedAt        time.Time         `json:"created_at"`
+}
+
+type discrepancyView struct {
+	Kind        string  `json:"kind"`
+	PaymentID   string  `json:"payment_id,omitempty"`
+	OrderID     string  `json:"order_id,omitempty"`
+	BankAmount  float64 `json:"bank_amount"`
+	LocalAmount float64 `json:"local_amount"`
+	BankStatus  string  `json:"bank_status,omitempty"`
+	LocalStatus string  `json:"local_status,omitempty"`
+}
+
+type riskRuleView struct {
+	Name       string `json:"name"`
+	Decision   string `json:"decision"`
+	Expression string `json:"expression"`
+	Enabled    bool   `json:"enabled"`
+	Source     string `json:"source"`
+}
+
+type statusView struct {
+	PaymentID string `json:"payment_id"`
+	Status    string `json:"status"`
+}
+
+var paymentHeader = []string{"PAYMENT_ID", "ORDER_ID", "USER_ID", "AMOUNT", "CAPTURED", "REMAINING", "REFUNDED", "FEE", "NET", "CURRENCY", "STATUS", "OPERATION", "BROKER", "CREATED_AT"}
+
+func toPaymentView(p models.Payment) paymentView {
+	return paymentView{
+		PaymentID: p.ID,
+		OrderID:   p.OrderID,
+		UserID:    p.UserID,
+		Amount:    p.Amount,
+		Currency:  p.Currency,
+		Status:    string(p.Status),
+		Operation: string(p.Operation),
+		Broker:    p.Broker,
+		CreatedAt: p.CreatedAt,
+
+		CapturedAmount:  p.CapturedAmount,
+		RemainingAmount: p.RemainingAmount(),
+		RefundedAmount:  p.RefundedAmount,
+		FeeAmount:       p.FeeAmount,
+		NetAmount:       p.NetAmount(),
+
+		RiskDecision: string(p.RiskDecision),
+		RiskRules:    p.RiskRules,
+
+		Description: p.Description,
+		Metadata:    p.Metadata,
+		Items:       toCartItemViews(p.Items),
+	}
+}
+
+func toCartItemViews(items []models.CartItem) []cartItemView {
+	views := make([]cartItemView, 0, len(items))
+	for _, item := range items {
+		views = append(views, cartItemView{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+			TaxRate:  item.TaxRate,
+			Amount:   item.Amount(),
+		})
+	}
+	return views
+}
+
+func (v paymentView) row() []string {
+	return []string{
+		v.PaymentID, v.OrderID, v.UserID,
+		strconv.FormatFloat(v.Amount, 'f', 2, 64),
+		strconv.FormatFloat(v.CapturedAmount, 'f', 2, 64),
+		strconv.FormatFloat(v.RemainingAmount, 'f', 2, 64),
+		strconv.FormatFloat(v.RefundedAmount, 'f', 2, 64),
+		strconv.FormatFloat(v.FeeAmount, 'f', 2, 64),
+		strconv.FormatFloat(v.NetAmount, 'f', 2, 64), v.Currency,
+		v.Status, v.Operation, v.Broker, v.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toSyncView(r models.SyncResult) syncView {
+	v := syncView{
+		PaymentID: r.PaymentID,
+		Previous:  string(r.Previous),
+		Current:   string(r.Current),
+		Changed:   r.Changed(),
+	}
+	if r.Err != nil {
+		v.Error = r.Err.Error()
+	}
+	return v
+}
+
+func printPayment(w io.Writer, format string, p models.Payment) error {
+	if format == formatJSON {
+		return printJSON(w, toPaymentView(p))
+	}
+	return printPayments(w, format, []models.Payment{p})
+}
+
+func printPayments(w io.Writer, format string, payments []models.Payment) error {
+	views := make([]paymentView, 0, len(payments))
+	for _, p := range payments {
+		views = append(views, toPaymentView(p))
+	}
+
+	switch format {
+	case formatJSON:
+		return printJSON(w, views)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(paymentHeader); err != nil {
+			return err
+		}
+		for _, v := range views {
+			if err := cw.Write(v.row()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case formatTable:
+		rows := make([][]string, 0, len(views))
+		for _, v := range views {
+			rows = append(rows, v.row())
+		}
+		return printTable(w, paymentHeader, rows)
+	default:
+		return unknownFormat(format)
+	}
+}
+
+// maxTableRows — наибольшее число платежей в табличной выгрузке.
+const maxTableRows = 1000
+
+// paymentJSONStream пишет платежи массивом JSON по мере чтения, не собирая выгрузку в памяти.
+// Вывод совпадает с printJSON для того же списка платежей.
+type paymentJSONStream struct {
+	w    io.Writer
+	rows int
+}
+
+func (s *paymentJSONStream) Write(p models.Payment) error {
+	raw, err := json.MarshalIndent(toPaymentView(p), "  ", "  ")
+	if err != nil {
+		return err
+	}
+	prefix := ",\n  "
+	if s.rows == 0 {
+		prefix = "[\n  "
+	}
+	if _, err := io.WriteString(s.w, prefix); err != nil {
+		return err
+	}
+	if _, err := s.w.Write(raw); err != nil {
+		return err
+	}
+	s.rows++
+	return nil
+}
+
+// Close закрывает массив; пустая выгрузка — пустой массив.
+func (s *paymentJSONStream) Close() error {
+	end := "\n]\n"
+	if s.rows == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(s.w, end)
+	return err
+}
+
+func printSyncResult(w io.Writer, format string, r models.SyncResult) error {
+	if format == formatJSON {
+		return printJSON(w, toSyncView(r))
+	}
+	return printSyncResults(w, format, []models.SyncResult{r})
+}
+
+func printSyncResults(w io.Writer, format string, results []models.SyncResult) error {
+	views := make([]syncView, 0, len(results))
+	for _, r := range results {
+		views = append(views, toSyncView(r))
+	}
+
+	switch format {
+	case formatJSON:
+		return printJSON(w, views)
+	case formatTable:
+		rows := make([][]string, 0, len(views))
+		for _, v := range views {
+			rows = append(rows, []string{v.PaymentID, v.Previous, v.Current, strconv.FormatBool(v.Changed), v.Error})
+		}
+		return printTable(w, []string{"PAYMENT_ID", "PREVIOUS", "CURRENT", "CHANGED", "ERROR"}, rows)
+	default:
+		return unknownFormat(format)
+	}
+}
+
+func printBillingResults(w io.Writer, format string, results []models.BillingResult) error {
+	views := make([]billingView, 0, len(results))
+	for _, r := range results {
+		v := billingView{SubscriptionID: r.SubscriptionID, PaymentID: r.PaymentID, Status: string(r.Status)}
+		if r.Err != nil {
+			v.Error = r.Err.Error()
+		}
+		views = append(views, v)
+	}
+
+	switch format {
+	case formatJSON:
+		return printJSON(w, views)
+	case formatTable:
+		rows := make([][]string, 0, len(views))
+		for _, v := range views {
+			rows = append(rows, []string{v.SubscriptionID, v.PaymentID, v.Status, v.Error})
+		}
+		return printTable(w, []string{"SUBSCRIPTION_ID", "PAYMENT_ID", "STATUS", "ERROR"}, rows)
+	default:
+		return unknownFormat(format)
+	}
+}
+
+var payoutHeader = []string{"PAYOUT_ID", "SELLER_ID", "CURRENCY", "AMOUNT", "ENTRIES"}
+
+func printPayout(w io.Writer, format string, payout models.Payout) error {
+	views := make([]payoutLineView, 0, len(payout.Lines))
+	rows := make([][]string, 0, len(payout.Lines))
+	for _, l := range payout.Lines {
+		v := payoutLineView{PayoutID: payout.ID, SellerID: l.SellerID, Currency: l.Currency, Amount: l.Amount, Entries: l.Entries}
+		views = append(views, v)
+		rows = append(rows, []string{
+			v.PayoutID, v.SellerID, v.Currency,
+			strconv.FormatFloat(v.Amount, 'f', 2, 64), strconv.Itoa(v.Entries),
+		})
+	}
+
+	switch format {
+	case formatJSON:
+		return printJSON(w, views)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(payoutHeader); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	case formatTable:
+		return printTable(w, payoutHeader, rows)
+	default:
+		return unknownFormat(format)
+	}
+}
+
+var trialBalanceHeader = []string{"ACCOUNT", "TYPE", "CURRENCY", "DEBIT", "CREDIT", "BALANCE"}
+
+func printTrialBalance(w io.Writer, format string, tb models.TrialBalance) error {
+	views := make([]trialBalanceView, 0, len(tb.Lines))
+	rows := make([][]string, 0, len(tb.Lines))
+	for _, l := range tb.Lines {
+		v := trialBalanceView{
+			Account: l.Account, Type: string(l.Type), Currency: l.Currency,
+			Debit: l.Debit, Credit: l.Credit, Balance: l.Balance(),
+		}
+		views = append(views, v)
+		rows = append(rows, []string{
+			v.Account, v.Type, v.Currency,
+			strconv.FormatFloat(v.Debit, 'f', 2, 64),
+			strconv.FormatFloat(v.Credit, 'f', 2, 64),
+			strconv.FormatFloat(v.Balance, 'f', 2, 64),
+		})
+	}
+
+	switch format {
+	case formatJSON:
+		return printJSON(w, views)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(trialBalanceHeader); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	case formatTable:
+		return printTable(w, trialBalanceHeader, rows)
+	default:
+		return unknownFormat(format)
+	}
+}
+
+var discrepancyHeader = []string{"KIND", "PAYMENT_ID", "ORDER_ID", "BANK_AMOUNT", "LOCAL_AMOUNT", "BANK_STATUS", "LOCAL_STATUS"}
+
+func printReconciliation(w io.Writer, format string, rec models.Reconciliation) error {
+	v := reconciliationView{
+		ReconciliationID: rec.ID,
+		FileName:         rec.FileName,
+		PeriodStart:      rec.PeriodStart,
+		PeriodEnd:        rec.PeriodEnd,
+		Lines:            rec.Lines,
+		Matched:          rec.Matched,
+		Discrepancies:    make([]discrepancyView, 0, len(rec.Discrepancies)),
+		CreatedAt:        rec.CreatedAt,
+	}
+	rows := make([][]string, 0, len(rec.Discrepancies))
+	for _, d := range rec.Discrepancies {
+		dv := discrepancyView{
+			Kind: string(d.Kind), PaymentID: d.PaymentID, OrderID: d.OrderID,
+			BankAmount: d.BankAmount, LocalAmount: d.LocalAmount,
+			BankStatus: string(d.BankStatus), LocalStatus: string(d.LocalStatus),
+		}
+		v.Discrepancies = append(v.Discrepancies, dv)
+		rows = append(rows, []string{
+			dv.Kind, dv.PaymentID, dv.OrderID,
+			strconv.FormatFloat(dv.BankAmount, 'f', 2, 64),
+			strconv.FormatFloat(dv.LocalAmount, 'f', 2, 64),
+			dv.BankStatus, dv.LocalStatus,
+		})
+	}
+
+	switch format {
+	case formatJSON:
+		return printJSON(w, v)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(discrepancyHeader); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	case formatTable:
+		fmt.Fprintf(w, "reconciliation %s (%s): %d lines, %d matched, %d discrepancies\n\n",
+			v.ReconciliationID, v.FileName, v.Lines, v.Matched, len(v.Discrepancies))
+		return printTable(w, discrepancyHeader, rows)
+	default:
+		return unknownFormat(format)
+	}
+}
+
+func printStatus(w io.Writer, format, paymentID string, status models.StatusType) error {
+	v := statusView{PaymentID: paymentID, Status: string(status)}
+
+	switch format {
+	case formatJSON:
+		return printJSON(w, v)
+	case formatTable:
+		return printTable(w, []string{"PAYMENT_ID", "STATUS"}, [][]string{{v.PaymentID, v.Status}})
+	default:
+		return unknownFormat(format)
+	}
+}
+
+var currencyHeader = []string{"CODE", "NUMERIC", "EXPONENT", "ENABLED", "BROKER", "SETTLEMENT"}
+
+func printCurrencies(w io.Writer, format, settlement string, currencies []models.Currency) error {
+	views := make([]currencyView, 0, len(currencies))
+	rows := make([][]string, 0, len(currencies))
+	for _, c := range currencies {
+		v := currencyView{
+			Code: c.Code, NumericCode: c.Numeric, Exponent: c.Exponent,
+			Enabled: c.Enabled, BrokerSupported: c.BrokerSupported, Settlement: c.Code == settlement,
+		}
+		views = append(views, v)
+		rows = append(rows, []string{
+			v.Code, v.NumericCode, strconv.Itoa(v.Exponent),
+			strconv.FormatBool(v.Enabled), strconv.FormatBool(v.BrokerSupported), strconv.FormatBool(v.Settlement),
+		})
+	}
+
+	switch format {
+	case formatJSON:
+		return printJSON(w, views)
+	case formatTable:
+		return printTable(w, currencyHeader, rows)
+	default:
+		return unknownFormat(format)
+	}
+}
+
+var riskRuleHeader = []string{"NAME", "DECISION", "ENABLED", "SOURCE", "EXPRESSION"}
+
+func printRiskRules(w io.Writer, format string, rules []models.RiskRule) error {
+	views := make([]riskRuleView, 0, len(rules))
+	rows := make([][]string, 0, len(rules))
+	for _, r := range rules {
+		v := riskRuleView{
+			Name: r.Name, Decision: string(r.Decision), Expression: r.Expression,
+			Enabled: r.Enabled, Source: r.Source,
+		}
+		views = append(views, v)
+		rows = append(rows, []string{v.Name, v.Decision, strconv.FormatBool(v.Enabled), v.Source, v.Expression})
+	}
+
+	switch format {
+	case formatJSON:
+		return printJSON(w, views)
+	case formatTable:
+		return printTable(w, riskRuleHeader, rows)
+	default:
+		return unknownFormat(format)
+	}
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func printTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	writeRow := func(cols []string) {
+		for i, col := range cols {
+			if i > 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			fmt.Fprint(tw, col)
+		}
+		fmt.Fprintln(tw)
+	}
+
+	writeRow(header)
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return tw.Flush()
+}
+
+func unknownFormat(format string) error {
+	return fmt.Errorf("unsupported output format %q", format)
+}
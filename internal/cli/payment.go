@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+)
+
+// Подкоманды payment
+const (
+	paymentGet     = "get"
+	paymentSync    = "sync"
+	paymentRefund  = "refund"
+	paymentApprove = "approve"
+	paymentReject  = "reject"
+)
+
+func (c *CLI) payment(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: payment subcommand\n\n%s", ErrMissingArg, usage)
+	}
+
+	fs, format := newFlagSet(CmdPayment + " " + args[0])
+	reason := fs.String("reason", "", "refund reason")
+	amount := fs.Float64("amount", 0, "refund amount (0 refunds the whole captured remainder)")
+	note := fs.String("note", "", "risk review note")
+	byOrder := fs.Bool("order", false, "treat the argument as merchant order ID")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	paymentID := fs.Arg(0)
+	if paymentID == "" {
+		return fmt.Errorf("%w: payment id", ErrMissingArg)
+	}
+
+	if *byOrder {
+		id, err := c.core.Service.ResolvePaymentID(ctx, "", paymentID)
+		if err != nil {
+			return err
+		}
+		paymentID = id
+	}
+
+	switch args[0] {
+	case paymentGet:
+		payment, err := c.core.Service.GetPayment(ctx, paymentID)
+		if err != nil {
+			return err
+		}
+		return printPayment(c.out, *format, payment)
+
+	case paymentSync:
+		result, err := c.core.Service.SyncPayment(ctx, paymentID)
+		if err != nil {
+			return err
+		}
+		return printSyncResult(c.out, *format, result)
+
+	case paymentRefund:
+		status, err := c.core.Service.RefundPayment(ctx, paymentID, *reason, *amount, "")
+		if err != nil {
+			return err
+		}
+		return printStatus(c.out, *format, paymentID, status)
+
+	case paymentApprove:
+		payment, next, err := c.core.Service.ApprovePayment(ctx, paymentID, *note)
+		if err != nil {
+			return err
+		}
+		if next != "" {
+			fmt.Fprintln(c.out, next)
+		}
+		return printPayment(c.out, *format, payment)
+
+	case paymentReject:
+		payment, err := c.core.Service.RejectPayment(ctx, paymentID, *note)
+		if err != nil {
+			return err
+		}
+		return printStatus(c.out, *format, payment.ID, payment.Status)
+
+	default:
+		return fmt.Errorf("%w \"payment %s\"\n\n%s", ErrUnknownCommand, args[0], usage)
+	}
+}
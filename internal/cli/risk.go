@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"payment/internal/domain/models"
+	"strings"
+)
+
+// Подкоманды risk
+const (
+	riskRules      = "rules"
+	riskAdd        = "add"
+	riskEnable     = "enable"
+	riskDisable    = "disable"
+	riskListAdd    = "list-add"
+	riskListRemove = "list-remove"
+)
+
+func (c *CLI) risk(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: risk subcommand\n\n%s", ErrMissingArg, usage)
+	}
+
+	fs, format := newFlagSet(CmdRisk + " " + args[0])
+	all := fs.Bool("all", false, "include disabled rules")
+	name := fs.String("name", "", "rule name")
+	decision := fs.String("decision", "", "rule decision: allow, review or deny")
+	expr := fs.String("expr", "", "rule expression")
+	list := fs.String("list", "", "risk list name")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case riskRules:
+		rules, err := c.core.Risk.ListRules(ctx, *all)
+		if err != nil {
+			return err
+		}
+		return printRiskRules(c.out, *format, rules)
+
+	case riskAdd:
+		rule, err := c.core.Risk.CreateRule(ctx, models.RiskRule{
+			Name:       *name,
+			Decision:   models.RiskDecision(strings.ToUpper(*decision)),
+			Expression: *expr,
+		})
+		if err != nil {
+			return err
+		}
+		return printRiskRules(c.out, *format, []models.RiskRule{rule})
+
+	case riskEnable, riskDisable:
+		if fs.Arg(0) == "" {
+			return fmt.Errorf("%w: rule name", ErrMissingArg)
+		}
+		rule, err := c.core.Risk.SetRuleEnabled(ctx, fs.Arg(0), args[0] == riskEnable)
+		if err != nil {
+			return err
+		}
+		return printRiskRules(c.out, *format, []models.RiskRule{rule})
+
+	case riskListAdd, riskListRemove:
+		if *list == "" {
+			return fmt.Errorf("%w: -list", ErrMissingArg)
+		}
+		if fs.Arg(0) == "" {
+			return fmt.Errorf("%w: list value", ErrMissingArg)
+		}
+		if args[0] == riskListAdd {
+			return c.core.Risk.AddListEntry(ctx, *list, fs.Arg(0))
+		}
+		return c.core.Risk.RemoveListEntry(ctx, *list, fs.Arg(0))
+
+	default:
+		return fmt.Errorf("%w \"risk %s\"\n\n%s", ErrUnknownCommand, args[0], usage)
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"payment/internal/adapters/settlement"
+	"unicode/utf8"
+)
+
+// Подкоманды settlement
+const (
+	settlementRun = "run"
+	settlementGet = "get"
+)
+
+func (c *CLI) settlement(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: settlement subcommand\n\n%s", ErrMissingArg, usage)
+	}
+
+	switch args[0] {
+	case settlementRun:
+		return c.settlementRun(ctx, args[1:])
+
+	case settlementGet:
+		fs, format := newFlagSet(CmdSettle + " " + settlementGet)
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.Arg(0) == "" {
+			return fmt.Errorf("%w: reconciliation id", ErrMissingArg)
+		}
+
+		rec, err := c.core.Settlements.GetReconciliation(ctx, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		return printReconciliation(c.out, *format, rec)
+
+	default:
+		return fmt.Errorf("%w \"settlement %s\"\n\n%s", ErrUnknownCommand, args[0], usage)
+	}
+}
+
+func (c *CLI) settlementRun(ctx context.Context, args []string) error {
+	fs, format := newFlagSet(CmdSettle + " " + settlementRun)
+	path := fs.String("file", "", "bank settlement CSV file")
+	since := fs.String("from", "", "period start")
+	until := fs.String("to", "", "period end (exclusive)")
+	columns := fs.String("columns", "", "column mapping: field=header,...")
+	statuses := fs.String("statuses", "", "status mapping: bank_status=status,...")
+	delimiter := fs.String("delimiter", ",", "column delimiter")
+	minor := fs.Bool("minor", false, "amounts are in minor units")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *path == "" {
+		return fmt.Errorf("%w: -file", ErrMissingArg)
+	}
+	from, err := parseTime("from", *since)
+	if err != nil {
+		return err
+	}
+	to, err := parseTime("to", *until)
+	if err != nil {
+		return err
+	}
+
+	mapping, err := settlement.ParseColumnMapping(*columns)
+	if err != nil {
+		return err
+	}
+	if mapping.Statuses, err = settlement.ParseStatusMap(*statuses); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(*delimiter) != 1 {
+		return fmt.Errorf("invalid -delimiter value %q", *delimiter)
+	}
+	mapping.Delimiter, _ = utf8.DecodeRuneInString(*delimiter)
+	mapping.MinorUnits = *minor
+
+	file, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rec, err := c.core.Settlements.Reconcile(ctx, filepath.Base(*path), file, mapping, from, to)
+	if err != nil {
+		return err
+	}
+	return printReconciliation(c.out, *format, rec)
+}
@@ -22,10 +22,12 @@ const (
 	ValidationFailed    = "validation_failed"
 
 	// CRUD операций с платежами
-	CreatePayment    = "create_payment"
-	GetPayment       = "get_payment"
-	GetPaymentStatus = "get_payment_status"
-	ListPayments     = "list_payments"
+	CreatePayment     = "create_payment"
+	GetPayment        = "get_payment"
+	GetPaymentByOrder = "get_payment_by_order"
+	GetPaymentStatus  = "get_payment_status"
+	GetPaymentHistory = "get_payment_history"
+	ListPayments      = "list_payments"
 
 	// Изменение состояния платежа
 	RefundPayment  = "refund_payment"
@@ -34,5 +36,70 @@ const (
 	DepositPayment = "deposit_payment"
 	ReversePayment = "reversal_payment"
 
+	// Сверка статусов с брокером
+	SyncPayment       = "sync_payment"
+	ReconcilePayments = "reconcile_payments"
+	MigrateDatabase   = "migrate_database"
+
+	// Сохранённые карты
+	ListBindings  = "list_bindings"
+	DeleteBinding = "delete_binding"
+	ChargeBinding = "charge_binding"
+	SaveBinding   = "save_binding"
+
+	// Подписки
+	CreatePlan         = "create_plan"
+	Subscribe          = "subscribe"
+	CancelSubscription = "cancel_subscription"
+	ChangePlan         = "change_plan"
+	BillSubscriptions  = "bill_subscriptions"
+
+	// Счета (платёжные ссылки)
+	CreateInvoice = "create_invoice"
+	OpenInvoice   = "open_invoice"
+
+	// Распределение платежей и выплаты продавцам
+	SellerBalance = "seller_balance"
+	CreatePayout  = "create_payout"
+	GetPayout     = "get_payout"
+	TrialBalance  = "trial_balance"
+
+	// Сверка с выписками банка
+	ReconcileSettlement = "reconcile_settlement"
+
+	// Валюты и курсы
+	ConvertCurrency = "convert_currency"
+	LoadFXRates     = "load_fx_rates"
+
+	// Комиссии банка
+	CreateFeeSchedule     = "create_fee_schedule"
+	DeactivateFeeSchedule = "deactivate_fee_schedule"
+
+	// Лимиты платежей
+	CheckLimits     = "check_limits"
+	CreateLimit     = "create_limit"
+	DeactivateLimit = "deactivate_limit"
+
+	// Проверка риска
+	AssessRisk     = "assess_risk"
+	CreateRiskRule = "create_risk_rule"
+	UpdateRiskRule = "update_risk_rule"
+	UpdateRiskList = "update_risk_list"
+	ApprovePayment = "approve_payment"
+	RejectPayment  = "reject_payment"
+
+	// Чеки
+	IssueReceipt  = "issue_receipt"
+	SendReceipt   = "send_receipt"
+	GetReceipt    = "get_receipt"
+	ResendReceipt = "resend_receipt"
+
+	// Выгрузка платежей
+	ExportPayments = "export_payments"
+
+	// Журнал аудита
+	AuditLog = "audit_log"
+
 	PaymentTransactionFail = "payment_broker_transaction_failed"
+	UnknownBrokerState     = "unknown_broker_state"
 )
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Результат операции в журнале аудита
+type AuditResult string
+
+const (
+	AuditSuccess AuditResult = "SUCCESS"
+	AuditFailure AuditResult = "FAILURE"
+)
+
+// AuditEntry — запись журнала аудита изменяющей операции.
+type AuditEntry struct {
+	ID           int64
+	Actor        string         // Вызывающая сторона по ключу API (или адрес клиента без ключа)
+	ClaimedActor string         // Значение заголовка x-actor от клиента; не проверяется
+	Method       string         // Метод API (например, /payment.v1.Payment/RefundPayment)
+	PaymentID    string         // Пустой, если платёж не был создан
+	Params       map[string]any // Параметры запроса (чувствительные поля скрыты)
+	Result       AuditResult
+	Error        string // Ошибка операции
+	BrokerError  string // Ошибка брокера, если операция упала на его стороне
+	CreatedAt    time.Time
+}
+
+// AuditFilter — параметры выборки журнала аудита.
+type AuditFilter struct {
+	PaymentID   string
+	Actor       string
+	Method      string
+	Result      AuditResult
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+
+	// ID записи, после которой продолжать выборку (записи идут от новых к старым)
+	AfterID int64
+	Limit   int
+}
+
+// AuditPage — страница журнала аудита.
+type AuditPage struct {
+	Entries []AuditEntry
+	NextID  int64 // Значение AfterID для следующей страницы, 0 если страница последняя
+}
+
+// Caller — вызывающая сторона операции.
+type Caller struct {
+	Actor        string // Проверенная вызывающая сторона: имя ключа API, оператор CLI или адрес клиента
+	ClaimedActor string // Вызывающая сторона со слов клиента (заголовок x-actor)
+	Method       string
+	IP           string // Адрес клиента (пусто, если неизвестен)
+}
+
+type callerKey struct{}
+
+// WithCaller сохраняет вызывающую сторону в контексте.
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext возвращает вызывающую сторону из контекста.
+func CallerFromContext(ctx context.Context) Caller {
+	caller, _ := ctx.Value(callerKey{}).(Caller)
+	if caller.Actor == "" {
+		caller.Actor = "unknown"
+	}
+	if caller.Method == "" {
+		caller.Method = "unknown"
+	}
+	return caller
+}
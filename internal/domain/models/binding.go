@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Binding — сохранённая карта покупателя (связка у брокера).
+type Binding struct {
+	ID        string // ID связки у брокера
+	UserID    string
+	Broker    string
+	MaskedPan string // Маскированный номер карты
+	Expiry    string // Срок действия карты (YYYYMM)
+	PaymentID string // Платёж, при котором карта сохранена
+	CreatedAt time.Time
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrCartTotalMismatch = errors.New("cart items total does not match payment amount")
+
+// Customer — контакты покупателя для отправки чека.
+type Customer struct {
+	Email string
+	Phone string // В формате E.164
+}
+
+func (c Customer) IsZero() bool {
+	return c.Email == "" && c.Phone == ""
+}
+
+// CartItem — позиция корзины для чека. Цена — в валюте покупателя (presentment).
+type CartItem struct {
+	Name     string
+	Quantity float64
+	Price    float64 // Цена за единицу
+	TaxRate  float64 // Ставка НДС в процентах (0 — без НДС)
+}
+
+// Amount — стоимость позиции.
+func (i CartItem) Amount() float64 {
+	return RoundAmount(i.Quantity * i.Price)
+}
+
+// TaxAmount — НДС, входящий в стоимость позиции.
+func (i CartItem) TaxAmount() float64 {
+	return RoundAmount(i.Amount() * i.TaxRate / (100 + i.TaxRate))
+}
+
+// CartTotal — стоимость всех позиций.
+func CartTotal(items []CartItem) float64 {
+	var total float64
+	for _, item := range items {
+		total += item.Amount()
+	}
+	return RoundAmount(total)
+}
+
+// ValidateCart проверяет позиции корзины и что их стоимость равна сумме платежа.
+// Количество должно быть положительным, а цена — неотрицательной: иначе одни позиции
+// скрывали бы другие в итоге. Стоимость каждой позиции округляется до копеек. Пустая корзина не проверяется.
+func ValidateCart(items []CartItem, amount float64) error {
+	for i, item := range items {
+		if item.Quantity <= 0 || item.Price < 0 {
+			return NewValidationError(nil, fmt.Sprintf("items[%d]", i),
+				fmt.Sprintf("item quantity %v must be greater than 0 and price %.2f must not be negative", item.Quantity, item.Price))
+		}
+	}
+	if len(items) == 0 || CartTotal(items) == RoundAmount(amount) {
+		return nil
+	}
+	return NewValidationError(ErrCartTotalMismatch, "items",
+		fmt.Sprintf("items total %.2f does not match amount %.2f", CartTotal(items), amount))
+}
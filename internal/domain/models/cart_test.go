@@ -0,0 +1,72 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateCart(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []CartItem
+		amount  float64
+		wantErr error // nil — корзина подходит; errAny — любая ошибка валидации
+	}{
+		{"empty cart", nil, 100, nil},
+		{"matches amount", []CartItem{{Name: "Чай", Quantity: 2, Price: 150}, {Name: "Сахар", Quantity: 1, Price: 50.5}}, 350.5, nil},
+		{"total mismatch", []CartItem{{Name: "Чай", Quantity: 2, Price: 150}}, 299.99, ErrCartTotalMismatch},
+		{"amount above total", []CartItem{{Name: "Чай", Quantity: 1, Price: 100}}, 100.01, ErrCartTotalMismatch},
+		{"fractional quantity", []CartItem{{Name: "Сыр", Quantity: 0.347, Price: 1290}}, 447.63, nil},
+		{"each item rounded", []CartItem{{Name: "a", Quantity: 1, Price: 0.005}, {Name: "b", Quantity: 1, Price: 0.005}}, 0.02, nil},
+		{"float sum", []CartItem{{Name: "a", Quantity: 1, Price: 0.1}, {Name: "b", Quantity: 1, Price: 0.2}}, 0.3, nil},
+		{"free item", []CartItem{{Name: "Пакет", Quantity: 1, Price: 0}, {Name: "Чай", Quantity: 1, Price: 100}}, 100, nil},
+		{"zero quantity", []CartItem{{Name: "Чай", Quantity: 0, Price: 100}, {Name: "Сахар", Quantity: 1, Price: 100}}, 100, errAny},
+		{"negative quantity hides item", []CartItem{{Name: "Чай", Quantity: 2, Price: 100}, {Name: "Скидка", Quantity: -1, Price: 100}}, 100, errAny},
+		{"negative price", []CartItem{{Name: "Чай", Quantity: 2, Price: 100}, {Name: "Скидка", Quantity: 1, Price: -100}}, 100, errAny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCart(tt.items, tt.amount)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("ValidateCart: %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("ValidateCart error = %v, want ValidationError", err)
+			}
+			if tt.wantErr != errAny && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateCart error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// errAny — ожидается ошибка валидации без конкретной причины.
+var errAny = errors.New("any validation error")
+
+func TestCartItemAmounts(t *testing.T) {
+	tests := []struct {
+		item   CartItem
+		amount float64
+		tax    float64
+	}{
+		{CartItem{Quantity: 3, Price: 33.33}, 99.99, 0},
+		{CartItem{Quantity: 1, Price: 112, TaxRate: 12}, 112, 12},
+		{CartItem{Quantity: 0.5, Price: 99.99, TaxRate: 12}, 50, 5.36},
+		{CartItem{Quantity: 1, Price: 100, TaxRate: 20}, 100, 16.67},
+	}
+
+	for _, tt := range tests {
+		if got := tt.item.Amount(); got != tt.amount {
+			t.Errorf("Amount(%+v) = %v, want %v", tt.item, got, tt.amount)
+		}
+		if got := tt.item.TaxAmount(); got != tt.tax {
+			t.Errorf("TaxAmount(%+v) = %v, want %v", tt.item, got, tt.tax)
+		}
+	}
+}
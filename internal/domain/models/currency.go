@@ -1,5 +1,10 @@
 package models
 
+import (
+	"math"
+	"time"
+)
+
 // Supported Currency
 const (
 	KZT   = "KZT"
@@ -8,3 +13,53 @@ const (
 	USD   = "USD"
 	EMPTY = ""
 )
+
+// Currency — валюта из реестра (ISO 4217).
+type Currency struct {
+	Code            string // Буквенный код
+	Numeric         string // Цифровой код
+	Exponent        int    // Количество знаков минорной единицы
+	Enabled         bool   // Доступна для платежей мерчанта
+	BrokerSupported bool   // Банк принимает платежи в валюте без пересчёта
+}
+
+// Round округляет сумму до минорной единицы валюты.
+func (c Currency) Round(amount float64) float64 {
+	return RoundTo(amount, c.Exponent)
+}
+
+// FXRate — курс: Rate единиц Quote за единицу Base.
+type FXRate struct {
+	Base   string
+	Quote  string
+	Rate   float64
+	Source string // Откуда получен курс (файл, БД)
+	AsOf   time.Time
+}
+
+// Invert — обратный курс.
+func (r FXRate) Invert() FXRate {
+	return FXRate{
+		Base:   r.Quote,
+		Quote:  r.Base,
+		Rate:   1 / r.Rate,
+		Source: r.Source,
+		AsOf:   r.AsOf,
+	}
+}
+
+// FXConversion — пересчёт суммы из валюты покупателя в валюту расчётов с банком.
+// Без пересчёта Rate равен 1, а Currency совпадает с валютой покупателя.
+type FXConversion struct {
+	Amount   float64 // Сумма в валюте расчётов
+	Currency string  // Валюта расчётов
+	Exponent int     // Минорная единица валюты расчётов
+	Rate     FXRate  // Курс от валюты покупателя к валюте расчётов
+}
+
+// Apply пересчитывает дополнительную сумму (например, долю продавца) по тому же курсу.
+// Округляется вниз, чтобы сумма долей не превысила пересчитанную сумму платежа.
+func (c FXConversion) Apply(amount float64) float64 {
+	scale := math.Pow10(c.Exponent)
+	return math.Floor(amount*c.Rate.Rate*scale+1e-9) / scale
+}
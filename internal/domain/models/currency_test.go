@@ -0,0 +1,138 @@
+package models
+
+import "testing"
+
+func TestCurrencyRound(t *testing.T) {
+	tests := []struct {
+		exponent int
+		amount   float64
+		want     float64
+	}{
+		{2, 10.005, 10.01},
+		{2, 10.004, 10},
+		{0, 10.5, 11},
+		{3, 1.2345, 1.235},
+	}
+
+	for _, tt := range tests {
+		if got := (Currency{Exponent: tt.exponent}).Round(tt.amount); got != tt.want {
+			t.Errorf("Round(%v) with exponent %d = %v, want %v", tt.amount, tt.exponent, got, tt.want)
+		}
+	}
+}
+
+func TestRoundTo(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		exponent int
+		want     float64
+	}{
+		{1234.5, 0, 1235},
+		{10.005, 2, 10.01},
+		{1.2345, 3, 1.235},
+		{0.12345, 4, 0.1235},
+	}
+
+	for _, tt := range tests {
+		if got := RoundTo(tt.amount, tt.exponent); got != tt.want {
+			t.Errorf("RoundTo(%v, %d) = %v, want %v", tt.amount, tt.exponent, got, tt.want)
+		}
+	}
+	if got := RoundAmount(1.2345); got != 1.23 {
+		t.Errorf("RoundAmount(1.2345) = %v, want 1.23", got)
+	}
+}
+
+func TestPaymentSettlementAmount(t *testing.T) {
+	p := Payment{Amount: 3450, Currency: "KZT", PresentmentAmount: 1000, PresentmentCurrency: "JPY", FXRate: 3.45}
+
+	tests := []struct {
+		amount   float64
+		currency string
+		want     float64
+		ok       bool
+	}{
+		{100, "KZT", 100, true},
+		{1000, "JPY", 3450, true},
+		{1, "JPY", 3.45, true},
+		{7, "JPY", 24.15, true},
+		{1, "USD", 0, false},
+	}
+
+	for _, tt := range tests {
+		if got, ok := p.SettlementAmount(tt.amount, tt.currency, 2); got != tt.want || ok != tt.ok {
+			t.Errorf("SettlementAmount(%v %s) = %v, %v; want %v, %v", tt.amount, tt.currency, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// Без сохранённого курса валюта покупателя не принимается
+	if _, ok := (Payment{Amount: 100, Currency: "KZT", PresentmentCurrency: "JPY"}).SettlementAmount(10, "JPY", 2); ok {
+		t.Error("SettlementAmount without fx rate = ok, want false")
+	}
+}
+
+func TestFXRateInvert(t *testing.T) {
+	rate := FXRate{Base: "KZT", Quote: "USD", Rate: 0.002, Source: "file:rates.csv"}.Invert()
+	if rate.Base != "USD" || rate.Quote != "KZT" || rate.Rate != 500 || rate.Source != "file:rates.csv" {
+		t.Fatalf("Invert = %+v, want USD/KZT 500 from the same source", rate)
+	}
+}
+
+func TestPaymentConvert(t *testing.T) {
+	splits := []SplitRule{{SellerID: "s1", Amount: 3.33}, {SellerID: "s2", Amount: 3.33}}
+	p := Payment{Amount: 9.99, Currency: "USD", Splits: splits}
+
+	p.Convert(FXConversion{
+		Amount:   5118.28,
+		Currency: "KZT",
+		Exponent: 2,
+		Rate:     FXRate{Base: "USD", Quote: "KZT", Rate: 512.34, Source: "db"},
+	})
+
+	if p.Amount != 5118.28 || p.Currency != "KZT" || p.PresentmentAmount != 9.99 || p.PresentmentCurrency != "USD" {
+		t.Fatalf("payment = %+v, want KZT 5118.28 converted from USD 9.99", p)
+	}
+	if p.FXRate != 512.34 || p.FXSource != "db" {
+		t.Fatalf("fx snapshot = %v %q, want 512.34 from db", p.FXRate, p.FXSource)
+	}
+
+	// Доли округляются вниз и вместе не превышают сумму платежа
+	var total float64
+	for _, s := range p.Splits {
+		if s.Amount != 1706.09 {
+			t.Fatalf("split %s = %.2f, want 1706.09", s.SellerID, s.Amount)
+		}
+		total += s.Amount
+	}
+	if total > p.Amount {
+		t.Fatalf("splits total %.2f exceeds payment amount %.2f", total, p.Amount)
+	}
+	if splits[0].Amount != 3.33 {
+		t.Fatal("Convert modified the caller's split rules")
+	}
+}
+
+func TestPaymentConvertSameCurrency(t *testing.T) {
+	p := Payment{Amount: 100, Currency: "KZT", Splits: []SplitRule{{SellerID: "s1", Amount: 40}}}
+	p.Convert(FXConversion{Amount: 100, Currency: "KZT", Exponent: 2, Rate: FXRate{Rate: 1}})
+
+	if p.PresentmentAmount != 100 || p.PresentmentCurrency != "KZT" || p.Splits[0].Amount != 40 {
+		t.Fatalf("payment = %+v, want unchanged amounts", p)
+	}
+}
+
+func TestReceiptPresentment(t *testing.T) {
+	p := Payment{Amount: 5000, Currency: "KZT", PresentmentAmount: 10, PresentmentCurrency: "USD", FXRate: 500}
+
+	if amount, currency := p.presentment(5000); amount != 10 || currency != "USD" {
+		t.Fatalf("presentment(full) = %v %s, want 10 USD", amount, currency)
+	}
+	if amount, currency := p.presentment(1250); amount != 2.5 || currency != "USD" {
+		t.Fatalf("presentment(part) = %v %s, want 2.5 USD", amount, currency)
+	}
+
+	same := Payment{Amount: 100, Currency: "KZT"}
+	if amount, currency := same.presentment(40); amount != 40 || currency != "KZT" {
+		t.Fatalf("presentment without conversion = %v %s, want 40 KZT", amount, currency)
+	}
+}
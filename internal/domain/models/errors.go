@@ -0,0 +1,72 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// Ошибки брокера, к которым адаптер приводит коды ответов банка
+var (
+	ErrBrokerUnavailable         = errors.New("broker unavailable")
+	ErrBrokerOrderNotFound       = errors.New("order is not found at broker")
+	ErrBrokerDuplicateOrder      = errors.New("order is already registered at broker")
+	ErrBrokerUnsupportedCurrency = errors.New("currency is not supported by broker")
+	ErrBrokerRejectedRequest     = errors.New("broker rejected request parameters")
+	ErrBrokerOperationImpossible = errors.New("operation is impossible for current order state")
+	ErrPaymentDeclined           = errors.New("payment is declined")
+	ErrInsufficientFunds         = errors.New("insufficient funds for operation")
+	ErrBindingsNotSupported      = errors.New("card bindings are not supported by broker")
+	ErrOperationNotSupported     = errors.New("operation is not supported by broker")
+)
+
+// ErrFXRateNotFound — у поставщика курсов нет курса для пары валют.
+var ErrFXRateNotFound = errors.New("fx rate is not found")
+
+// ErrLimitExceeded — платёж превышает лимит пользователя или мерчанта (см. LimitExceededError).
+var ErrLimitExceeded = errors.New("payment limit exceeded")
+
+// FieldViolation — нарушение правила валидации для поля запроса.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError — ошибка валидации запроса с нарушениями по полям.
+// Может оборачивать доменную ошибку (например, превышение суммы) для errors.Is.
+type ValidationError struct {
+	Violations []FieldViolation
+	Cause      error
+}
+
+// NewValidationError создаёт ошибку валидации с одним нарушением.
+func NewValidationError(cause error, field, description string) *ValidationError {
+	return &ValidationError{
+		Violations: []FieldViolation{{Field: field, Description: description}},
+		Cause:      cause,
+	}
+}
+
+// Add добавляет нарушение для поля.
+func (e *ValidationError) Add(field, description string) {
+	e.Violations = append(e.Violations, FieldViolation{Field: field, Description: description})
+}
+
+// Err возвращает ошибку, если есть нарушения, иначе nil.
+func (e *ValidationError) Err() error {
+	if len(e.Violations) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		parts = append(parts, v.Field+": "+v.Description)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Cause
+}
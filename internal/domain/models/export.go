@@ -0,0 +1,19 @@
+package models
+
+// Формат выгрузки платежей
+type ExportFormat string
+
+const (
+	ExportCSV     ExportFormat = "CSV"
+	ExportJSONL   ExportFormat = "JSONL" // JSON Lines: один объект платежа на строку
+	ExportParquet ExportFormat = "PARQUET"
+)
+
+func IsExportFormatSupported(format ExportFormat) bool {
+	switch format {
+	case ExportCSV, ExportJSONL, ExportParquet:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+type FeeKind string
+
+const (
+	FeeDeposit FeeKind = "DEPOSIT"
+	FeeRefund  FeeKind = "REFUND"
+)
+
+// FeeTier — ставка для платежей на сумму до UpTo включительно (0 — без ограничения, только последняя).
+type FeeTier struct {
+	UpTo    float64
+	Percent float64
+	Fixed   float64
+}
+
+// FeeSchedule — тариф комиссии банка. Пустые Broker, Merchant и Currency подходят под любое значение,
+// из подходящих тарифов применяется самый конкретный.
+type FeeSchedule struct {
+	ID          string
+	Broker      string
+	Merchant    string
+	Currency    string
+	Percent     float64   // Процент от суммы списания
+	Fixed       float64   // Фиксированная часть за списание
+	Min         float64   // Минимальная комиссия за списание (0 — без ограничения)
+	Max         float64   // Максимальная комиссия за списание (0 — без ограничения)
+	Tiers       []FeeTier // Ставки по сумме платежа; если заданы, заменяют Percent и Fixed
+	RefundFixed float64   // Комиссия за каждый возврат
+	Active      bool
+	CreatedAt   time.Time
+}
+
+// PaymentFee — начисленная по платежу комиссия.
+type PaymentFee struct {
+	ID         int64
+	PaymentID  string
+	ScheduleID string
+	Kind       FeeKind
+	BaseAmount float64 // Сумма, от которой рассчитана комиссия
+	Amount     float64
+	Currency   string
+	CreatedAt  time.Time
+}
+
+// DepositFee — комиссия за списание суммы amount.
+func (s FeeSchedule) DepositFee(amount float64) float64 {
+	percent, fixed := s.Percent, s.Fixed
+	if len(s.Tiers) > 0 {
+		tier := s.tier(amount)
+		percent, fixed = tier.Percent, tier.Fixed
+	}
+
+	fee := amount*percent/100 + fixed
+	if s.Min > 0 && fee < s.Min {
+		fee = s.Min
+	}
+	if s.Max > 0 && fee > s.Max {
+		fee = s.Max
+	}
+	return RoundAmount(fee)
+}
+
+// tier — первая ставка, в диапазон которой попадает сумма; иначе последняя.
+// Ставки упорядочены по возрастанию UpTo, проверяется при создании тарифа.
+func (s FeeSchedule) tier(amount float64) FeeTier {
+	for _, t := range s.Tiers {
+		if t.UpTo == 0 || amount <= t.UpTo {
+			return t
+		}
+	}
+	return s.Tiers[len(s.Tiers)-1]
+}
@@ -0,0 +1,42 @@
+package models
+
+import "testing"
+
+func TestDepositFee(t *testing.T) {
+	tiered := FeeSchedule{Tiers: []FeeTier{
+		{UpTo: 1000, Percent: 3, Fixed: 10},
+		{UpTo: 10000, Percent: 2},
+		{Percent: 1.5},
+	}}
+
+	tests := []struct {
+		name     string
+		schedule FeeSchedule
+		amount   float64
+		want     float64
+	}{
+		{"percent", FeeSchedule{Percent: 2.5}, 1000, 25},
+		{"percent and fixed", FeeSchedule{Percent: 2, Fixed: 15}, 1000, 35},
+		{"rounded to minor units", FeeSchedule{Percent: 2.9}, 333.33, 9.67},
+		{"min applies", FeeSchedule{Percent: 1, Min: 50}, 1000, 50},
+		{"min not reached", FeeSchedule{Percent: 1, Min: 50}, 10000, 100},
+		{"max applies", FeeSchedule{Percent: 3, Max: 200}, 10000, 200},
+		{"max not reached", FeeSchedule{Percent: 3, Max: 200}, 1000, 30},
+		{"zero schedule", FeeSchedule{}, 1000, 0},
+		{"first tier", tiered, 500, 25},
+		{"tier bound is inclusive", tiered, 1000, 40},
+		{"middle tier", tiered, 1000.01, 20},
+		{"open-ended tier", tiered, 20000, 300},
+		{"tiers replace flat rate", FeeSchedule{Percent: 10, Fixed: 100, Tiers: []FeeTier{{Percent: 1}}}, 1000, 10},
+		{"tiers with max", FeeSchedule{Max: 250, Tiers: tiered.Tiers}, 20000, 250},
+		{"last tier for amounts above bounded tiers", FeeSchedule{Tiers: []FeeTier{{UpTo: 100, Percent: 1}, {UpTo: 200, Percent: 2}}}, 500, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.DepositFee(tt.amount); got != tt.want {
+				t.Fatalf("DepositFee(%.2f) = %.2f, want %.2f", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// Поля сортировки списка платежей
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortByAmount    SortField = "amount"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// PaymentFilter — параметры выборки списка платежей.
+// Пустые поля не участвуют в фильтрации.
+type PaymentFilter struct {
+	UserID        string
+	Statuses      []StatusType
+	Currencies    []string
+	Broker        string
+	OrderIDPrefix string
+	CreatedFrom   time.Time // Включительно
+	CreatedTo     time.Time // Не включительно
+	AmountMin     *float64
+	AmountMax     *float64
+	Metadata      map[string]string // Пары, которые должны быть в Metadata платежа
+
+	SortBy   SortField
+	SortDesc bool
+
+	// Непрозрачный курсор из PaymentPage.NextCursor. Имеет приоритет над Offset.
+	Cursor string
+	Offset int
+	Limit  int
+}
+
+// PaymentPage — страница списка платежей.
+type PaymentPage struct {
+	Payments   []Payment
+	Total      int    // Общее количество платежей, подходящих под фильтр
+	NextCursor string // Пустой, если страница последняя
+}
+
+func IsSortFieldSupported(field SortField) bool {
+	switch field {
+	case SortByCreatedAt, SortByAmount:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsStatusSupported(status StatusType) bool {
+	switch status {
+	case OrderReview, OrderCreated, OrderPending3DS, OrderApproved, OrderPartiallyDeposited, OrderDeposited,
+		OrderDeclined, OrderReversed, OrderPartiallyRefunded, OrderRefunded:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+type InvoiceStatus string
+
+const (
+	InvoiceOpen    InvoiceStatus = "OPEN"    // Ссылка принимает оплату
+	InvoicePaid    InvoiceStatus = "PAID"    // Одноразовый счёт оплачен
+	InvoiceExpired InvoiceStatus = "EXPIRED" // Срок действия ссылки истёк
+)
+
+// Invoice — счёт (платёжная ссылка), по которому платёж создаётся при открытии ссылки.
+type Invoice struct {
+	ID          string
+	Code        string // Короткий код публичной ссылки
+	UserID      string // Покупатель; если не задан, платежи создаются от имени счёта
+	Amount      float64
+	Currency    string
+	Description string
+	ReturnURL   string
+	FailURL     string
+	MultiUse    bool // Ссылку можно оплатить несколько раз
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+
+	Payments []InvoicePayment // Платежи, созданные по ссылке (от старых к новым)
+}
+
+// InvoicePayment — платёж, созданный при открытии ссылки.
+type InvoicePayment struct {
+	PaymentID  string
+	PaymentURL string
+	Status     StatusType
+	CreatedAt  time.Time
+}
+
+// IsPaid сообщает, прошла ли оплата (в том числе с последующим возвратом).
+func (p InvoicePayment) IsPaid() bool {
+	switch p.Status {
+	case OrderApproved, OrderPartiallyDeposited, OrderDeposited, OrderPartiallyRefunded, OrderRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsPending сообщает, ожидает ли платёж оплаты покупателем.
+func (p InvoicePayment) IsPending() bool {
+	return p.Status == OrderCreated || p.Status == OrderPending3DS
+}
+
+// PaidCount — количество оплаченных платежей по ссылке.
+func (inv Invoice) PaidCount() int {
+	var n int
+	for _, p := range inv.Payments {
+		if p.IsPaid() {
+			n++
+		}
+	}
+	return n
+}
+
+// StatusAt — статус счёта на момент now, определяемый платежами по нему.
+func (inv Invoice) StatusAt(now time.Time) InvoiceStatus {
+	if !inv.MultiUse && inv.PaidCount() > 0 {
+		return InvoicePaid
+	}
+	if !now.Before(inv.ExpiresAt) {
+		return InvoiceExpired
+	}
+	return InvoiceOpen
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+type AccountType string
+
+const (
+	AccountAsset     AccountType = "ASSET"
+	AccountLiability AccountType = "LIABILITY"
+	AccountRevenue   AccountType = "REVENUE"
+	AccountExpense   AccountType = "EXPENSE"
+	AccountMemo      AccountType = "MEMO" // Забалансовые счета (блокировки на картах покупателей)
+)
+
+// План счетов. Счета создаются отдельно для каждой валюты при первой проводке.
+const (
+	AccountAuthHolds        = "AUTH_HOLDS"        // Заблокированные на картах суммы
+	AccountAuthCommitments  = "AUTH_COMMITMENTS"  // Обязательства по блокировкам (контрсчёт AUTH_HOLDS)
+	AccountBrokerReceivable = "BROKER_RECEIVABLE" // Списанные суммы к получению от банка
+	AccountSales            = "SALES"             // Выручка по платежам
+	AccountRefunds          = "REFUNDS"           // Возвраты покупателям (уменьшают выручку)
+	AccountFees             = "FEES"              // Комиссии банка
+)
+
+var ChartOfAccounts = map[string]AccountType{
+	AccountAuthHolds:        AccountMemo,
+	AccountAuthCommitments:  AccountMemo,
+	AccountBrokerReceivable: AccountAsset,
+	AccountSales:            AccountRevenue,
+	AccountRefunds:          AccountRevenue,
+	AccountFees:             AccountExpense,
+}
+
+type JournalKind string
+
+const (
+	JournalAuthorization JournalKind = "AUTHORIZATION"
+	JournalCapture       JournalKind = "CAPTURE"
+	JournalRefund        JournalKind = "REFUND"
+	JournalReversal      JournalKind = "REVERSAL"
+	JournalAdjustment    JournalKind = "ADJUSTMENT"
+)
+
+// JournalKindFor — вид проводки для перехода платежа в статус.
+func JournalKindFor(status StatusType) JournalKind {
+	switch status {
+	case OrderApproved:
+		return JournalAuthorization
+	case OrderDeposited, OrderPartiallyDeposited:
+		return JournalCapture
+	case OrderRefunded, OrderPartiallyRefunded:
+		return JournalRefund
+	case OrderReversed:
+		return JournalReversal
+	default:
+		return JournalAdjustment
+	}
+}
+
+// Posting — строка проводки: дебет положительный, кредит отрицательный.
+type Posting struct {
+	Account string
+	Amount  float64
+}
+
+// PaymentPosition — денежное состояние платежа, по которому строятся проводки.
+type PaymentPosition struct {
+	Status   StatusType
+	Amount   float64
+	Captured float64
+	Released float64
+	Refunded float64
+	Fees     float64
+}
+
+// Balances — сальдо счетов по платежу в текущем состоянии. Сумма всегда равна нулю.
+// Проводка перехода — разница между этим сальдо и уже проведённым.
+func (p PaymentPosition) Balances() map[string]float64 {
+	var hold, captured float64
+	switch p.Status {
+	case OrderApproved, OrderPartiallyDeposited:
+		hold = RoundAmount(p.Amount - p.Captured - p.Released)
+	}
+	switch p.Status {
+	case OrderDeposited, OrderPartiallyDeposited, OrderPartiallyRefunded, OrderRefunded, OrderReversed:
+		captured = p.Captured
+		// Одностадийный платёж списывается целиком без отдельного capture
+		if captured == 0 && p.Status != OrderReversed {
+			captured = p.Amount
+		}
+	}
+
+	return map[string]float64{
+		AccountAuthHolds:        hold,
+		AccountAuthCommitments:  -hold,
+		AccountBrokerReceivable: RoundAmount(captured - p.Refunded - p.Fees),
+		AccountSales:            -captured,
+		AccountRefunds:          p.Refunded,
+		AccountFees:             p.Fees,
+	}
+}
+
+// Postings — проводка перехода в текущее состояние: разница между сальдо Balances
+// и уже проведёнными по платежу суммами posted. Пустая, если состояние уже проведено.
+func (p PaymentPosition) Postings(posted []Posting) []Posting {
+	balances := p.Balances()
+	for _, posting := range posted {
+		balances[posting.Account] -= posting.Amount
+	}
+
+	postings := make([]Posting, 0, len(balances))
+	for account, amount := range balances {
+		if amount = RoundAmount(amount); amount != 0 {
+			postings = append(postings, Posting{Account: account, Amount: amount})
+		}
+	}
+	slices.SortFunc(postings, func(a, b Posting) int { return strings.Compare(a.Account, b.Account) })
+	return postings
+}
+
+// TrialBalanceLine — обороты и сальдо счёта.
+type TrialBalanceLine struct {
+	Account  string
+	Type     AccountType
+	Currency string
+	Debit    float64
+	Credit   float64
+}
+
+// Balance — дебетовое сальдо (отрицательное для кредитового).
+func (l TrialBalanceLine) Balance() float64 {
+	return RoundAmount(l.Debit - l.Credit)
+}
+
+// TrialBalance — оборотно-сальдовая ведомость на момент AsOf.
+type TrialBalance struct {
+	AsOf  time.Time
+	Lines []TrialBalanceLine
+}
+
+// Balanced — обороты по дебету и кредиту совпадают в каждой валюте.
+func (tb TrialBalance) Balanced() bool {
+	totals := make(map[string]float64)
+	for _, l := range tb.Lines {
+		totals[l.Currency] += l.Debit - l.Credit
+	}
+	for _, total := range totals {
+		if RoundAmount(total) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package models
+
+import "testing"
+
+var allStatuses = []StatusType{
+	OrderReview, OrderCreated, OrderPending3DS, OrderApproved, OrderPartiallyDeposited, OrderDeposited,
+	OrderDeclined, OrderReversed, OrderPartiallyRefunded, OrderRefunded, OrderUnknown,
+}
+
+func postingsTotal(postings []Posting) float64 {
+	var total float64
+	for _, p := range postings {
+		total += p.Amount
+	}
+	return RoundAmount(total)
+}
+
+func TestBalancesSumToZero(t *testing.T) {
+	positions := []PaymentPosition{
+		{Amount: 1000},
+		{Amount: 1000, Captured: 400},
+		{Amount: 1000, Captured: 400, Released: 600},
+		{Amount: 1000, Captured: 1000, Refunded: 250.55, Fees: 12.3},
+		{Amount: 999.99, Captured: 0.01, Refunded: 0.01, Fees: 0.02},
+	}
+
+	for _, status := range allStatuses {
+		for _, pos := range positions {
+			pos.Status = status
+			var total float64
+			for _, amount := range pos.Balances() {
+				total += amount
+			}
+			if RoundAmount(total) != 0 {
+				t.Errorf("%s %+v: balances sum to %v, want 0", status, pos, total)
+			}
+		}
+	}
+}
+
+// TestJournalLifecycle проводит платёж через все переходы так же, как postJournalTx:
+// каждая проводка сходится, а накопленные обороты равны сальдо текущего состояния.
+func TestJournalLifecycle(t *testing.T) {
+	lifecycles := map[string][]PaymentPosition{
+		"two-stage with refunds": {
+			{Status: OrderCreated, Amount: 1000},
+			{Status: OrderApproved, Amount: 1000},
+			{Status: OrderPartiallyDeposited, Amount: 1000, Captured: 300, Fees: 3},
+			{Status: OrderDeposited, Amount: 1000, Captured: 800, Released: 200, Fees: 8},
+			{Status: OrderPartiallyRefunded, Amount: 1000, Captured: 800, Released: 200, Refunded: 100, Fees: 9},
+			{Status: OrderRefunded, Amount: 1000, Captured: 800, Released: 200, Refunded: 800, Fees: 16},
+		},
+		"one-stage": {
+			{Status: OrderCreated, Amount: 500},
+			{Status: OrderDeposited, Amount: 500, Fees: 5},
+			{Status: OrderRefunded, Amount: 500, Refunded: 500, Fees: 10},
+		},
+		"reversed authorization": {
+			{Status: OrderApproved, Amount: 700},
+			{Status: OrderReversed, Amount: 700, Released: 700},
+		},
+		"declined": {
+			{Status: OrderPending3DS, Amount: 300},
+			{Status: OrderDeclined, Amount: 300},
+		},
+	}
+
+	for name, steps := range lifecycles {
+		t.Run(name, func(t *testing.T) {
+			var posted []Posting
+			for _, pos := range steps {
+				entry := pos.Postings(posted)
+				if total := postingsTotal(entry); total != 0 {
+					t.Fatalf("%s: entry %+v does not balance: %v", pos.Status, entry, total)
+				}
+				posted = append(posted, entry...)
+
+				balances := pos.Balances()
+				totals := make(map[string]float64)
+				for _, p := range posted {
+					totals[p.Account] += p.Amount
+				}
+				for account, want := range balances {
+					if got := RoundAmount(totals[account]); got != RoundAmount(want) {
+						t.Fatalf("%s: %s posted %v, want balance %v", pos.Status, account, got, want)
+					}
+				}
+
+				if again := pos.Postings(posted); len(again) != 0 {
+					t.Fatalf("%s: re-posting the same state produced %+v", pos.Status, again)
+				}
+			}
+		})
+	}
+}
+
+func TestTrialBalanceBalanced(t *testing.T) {
+	balanced := TrialBalance{Lines: []TrialBalanceLine{
+		{Account: AccountBrokerReceivable, Currency: "KZT", Debit: 1000},
+		{Account: AccountSales, Currency: "KZT", Credit: 1000},
+		{Account: AccountBrokerReceivable, Currency: "USD", Debit: 10.1},
+		{Account: AccountSales, Currency: "USD", Credit: 10.1},
+	}}
+	if !balanced.Balanced() {
+		t.Fatal("Balanced() = false for balanced lines")
+	}
+
+	// Обороты сходятся в сумме, но не внутри каждой валюты
+	unbalanced := TrialBalance{Lines: []TrialBalanceLine{
+		{Account: AccountBrokerReceivable, Currency: "KZT", Debit: 10},
+		{Account: AccountSales, Currency: "USD", Credit: 10},
+	}}
+	if unbalanced.Balanced() {
+		t.Fatal("Balanced() = true for lines balanced only across currencies")
+	}
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Кому применяется лимит
+type LimitScope string
+
+const (
+	LimitScopeUser     LimitScope = "USER"     // Платежи пользователя (User_id)
+	LimitScopeMerchant LimitScope = "MERCHANT" // Платежи мерчанта (логин у банка)
+)
+
+func IsLimitScopeSupported(scope LimitScope) bool {
+	switch scope {
+	case LimitScopeUser, LimitScopeMerchant:
+		return true
+	default:
+		return false
+	}
+}
+
+// Период, за который суммируются платежи
+type LimitPeriod string
+
+const (
+	LimitSingle  LimitPeriod = "SINGLE"  // Один платёж
+	LimitDaily   LimitPeriod = "DAILY"   // Календарный день (UTC)
+	LimitMonthly LimitPeriod = "MONTHLY" // Календарный месяц (UTC)
+)
+
+// PaymentLimit — лимит платежей в валюте. Пустой Subject применяет лимит к каждому пользователю
+// (или мерчанту) отдельно. Нулевое значение лимита означает отсутствие ограничения.
+type PaymentLimit struct {
+	ID        string
+	Scope     LimitScope
+	Subject   string // ID пользователя или логин мерчанта
+	Currency  string
+	MaxSingle float64
+	Daily     float64
+	Monthly   float64
+	Active    bool
+	CreatedAt time.Time
+}
+
+// PendingLimitTTL — сколько неоплаченный платёж (CREATED, PENDING_3DS, REVIEW) держит лимит после
+// перехода в статус: время жизни сессии платёжной формы банка по умолчанию. Брошенная форма
+// перестаёт занимать лимит, а одобренный после ручной проверки платёж проверяется заново.
+const PendingLimitTTL = 20 * time.Minute
+
+// HoldsLimit сообщает, учитывается ли платёж в использованной части лимитов на момент now.
+// statusAt — время перехода в текущий статус. Отклонённые и отменённые платежи лимит не держат,
+// авторизованные и списанные держат его до конца периода.
+func (p Payment) HoldsLimit(statusAt, now time.Time) bool {
+	switch p.Status {
+	case OrderDeclined, OrderReversed:
+		return false
+	case OrderCreated, OrderPending3DS, OrderReview:
+		return now.Sub(statusAt) < PendingLimitTTL
+	default:
+		return true
+	}
+}
+
+// LimitUsage — использованная часть лимита за период без учёта проверяемого платежа.
+type LimitUsage struct {
+	Daily   float64
+	Monthly float64
+}
+
+// LimitExceededError — платёж превышает лимит. Содержит остаток лимита за период.
+type LimitExceededError struct {
+	Limit     PaymentLimit
+	Subject   string // Пользователь или мерчант, для которого сработал лимит
+	Period    LimitPeriod
+	Max       float64
+	Used      float64
+	Remaining float64
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s %s limit %.2f %s exceeded for %s: used %.2f, remaining %.2f",
+		e.Limit.Scope, e.Period, e.Max, e.Limit.Currency, e.Subject, e.Used, e.Remaining)
+}
+
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// Check проверяет платёж на сумму amount по лимиту с учётом уже использованной части.
+// Возвращает *LimitExceededError для первого превышенного периода.
+func (l PaymentLimit) Check(subject string, amount float64, usage LimitUsage) error {
+	periods := []struct {
+		period LimitPeriod
+		max    float64
+		used   float64
+	}{
+		{LimitSingle, l.MaxSingle, 0},
+		{LimitDaily, l.Daily, usage.Daily},
+		{LimitMonthly, l.Monthly, usage.Monthly},
+	}
+
+	for _, p := range periods {
+		if p.max <= 0 || RoundAmount(p.used+amount) <= p.max {
+			continue
+		}
+		remaining := RoundAmount(p.max - p.used)
+		if remaining < 0 {
+			remaining = 0
+		}
+		return &LimitExceededError{
+			Limit:     l,
+			Subject:   subject,
+			Period:    p.period,
+			Max:       p.max,
+			Used:      RoundAmount(p.used),
+			Remaining: remaining,
+		}
+	}
+	return nil
+}
+
+// LimitSubject — пользователь или мерчант платежа, по которому суммируются платежи для лимита.
+func (p Payment) LimitSubject(scope LimitScope) string {
+	if scope == LimitScopeMerchant {
+		return p.Merchant
+	}
+	return p.UserID
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPaymentLimitCheck(t *testing.T) {
+	limit := PaymentLimit{Scope: LimitScopeUser, Currency: "KZT", MaxSingle: 500, Daily: 1000, Monthly: 3000}
+
+	tests := []struct {
+		name      string
+		limit     PaymentLimit
+		amount    float64
+		usage     LimitUsage
+		period    LimitPeriod // Пусто — платёж проходит
+		used      float64
+		remaining float64
+	}{
+		{"within limits", limit, 500, LimitUsage{Daily: 500, Monthly: 2500}, "", 0, 0},
+		{"above single", limit, 500.01, LimitUsage{}, LimitSingle, 0, 500},
+		{"above daily", limit, 300, LimitUsage{Daily: 800, Monthly: 800}, LimitDaily, 800, 200},
+		{"above monthly", limit, 300, LimitUsage{Daily: 0, Monthly: 2900}, LimitMonthly, 2900, 100},
+		{"daily checked before monthly", limit, 300, LimitUsage{Daily: 900, Monthly: 2900}, LimitDaily, 900, 100},
+		{"usage already above limit", limit, 100, LimitUsage{Daily: 1200, Monthly: 1200}, LimitDaily, 1200, 0},
+		{"float sum at the limit", limit, 0.3, LimitUsage{Daily: 999.7, Monthly: 999.7}, "", 0, 0},
+		{"zero means no limit", PaymentLimit{Daily: 1000}, 1e9, LimitUsage{Monthly: 1e9}, LimitDaily, 0, 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limit.Check("u1", tt.amount, tt.usage)
+			if tt.period == "" {
+				if err != nil {
+					t.Fatalf("Check: %v", err)
+				}
+				return
+			}
+
+			var lerr *LimitExceededError
+			if !errors.As(err, &lerr) || !errors.Is(err, ErrLimitExceeded) {
+				t.Fatalf("Check error = %v, want LimitExceededError", err)
+			}
+			if lerr.Period != tt.period || lerr.Used != tt.used || lerr.Remaining != tt.remaining || lerr.Subject != "u1" {
+				t.Fatalf("Check = %s used %v remaining %v for %s, want %s used %v remaining %v",
+					lerr.Period, lerr.Used, lerr.Remaining, lerr.Subject, tt.period, tt.used, tt.remaining)
+			}
+		})
+	}
+}
+
+func TestPaymentHoldsLimit(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	fresh, stale := now.Add(-time.Minute), now.Add(-PendingLimitTTL)
+
+	tests := []struct {
+		status   StatusType
+		statusAt time.Time
+		want     bool
+	}{
+		{OrderCreated, fresh, true},
+		{OrderCreated, stale, false},
+		{OrderPending3DS, fresh, true},
+		{OrderPending3DS, stale, false},
+		{OrderReview, fresh, true},
+		{OrderReview, stale, false},
+		{OrderApproved, stale, true},
+		{OrderPartiallyDeposited, stale, true},
+		{OrderDeposited, stale, true},
+		{OrderPartiallyRefunded, stale, true},
+		{OrderRefunded, stale, true}, // Возвращённая сумма вычитается из использованной
+		{OrderUnknown, stale, true},
+		{OrderDeclined, fresh, false},
+		{OrderReversed, fresh, false},
+	}
+
+	for _, tt := range tests {
+		if got := (Payment{Status: tt.status}).HoldsLimit(tt.statusAt, now); got != tt.want {
+			t.Errorf("HoldsLimit(%s, %v ago) = %v, want %v", tt.status, now.Sub(tt.statusAt), got, tt.want)
+		}
+	}
+}
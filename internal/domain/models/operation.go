@@ -0,0 +1,109 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+type PaymentOperation string
+
+const (
+	URLpayment     = "URL_payment"     // Платёжная форма банка (hosted page)
+	BindingPayment = "BINDING_payment" // Оплата сохранённой картой без платёжной формы
+	CardPayment    = "CARD_payment"    // Оплата по токену карты (tokenized PAN) без платёжной формы
+	QRPayment      = "QR_payment"      // QR код для оплаты в приложении банка
+	WalletPayment  = "WALLET_payment"  // Оплата кошельком (Apple Pay, Google Pay) по токену кошелька
+)
+
+// Кошельки для WALLET_payment
+const (
+	WalletApplePay  = "APPLE_PAY"
+	WalletGooglePay = "GOOGLE_PAY"
+)
+
+var ErrInvalidPaymentMethod = errors.New("payment method does not match operation")
+
+// PaymentMethod — данные способа оплаты, которые нужны операции помимо суммы.
+type PaymentMethod struct {
+	ReturnURL   string // Адрес возврата после успешной оплаты на платёжной форме
+	FailURL     string // Адрес возврата после ошибки на платёжной форме
+	BindingID   string
+	CardToken   string // Токен карты от токенизатора; номер карты (PAN) не принимается
+	WalletType  string
+	WalletToken string
+	PreAuth     bool // Двухстадийная оплата на форме: банк только блокирует сумму до DepositPayment
+}
+
+// OperationRule — требования операции к способу оплаты.
+type OperationRule struct {
+	Operation  PaymentOperation
+	HostedPage bool // Покупатель платит на форме банка, нужны адреса возврата
+	Binding    bool // Нужна сохранённая карта
+	CardToken  bool // Нужен токен карты
+	Wallet     bool // Нужен токен кошелька
+	Direct     bool // Банк проводит оплату сразу, без действий покупателя
+}
+
+// OperationRules — поддерживаемые операции оплаты.
+var OperationRules = map[PaymentOperation]OperationRule{
+	URLpayment:     {Operation: URLpayment, HostedPage: true},
+	BindingPayment: {Operation: BindingPayment, Binding: true, Direct: true},
+	CardPayment:    {Operation: CardPayment, CardToken: true, Direct: true},
+	QRPayment:      {Operation: QRPayment},
+	WalletPayment:  {Operation: WalletPayment, Wallet: true, Direct: true},
+}
+
+// Validate проверяет, что способ оплаты содержит всё, что нужно операции, и не содержит лишнего.
+func (r OperationRule) Validate(m PaymentMethod) error {
+	verr := ValidationError{Cause: ErrInvalidPaymentMethod}
+	op := string(r.Operation)
+
+	if r.HostedPage {
+		for _, f := range []struct{ field, value string }{{"return_url", m.ReturnURL}, {"error_url", m.FailURL}} {
+			if f.value == "" {
+				verr.Add(f.field, fmt.Sprintf("%s is required for %s", f.field, op))
+			} else if u, err := url.Parse(f.value); err != nil || !u.IsAbs() {
+				verr.Add(f.field, fmt.Sprintf("%s %q must be an absolute URL", f.field, f.value))
+			}
+		}
+	}
+
+	requireOnly := func(field, value string, required bool) {
+		switch {
+		case required && value == "":
+			verr.Add(field, fmt.Sprintf("%s is required for %s", field, op))
+		case !required && value != "":
+			verr.Add(field, fmt.Sprintf("%s is not allowed for %s", field, op))
+		}
+	}
+	requireOnly("binding_id", m.BindingID, r.Binding)
+	requireOnly("card_token", m.CardToken, r.CardToken)
+	requireOnly("wallet.type", m.WalletType, r.Wallet)
+	requireOnly("wallet.token", m.WalletToken, r.Wallet)
+
+	if m.CardToken != "" && looksLikePAN(m.CardToken) {
+		verr.Add("card_token", "card_token must be a token issued by the tokenizer, not a card number")
+	}
+	if m.WalletType != "" && !slices.Contains([]string{WalletApplePay, WalletGooglePay}, m.WalletType) {
+		verr.Add("wallet.type", fmt.Sprintf("wallet type %q is not supported", m.WalletType))
+	}
+
+	return verr.Err()
+}
+
+// looksLikePAN — строка из 12-19 цифр (возможно, с пробелами или дефисами), то есть номер карты.
+func looksLikePAN(s string) bool {
+	digits := 0
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == ' ' || r == '-':
+		default:
+			return false
+		}
+	}
+	return digits >= 12 && digits <= 19
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOperationRuleValidate(t *testing.T) {
+	hosted := PaymentMethod{ReturnURL: "https://shop.example.com/ok", FailURL: "https://shop.example.com/fail"}
+
+	tests := []struct {
+		name      string
+		operation PaymentOperation
+		method    PaymentMethod
+		fields    []string // Поля с нарушениями; пусто — способ оплаты подходит
+	}{
+		{"url payment", URLpayment, hosted, nil},
+		{"url payment without return urls", URLpayment, PaymentMethod{}, []string{"return_url", "error_url"}},
+		{"url payment with relative url", URLpayment, PaymentMethod{ReturnURL: "/ok", FailURL: hosted.FailURL}, []string{"return_url"}},
+		{"url payment with binding", URLpayment, PaymentMethod{ReturnURL: hosted.ReturnURL, FailURL: hosted.FailURL, BindingID: "b1"}, []string{"binding_id"}},
+		{"binding payment", BindingPayment, PaymentMethod{BindingID: "b1"}, nil},
+		{"binding payment without binding", BindingPayment, PaymentMethod{}, []string{"binding_id"}},
+		{"card payment", CardPayment, PaymentMethod{CardToken: "tok_123"}, nil},
+		{"card payment with pan", CardPayment, PaymentMethod{CardToken: "4111 1111 1111 1111"}, []string{"card_token"}},
+		{"card payment with wallet", CardPayment, PaymentMethod{CardToken: "tok_123", WalletType: WalletApplePay}, []string{"wallet.type"}},
+		{"wallet payment", WalletPayment, PaymentMethod{WalletType: WalletGooglePay, WalletToken: "w1"}, nil},
+		{"wallet payment without token", WalletPayment, PaymentMethod{WalletType: WalletApplePay}, []string{"wallet.token"}},
+		{"unknown wallet", WalletPayment, PaymentMethod{WalletType: "SAMSUNG_PAY", WalletToken: "w1"}, []string{"wallet.type"}},
+		{"qr payment", QRPayment, PaymentMethod{}, nil},
+		{"qr payment with card token", QRPayment, PaymentMethod{CardToken: "tok_123"}, []string{"card_token"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := OperationRules[tt.operation].Validate(tt.method)
+			if len(tt.fields) == 0 {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) || !errors.Is(err, ErrInvalidPaymentMethod) {
+				t.Fatalf("Validate error = %v, want ValidationError with ErrInvalidPaymentMethod", err)
+			}
+			if len(verr.Violations) != len(tt.fields) {
+				t.Fatalf("violations = %+v, want fields %v", verr.Violations, tt.fields)
+			}
+			for i, field := range tt.fields {
+				if verr.Violations[i].Field != field {
+					t.Errorf("violation %d field = %s, want %s", i, verr.Violations[i].Field, field)
+				}
+			}
+		})
+	}
+}
+
+func TestOperationRules(t *testing.T) {
+	for operation, rule := range OperationRules {
+		if rule.Operation != operation {
+			t.Errorf("rule for %s has operation %s", operation, rule.Operation)
+		}
+		// Прямые операции проходят без покупателя, поэтому платёжная форма им не нужна
+		if rule.Direct && rule.HostedPage {
+			t.Errorf("%s is both direct and hosted", operation)
+		}
+	}
+	if _, ok := OperationRules["SBP_payment"]; ok {
+		t.Error("unknown operation has a rule")
+	}
+}
+
+func TestLooksLikePAN(t *testing.T) {
+	for value, want := range map[string]bool{
+		"4111111111111111":     true,
+		"4111-1111-1111-1111":  true,
+		"411111111111":         true,
+		"41111111111":          false,
+		"41111111111111111111": false,
+		"tok_4111111111111111": false,
+		"":                     false,
+	} {
+		if got := looksLikePAN(value); got != want {
+			t.Errorf("looksLikePAN(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -1,23 +1,131 @@
 package models
 
 import (
+	"math"
+	"slices"
 	"time"
 )
 
-type PaymentOperation string
-
-const (
-	URLpayment = "URL_payment"
-)
-
 type Payment struct {
 	ID        string  // Создается на стороне брокера
 	OrderID   string  // ID заказа
 	UserID    string  // ID заказчика
 	Broker    string  // Имя банка
-	Amount    float64 // Сумма заказа
+	Merchant  string  // Логин мерчанта у банка
+	Amount    float64 // Сумма заказа в валюте расчётов с банком
 	Currency  string  //  Тип валюты (стандарт ISO)
 	Operation PaymentOperation
 	Status    StatusType
 	CreatedAt time.Time
+
+	CapturedAmount float64 // Списанная сумма (сумма всех capture)
+	ReleasedAmount float64 // Разблокированный остаток после финального capture
+	RefundedAmount float64 // Сумма возвратов
+	FeeAmount      float64 // Комиссии банка за списания и возвраты
+
+	PresentmentAmount   float64   // Сумма в валюте покупателя
+	PresentmentCurrency string    // Валюта покупателя
+	FXRate              float64   // Курс пересчёта в валюту расчётов (1 — без пересчёта)
+	FXSource            string    // Источник курса
+	FXRateAt            time.Time // Момент, на который взят курс
+
+	Splits []SplitRule // Доли продавцов; остаток — комиссия платформы
+
+	ClientIP     string       // Адрес покупателя
+	RiskDecision RiskDecision // Решение проверки риска (пусто для платежей до её появления)
+	RiskRules    []string     // Сработавшие правила риска
+
+	Description string         // Описание для покупателя
+	Metadata    map[string]any // Произвольные данные мерчанта (ID корзины, SKU и т.п.)
+
+	// Данные для чека. Загружаются только при получении одного платежа.
+	Customer Customer
+	Items    []CartItem
+}
+
+// PaymentDetails — описание, данные мерчанта и данные для чека, которые сохраняются с платежом
+// и передаются брокеру.
+type PaymentDetails struct {
+	Description string
+	Metadata    map[string]any
+	Customer    Customer
+	Items       []CartItem
+}
+
+// RemainingAmount — авторизованная сумма, доступная для списания.
+func (p Payment) RemainingAmount() float64 {
+	return RoundAmount(p.Amount - p.CapturedAmount - p.ReleasedAmount)
+}
+
+// RefundableAmount — списанная сумма, ещё не возвращённая покупателю.
+// Одностадийный платёж при переходе в DEPOSITED считается списанным целиком.
+func (p Payment) RefundableAmount() float64 {
+	return RoundAmount(p.CapturedAmount - p.RefundedAmount)
+}
+
+// SettlementAmount пересчитывает сумму операции по платежу в валюту расчётов.
+// Сумма в валюте покупателя пересчитывается по курсу, сохранённому при создании платежа,
+// и округляется до exponent знаков валюты расчётов; полная сумма в валюте покупателя
+// соответствует полной сумме платежа. Сумма в валюте расчётов не меняется.
+// Возвращает false, если валюта не совпадает ни с одной из валют платежа.
+func (p Payment) SettlementAmount(amount float64, currency string, exponent int) (float64, bool) {
+	switch {
+	case currency == p.Currency:
+		return amount, true
+	case currency != p.PresentmentCurrency || p.FXRate <= 0:
+		return 0, false
+	case amount == p.PresentmentAmount:
+		return p.Amount, true
+	default:
+		return RoundTo(amount*p.FXRate, exponent), true
+	}
+}
+
+// NetAmount — сумма к получению от банка: списано за вычетом возвратов и комиссий.
+func (p Payment) NetAmount() float64 {
+	return PaymentPosition{
+		Status:   p.Status,
+		Amount:   p.Amount,
+		Captured: p.CapturedAmount,
+		Released: p.ReleasedAmount,
+		Refunded: p.RefundedAmount,
+		Fees:     p.FeeAmount,
+	}.Settled()
+}
+
+// Convert переводит платёж в валюту расчётов с банком и сохраняет снимок курса.
+// Доли продавцов пересчитываются по тому же курсу.
+func (p *Payment) Convert(conv FXConversion) {
+	p.PresentmentAmount, p.PresentmentCurrency = p.Amount, p.Currency
+	p.Amount, p.Currency = conv.Amount, conv.Currency
+	p.FXRate, p.FXSource, p.FXRateAt = conv.Rate.Rate, conv.Rate.Source, conv.Rate.AsOf
+
+	if p.PresentmentCurrency == p.Currency {
+		return
+	}
+	p.Splits = slices.Clone(p.Splits)
+	for i := range p.Splits {
+		p.Splits[i].Amount = conv.Apply(p.Splits[i].Amount)
+	}
+}
+
+// Capture — списание (полное или частичное) ранее авторизованных средств.
+type Capture struct {
+	PaymentID  string
+	Amount     float64
+	Final      bool // Остаток после списания разблокируется
+	BrokerCode string
+	CreatedAt  time.Time
+}
+
+// RoundAmount округляет сумму до копеек — масштаба денежных колонок БД.
+// Сумму в валюте с другой минорной единицей округляет RoundTo.
+func RoundAmount(amount float64) float64 {
+	return RoundTo(amount, 2)
+}
+
+// RoundTo округляет сумму до exponent знаков после запятой (JPY — 0, KZT — 2, KWD — 3).
+func RoundTo(amount float64, exponent int) float64 {
+	scale := math.Pow10(exponent)
+	return math.Round(amount*scale) / scale
 }
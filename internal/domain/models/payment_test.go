@@ -0,0 +1,25 @@
+package models
+
+import "testing"
+
+func TestRefundableAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		payment Payment
+		want    float64
+	}{
+		{"authorized only", Payment{Amount: 1000}, 0},
+		{"captured", Payment{Amount: 1000, CapturedAmount: 1000}, 1000},
+		{"partially captured", Payment{Amount: 1000, CapturedAmount: 400, ReleasedAmount: 600}, 400},
+		{"partially refunded", Payment{Amount: 1000, CapturedAmount: 1000, RefundedAmount: 250.55}, 749.45},
+		{"fully refunded", Payment{Amount: 1000, CapturedAmount: 400, RefundedAmount: 400}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.payment.RefundableAmount(); got != tt.want {
+				t.Fatalf("RefundableAmount = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
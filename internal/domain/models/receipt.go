@@ -0,0 +1,154 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrReceiptNoContact = errors.New("customer has no email or phone for receipt")
+	ErrReceiptsDisabled = errors.New("receipts are disabled")
+)
+
+// Вид чека
+type ReceiptKind string
+
+const (
+	ReceiptSale   ReceiptKind = "SALE"   // Приход: платёж списан (DEPOSITED)
+	ReceiptRefund ReceiptKind = "REFUND" // Возврат прихода: платёж возвращён (REFUNDED)
+)
+
+func IsReceiptKindSupported(kind ReceiptKind) bool {
+	switch kind {
+	case ReceiptSale, ReceiptRefund:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReceiptKindFor — вид чека, который формируется при переходе платежа в статус.
+func ReceiptKindFor(status StatusType) (ReceiptKind, bool) {
+	switch status {
+	case OrderDeposited:
+		return ReceiptSale, true
+	case OrderRefunded:
+		return ReceiptRefund, true
+	default:
+		return "", false
+	}
+}
+
+// Состояние чека
+type ReceiptStatus string
+
+const (
+	ReceiptPending ReceiptStatus = "PENDING" // Ждёт регистрации у фискального провайдера
+	ReceiptIssued  ReceiptStatus = "ISSUED"  // Зарегистрирован
+	ReceiptFailed  ReceiptStatus = "FAILED"  // Попытки регистрации исчерпаны
+)
+
+// Формат документа чека
+type ReceiptFormat string
+
+const (
+	ReceiptHTML ReceiptFormat = "HTML"
+	ReceiptPDF  ReceiptFormat = "PDF"
+)
+
+func IsReceiptFormatSupported(format ReceiptFormat) bool {
+	switch format {
+	case ReceiptHTML, ReceiptPDF:
+		return true
+	default:
+		return false
+	}
+}
+
+// Receipt — чек по платежу. Фискальные данные хранятся в БД,
+// содержимое собирается из сохранённого платежа и корзины (Fill).
+type Receipt struct {
+	ID        string
+	PaymentID string
+	Kind      ReceiptKind
+	Status    ReceiptStatus
+
+	Provider     string // Фискальный провайдер, зарегистрировавший чек
+	FiscalNumber string // Фискальный признак чека
+	FiscalURL    string // Ссылка на чек у провайдера
+	Attempts     int
+	LastError    string
+
+	CreatedAt time.Time
+	IssuedAt  *time.Time // Пусто, пока чек не зарегистрирован
+	SentAt    *time.Time // Последняя отправка покупателю
+
+	// Содержимое чека
+	OrderID     string
+	Merchant    string
+	Description string
+	Amount      float64 // Сумма чека в валюте покупателя
+	Currency    string
+	Customer    Customer
+	Items       []CartItem
+}
+
+// Fiscalization — результат регистрации чека у фискального провайдера.
+type Fiscalization struct {
+	Provider string
+	Number   string
+	URL      string
+}
+
+// ReceiptDocument — отрисованный чек.
+type ReceiptDocument struct {
+	Format      ReceiptFormat
+	ContentType string
+	Content     []byte
+}
+
+// Fill заполняет содержимое чека по платежу с корзиной.
+// Сумма чека — списанная (для прихода) или возвращённая (для возврата) сумма в валюте покупателя.
+// Если корзины нет или её сумма не совпадает с суммой чека (частичное списание),
+// чек содержит одну позицию на всю сумму.
+func (r *Receipt) Fill(p Payment) {
+	r.OrderID, r.Merchant, r.Description = p.OrderID, p.Merchant, p.Description
+	r.Customer = p.Customer
+
+	settled := p.CapturedAmount
+	if r.Kind == ReceiptRefund && p.RefundedAmount > 0 {
+		settled = p.RefundedAmount
+	}
+	r.Amount, r.Currency = p.presentment(settled)
+
+	if len(p.Items) > 0 && CartTotal(p.Items) == r.Amount {
+		r.Items = p.Items
+		return
+	}
+
+	name := p.Description
+	if name == "" {
+		name = "Order " + p.OrderID
+	}
+	r.Items = []CartItem{{Name: name, Quantity: 1, Price: r.Amount}}
+}
+
+// TaxTotal — НДС, входящий в сумму чека.
+func (r Receipt) TaxTotal() float64 {
+	var total float64
+	for _, item := range r.Items {
+		total += item.TaxAmount()
+	}
+	return RoundAmount(total)
+}
+
+// presentment переводит сумму в валюте расчётов в валюту покупателя по курсу платежа.
+func (p Payment) presentment(amount float64) (float64, string) {
+	if p.PresentmentCurrency == "" || p.PresentmentCurrency == p.Currency || p.FXRate == 0 {
+		return amount, p.Currency
+	}
+	if amount == p.Amount {
+		return p.PresentmentAmount, p.PresentmentCurrency
+	}
+	return RoundAmount(amount / p.FXRate), p.PresentmentCurrency
+}
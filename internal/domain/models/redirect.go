@@ -0,0 +1,123 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidRedirectURL   = errors.New("redirect URL is not allowed")
+	ErrInvalidRedirectHosts = errors.New("invalid redirect hosts")
+)
+
+// Подстановки в адресах возврата. Значения экранируются как сегмент пути или как значение query.
+const (
+	RedirectOrderID   = "order_id"
+	RedirectUserID    = "user_id"
+	RedirectPaymentID = "payment_id" // ID платежа у мерчанта: известен до регистрации заказа у банка
+)
+
+var redirectPlaceholder = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// RedirectPolicy — ограничения адресов возврата с платёжной формы банка.
+type RedirectPolicy struct {
+	Hosts     []string // Разрешённые хосты; *.example.com — любой поддомен. Пустой список — ни один хост
+	AllowHTTP bool     // Разрешить http (только для разработки)
+}
+
+// RedirectVars — значения подстановок для платежа. Вместо ID заказа у банка, который появляется
+// только после передачи банку адресов возврата, подставляется ID платежа у мерчанта.
+func (p Payment) RedirectVars(localID string) map[string]string {
+	return map[string]string{
+		RedirectOrderID:   p.OrderID,
+		RedirectUserID:    p.UserID,
+		RedirectPaymentID: localID,
+	}
+}
+
+// ExpandRedirectURL подставляет значения в шаблон адреса вида https://shop.kz/orders/{order_id}.
+// До ? значение экранируется как сегмент пути, после — как значение query.
+// Неизвестная подстановка добавляет нарушение для поля.
+func ExpandRedirectURL(field, template string, vars map[string]string, verr *ValidationError) string {
+	expand := func(part string, escape func(string) string) string {
+		return redirectPlaceholder.ReplaceAllStringFunc(part, func(match string) string {
+			name := match[1 : len(match)-1]
+			value, ok := vars[name]
+			if !ok {
+				verr.Add(field, fmt.Sprintf("unknown placeholder %s", match))
+			}
+			return escape(value)
+		})
+	}
+
+	query := strings.IndexAny(template, "?#")
+	if query < 0 {
+		query = len(template)
+	}
+	return expand(template[:query], url.PathEscape) + expand(template[query:], url.QueryEscape)
+}
+
+// ParseRedirectHosts разбирает список хостов через запятую или пробел. Допустим хост
+// или *.example.com для поддоменов; другие формы * не принимаются.
+func ParseRedirectHosts(value string) ([]string, error) {
+	var hosts []string
+	for _, host := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		host = strings.ToLower(host)
+		name := strings.TrimPrefix(host, "*.")
+		if name == "" || strings.Contains(name, "*") || strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") ||
+			strings.ContainsAny(name, ":/@?#") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRedirectHosts, host)
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+// Check проверяет адрес возврата: абсолютный URL со схемой https (или http, если разрешено),
+// без логина и пароля, с хостом из списка разрешённых.
+func (p RedirectPolicy) Check(field, raw string, verr *ValidationError) {
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		verr.Add(field, fmt.Sprintf("%s %q must be an absolute URL", field, raw))
+		return
+	}
+
+	switch {
+	case u.Scheme == "https":
+	case u.Scheme == "http" && p.AllowHTTP:
+	default:
+		verr.Add(field, fmt.Sprintf("%s scheme %q is not allowed", field, u.Scheme))
+	}
+	if u.User != nil {
+		verr.Add(field, fmt.Sprintf("%s must not contain credentials", field))
+	}
+	if !p.hostAllowed(u.Hostname()) {
+		verr.Add(field, fmt.Sprintf("%s host %q is not in the allowlist", field, u.Hostname()))
+	}
+}
+
+// hostAllowed — хост есть в списке. *.example.com совпадает с поддоменом любой глубины, но не
+// с самим example.com и не с badexample.com. Пустой список не разрешает ни один хост.
+func (p RedirectPolicy) hostAllowed(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" {
+		return false
+	}
+
+	for _, allowed := range p.Hosts {
+		allowed = strings.ToLower(allowed)
+		if parent, ok := strings.CutPrefix(allowed, "*."); ok {
+			if sub, ok := strings.CutSuffix(host, "."+parent); ok && sub != "" {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package models
+
+import "time"
+
+// RiskDecision — решение проверки риска перед обращением к банку.
+type RiskDecision string
+
+const (
+	RiskAllow  RiskDecision = "ALLOW"  // Платёж проводится
+	RiskReview RiskDecision = "REVIEW" // Платёж ждёт ручного решения в статусе REVIEW
+	RiskDeny   RiskDecision = "DENY"   // Платёж отклоняется без обращения к банку
+)
+
+// IsRiskDecisionSupported сообщает, является ли строка решением проверки риска.
+func IsRiskDecisionSupported(decision RiskDecision) bool {
+	switch decision {
+	case RiskAllow, RiskReview, RiskDeny:
+		return true
+	default:
+		return false
+	}
+}
+
+// Источник правила риска
+const (
+	RiskSourceConfig = "config"
+	RiskSourceDB     = "db"
+)
+
+// RiskRule — правило проверки риска: при выполнении условия Expression платёж получает решение Decision.
+type RiskRule struct {
+	ID         string
+	Name       string
+	Decision   RiskDecision
+	Expression string
+	Enabled    bool
+	Source     string // config или db
+	CreatedAt  time.Time
+}
+
+// RiskInput — данные платежа, по которым проверяется риск.
+type RiskInput struct {
+	OrderID   string
+	UserID    string
+	ClientIP  string
+	Amount    float64 // Сумма в валюте покупателя
+	Currency  string
+	Operation PaymentOperation
+}
+
+// RiskVelocity — количество и сумма недавних платежей пользователя и адреса.
+type RiskVelocity struct {
+	UserCount1h   int
+	UserCount24h  int
+	UserAmount24h float64 // Сумма платежей пользователя за сутки в валюте проверяемого платежа
+	IPCount1h     int
+	IPCount24h    int
+}
+
+// RiskAssessment — результат проверки риска и сработавшие правила.
+type RiskAssessment struct {
+	Decision RiskDecision
+	Rules    []string
+}
+
+// ReviewStatus — состояние ручной проверки платежа.
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "PENDING"
+	ReviewApproved ReviewStatus = "APPROVED"
+	ReviewRejected ReviewStatus = "REJECTED"
+)
+
+// PaymentReview — платёж, отложенный проверкой риска до ручного решения.
+// ReviewID — ID, под которым платёж был создан; после одобрения платёж получает ID заказа у банка.
+type PaymentReview struct {
+	ReviewID   string
+	PaymentID  string
+	Status     ReviewStatus
+	Method     PaymentMethod // Очищается после решения
+	Reviewer   string
+	Note       string
+	CreatedAt  time.Time
+	ResolvedAt time.Time
+}
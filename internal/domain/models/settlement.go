@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+type DiscrepancyKind string
+
+const (
+	MissingLocally   DiscrepancyKind = "MISSING_LOCALLY"   // Операция есть в выписке банка, но не найдена в БД
+	MissingAtBank    DiscrepancyKind = "MISSING_AT_BANK"   // Списанный платёж за период отсутствует в выписке
+	AmountMismatch   DiscrepancyKind = "AMOUNT_MISMATCH"   // Сумма в выписке не совпадает с БД
+	CurrencyMismatch DiscrepancyKind = "CURRENCY_MISMATCH" // Валюта в выписке не совпадает с валютой платежа
+	StatusMismatch   DiscrepancyKind = "STATUS_MISMATCH"   // Статус в выписке не совпадает с текущим статусом платежа
+)
+
+// ColumnMapping — заголовки колонок файла выписки банка.
+// Должна быть задана колонка суммы и хотя бы одна из колонок payment_id/order_id.
+type ColumnMapping struct {
+	PaymentID  string
+	OrderID    string
+	Amount     string
+	Currency   string
+	Status     string
+	Delimiter  rune
+	MinorUnits bool                  // Суммы указаны в копейках
+	Statuses   map[string]StatusType // Статусы банка в выписке -> статусы платежа
+}
+
+// DefaultColumnMapping — формат выписки по умолчанию.
+func DefaultColumnMapping() ColumnMapping {
+	return ColumnMapping{
+		PaymentID: "payment_id",
+		OrderID:   "order_id",
+		Amount:    "amount",
+		Currency:  "currency",
+		Status:    "status",
+		Delimiter: ',',
+	}
+}
+
+// SettlementLine — строка выписки банка. Возвраты указываются отрицательной суммой.
+type SettlementLine struct {
+	Line      int // Номер строки в файле
+	PaymentID string
+	OrderID   string
+	Amount    float64
+	Currency  string
+	Status    StatusType
+}
+
+// LocalSettlement — платёж из БД, сопоставляемый со строками выписки.
+type LocalSettlement struct {
+	PaymentID string
+	OrderID   string
+	Currency  string
+	Status    StatusType
+	Settled   float64 // Списано за вычетом возвратов и комиссий
+}
+
+// Discrepancy — расхождение выписки банка с БД.
+type Discrepancy struct {
+	Kind        DiscrepancyKind
+	PaymentID   string
+	OrderID     string
+	BankAmount  float64
+	LocalAmount float64
+	BankStatus  StatusType
+	LocalStatus StatusType
+}
+
+// Reconciliation — результат сверки файла выписки за период.
+type Reconciliation struct {
+	ID            string
+	FileName      string
+	PeriodStart   time.Time
+	PeriodEnd     time.Time
+	Lines         int // Строк в выписке
+	Matched       int // Платежей без расхождений
+	Discrepancies []Discrepancy
+	CreatedAt     time.Time
+}
+
+// Settled — списанная сумма за вычетом возвратов и комиссий (сальдо BROKER_RECEIVABLE по платежу).
+func (p PaymentPosition) Settled() float64 {
+	return p.Balances()[AccountBrokerReceivable]
+}
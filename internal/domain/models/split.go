@@ -0,0 +1,121 @@
+package models
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+)
+
+// PlatformParty — участник проводок, получающий комиссию платформы (остаток после долей продавцов).
+const PlatformParty = "platform"
+
+type LedgerEntryType string
+
+const (
+	LedgerDeposit  LedgerEntryType = "DEPOSIT"
+	LedgerRefund   LedgerEntryType = "REFUND"
+	LedgerReversal LedgerEntryType = "REVERSAL"
+)
+
+// SplitRule — доля продавца в сумме платежа.
+type SplitRule struct {
+	SellerID string
+	Amount   float64
+}
+
+// LedgerShare — сумма, причитающаяся участнику.
+type LedgerShare struct {
+	Party  string
+	Amount float64
+}
+
+// SellerBalance — баланс продавца в одной валюте.
+type SellerBalance struct {
+	SellerID  string
+	Currency  string
+	Available float64 // Начислено и ещё не выплачено
+	PaidOut   float64
+}
+
+// Payout — пакет выплат продавцам.
+type Payout struct {
+	ID        string
+	Lines     []PayoutLine
+	CreatedAt time.Time
+}
+
+type PayoutLine struct {
+	SellerID string
+	Currency string
+	Amount   float64
+	Entries  int // Количество проводок в выплате
+}
+
+// SplitsTotal — сумма долей продавцов.
+func SplitsTotal(rules []SplitRule) float64 {
+	var total float64
+	for _, r := range rules {
+		total += r.Amount
+	}
+	return RoundAmount(total)
+}
+
+// AllocateSplits распределяет списанную сумму deposited платежа на сумму total между продавцами
+// пропорционально их долям. Продавцы вместе получают округлённую пропорциональную часть суммы
+// их долей: копейки, потерянные округлением отдельных долей, достаются продавцам с наибольшей
+// дробной частью (при равенстве — первому по порядку правил). Остаток достаётся платформе
+// и не бывает отрицательным.
+func AllocateSplits(rules []SplitRule, total, deposited float64) []LedgerShare {
+	shares := make([]LedgerShare, 0, len(rules)+1)
+	if deposited == total {
+		for _, r := range rules {
+			shares = append(shares, LedgerShare{Party: r.SellerID, Amount: r.Amount})
+		}
+		return append(shares, LedgerShare{Party: PlatformParty, Amount: RoundAmount(deposited - SplitsTotal(rules))})
+	}
+
+	// Расчёт в копейках: доли округляются вниз, недостающие копейки раздаются по дробной части
+	sellers := math.Round(SplitsTotal(rules) * deposited / total * 100)
+	cents := make([]float64, len(rules))
+	fractions := make([]float64, len(rules))
+	var allocated float64
+	for i, r := range rules {
+		exact := r.Amount * deposited / total * 100
+		cents[i] = math.Floor(exact + 1e-9)
+		fractions[i] = exact - cents[i]
+		allocated += cents[i]
+	}
+
+	order := make([]int, len(rules))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(fractions[b], fractions[a]) })
+	for _, i := range order {
+		if allocated >= sellers {
+			break
+		}
+		cents[i]++
+		allocated++
+	}
+
+	for i, r := range rules {
+		shares = append(shares, LedgerShare{Party: r.SellerID, Amount: cents[i] / 100})
+	}
+	return append(shares, LedgerShare{Party: PlatformParty, Amount: RoundAmount(deposited - allocated/100)})
+}
+
+// LedgerDeltas — проводки, приводящие уже начисленные доли posted к распределению shares.
+// При частичном возврате (decreaseOnly) доли только уменьшаются: возврат не начисляет новых сумм.
+func LedgerDeltas(shares []LedgerShare, posted map[string]float64, decreaseOnly bool) []LedgerShare {
+	var deltas []LedgerShare
+	for _, share := range shares {
+		delta := RoundAmount(share.Amount - posted[share.Party])
+		if delta == 0 || (decreaseOnly && delta > 0) {
+			continue
+		}
+		deltas = append(deltas, LedgerShare{Party: share.Party, Amount: delta})
+	}
+	return deltas
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllocateSplits(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     []SplitRule
+		total     float64
+		deposited float64
+		want      []LedgerShare
+	}{
+		{
+			name:      "full deposit keeps rule amounts",
+			rules:     []SplitRule{{"a", 600}, {"b", 300}},
+			total:     1000,
+			deposited: 1000,
+			want:      []LedgerShare{{"a", 600}, {"b", 300}, {PlatformParty, 100}},
+		},
+		{
+			name:      "partial capture is proportional",
+			rules:     []SplitRule{{"a", 600}, {"b", 300}},
+			total:     1000,
+			deposited: 500,
+			want:      []LedgerShare{{"a", 300}, {"b", 150}, {PlatformParty, 50}},
+		},
+		{
+			// 33.333… каждому: копейка округления достаётся первому продавцу, а не платформе
+			name:      "rounding remainder goes to a seller",
+			rules:     []SplitRule{{"a", 50}, {"b", 50}, {"c", 50}},
+			total:     150,
+			deposited: 100,
+			want:      []LedgerShare{{"a", 33.34}, {"b", 33.33}, {"c", 33.33}, {PlatformParty, 0}},
+		},
+		{
+			// Дробная часть у b больше: копейка достаётся ему
+			name:      "largest fraction gets the cent",
+			rules:     []SplitRule{{"a", 10}, {"b", 20}},
+			total:     30,
+			deposited: 0.1,
+			want:      []LedgerShare{{"a", 0.03}, {"b", 0.07}, {PlatformParty, 0}},
+		},
+		{
+			// Округление каждой доли вверх дало бы продавцам 0.02 из 0.01
+			name:      "platform never goes negative",
+			rules:     []SplitRule{{"a", 0.5}, {"b", 0.5}},
+			total:     1,
+			deposited: 0.01,
+			want:      []LedgerShare{{"a", 0.01}, {"b", 0}, {PlatformParty, 0}},
+		},
+		{
+			name:      "platform keeps remainder outside splits",
+			rules:     []SplitRule{{"a", 333.33}},
+			total:     1000,
+			deposited: 999.99,
+			want:      []LedgerShare{{"a", 333.33}, {PlatformParty, 666.66}},
+		},
+		{
+			name:      "no splits",
+			total:     1000,
+			deposited: 400,
+			want:      []LedgerShare{{PlatformParty, 400}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AllocateSplits(tt.rules, tt.total, tt.deposited)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("AllocateSplits = %v, want %v", got, tt.want)
+			}
+
+			var sum float64
+			for _, s := range got {
+				if s.Amount < 0 {
+					t.Fatalf("share %s = %.2f is negative", s.Party, s.Amount)
+				}
+				sum += s.Amount
+			}
+			if RoundAmount(sum) != tt.deposited {
+				t.Fatalf("shares sum to %.2f, want deposited %.2f", sum, tt.deposited)
+			}
+		})
+	}
+}
+
+// Баланс продавца — сумма его проводок: начисление при списании, уменьшение при частичном
+// возврате и сторно при полном возврате.
+func TestSellerBalanceLifecycle(t *testing.T) {
+	rules := []SplitRule{{"a", 500}, {"b", 250}}
+	const total = 1000.0
+
+	balances := make(map[string]float64)
+	post := func(deltas []LedgerShare) {
+		for _, d := range deltas {
+			balances[d.Party] = RoundAmount(balances[d.Party] + d.Amount)
+		}
+	}
+	check := func(step string, want map[string]float64) {
+		t.Helper()
+		if !reflect.DeepEqual(balances, want) {
+			t.Fatalf("%s: balances = %v, want %v", step, balances, want)
+		}
+	}
+
+	// Частичное списание, затем списание остатка: доначисляется только разница
+	post(LedgerDeltas(AllocateSplits(rules, total, 400), balances, false))
+	check("capture 400", map[string]float64{"a": 200, "b": 100, PlatformParty: 100})
+	post(LedgerDeltas(AllocateSplits(rules, total, 1000), balances, false))
+	check("capture 1000", map[string]float64{"a": 500, "b": 250, PlatformParty: 250})
+
+	// Повторная проводка того же состояния ничего не добавляет
+	if deltas := LedgerDeltas(AllocateSplits(rules, total, 1000), balances, false); len(deltas) != 0 {
+		t.Fatalf("repeated deposit deltas = %v, want none", deltas)
+	}
+
+	// Частичный возврат 100.01 уменьшает доли до распределения 899.99: продавцам 674.99,
+	// копейка округления у b с большей дробной частью (224.9975 против 449.995)
+	post(LedgerDeltas(AllocateSplits(rules, total, 899.99), balances, true))
+	check("refund 100.01", map[string]float64{"a": 449.99, "b": 225, PlatformParty: 225})
+
+	// При частичном возврате доли не растут, даже если распределение больше начисленного
+	if deltas := LedgerDeltas(AllocateSplits(rules, total, 1000), balances, true); len(deltas) != 0 {
+		t.Fatalf("refund deltas = %v, want no increases", deltas)
+	}
+
+	// Полный возврат сторнирует начисленное
+	for party, amount := range balances {
+		post([]LedgerShare{{party, -amount}})
+	}
+	check("refund in full", map[string]float64{"a": 0, "b": 0, PlatformParty: 0})
+}
@@ -7,16 +7,60 @@ import (
 type StatusType string
 
 const (
-	OrderCreated   StatusType = "CREATED"   // Заказ создан (но не оплачен)
-	OrderApproved  StatusType = "APPROVED"  // Заказ одобрен (средства на счету покупателя заблокированы)
-	OrderDeposited StatusType = "DEPOSITED" // Заказ завершен (деньги списаны со счета покупателя)
-	OrderDeclined  StatusType = "DECLINED"  // Заказ отклонен
-	OrderReversed  StatusType = "REVERSED"  // Авторизованный заказ отклонен
-	OrderRefunded  StatusType = "REFUNDED"  // Возврат средств
+	OrderReview             StatusType = "REVIEW"              // Платёж ждёт ручного решения по проверке риска, в банк не отправлен
+	OrderCreated            StatusType = "CREATED"             // Заказ создан (но не оплачен)
+	OrderPending3DS         StatusType = "PENDING_3DS"         // Ожидается подтверждение 3-D Secure
+	OrderApproved           StatusType = "APPROVED"            // Заказ одобрен (средства на счету покупателя заблокированы)
+	OrderPartiallyDeposited StatusType = "PARTIALLY_DEPOSITED" // Часть авторизованной суммы списана
+	OrderDeposited          StatusType = "DEPOSITED"           // Заказ завершен (деньги списаны со счета покупателя)
+	OrderDeclined           StatusType = "DECLINED"            // Заказ отклонен
+	OrderReversed           StatusType = "REVERSED"            // Авторизованный заказ отклонен
+	OrderPartiallyRefunded  StatusType = "PARTIALLY_REFUNDED"  // Возвращена часть списанных средств
+	OrderRefunded           StatusType = "REFUNDED"            // Возврат средств
+
+	// Состояние заказа у брокера не распознано. В БД не сохраняется.
+	OrderUnknown StatusType = "UNKNOWN"
+)
+
+// Источник перехода платежа в новый статус
+type StatusSource string
+
+const (
+	SourceAPI          StatusSource = "API"           // Вызов API сервиса
+	SourceBankCallback StatusSource = "BANK_CALLBACK" // Подтверждение оплаты после редиректа из банка
+	SourceReconciler   StatusSource = "RECONCILER"    // Сверка статуса с брокером
 )
 
 type PaymentStatus struct {
+	PaymentID  string
+	CreatedAt  time.Time
+	Status     string
+	Source     StatusSource
+	BrokerCode string // Код ответа брокера (пустой, если брокер не вызывался)
+}
+
+// StatusChange — переход платежа в новый статус.
+type StatusChange struct {
+	Status     StatusType
+	Source     StatusSource
+	BrokerCode string
+}
+
+// BrokerStatus — статус заказа на стороне брокера.
+type BrokerStatus struct {
+	Status StatusType
+	Code   string // Исходное состояние заказа у брокера
+}
+
+// SyncResult — результат сверки локального статуса платежа со статусом у брокера.
+type SyncResult struct {
 	PaymentID string
-	CreatedAt time.Time
-	Status    string
+	Previous  StatusType // Статус в БД до сверки
+	Current   StatusType // Статус у брокера
+	Err       error
+}
+
+// Changed сообщает, был ли обновлён статус платежа.
+func (r SyncResult) Changed() bool {
+	return r.Err == nil && r.Previous != r.Current
 }
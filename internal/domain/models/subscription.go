@@ -0,0 +1,114 @@
+package models
+
+import "time"
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionTrialing SubscriptionStatus = "TRIALING" // Пробный период, списаний ещё не было
+	SubscriptionActive   SubscriptionStatus = "ACTIVE"   // Текущий период оплачен
+	SubscriptionPastDue  SubscriptionStatus = "PAST_DUE" // Списание не прошло, идут повторные попытки
+	SubscriptionCanceled SubscriptionStatus = "CANCELED" // Подписка отменена
+)
+
+type SubscriptionEventType string
+
+const (
+	EventSubscriptionCreated        SubscriptionEventType = "CREATED"
+	EventSubscriptionRenewed        SubscriptionEventType = "RENEWED"
+	EventSubscriptionFailed         SubscriptionEventType = "PAYMENT_FAILED"
+	EventSubscriptionPaymentPending SubscriptionEventType = "PAYMENT_PENDING" // Списание ждёт проверки или ответа банка
+	EventPlanChanged                SubscriptionEventType = "PLAN_CHANGED"
+	EventCancelScheduled            SubscriptionEventType = "CANCEL_SCHEDULED"
+	EventSubscriptionCancelled      SubscriptionEventType = "CANCELED"
+)
+
+// Интервалы повторных списаний после неудачной оплаты (dunning).
+// После исчерпания попыток подписка отменяется.
+var DunningSchedule = []time.Duration{
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+}
+
+// Plan — тарифный план подписки.
+type Plan struct {
+	ID             string
+	Name           string
+	Amount         float64 // Стоимость одного периода
+	Currency       string
+	IntervalMonths int // Длительность периода в месяцах
+	TrialDays      int // Длительность пробного периода
+	Active         bool
+	CreatedAt      time.Time
+}
+
+// PeriodEnd — окончание периода, начинающегося в start.
+func (p Plan) PeriodEnd(start time.Time) time.Time {
+	return start.AddDate(0, p.IntervalMonths, 0)
+}
+
+// Subscription — подписка пользователя на план с оплатой сохранённой картой.
+type Subscription struct {
+	ID                 string
+	UserID             string
+	PlanID             string
+	BindingID          string
+	Status             SubscriptionStatus
+	CurrentPeriodStart time.Time
+	CurrentPeriodEnd   time.Time
+	NextChargeAt       time.Time
+	Cycle              int     // Количество оплаченных периодов
+	FailedAttempts     int     // Неудачные попытки списания за текущий период
+	Credit             float64 // Остаток после понижения плана, уменьшает следующее списание
+	CancelAtPeriodEnd  bool
+	CanceledAt         *time.Time
+	CreatedAt          time.Time
+}
+
+// SubscriptionCharge — попытка списания по подписке.
+type SubscriptionCharge struct {
+	SubscriptionID string
+	PaymentID      string
+	OrderID        string
+	Cycle          int
+	Attempt        int
+	Amount         float64
+	Succeeded      bool
+	Error          string
+}
+
+// SubscriptionEvent — событие жизненного цикла подписки.
+type SubscriptionEvent struct {
+	ID             int64
+	SubscriptionID string
+	Type           SubscriptionEventType
+	PaymentID      string
+	Amount         float64
+	Reason         string
+	CreatedAt      time.Time
+}
+
+// BillingResult — результат списания по подписке.
+type BillingResult struct {
+	SubscriptionID string
+	PaymentID      string
+	Status         SubscriptionStatus
+	Err            error
+}
+
+// Prorate — доплата (или возврат в кредит, если отрицательна) при смене плана посреди периода.
+// Считается пропорционально неиспользованной части периода.
+func Prorate(oldAmount, newAmount float64, start, end, now time.Time) float64 {
+	total := end.Sub(start)
+	if total <= 0 || !now.Before(end) {
+		return 0
+	}
+
+	unused := end.Sub(now)
+	if unused > total {
+		unused = total
+	}
+
+	return RoundAmount((newAmount - oldAmount) * unused.Seconds() / total.Seconds())
+}
@@ -2,42 +2,265 @@ package ports
 
 import (
 	"context"
+	"io"
 	"payment/internal/domain/models"
+	"time"
 )
 
 type Broker interface {
 	CreateOrder(ctx context.Context, payment *models.Payment, returnURL string, errorURL string) (formURL string, err error)
 	CreateAuthOrder(ctx context.Context, payment *models.Payment, returnURL string, errorURL string) (string, error)
-	GetOrderStatus(ctx context.Context, paymentID string) (models.StatusType, error)
+	GetOrderStatus(ctx context.Context, paymentID string) (models.BrokerStatus, error)
 	GetOrderDetails(ctx context.Context, paymentID string) (models.Payment, error)
-	DepositOrder(ctx context.Context, paymentID string, amount float64, currency string) error
-	ReversalOrder(ctx context.Context, paymentID string, amount float64, currencyStr string) error
-	RefundOrder(ctx context.Context, paymentID string, amount float64, currencyStr string) error
+	DepositOrder(ctx context.Context, paymentID string, amount float64, currency string) (brokerCode string, err error)
+	ReversalOrder(ctx context.Context, paymentID string, amount float64, currencyStr string) (brokerCode string, err error)
+	RefundOrder(ctx context.Context, paymentID string, amount float64, currencyStr string) (brokerCode string, err error)
+	GetOrderBinding(ctx context.Context, paymentID string) (*models.Binding, error)
+	ChargeBinding(ctx context.Context, payment *models.Payment, bindingID string) (models.BrokerStatus, error)
+	ChargeCard(ctx context.Context, payment *models.Payment, cardToken string) (models.BrokerStatus, error)
+	ChargeWallet(ctx context.Context, payment *models.Payment, walletType, walletToken string) (models.BrokerStatus, error)
+	CreateQROrder(ctx context.Context, payment *models.Payment) (qrPayload string, err error)
+	Supports(operation models.PaymentOperation) bool
+	Merchant() string
 	Ping() error
 }
 
 type PaymentRepo interface {
-	Create(ctx context.Context, transaction models.Payment) error
+	Reserve(ctx context.Context, transaction models.Payment) error
+	ConfirmReservation(ctx context.Context, reservationID string, transaction models.Payment, change models.StatusChange, audit models.AuditEntry) error
+	ReleaseReservation(ctx context.Context, reservationID string) error
 	Delete(ctx context.Context, paymentID string) error
 	IsUnique(ctx context.Context, orderID string) (uniq bool, err error)
 	GetStatus(ctx context.Context, paymentID string) (*models.PaymentStatus, error)
-	MarkStatus(ctx context.Context, paymentID string, status models.StatusType) error
-	Refund(ctx context.Context, paymentID, reason string, amount float64) error
+	GetStatusHistory(ctx context.Context, paymentID string) ([]models.PaymentStatus, error)
+	MarkStatus(ctx context.Context, paymentID string, change models.StatusChange, audit models.AuditEntry) error
+	Refund(ctx context.Context, paymentID, reason string, amount float64, change models.StatusChange, audit models.AuditEntry) error
+	Capture(ctx context.Context, capture models.Capture, change models.StatusChange, audit models.AuditEntry) error
+	Release(ctx context.Context, paymentID string, amount float64, change models.StatusChange, audit models.AuditEntry) error
+	SaveAudit(ctx context.Context, entry models.AuditEntry) error
+	ListAudit(ctx context.Context, filter models.AuditFilter) (models.AuditPage, error)
 	GetTransactionByPaymentID(ctx context.Context, paymentID string) (*models.Payment, error)
-	UserPaymentsList(ctx context.Context, userID string, offset, limit int) ([]models.Payment, error)
+	PaymentIDByLocalID(ctx context.Context, localID string) (string, error)
+	GetTransactionByOrderID(ctx context.Context, orderID string) (*models.Payment, error)
+	ListPayments(ctx context.Context, filter models.PaymentFilter) (models.PaymentPage, error)
+	ExportPayments(ctx context.Context, filter models.PaymentFilter, fn func(models.Payment) error) error
+	PaymentsSince(ctx context.Context, since time.Time) ([]models.Payment, error)
 	UpdateByOrderID(ctx context.Context, transaction models.Payment) error
+	SaveBinding(ctx context.Context, binding models.Binding) error
+	GetBinding(ctx context.Context, bindingID string) (*models.Binding, error)
+	ListBindings(ctx context.Context, userID string) ([]models.Binding, error)
+	DeleteBinding(ctx context.Context, bindingID string, audit models.AuditEntry) error
+	CreateReview(ctx context.Context, transaction models.Payment, method models.PaymentMethod, audit models.AuditEntry) error
+	GetReview(ctx context.Context, paymentID string) (*models.PaymentReview, error)
+	ResolveReview(ctx context.Context, review models.PaymentReview, payment models.Payment, paymentURL string, change models.StatusChange, audit models.AuditEntry) error
+	CheckLimits(ctx context.Context, transaction models.Payment) error
+	GetPaymentCart(ctx context.Context, paymentID string) (models.Customer, []models.CartItem, error)
 	Ping(context.Context) error
 }
 
 type PaymentService interface {
 	HealthCheck(ctx context.Context) error
-	CreatePayment(ctx context.Context, orderID, userID string, amount float64, currency string, operation string, returnUrl, failUrl string) (payment models.Payment, pay_url string, err error)
-	AuthPayment(ctx context.Context, orderID, userID string, amount float64, currency string, returnUrl, failUrl string) (payment models.Payment, pay_url string, err error)
-	DepositPayment(context.Context, string, float64, string) (models.StatusType, error)
+	CreatePayment(ctx context.Context, orderID, userID string, amount float64, currency string, operation string, method models.PaymentMethod, splits []models.SplitRule, details models.PaymentDetails) (payment models.Payment, next string, err error)
+	AuthPayment(ctx context.Context, orderID, userID string, amount float64, currency string, returnUrl, failUrl string, details models.PaymentDetails) (payment models.Payment, pay_url string, err error)
+	DepositPayment(ctx context.Context, paymentID string, amount float64, currency string, final bool) (models.Payment, error)
 	GetPayment(ctx context.Context, orderID string) (models.Payment, error)
+	GetPaymentByOrderID(ctx context.Context, orderID string) (models.Payment, error)
+	ResolvePaymentID(ctx context.Context, paymentID, orderID string) (string, error)
 	GetPaymentStatus(ctx context.Context, orderID string) (models.StatusType, error)
-	RefundPayment(ctx context.Context, orderID, reason string) (models.StatusType, error)
+	GetPaymentHistory(ctx context.Context, paymentID string) ([]models.PaymentStatus, error)
+	AuditLog(ctx context.Context, filter models.AuditFilter) (models.AuditPage, error)
+	RefundPayment(ctx context.Context, paymentID, reason string, amount float64, currency string) (models.StatusType, error)
 	SuccessPayment(ctx context.Context, orderID string) (models.StatusType, error)
-	PaymentsList(ctx context.Context, userID string, pageNumber, pageSize int) ([]models.Payment, error)
+	PaymentsList(ctx context.Context, filter models.PaymentFilter, pageNumber int) (models.PaymentPage, error)
 	ReversalPayment(ctx context.Context, paymentID string, amount float64, currency string) (models.StatusType, error)
+	SyncPayment(ctx context.Context, paymentID string) (models.SyncResult, error)
+	ReconcilePayments(ctx context.Context, since time.Time) ([]models.SyncResult, error)
+	ListBindings(ctx context.Context, userID string) ([]models.Binding, error)
+	DeleteBinding(ctx context.Context, userID, bindingID string) error
+	ChargeBinding(ctx context.Context, orderID, userID, bindingID string, amount float64, currency string) (models.Payment, error)
+	ApprovePayment(ctx context.Context, paymentID, note string) (payment models.Payment, next string, err error)
+	RejectPayment(ctx context.Context, paymentID, note string) (models.Payment, error)
+}
+
+type SubscriptionRepo interface {
+	CreatePlan(ctx context.Context, plan models.Plan) (models.Plan, error)
+	GetPlan(ctx context.Context, planID string) (*models.Plan, error)
+	ListPlans(ctx context.Context, includeInactive bool) ([]models.Plan, error)
+	CreateSubscription(ctx context.Context, sub models.Subscription, event models.SubscriptionEvent) (models.Subscription, error)
+	GetSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error)
+	UpdateSubscription(ctx context.Context, sub models.Subscription, charge *models.SubscriptionCharge, event models.SubscriptionEvent) error
+	ClaimDueSubscriptions(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.Subscription, error)
+	ListSubscriptionEvents(ctx context.Context, subscriptionID string) ([]models.SubscriptionEvent, error)
+}
+
+type SubscriptionService interface {
+	CreatePlan(ctx context.Context, plan models.Plan) (models.Plan, error)
+	ListPlans(ctx context.Context, includeInactive bool) ([]models.Plan, error)
+	Subscribe(ctx context.Context, userID, planID, bindingID string) (models.Subscription, error)
+	GetSubscription(ctx context.Context, subscriptionID string) (models.Subscription, error)
+	CancelSubscription(ctx context.Context, subscriptionID string, atPeriodEnd bool) (models.Subscription, error)
+	ChangePlan(ctx context.Context, subscriptionID, planID string) (models.Subscription, error)
+	SubscriptionEvents(ctx context.Context, subscriptionID string) ([]models.SubscriptionEvent, error)
+	BillDueSubscriptions(ctx context.Context, now time.Time) ([]models.BillingResult, error)
+}
+
+type InvoiceRepo interface {
+	CreateInvoice(ctx context.Context, invoice models.Invoice) (models.Invoice, error)
+	GetInvoice(ctx context.Context, invoiceID string) (*models.Invoice, error)
+	GetInvoiceByCode(ctx context.Context, code string) (*models.Invoice, error)
+	AddInvoicePayment(ctx context.Context, invoiceID string, payment models.InvoicePayment) error
+}
+
+type InvoiceService interface {
+	CreateInvoice(ctx context.Context, invoice models.Invoice) (models.Invoice, error)
+	GetInvoice(ctx context.Context, invoiceID string) (models.Invoice, error)
+	InvoiceByCode(ctx context.Context, code string) (models.Invoice, error)
+	OpenInvoice(ctx context.Context, code string) (paymentURL string, err error)
+	InvoiceURL(code string) string
+}
+
+type LedgerRepo interface {
+	SellerBalances(ctx context.Context, sellerID string) ([]models.SellerBalance, error)
+	CreatePayout(ctx context.Context) (*models.Payout, error)
+	GetPayout(ctx context.Context, payoutID string) (*models.Payout, error)
+	TrialBalance(ctx context.Context, asOf time.Time) (models.TrialBalance, error)
+}
+
+type LedgerService interface {
+	SellerBalances(ctx context.Context, sellerID string) ([]models.SellerBalance, error)
+	CreatePayout(ctx context.Context) (models.Payout, error)
+	GetPayout(ctx context.Context, payoutID string) (models.Payout, error)
+	TrialBalance(ctx context.Context, asOf time.Time) (models.TrialBalance, error)
+}
+
+type SettlementRepo interface {
+	LocalSettlements(ctx context.Context, paymentIDs, orderIDs []string) ([]models.LocalSettlement, error)
+	SettledPayments(ctx context.Context, from, to time.Time) ([]models.LocalSettlement, error)
+	SaveReconciliation(ctx context.Context, rec models.Reconciliation) (models.Reconciliation, error)
+	GetReconciliation(ctx context.Context, reconciliationID string) (*models.Reconciliation, error)
+}
+
+type SettlementService interface {
+	Reconcile(ctx context.Context, fileName string, file io.Reader, mapping models.ColumnMapping, from, to time.Time) (models.Reconciliation, error)
+	GetReconciliation(ctx context.Context, reconciliationID string) (models.Reconciliation, error)
+}
+
+type FeeRepo interface {
+	CreateFeeSchedule(ctx context.Context, schedule models.FeeSchedule) (models.FeeSchedule, error)
+	ListFeeSchedules(ctx context.Context, includeInactive bool) ([]models.FeeSchedule, error)
+	DeactivateFeeSchedule(ctx context.Context, scheduleID string) (models.FeeSchedule, error)
+}
+
+type FeeService interface {
+	CreateFeeSchedule(ctx context.Context, schedule models.FeeSchedule) (models.FeeSchedule, error)
+	ListFeeSchedules(ctx context.Context, includeInactive bool) ([]models.FeeSchedule, error)
+	DeactivateFeeSchedule(ctx context.Context, scheduleID string) (models.FeeSchedule, error)
+}
+
+type RedirectRepo interface {
+	MerchantRedirectHosts(ctx context.Context, merchant string) ([]string, error)
+}
+
+// RedirectResolver — адреса возврата покупателя с платёжной формы банка.
+type RedirectResolver interface {
+	Resolve(ctx context.Context, returnURL, failURL string, vars map[string]string) (string, string, error)
+	Validate(ctx context.Context, returnURL, failURL string) error
+}
+
+type LimitRepo interface {
+	CreateLimit(ctx context.Context, limit models.PaymentLimit) (models.PaymentLimit, error)
+	ListLimits(ctx context.Context, includeInactive bool) ([]models.PaymentLimit, error)
+	DeactivateLimit(ctx context.Context, limitID string) (models.PaymentLimit, error)
+}
+
+type LimitService interface {
+	CreateLimit(ctx context.Context, limit models.PaymentLimit) (models.PaymentLimit, error)
+	ListLimits(ctx context.Context, includeInactive bool) ([]models.PaymentLimit, error)
+	DeactivateLimit(ctx context.Context, limitID string) (models.PaymentLimit, error)
+}
+
+type RiskRepo interface {
+	ListRiskRules(ctx context.Context, includeDisabled bool) ([]models.RiskRule, error)
+	CreateRiskRule(ctx context.Context, rule models.RiskRule) (models.RiskRule, error)
+	SetRiskRuleEnabled(ctx context.Context, name string, enabled bool) (models.RiskRule, error)
+	AddListEntry(ctx context.Context, list, value string) error
+	RemoveListEntry(ctx context.Context, list, value string) error
+	ListMatches(ctx context.Context, lists, values []string) (map[string]map[string]bool, error)
+	Velocity(ctx context.Context, userID, clientIP, currency string, now time.Time) (models.RiskVelocity, error)
+}
+
+// RiskEngine — проверка риска платежа перед обращением к банку.
+type RiskEngine interface {
+	Assess(ctx context.Context, input models.RiskInput) (models.RiskAssessment, error)
+}
+
+type RiskService interface {
+	RiskEngine
+	ListRules(ctx context.Context, includeDisabled bool) ([]models.RiskRule, error)
+	CreateRule(ctx context.Context, rule models.RiskRule) (models.RiskRule, error)
+	SetRuleEnabled(ctx context.Context, name string, enabled bool) (models.RiskRule, error)
+	AddListEntry(ctx context.Context, list, value string) error
+	RemoveListEntry(ctx context.Context, list, value string) error
+}
+
+type CurrencyRepo interface {
+	Currency(ctx context.Context, code, merchant string) (*models.Currency, error)
+	ListCurrencies(ctx context.Context, merchant string) ([]models.Currency, error)
+	SaveRates(ctx context.Context, rates []models.FXRate) error
+}
+
+// RateProvider — поставщик курсов валют. Возвращает models.ErrFXRateNotFound, если курса пары нет.
+type RateProvider interface {
+	Rate(ctx context.Context, base, quote string) (models.FXRate, error)
+}
+
+type CurrencyService interface {
+	Convert(ctx context.Context, amount float64, currency string) (models.FXConversion, error)
+	Validate(ctx context.Context, currency string) error
+	Exponent(ctx context.Context, currency string) (int, error)
+	ListCurrencies(ctx context.Context) ([]models.Currency, error)
+	LoadRates(ctx context.Context, rates []models.FXRate) error
+	SettlementCurrency() string
+}
+
+type ReceiptRepo interface {
+	GetReceipt(ctx context.Context, paymentID string, kind models.ReceiptKind) (*models.Receipt, error)
+	PendingReceipts(ctx context.Context, limit int) ([]models.Receipt, error)
+	MarkReceiptIssued(ctx context.Context, receiptID string, fiscal models.Fiscalization) (models.Receipt, error)
+	MarkReceiptFailed(ctx context.Context, receiptID, reason string, maxAttempts int) error
+	MarkReceiptSent(ctx context.Context, receiptID string) error
+}
+
+// ReceiptRenderer — отрисовка чека для покупателя.
+type ReceiptRenderer interface {
+	Render(receipt models.Receipt, format models.ReceiptFormat) (models.ReceiptDocument, error)
+}
+
+// FiscalProvider — оператор фискальных данных: регистрирует чек и доставляет его покупателю.
+type FiscalProvider interface {
+	Name() string
+	Register(ctx context.Context, receipt models.Receipt) (models.Fiscalization, error)
+	Send(ctx context.Context, receipt models.Receipt, document models.ReceiptDocument) error
+}
+
+type ReceiptService interface {
+	GetReceipt(ctx context.Context, paymentID string, kind models.ReceiptKind, format models.ReceiptFormat) (models.Receipt, models.ReceiptDocument, error)
+	ResendReceipt(ctx context.Context, paymentID string, kind models.ReceiptKind, customer models.Customer) (models.Receipt, error)
+}
+
+// PaymentWriter — потоковая запись платежей в файл выгрузки. Close дописывает окончание файла.
+type PaymentWriter interface {
+	Write(payment models.Payment) error
+	Close() error
+}
+
+// PaymentExporter — писатели выгрузки платежей по формату.
+type PaymentExporter interface {
+	NewWriter(format models.ExportFormat, w io.Writer) (PaymentWriter, error)
+	ContentType(format models.ExportFormat) string
+}
+
+type ExportService interface {
+	ExportPayments(ctx context.Context, filter models.PaymentFilter, format models.ExportFormat, w io.Writer) (int, error)
+	ContentType(format models.ExportFormat) string
 }
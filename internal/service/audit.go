@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"strings"
+)
+
+const redacted = "***"
+
+// Параметры запроса, значения которых не попадают в журнал аудита
+var sensitiveParams = map[string]bool{
+	"pan":          true,
+	"cvc":          true,
+	"cvv":          true,
+	"password":     true,
+	"token":        true,
+	"card_token":   true,
+	"wallet_token": true,
+	"email":        true,
+	"phone":        true,
+}
+
+// newAuditEntry создаёт успешную запись аудита для вызывающей стороны из контекста.
+func newAuditEntry(ctx context.Context, paymentID string, params map[string]any) models.AuditEntry {
+	caller := models.CallerFromContext(ctx)
+	return models.AuditEntry{
+		Actor:        caller.Actor,
+		ClaimedActor: caller.ClaimedActor,
+		Method:       caller.Method,
+		PaymentID:    paymentID,
+		Params:       redactParams(params),
+		Result:       models.AuditSuccess,
+	}
+}
+
+// redactParams скрывает чувствительные параметры, а также учётные данные и query в URL.
+func redactParams(params map[string]any) map[string]any {
+	out := make(map[string]any, len(params))
+	for key, value := range params {
+		if sensitiveParams[strings.ToLower(key)] {
+			out[key] = redacted
+			continue
+		}
+
+		if str, ok := value.(string); ok && strings.Contains(str, "://") {
+			value = redactURL(str)
+		}
+		out[key] = value
+	}
+	return out
+}
+
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	if u.User != nil {
+		u.User = url.User(redacted)
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = redacted
+	}
+	return u.String()
+}
+
+// auditFailure записывает в журнал аудита неуспешную операцию.
+// Ошибка записи журнала не прерывает обработку и только логируется.
+func (s *PaymentService) auditFailure(ctx context.Context, entry models.AuditEntry, err, brokerErr error) {
+	entry.Result = models.AuditFailure
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if brokerErr != nil {
+		entry.BrokerError = brokerErr.Error()
+	}
+
+	if saveErr := s.repo.SaveAudit(ctx, entry); saveErr != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, saveErr, "failed to save audit entry",
+			"method", entry.Method, "payment_id", entry.PaymentID)
+	}
+}
+
+// AuditLog — возвращает записи журнала аудита по фильтру.
+func (s *PaymentService) AuditLog(ctx context.Context, filter models.AuditFilter) (models.AuditPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = models.DefaultPageSize
+	}
+	filter.Limit = min(filter.Limit, models.MaxPageSize)
+
+	l := s.log.With("payment_id", filter.PaymentID, "actor", filter.Actor, "method", filter.Method)
+	l.Debug(ctx, action.AuditLog, "begin")
+
+	page, err := s.repo.ListAudit(ctx, filter)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get audit log")
+		return models.AuditPage{}, err
+	}
+
+	l.Debug(ctx, action.AuditLog, "success", "count", len(page.Entries))
+	return page, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+)
+
+// ListBindings — сохранённые карты пользователя.
+func (s *PaymentService) ListBindings(ctx context.Context, userID string) ([]models.Binding, error) {
+	l := s.log.With("user_id", userID)
+	l.Debug(ctx, action.ListBindings, "begin")
+
+	bindings, err := s.repo.ListBindings(ctx, userID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get bindings")
+		return nil, err
+	}
+
+	l.Info(ctx, action.ListBindings, "success", "count", len(bindings))
+	return bindings, nil
+}
+
+// DeleteBinding — удаляет сохранённую карту пользователя.
+// Связка другого пользователя считается ненайденной.
+func (s *PaymentService) DeleteBinding(ctx context.Context, userID, bindingID string) error {
+	l := s.log.With("user_id", userID, "binding_id", bindingID)
+	l.Debug(ctx, action.DeleteBinding, "begin")
+
+	audit := newAuditEntry(ctx, "", map[string]any{
+		"user_id":    userID,
+		"binding_id": bindingID,
+	})
+
+	binding, err := s.userBinding(ctx, userID, bindingID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get binding")
+		s.auditFailure(ctx, audit, err, nil)
+		return err
+	}
+	audit.PaymentID = binding.PaymentID
+
+	if err := s.repo.DeleteBinding(ctx, bindingID, audit); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to delete binding")
+		s.auditFailure(ctx, audit, err, nil)
+		return err
+	}
+
+	l.Info(ctx, action.DeleteBinding, "success")
+	return nil
+}
+
+// ChargeBinding — списывает оплату с сохранённой карты без платёжной формы (merchant-initiated).
+// Списание проходит те же проверки, что и CreatePayment: связку, уникальность заказа, риск и лимиты.
+// Отказ банка сохраняет платёж в статусе DECLINED и возвращает models.ErrPaymentDeclined.
+// Платёж, отложенный правилами риска, возвращается в статусе REVIEW без списания.
+func (s *PaymentService) ChargeBinding(
+	ctx context.Context,
+	orderID, userID, bindingID string,
+	amount float64,
+	currency string,
+) (models.Payment, error) {
+	l := s.log.With("order_id", orderID, "user_id", userID, "binding_id", bindingID)
+	l.Debug(ctx, action.ChargeBinding, "begin")
+
+	payment, _, err := s.CreatePayment(ctx, orderID, userID, amount, currency, models.BindingPayment,
+		models.PaymentMethod{BindingID: bindingID}, nil, models.PaymentDetails{})
+	if err != nil {
+		return payment, err
+	}
+
+	l.Info(ctx, action.ChargeBinding, "success", "payment_id", payment.ID, "status", payment.Status)
+	return payment, nil
+}
+
+// userBinding возвращает связку, если она принадлежит пользователю.
+func (s *PaymentService) userBinding(ctx context.Context, userID, bindingID string) (*models.Binding, error) {
+	binding, err := s.repo.GetBinding(ctx, bindingID)
+	if err != nil {
+		return nil, err
+	}
+	if binding.UserID != userID {
+		return nil, repo.ErrBindingNotFound
+	}
+	return binding, nil
+}
+
+// saveBinding сохраняет карту, привязанную к оплаченному заказу.
+// Ошибка не влияет на результат оплаты и только логируется.
+func (s *PaymentService) saveBinding(ctx context.Context, paymentID string) {
+	l := s.log.With("payment_id", paymentID)
+
+	binding, err := s.broker.GetOrderBinding(ctx, paymentID)
+	if err != nil {
+		if errors.Is(err, models.ErrBindingsNotSupported) {
+			l.Debug(ctx, action.SaveBinding, "bindings are not supported by broker")
+			return
+		}
+		l.Warn(ctx, action.SaveBinding, "failed to get order binding", "error", err)
+		return
+	}
+	if binding == nil {
+		return
+	}
+
+	binding.PaymentID = paymentID
+	if err := s.repo.SaveBinding(ctx, *binding); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to save binding")
+		return
+	}
+
+	l.Info(ctx, action.SaveBinding, "success", "binding_id", binding.ID)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+)
+
+// DepositPayment — списывает (capture) ранее авторизованные средства.
+// Допускаются несколько частичных списаний в пределах авторизованной суммы.
+// При final = true остаток авторизованной суммы разблокируется у брокера.
+// Нулевая сумма означает списание всего остатка.
+func (s *PaymentService) DepositPayment(ctx context.Context, paymentID string, amount float64, currency string, final bool) (models.Payment, error) {
+	l := s.log.With("payment_id", paymentID, "amount", amount, "currency", currency, "final", final)
+	l.Debug(ctx, action.DepositPayment, "begin")
+
+	payment, err := s.repo.GetTransactionByPaymentID(ctx, paymentID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to load payment")
+		return models.Payment{}, err
+	}
+
+	if !hasOpenAuthorization(payment.Status) {
+		l.Error(ctx, action.ValidationFailed, ErrPaymentNotCapturable, "payment cannot be captured", "status", payment.Status)
+		return models.Payment{}, ErrPaymentNotCapturable
+	}
+
+	// Списывание полной суммы
+	if amount == 0 {
+		amount, currency = payment.RemainingAmount(), payment.Currency
+		final = true
+	}
+
+	amount, currency, err = s.settlementAmount(ctx, payment, amount, currency)
+	if err == nil {
+		err = validateAgainstPayment(payment, amount)
+	}
+	if err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "capture request does not match payment")
+		return models.Payment{}, err
+	}
+
+	audit := newAuditEntry(ctx, paymentID, map[string]any{
+		"amount":   amount,
+		"currency": currency,
+		"final":    final,
+	})
+
+	// Инициируем списание у брокера
+	brokerCode, err := s.broker.DepositOrder(ctx, paymentID, amount, currency)
+	if err != nil {
+		l.Error(ctx, action.PaymentTransactionFail, err, "broker deposit failed")
+		s.auditFailure(ctx, audit, ErrBrokerOperationFailed, err)
+		return models.Payment{}, fmt.Errorf("%w: %w", ErrBrokerOperationFailed, err)
+	}
+
+	payment.CapturedAmount = models.RoundAmount(payment.CapturedAmount + amount)
+	payment.Status = models.OrderPartiallyDeposited
+	if payment.RemainingAmount() == 0 {
+		payment.Status = models.OrderDeposited
+	}
+
+	// Сохраняем списание и обновляем статус
+	capture := models.Capture{PaymentID: paymentID, Amount: amount, Final: final, BrokerCode: brokerCode}
+	change := models.StatusChange{Status: payment.Status, Source: models.SourceAPI, BrokerCode: brokerCode}
+	if err := s.repo.Capture(ctx, capture, change, audit); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to save capture in db")
+		s.auditFailure(ctx, audit, err, nil)
+		return models.Payment{}, err
+	}
+
+	// Разблокируем остаток после финального списания
+	if final && payment.RemainingAmount() > 0 {
+		if err := s.releaseRemainder(ctx, payment); err != nil {
+			return models.Payment{}, err
+		}
+		payment.ReleasedAmount = models.RoundAmount(payment.ReleasedAmount + payment.RemainingAmount())
+		payment.Status = models.OrderDeposited
+	}
+
+	l.Info(ctx, action.DepositPayment, "success",
+		"captured", payment.CapturedAmount, "released", payment.ReleasedAmount, "status", payment.Status)
+	return *payment, nil
+}
+
+// releaseRemainder разблокирует у брокера остаток авторизованной суммы платежа.
+func (s *PaymentService) releaseRemainder(ctx context.Context, payment *models.Payment) error {
+	remaining := payment.RemainingAmount()
+	l := s.log.With("payment_id", payment.ID, "amount", remaining, "currency", payment.Currency)
+
+	audit := newAuditEntry(ctx, payment.ID, map[string]any{
+		"amount":   remaining,
+		"currency": payment.Currency,
+		"release":  true,
+	})
+
+	brokerCode, err := s.broker.ReversalOrder(ctx, payment.ID, remaining, payment.Currency)
+	if err != nil {
+		l.Error(ctx, action.PaymentTransactionFail, err, "broker release of remaining amount failed")
+		s.auditFailure(ctx, audit, ErrBrokerOperationFailed, err)
+		return fmt.Errorf("%w: %w", ErrBrokerOperationFailed, err)
+	}
+
+	change := models.StatusChange{Status: models.OrderDeposited, Source: models.SourceAPI, BrokerCode: brokerCode}
+	if err := s.repo.Release(ctx, payment.ID, remaining, change, audit); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to save released amount in db")
+		s.auditFailure(ctx, audit, err, nil)
+		return err
+	}
+
+	return nil
+}
+
+// settlementAmount приводит сумму списания, реверса или возврата к валюте расчётов с банком.
+// Сумму можно передать в валюте расчётов или в валюте покупателя: тогда она пересчитывается
+// по курсу, сохранённому при создании платежа. Пустая валюта заменяется валютой расчётов.
+// Сумма с большим числом знаков, чем у валюты, отклоняется. Возвращает сумму и валюту расчётов.
+func (s *PaymentService) settlementAmount(ctx context.Context, payment *models.Payment, amount float64, currency string) (float64, string, error) {
+	if currency == "" {
+		currency = payment.Currency
+	}
+	if currency != payment.Currency && currency != payment.PresentmentCurrency {
+		return 0, "", models.NewValidationError(ErrCurrencyMismatch, "currency",
+			fmt.Sprintf("currency %s does not match payment currency %s", currency, payment.Currency))
+	}
+
+	exponent, err := s.currencies.Exponent(ctx, currency)
+	if err != nil {
+		return 0, "", err
+	}
+	if models.RoundTo(amount, exponent) != amount {
+		return 0, "", models.NewValidationError(ErrAmountPrecision, "amount",
+			fmt.Sprintf("amount %v has more than %d decimal places allowed for %s", amount, exponent, currency))
+	}
+
+	if currency != payment.Currency {
+		if exponent, err = s.currencies.Exponent(ctx, payment.Currency); err != nil {
+			return 0, "", err
+		}
+	}
+	settlement, ok := payment.SettlementAmount(amount, currency, exponent)
+	if !ok {
+		return 0, "", models.NewValidationError(ErrCurrencyMismatch, "currency",
+			fmt.Sprintf("payment %s has no fx rate for currency %s", payment.ID, currency))
+	}
+	return settlement, payment.Currency, nil
+}
+
+// validateAgainstPayment проверяет сумму списания или реверса в валюте расчётов по сохранённому платежу.
+func validateAgainstPayment(payment *models.Payment, amount float64) error {
+	remaining := payment.RemainingAmount()
+	if remaining <= 0 {
+		return models.NewValidationError(repo.ErrCaptureExceedsAuthorized, "amount",
+			"payment has no remaining authorized amount")
+	}
+	if amount <= 0 {
+		return models.NewValidationError(nil, "amount",
+			fmt.Sprintf("amount %.2f must be greater than 0", amount))
+	}
+	if amount > remaining {
+		return models.NewValidationError(repo.ErrCaptureExceedsAuthorized, "amount",
+			fmt.Sprintf("amount %.2f exceeds remaining authorized amount %.2f", amount, remaining))
+	}
+
+	return nil
+}
+
+// hasOpenAuthorization сообщает, остались ли по платежу в данном статусе заблокированные средства,
+// которые можно списать или разблокировать. Платёж, который ещё не авторизован банком
+// (CREATED, PENDING_3DS), ждёт решения по проверке риска (REVIEW) или в неизвестном состоянии,
+// к банку не отправляется.
+func hasOpenAuthorization(status models.StatusType) bool {
+	switch status {
+	case models.OrderApproved, models.OrderPartiallyDeposited:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRefundable сообщает, есть ли по платежу в данном статусе списанные средства, которые можно вернуть.
+// Авторизованные, но не списанные средства возвращаются реверсом, а не возвратом.
+func isRefundable(status models.StatusType) bool {
+	switch status {
+	case models.OrderDeposited, models.OrderPartiallyRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// validateRefund проверяет сумму возврата в валюте расчётов по сохранённому платежу:
+// вернуть можно не больше списанной суммы за вычетом прошлых возвратов.
+func validateRefund(payment *models.Payment, amount float64) error {
+	refundable := payment.RefundableAmount()
+	if refundable <= 0 {
+		return models.NewValidationError(repo.ErrRefundExceedsCaptured, "amount",
+			"payment has no captured amount left to refund")
+	}
+	if amount <= 0 {
+		return models.NewValidationError(nil, "amount",
+			fmt.Sprintf("amount %.2f must be greater than 0", amount))
+	}
+	if amount > refundable {
+		return models.NewValidationError(repo.ErrRefundExceedsCaptured, "amount",
+			fmt.Sprintf("amount %.2f exceeds refundable amount %.2f", amount, refundable))
+	}
+
+	return nil
+}
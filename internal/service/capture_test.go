@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/models"
+	"testing"
+)
+
+func TestHasOpenAuthorization(t *testing.T) {
+	open := map[models.StatusType]bool{
+		models.OrderApproved:           true,
+		models.OrderPartiallyDeposited: true,
+	}
+
+	for _, status := range []models.StatusType{
+		models.OrderReview, models.OrderCreated, models.OrderPending3DS, models.OrderApproved,
+		models.OrderPartiallyDeposited, models.OrderDeposited, models.OrderDeclined, models.OrderReversed,
+		models.OrderPartiallyRefunded, models.OrderRefunded, models.OrderUnknown,
+	} {
+		if got := hasOpenAuthorization(status); got != open[status] {
+			t.Errorf("hasOpenAuthorization(%s) = %v, want %v", status, got, open[status])
+		}
+	}
+}
+
+func TestSettlementAmount(t *testing.T) {
+	// Платёж в JPY пересчитан в KZT по курсу 3.45
+	converted := models.Payment{
+		ID: "p1", Amount: 3450, Currency: "KZT", PresentmentAmount: 1000, PresentmentCurrency: "JPY", FXRate: 3.45,
+	}
+	same := models.Payment{ID: "p2", Amount: 1000, Currency: "KZT", PresentmentAmount: 1000, PresentmentCurrency: "KZT", FXRate: 1}
+
+	tests := []struct {
+		name     string
+		payment  models.Payment
+		amount   float64
+		currency string
+		want     float64
+		wantErr  error
+	}{
+		{"settlement currency", converted, 1234.56, "KZT", 1234.56, nil},
+		{"empty currency", converted, 100, "", 100, nil},
+		{"full presentment amount", converted, 1000, "JPY", 3450, nil},
+		{"partial presentment amount", converted, 333, "JPY", 1148.85, nil},
+		{"presentment precision", converted, 333.5, "JPY", 0, ErrAmountPrecision},
+		{"settlement precision", converted, 10.005, "KZT", 0, ErrAmountPrecision},
+		{"other currency", converted, 10, "USD", 0, ErrCurrencyMismatch},
+		{"no conversion", same, 250.5, "KZT", 250.5, nil},
+		{"no conversion other currency", same, 10, "JPY", 0, ErrCurrencyMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, s := newRefundFixture()
+			amount, currency, err := s.settlementAmount(context.Background(), &tt.payment, tt.amount, tt.currency)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("settlementAmount error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("settlementAmount: %v", err)
+			}
+			if amount != tt.want || currency != tt.payment.Currency {
+				t.Fatalf("settlementAmount = %v %s, want %v %s", amount, currency, tt.want, tt.payment.Currency)
+			}
+		})
+	}
+}
+
+func TestRefundPaymentInPresentmentCurrency(t *testing.T) {
+	broker, store, s := newRefundFixture(models.Payment{
+		ID: "p1", Amount: 3450, CapturedAmount: 3450, Currency: "KZT", Status: models.OrderDeposited,
+		PresentmentAmount: 1000, PresentmentCurrency: "JPY", FXRate: 3.45,
+	})
+	ctx := context.Background()
+
+	// Часть в иенах пересчитывается по курсу платежа, остаток возвращается в валюте расчётов
+	if _, err := s.RefundPayment(ctx, "p1", "", 400, "JPY"); err != nil {
+		t.Fatalf("RefundPayment(400 JPY): %v", err)
+	}
+	if _, err := s.RefundPayment(ctx, "p1", "", 1000, "JPY"); !errors.Is(err, repo.ErrRefundExceedsCaptured) {
+		t.Fatalf("RefundPayment(1000 JPY) after partial refund error = %v, want refund above captured", err)
+	}
+	status, err := s.RefundPayment(ctx, "p1", "", 0, "JPY")
+	if err != nil {
+		t.Fatalf("RefundPayment(rest): %v", err)
+	}
+
+	if status != models.OrderRefunded || store.payments["p1"].RefundedAmount != 3450 {
+		t.Fatalf("status = %s, refunded = %v; want REFUNDED 3450", status, store.payments["p1"].RefundedAmount)
+	}
+	if want := []float64{1380, 2070}; len(broker.refunds) != 2 || broker.refunds[0] != want[0] || broker.refunds[1] != want[1] {
+		t.Fatalf("broker refunds = %v, want %v in KZT", broker.refunds, want)
+	}
+}
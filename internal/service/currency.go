@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+	"time"
+)
+
+var (
+	ErrFXRateStale     = errors.New("fx rate is stale")
+	ErrAmountPrecision = errors.New("amount has more decimal places than currency allows")
+)
+
+// CurrencyService — реестр валют и пересчёт валюты покупателя в валюту расчётов с банком.
+type CurrencyService struct {
+	repo       ports.CurrencyRepo
+	rates      ports.RateProvider
+	merchant   string        // Логин мерчанта, для которого проверяется доступность валют
+	settlement string        // Валюта расчётов для валют, которые банк не принимает
+	maxAge     time.Duration // Максимальный возраст курса
+	log        logger.Logger
+}
+
+func NewCurrencyService(
+	repo ports.CurrencyRepo,
+	rates ports.RateProvider,
+	merchant, settlement string,
+	maxAge time.Duration,
+	log logger.Logger,
+) *CurrencyService {
+	return &CurrencyService{
+		repo:       repo,
+		rates:      rates,
+		merchant:   merchant,
+		settlement: settlement,
+		maxAge:     maxAge,
+		log:        log,
+	}
+}
+
+// SettlementCurrency — валюта расчётов с банком для пересчитанных платежей.
+func (s *CurrencyService) SettlementCurrency() string {
+	return s.settlement
+}
+
+// Validate — валюта есть в реестре и доступна мерчанту.
+func (s *CurrencyService) Validate(ctx context.Context, currency string) error {
+	_, err := s.currency(ctx, currency)
+	return err
+}
+
+// ListCurrencies — реестр валют с доступностью для мерчанта.
+func (s *CurrencyService) ListCurrencies(ctx context.Context) ([]models.Currency, error) {
+	currencies, err := s.repo.ListCurrencies(ctx, s.merchant)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to list currencies")
+		return nil, err
+	}
+	return currencies, nil
+}
+
+// Convert — сумма платежа в валюте, которую принимает банк.
+// Валюта, которую банк принимает, не пересчитывается; иначе сумма пересчитывается
+// в валюту расчётов по курсу поставщика и округляется до её минорной единицы.
+func (s *CurrencyService) Convert(ctx context.Context, amount float64, currency string) (models.FXConversion, error) {
+	l := s.log.With("amount", amount, "currency", currency)
+
+	presentment, err := s.currency(ctx, currency)
+	if err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "currency is not supported")
+		return models.FXConversion{}, err
+	}
+	if presentment.Round(amount) != amount {
+		l.Error(ctx, action.ValidationFailed, ErrAmountPrecision, "amount precision exceeds currency exponent",
+			"exponent", presentment.Exponent)
+		return models.FXConversion{}, ErrAmountPrecision
+	}
+
+	if presentment.BrokerSupported {
+		return models.FXConversion{
+			Amount:   amount,
+			Currency: presentment.Code,
+			Exponent: presentment.Exponent,
+			Rate:     models.FXRate{Base: presentment.Code, Quote: presentment.Code, Rate: 1, AsOf: time.Now()},
+		}, nil
+	}
+
+	settlement, err := s.repo.Currency(ctx, s.settlement, s.merchant)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get settlement currency", "settlement", s.settlement)
+		return models.FXConversion{}, err
+	}
+
+	rate, err := s.rate(ctx, presentment.Code, settlement.Code)
+	if err != nil {
+		l.Error(ctx, action.ConvertCurrency, err, "fx rate is unavailable", "settlement", settlement.Code)
+		return models.FXConversion{}, err
+	}
+
+	conv := models.FXConversion{
+		Amount:   settlement.Round(amount * rate.Rate),
+		Currency: settlement.Code,
+		Exponent: settlement.Exponent,
+		Rate:     rate,
+	}
+	l.Info(ctx, action.ConvertCurrency, "converted",
+		"settlement_amount", conv.Amount, "settlement", conv.Currency, "rate", rate.Rate, "source", rate.Source)
+	return conv, nil
+}
+
+// Exponent — минорная единица валюты из реестра. Доступность мерчанту не проверяется:
+// по уже созданному платежу операции проводятся и после отключения валюты.
+func (s *CurrencyService) Exponent(ctx context.Context, code string) (int, error) {
+	currency, err := s.repo.Currency(ctx, code, s.merchant)
+	if errors.Is(err, repo.ErrCurrencyNotFound) {
+		return 0, ErrUnsupportedCurrency
+	}
+	if err != nil {
+		return 0, err
+	}
+	return currency.Exponent, nil
+}
+
+// currency — валюта из реестра, доступная мерчанту.
+func (s *CurrencyService) currency(ctx context.Context, code string) (models.Currency, error) {
+	currency, err := s.repo.Currency(ctx, code, s.merchant)
+	if errors.Is(err, repo.ErrCurrencyNotFound) {
+		return models.Currency{}, ErrUnsupportedCurrency
+	}
+	if err != nil {
+		return models.Currency{}, err
+	}
+	if !currency.Enabled {
+		return models.Currency{}, ErrUnsupportedCurrency
+	}
+	return *currency, nil
+}
+
+// rate — курс пары; если у поставщика есть только обратный курс, он инвертируется.
+func (s *CurrencyService) rate(ctx context.Context, base, quote string) (models.FXRate, error) {
+	rate, err := s.rates.Rate(ctx, base, quote)
+	if errors.Is(err, models.ErrFXRateNotFound) {
+		var inverse models.FXRate
+		if inverse, err = s.rates.Rate(ctx, quote, base); err == nil {
+			rate = inverse.Invert()
+		}
+	}
+	if err != nil {
+		return models.FXRate{}, err
+	}
+
+	if s.maxAge > 0 && time.Since(rate.AsOf) > s.maxAge {
+		return models.FXRate{}, ErrFXRateStale
+	}
+	return rate, nil
+}
+
+// LoadRates — сохраняет курсы для поставщика курсов из БД.
+func (s *CurrencyService) LoadRates(ctx context.Context, rates []models.FXRate) error {
+	s.log.Debug(ctx, action.LoadFXRates, "begin", "rates", len(rates))
+
+	if err := s.repo.SaveRates(ctx, rates); err != nil {
+		s.log.Error(ctx, action.LoadFXRates, err, "failed to save fx rates")
+		return err
+	}
+
+	s.log.Info(ctx, action.LoadFXRates, "success", "rates", len(rates))
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"testing"
+	"time"
+)
+
+// fakeCurrencyRepo — реестр валют в памяти.
+type fakeCurrencyRepo struct {
+	ports.CurrencyRepo
+	currencies map[string]models.Currency
+}
+
+func (r fakeCurrencyRepo) Currency(ctx context.Context, code, merchant string) (*models.Currency, error) {
+	c, ok := r.currencies[code]
+	if !ok {
+		return nil, repo.ErrCurrencyNotFound
+	}
+	return &c, nil
+}
+
+// fakeRates — курсы по паре base/quote.
+type fakeRates map[string]models.FXRate
+
+func (r fakeRates) Rate(ctx context.Context, base, quote string) (models.FXRate, error) {
+	rate, ok := r[base+"/"+quote]
+	if !ok {
+		return models.FXRate{}, models.ErrFXRateNotFound
+	}
+	return rate, nil
+}
+
+func newCurrencyService(rates fakeRates) *CurrencyService {
+	currencies := fakeCurrencyRepo{currencies: map[string]models.Currency{
+		"KZT": {Code: "KZT", Exponent: 2, Enabled: true, BrokerSupported: true},
+		"USD": {Code: "USD", Exponent: 2, Enabled: true},
+		"JPY": {Code: "JPY", Exponent: 0, Enabled: true},
+		"EUR": {Code: "EUR", Exponent: 2, Enabled: false},
+	}}
+	return NewCurrencyService(currencies, rates, "merchant", "KZT", time.Hour, testLogger())
+}
+
+func TestConvertBrokerCurrency(t *testing.T) {
+	s := newCurrencyService(fakeRates{})
+
+	conv, err := s.Convert(context.Background(), 1500.5, "KZT")
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	if conv.Amount != 1500.5 || conv.Currency != "KZT" || conv.Rate.Rate != 1 {
+		t.Fatalf("conversion = %+v, want KZT 1500.50 at rate 1", conv)
+	}
+}
+
+func TestConvertToSettlementCurrency(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		rates    fakeRates
+		amount   float64
+		currency string
+		want     float64
+		wantRate float64
+	}{
+		{"direct rate", fakeRates{"USD/KZT": {Base: "USD", Quote: "KZT", Rate: 512.34, AsOf: now}}, 10.99, "USD", 5630.62, 512.34},
+		{"inverse rate", fakeRates{"KZT/USD": {Base: "KZT", Quote: "USD", Rate: 0.002, AsOf: now}}, 10, "USD", 5000, 500},
+		{"rounded to settlement minor unit", fakeRates{"JPY/KZT": {Base: "JPY", Quote: "KZT", Rate: 3.3333, AsOf: now}}, 1000, "JPY", 3333.3, 3.3333},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv, err := newCurrencyService(tt.rates).Convert(context.Background(), tt.amount, tt.currency)
+			if err != nil {
+				t.Fatalf("Convert: %v", err)
+			}
+			if conv.Amount != tt.want || conv.Currency != "KZT" || conv.Exponent != 2 {
+				t.Fatalf("conversion = %.2f %s, want %.2f KZT", conv.Amount, conv.Currency, tt.want)
+			}
+			if conv.Rate.Rate != tt.wantRate || conv.Rate.Base != tt.currency || conv.Rate.Quote != "KZT" {
+				t.Fatalf("rate = %+v, want %s/KZT %v", conv.Rate, tt.currency, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestConvertRejected(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		rates    fakeRates
+		amount   float64
+		currency string
+		want     error
+	}{
+		{"unknown currency", fakeRates{}, 10, "GBP", ErrUnsupportedCurrency},
+		{"disabled currency", fakeRates{}, 10, "EUR", ErrUnsupportedCurrency},
+		{"too many decimals", fakeRates{}, 10.001, "USD", ErrAmountPrecision},
+		{"fraction of zero-exponent currency", fakeRates{}, 10.5, "JPY", ErrAmountPrecision},
+		{"no rate", fakeRates{}, 10, "USD", models.ErrFXRateNotFound},
+		{"stale rate", fakeRates{"USD/KZT": {Base: "USD", Quote: "KZT", Rate: 500, AsOf: now.Add(-2 * time.Hour)}}, 10, "USD", ErrFXRateStale},
+		{"stale inverse rate", fakeRates{"KZT/USD": {Base: "KZT", Quote: "USD", Rate: 0.002, AsOf: now.Add(-2 * time.Hour)}}, 10, "USD", ErrFXRateStale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newCurrencyService(tt.rates).Convert(context.Background(), tt.amount, tt.currency); !errors.Is(err, tt.want) {
+				t.Fatalf("Convert error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"io"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+)
+
+// ExportService — выгрузка платежей для финансовой отчётности.
+type ExportService struct {
+	repo     ports.PaymentRepo
+	exporter ports.PaymentExporter
+	log      logger.Logger
+}
+
+func NewExportService(repo ports.PaymentRepo, exporter ports.PaymentExporter, log logger.Logger) *ExportService {
+	return &ExportService{
+		repo:     repo,
+		exporter: exporter,
+		log:      log,
+	}
+}
+
+// ExportPayments — пишет в w платежи по фильтру в формате format и возвращает их количество.
+// Платежи читаются из БД потоком, поэтому объём выгрузки не ограничен памятью.
+func (s *ExportService) ExportPayments(ctx context.Context, filter models.PaymentFilter, format models.ExportFormat, w io.Writer) (int, error) {
+	l := s.log.With("format", format, "user_id", filter.UserID)
+	l.Debug(ctx, action.ExportPayments, "begin")
+
+	writer, err := s.exporter.NewWriter(format, w)
+	if err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "export format is not supported")
+		return 0, err
+	}
+
+	rows := 0
+	err = s.repo.ExportPayments(ctx, filter, func(p models.Payment) error {
+		rows++
+		return writer.Write(p)
+	})
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		l.Error(ctx, action.ExportPayments, err, "failed to export payments", "rows", rows)
+		return rows, err
+	}
+
+	l.Info(ctx, action.ExportPayments, "success", "rows", rows)
+	return rows, nil
+}
+
+func (s *ExportService) ContentType(format models.ExportFormat) string {
+	return s.exporter.ContentType(format)
+}
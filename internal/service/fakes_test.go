@@ -0,0 +1,625 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Фейки портов для тестов сервисов. Неиспользуемые методы интерфейсов не реализованы:
+// встроенный интерфейс равен nil, и их вызов завершает тест паникой.
+
+func testLogger() logger.Logger {
+	return logger.NewWithWriter("error", io.Discard)
+}
+
+// fakeBroker — банк в памяти: заказы с платёжной формой, оплата по связке с заданным результатом и возвраты.
+type fakeBroker struct {
+	ports.Broker
+
+	mu         sync.Mutex
+	states     map[string]models.StatusType // Состояние заказов по ID
+	decline    map[string]bool              // Связки, оплата которыми отклоняется
+	charges    int                          // Вызовы ChargeBinding
+	orders     int                          // Вызовы CreateOrder
+	authOrders int                          // Вызовы CreateAuthOrder
+	refunds    []float64                    // Суммы вызовов RefundOrder
+	err        error                        // Ошибка обращения к банку при оплате по связке
+	returnURL  string                       // Адрес возврата последнего заказа с платёжной формой
+
+	unsupported []models.PaymentOperation // Операции, которые банк не поддерживает
+}
+
+func newFakeBroker() *fakeBroker {
+	return &fakeBroker{states: make(map[string]models.StatusType), decline: make(map[string]bool)}
+}
+
+func (b *fakeBroker) Supports(operation models.PaymentOperation) bool {
+	return !slices.Contains(b.unsupported, operation)
+}
+
+func (b *fakeBroker) Merchant() string { return "merchant" }
+
+func (b *fakeBroker) ChargeBinding(ctx context.Context, payment *models.Payment, bindingID string) (models.BrokerStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.err != nil {
+		return models.BrokerStatus{}, b.err
+	}
+	b.charges++
+	payment.ID = fmt.Sprintf("bank-%d", b.charges)
+	payment.Broker = "FAKE"
+
+	status := models.OrderDeposited
+	if b.decline[bindingID] {
+		status = models.OrderDeclined
+	}
+	b.states[payment.ID] = status
+	return models.BrokerStatus{Status: status, Code: string(status)}, nil
+}
+
+func (b *fakeBroker) CreateOrder(ctx context.Context, payment *models.Payment, returnURL, failURL string) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.orders++
+	b.returnURL = returnURL
+	return b.register(payment), nil
+}
+
+func (b *fakeBroker) CreateAuthOrder(ctx context.Context, payment *models.Payment, returnURL, failURL string) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.authOrders++
+	b.returnURL = returnURL
+	return b.register(payment), nil
+}
+
+// register регистрирует заказ с платёжной формой и возвращает её адрес; вызывается под b.mu.
+func (b *fakeBroker) register(payment *models.Payment) string {
+	payment.ID = fmt.Sprintf("bank-order-%d", b.orders+b.authOrders)
+	payment.Broker = "FAKE"
+	b.states[payment.ID] = models.OrderCreated
+	return "https://bank.example.com/form/" + payment.ID
+}
+
+func (b *fakeBroker) GetOrderStatus(ctx context.Context, paymentID string) (models.BrokerStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status, ok := b.states[paymentID]
+	if !ok {
+		return models.BrokerStatus{}, models.ErrBrokerOrderNotFound
+	}
+	return models.BrokerStatus{Status: status, Code: string(status)}, nil
+}
+
+func (b *fakeBroker) RefundOrder(ctx context.Context, paymentID string, amount float64, currency string) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refunds = append(b.refunds, amount)
+	return "0", nil
+}
+
+func (b *fakeBroker) GetOrderBinding(ctx context.Context, paymentID string) (*models.Binding, error) {
+	return nil, nil
+}
+
+// fakePaymentRepo — платежи и связки в памяти.
+type fakePaymentRepo struct {
+	ports.PaymentRepo
+
+	mu       sync.Mutex
+	payments map[string]*models.Payment // По ID платежа
+	reviews  map[string]models.PaymentReview
+	bindings map[string]models.Binding
+	audit    []models.AuditEntry
+	limit    *models.PaymentLimit // Лимит на платежи пользователя, проверяемый при сохранении
+	local    map[string]string    // Текущий ID платежа по ID у мерчанта
+	history  map[string][]models.PaymentStatus
+}
+
+func newFakePaymentRepo(bindings ...models.Binding) *fakePaymentRepo {
+	r := &fakePaymentRepo{
+		payments: make(map[string]*models.Payment),
+		reviews:  make(map[string]models.PaymentReview),
+		bindings: make(map[string]models.Binding),
+		local:    make(map[string]string),
+		history:  make(map[string][]models.PaymentStatus),
+	}
+	for _, b := range bindings {
+		r.bindings[b.ID] = b
+	}
+	return r
+}
+
+func (r *fakePaymentRepo) IsUnique(ctx context.Context, orderID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.byOrder(orderID) == nil, nil
+}
+
+// insert сохраняет новый платёж, проверяя уникальность заказа и лимит, как при сохранении под блокировкой.
+func (r *fakePaymentRepo) insert(payment models.Payment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.byOrder(payment.OrderID) != nil {
+		return repo.ErrOrderIDConflict
+	}
+	if err := r.checkLimit(payment); err != nil {
+		return err
+	}
+	payment.CreatedAt = time.Now()
+	r.payments[payment.ID] = &payment
+	r.local[payment.ID] = payment.ID
+	return nil
+}
+
+// checkLimit проверяет платёж по лимиту пользователя; вызывается под r.mu.
+// Время перехода в текущий статус — время создания платежа.
+func (r *fakePaymentRepo) checkLimit(payment models.Payment) error {
+	if r.limit == nil {
+		return nil
+	}
+	var usage models.LimitUsage
+	for _, p := range r.payments {
+		if p.ID != payment.ID && p.UserID == payment.UserID && p.HoldsLimit(p.CreatedAt, time.Now()) {
+			used := p.Amount - p.ReleasedAmount - p.RefundedAmount
+			usage.Daily, usage.Monthly = usage.Daily+used, usage.Monthly+used
+		}
+	}
+	return r.limit.Check(payment.UserID, payment.Amount, usage)
+}
+
+func (r *fakePaymentRepo) CheckLimits(ctx context.Context, payment models.Payment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.checkLimit(payment)
+}
+
+func (r *fakePaymentRepo) Reserve(ctx context.Context, payment models.Payment) error {
+	return r.insert(payment)
+}
+
+func (r *fakePaymentRepo) ConfirmReservation(ctx context.Context, reservationID string, payment models.Payment, change models.StatusChange, audit models.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reserved, ok := r.payments[reservationID]
+	if !ok || reserved.Status != models.OrderCreated {
+		return repo.ErrPaymentNotFound
+	}
+	delete(r.payments, reservationID)
+	payment.Status, payment.CreatedAt = change.Status, reserved.CreatedAt
+	if payment.Status == models.OrderDeposited {
+		payment.CapturedAmount = payment.Amount
+	}
+	r.payments[payment.ID] = &payment
+	r.local[reservationID] = payment.ID
+	r.record(payment.ID, change)
+	r.audit = append(r.audit, audit)
+	return nil
+}
+
+func (r *fakePaymentRepo) ReleaseReservation(ctx context.Context, reservationID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.payments[reservationID]; !ok || p.Status != models.OrderCreated {
+		return repo.ErrPaymentNotFound
+	}
+	delete(r.payments, reservationID)
+	delete(r.local, reservationID)
+	return nil
+}
+
+func (r *fakePaymentRepo) CreateReview(ctx context.Context, payment models.Payment, method models.PaymentMethod, audit models.AuditEntry) error {
+	if err := r.insert(payment); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.audit = append(r.audit, audit)
+	r.reviews[payment.ID] = models.PaymentReview{ReviewID: "r-" + payment.ID, PaymentID: payment.ID, Status: models.ReviewPending, Method: method}
+	return nil
+}
+
+func (r *fakePaymentRepo) GetReview(ctx context.Context, paymentID string) (*models.PaymentReview, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	review, ok := r.reviews[paymentID]
+	if !ok {
+		return nil, repo.ErrPaymentNotFound
+	}
+	return &review, nil
+}
+
+func (r *fakePaymentRepo) ResolveReview(ctx context.Context, review models.PaymentReview, payment models.Payment, paymentURL string, change models.StatusChange, audit models.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.payments, review.PaymentID)
+	payment.Status = change.Status
+	r.payments[payment.ID] = &payment
+	r.local[review.PaymentID] = payment.ID
+	review.Method = models.PaymentMethod{}
+	r.reviews[review.PaymentID] = review
+	r.audit = append(r.audit, audit)
+	return nil
+}
+
+func (r *fakePaymentRepo) MarkStatus(ctx context.Context, paymentID string, change models.StatusChange, audit models.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.payments[paymentID]
+	if !ok {
+		return repo.ErrPaymentNotFound
+	}
+	p.Status = change.Status
+	r.record(paymentID, change)
+	r.audit = append(r.audit, audit)
+	return nil
+}
+
+func (r *fakePaymentRepo) Refund(ctx context.Context, paymentID, reason string, amount float64, change models.StatusChange, audit models.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.payments[paymentID]
+	if !ok {
+		return repo.ErrPaymentNotFound
+	}
+	// Одностадийный платёж списывается целиком при первом возврате, как в captureInFullTx
+	if p.CapturedAmount == 0 && p.ReleasedAmount == 0 {
+		p.CapturedAmount = p.Amount
+	}
+	if models.RoundAmount(p.RefundedAmount+amount) > p.CapturedAmount {
+		return repo.ErrRefundExceedsCaptured
+	}
+	p.RefundedAmount = models.RoundAmount(p.RefundedAmount + amount)
+	p.Status = change.Status
+	r.record(paymentID, change)
+	r.audit = append(r.audit, audit)
+	return nil
+}
+
+func (r *fakePaymentRepo) Release(ctx context.Context, paymentID string, amount float64, change models.StatusChange, audit models.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.payments[paymentID]
+	if !ok {
+		return repo.ErrPaymentNotFound
+	}
+	if amount > p.RemainingAmount() {
+		return repo.ErrCaptureExceedsAuthorized
+	}
+	p.ReleasedAmount = models.RoundAmount(p.ReleasedAmount + amount)
+	p.Status = change.Status
+	r.record(paymentID, change)
+	r.audit = append(r.audit, audit)
+	return nil
+}
+
+func (r *fakePaymentRepo) SaveAudit(ctx context.Context, entry models.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.audit = append(r.audit, entry)
+	return nil
+}
+
+func (r *fakePaymentRepo) GetTransactionByPaymentID(ctx context.Context, paymentID string) (*models.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.payments[paymentID]
+	if !ok {
+		return nil, repo.ErrPaymentNotFound
+	}
+	payment := *p
+	return &payment, nil
+}
+
+func (r *fakePaymentRepo) PaymentIDByLocalID(ctx context.Context, localID string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	paymentID, ok := r.local[localID]
+	if !ok {
+		return "", repo.ErrPaymentNotFound
+	}
+	return paymentID, nil
+}
+
+func (r *fakePaymentRepo) GetTransactionByOrderID(ctx context.Context, orderID string) (*models.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := r.byOrder(orderID)
+	if p == nil {
+		return nil, repo.ErrPaymentNotFound
+	}
+	payment := *p
+	return &payment, nil
+}
+
+func (r *fakePaymentRepo) GetPaymentCart(ctx context.Context, paymentID string) (models.Customer, []models.CartItem, error) {
+	return models.Customer{}, nil, nil
+}
+
+func (r *fakePaymentRepo) GetBinding(ctx context.Context, bindingID string) (*models.Binding, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.bindings[bindingID]
+	if !ok {
+		return nil, repo.ErrBindingNotFound
+	}
+	return &b, nil
+}
+
+func (r *fakePaymentRepo) ListBindings(ctx context.Context, userID string) ([]models.Binding, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var bindings []models.Binding
+	for _, b := range r.bindings {
+		if b.UserID == userID {
+			bindings = append(bindings, b)
+		}
+	}
+	return bindings, nil
+}
+
+func (r *fakePaymentRepo) GetStatusHistory(ctx context.Context, paymentID string) ([]models.PaymentStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.history[paymentID], nil
+}
+
+// record добавляет переход статуса в историю платежа; вызывается под r.mu.
+func (r *fakePaymentRepo) record(paymentID string, change models.StatusChange) {
+	r.history[paymentID] = append(r.history[paymentID], models.PaymentStatus{
+		PaymentID:  paymentID,
+		Status:     string(change.Status),
+		Source:     change.Source,
+		BrokerCode: change.BrokerCode,
+	})
+}
+
+// byOrder — платёж по ID заказа мерчанта; вызывается под r.mu.
+func (r *fakePaymentRepo) byOrder(orderID string) *models.Payment {
+	for _, p := range r.payments {
+		if p.OrderID == orderID {
+			return p
+		}
+	}
+	return nil
+}
+
+// fakeCurrencies принимает любую валюту без пересчёта. У JPY нет минорной единицы, у остальных — два знака.
+type fakeCurrencies struct {
+	ports.CurrencyService
+}
+
+func (fakeCurrencies) Convert(ctx context.Context, amount float64, currency string) (models.FXConversion, error) {
+	return models.FXConversion{Amount: amount, Currency: currency, Exponent: 2, Rate: models.FXRate{Rate: 1}}, nil
+}
+
+func (fakeCurrencies) Validate(ctx context.Context, currency string) error { return nil }
+
+func (fakeCurrencies) Exponent(ctx context.Context, currency string) (int, error) {
+	if currency == "JPY" {
+		return 0, nil
+	}
+	return 2, nil
+}
+
+// fakeRedirects принимает адреса возврата без проверки.
+type fakeRedirects struct{}
+
+func (fakeRedirects) Resolve(ctx context.Context, returnURL, failURL string, vars map[string]string) (string, string, error) {
+	return returnURL, failURL, nil
+}
+
+func (fakeRedirects) Validate(ctx context.Context, returnURL, failURL string) error { return nil }
+
+// fakeRedirectRepo — списки хостов возврата мерчантов в памяти.
+type fakeRedirectRepo map[string][]string
+
+func (r fakeRedirectRepo) MerchantRedirectHosts(ctx context.Context, merchant string) ([]string, error) {
+	return r[merchant], nil
+}
+
+// fakeRisk возвращает заданное решение для всех платежей.
+type fakeRisk struct {
+	decision models.RiskDecision
+}
+
+func (r fakeRisk) Assess(ctx context.Context, input models.RiskInput) (models.RiskAssessment, error) {
+	if r.decision == "" {
+		return models.RiskAssessment{Decision: models.RiskAllow}, nil
+	}
+	return models.RiskAssessment{Decision: r.decision, Rules: []string{"test"}}, nil
+}
+
+// fakeSubscriptionRepo — планы, подписки, попытки списания и события в памяти.
+type fakeSubscriptionRepo struct {
+	mu      sync.Mutex
+	plans   map[string]models.Plan
+	subs    map[string]models.Subscription
+	charges []models.SubscriptionCharge
+	events  []models.SubscriptionEvent
+
+	failUpdates int // Количество следующих UpdateSubscription, завершающихся ошибкой
+}
+
+var errFakeUpdate = errors.New("update failed")
+
+func newFakeSubscriptionRepo(plans ...models.Plan) *fakeSubscriptionRepo {
+	r := &fakeSubscriptionRepo{plans: make(map[string]models.Plan), subs: make(map[string]models.Subscription)}
+	for _, p := range plans {
+		r.plans[p.ID] = p
+	}
+	return r
+}
+
+func (r *fakeSubscriptionRepo) CreatePlan(ctx context.Context, plan models.Plan) (models.Plan, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.plans[plan.ID] = plan
+	return plan, nil
+}
+
+func (r *fakeSubscriptionRepo) GetPlan(ctx context.Context, planID string) (*models.Plan, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.plans[planID]
+	if !ok {
+		return nil, repo.ErrPlanNotFound
+	}
+	return &p, nil
+}
+
+func (r *fakeSubscriptionRepo) ListPlans(ctx context.Context, includeInactive bool) ([]models.Plan, error) {
+	return nil, nil
+}
+
+func (r *fakeSubscriptionRepo) CreateSubscription(ctx context.Context, sub models.Subscription, event models.SubscriptionEvent) (models.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sub.ID = fmt.Sprintf("s%d", len(r.subs)+1)
+	r.subs[sub.ID] = sub
+	event.SubscriptionID = sub.ID
+	r.events = append(r.events, event)
+	return sub, nil
+}
+
+func (r *fakeSubscriptionRepo) GetSubscription(ctx context.Context, subscriptionID string) (*models.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sub, ok := r.subs[subscriptionID]
+	if !ok {
+		return nil, repo.ErrSubscriptionNotFound
+	}
+	return &sub, nil
+}
+
+func (r *fakeSubscriptionRepo) UpdateSubscription(ctx context.Context, sub models.Subscription, charge *models.SubscriptionCharge, event models.SubscriptionEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failUpdates > 0 {
+		r.failUpdates--
+		return errFakeUpdate
+	}
+	r.subs[sub.ID] = sub
+	if charge != nil {
+		r.charges = append(r.charges, *charge)
+	}
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *fakeSubscriptionRepo) ClaimDueSubscriptions(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []models.Subscription
+	for _, sub := range r.subs {
+		if sub.Status != models.SubscriptionCanceled && !sub.NextChargeAt.After(now) {
+			due = append(due, sub)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeSubscriptionRepo) ListSubscriptionEvents(ctx context.Context, subscriptionID string) ([]models.SubscriptionEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []models.SubscriptionEvent
+	for _, e := range r.events {
+		if e.SubscriptionID == subscriptionID {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+// fakeInvoiceRepo — счета по коду; статусы привязанных платежей берутся из хранилища платежей,
+// как в запросе с JOIN Transactions.
+type fakeInvoiceRepo struct {
+	ports.InvoiceRepo
+
+	mu       sync.Mutex
+	invoices map[string]*models.Invoice // Счета по коду
+	payments *fakePaymentRepo
+
+	failLinks int // Количество следующих AddInvoicePayment, завершающихся ошибкой
+}
+
+var errFakeLink = errors.New("link failed")
+
+func (r *fakeInvoiceRepo) GetInvoiceByCode(ctx context.Context, code string) (*models.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inv, ok := r.invoices[code]
+	if !ok {
+		return nil, repo.ErrInvoiceNotFound
+	}
+	res := *inv
+	res.Payments = append([]models.InvoicePayment(nil), inv.Payments...)
+	for i, p := range res.Payments {
+		if payment, err := r.payments.GetTransactionByPaymentID(ctx, p.PaymentID); err == nil {
+			res.Payments[i].Status = payment.Status
+		}
+	}
+	return &res, nil
+}
+
+func (r *fakeInvoiceRepo) AddInvoicePayment(ctx context.Context, invoiceID string, payment models.InvoicePayment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failLinks > 0 {
+		r.failLinks--
+		return errFakeLink
+	}
+	for _, inv := range r.invoices {
+		if inv.ID != invoiceID {
+			continue
+		}
+		// Повторная привязка только дописывает URL формы
+		for i, p := range inv.Payments {
+			if p.PaymentID == payment.PaymentID {
+				if p.PaymentURL == "" {
+					inv.Payments[i].PaymentURL = payment.PaymentURL
+				}
+				return nil
+			}
+		}
+		inv.Payments = append(inv.Payments, payment)
+		return nil
+	}
+	return repo.ErrInvoiceNotFound
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+)
+
+// FeeService — тарифы комиссий банка.
+// Комиссии начисляются репозиторием платежей вместе со сменой статуса платежа.
+type FeeService struct {
+	repo       ports.FeeRepo
+	currencies ports.CurrencyService
+	log        logger.Logger
+}
+
+func NewFeeService(repo ports.FeeRepo, currencies ports.CurrencyService, log logger.Logger) *FeeService {
+	return &FeeService{
+		repo:       repo,
+		currencies: currencies,
+		log:        log,
+	}
+}
+
+// CreateFeeSchedule — добавляет тариф. Применяется к платежам, списанным после создания.
+func (s *FeeService) CreateFeeSchedule(ctx context.Context, schedule models.FeeSchedule) (models.FeeSchedule, error) {
+	l := s.log.With("broker", schedule.Broker, "merchant", schedule.Merchant, "currency", schedule.Currency)
+	l.Debug(ctx, action.CreateFeeSchedule, "begin")
+
+	if schedule.Currency != "" {
+		if err := s.currencies.Validate(ctx, schedule.Currency); err != nil {
+			l.Error(ctx, action.ValidationFailed, err, "currency is not supported")
+			return models.FeeSchedule{}, err
+		}
+	}
+
+	created, err := s.repo.CreateFeeSchedule(ctx, schedule)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to create fee schedule")
+		return models.FeeSchedule{}, err
+	}
+
+	l.Info(ctx, action.CreateFeeSchedule, "success", "schedule_id", created.ID)
+	return created, nil
+}
+
+// ListFeeSchedules — тарифы, по умолчанию только активные.
+func (s *FeeService) ListFeeSchedules(ctx context.Context, includeInactive bool) ([]models.FeeSchedule, error) {
+	schedules, err := s.repo.ListFeeSchedules(ctx, includeInactive)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to list fee schedules")
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// DeactivateFeeSchedule — отключает тариф для новых платежей.
+func (s *FeeService) DeactivateFeeSchedule(ctx context.Context, scheduleID string) (models.FeeSchedule, error) {
+	l := s.log.With("schedule_id", scheduleID)
+
+	schedule, err := s.repo.DeactivateFeeSchedule(ctx, scheduleID)
+	if err != nil {
+		l.Error(ctx, action.DeactivateFeeSchedule, err, "failed to deactivate fee schedule")
+		return models.FeeSchedule{}, err
+	}
+
+	l.Info(ctx, action.DeactivateFeeSchedule, "success")
+	return schedule, nil
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+	"strings"
+	"time"
+)
+
+const (
+	invoiceCodeAlphabet = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789" // без похожих символов
+	invoiceCodeLength   = 10
+	invoiceCodeAttempts = 3
+	invoicePathPrefix   = "/i/"
+)
+
+var (
+	ErrInvoicePaid    = errors.New("invoice is already paid")
+	ErrInvoiceExpired = errors.New("invoice is expired")
+)
+
+type InvoiceService struct {
+	payments   ports.PaymentService
+	currencies ports.CurrencyService
+	redirects  ports.RedirectResolver
+	repo       ports.InvoiceRepo
+	baseURL    string // Публичный адрес HTTP шлюза для ссылок на оплату
+	log        logger.Logger
+}
+
+func NewInvoiceService(payments ports.PaymentService, currencies ports.CurrencyService, redirects ports.RedirectResolver, repo ports.InvoiceRepo, baseURL string, log logger.Logger) *InvoiceService {
+	return &InvoiceService{
+		payments:   payments,
+		currencies: currencies,
+		redirects:  redirects,
+		repo:       repo,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		log:        log,
+	}
+}
+
+// InvoiceURL — публичная ссылка на оплату счёта.
+func (s *InvoiceService) InvoiceURL(code string) string {
+	return s.baseURL + invoicePathPrefix + code
+}
+
+// CreateInvoice — создаёт счёт с публичной ссылкой. Платёж создаётся при оплате по ссылке.
+func (s *InvoiceService) CreateInvoice(ctx context.Context, invoice models.Invoice) (models.Invoice, error) {
+	l := s.log.With("amount", invoice.Amount, "currency", invoice.Currency, "multi_use", invoice.MultiUse)
+	l.Debug(ctx, action.CreateInvoice, "begin")
+
+	if err := s.currencies.Validate(ctx, invoice.Currency); err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "currency is not supported")
+		return models.Invoice{}, err
+	}
+
+	// Подстановки раскрываются при создании платежа, здесь проверяются шаблоны
+	if err := s.redirects.Validate(ctx, invoice.ReturnURL, invoice.FailURL); err != nil {
+		return models.Invoice{}, err
+	}
+
+	var err error
+	for range invoiceCodeAttempts {
+		invoice.Code = newInvoiceCode()
+		var created models.Invoice
+		if created, err = s.repo.CreateInvoice(ctx, invoice); err == nil {
+			l.Info(ctx, action.CreateInvoice, "success", "invoice_id", created.ID, "code", created.Code)
+			return created, nil
+		}
+		if !errors.Is(err, repo.ErrInvoiceCodeConflict) {
+			break
+		}
+	}
+
+	l.Error(ctx, action.DbTransactionFailed, err, "failed to create invoice")
+	return models.Invoice{}, err
+}
+
+// GetInvoice — счёт с платежами по нему.
+func (s *InvoiceService) GetInvoice(ctx context.Context, invoiceID string) (models.Invoice, error) {
+	invoice, err := s.repo.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to get invoice", "invoice_id", invoiceID)
+		return models.Invoice{}, err
+	}
+	return *invoice, nil
+}
+
+// InvoiceByCode — открытый счёт по коду публичной ссылки для страницы оплаты.
+// Оплаченный и просроченный счета возвращают ErrInvoicePaid и ErrInvoiceExpired.
+func (s *InvoiceService) InvoiceByCode(ctx context.Context, code string) (models.Invoice, error) {
+	invoice, err := s.openInvoice(ctx, code)
+	if err != nil {
+		return models.Invoice{}, err
+	}
+	return *invoice, nil
+}
+
+// OpenInvoice — вызывается при оплате по публичной ссылке и возвращает URL платёжной формы.
+// Для одноразового счёта повторная оплата возвращает форму неоплаченного платежа,
+// многоразовый счёт создаёт новый платёж при каждой оплате.
+func (s *InvoiceService) OpenInvoice(ctx context.Context, code string) (string, error) {
+	l := s.log.With("code", code)
+	l.Debug(ctx, action.OpenInvoice, "begin")
+
+	invoice, err := s.openInvoice(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	l = l.With("invoice_id", invoice.ID)
+
+	if n := len(invoice.Payments); !invoice.MultiUse && n > 0 && invoice.Payments[n-1].Status == models.OrderReview {
+		return "", ErrPaymentInReview
+	}
+	if n := len(invoice.Payments); !invoice.MultiUse && n > 0 && invoice.Payments[n-1].IsPending() && invoice.Payments[n-1].PaymentURL != "" {
+		l.Info(ctx, action.OpenInvoice, "reuse pending payment", "payment_id", invoice.Payments[n-1].PaymentID)
+		return invoice.Payments[n-1].PaymentURL, nil
+	}
+
+	// Номер заказа зависит от количества платежей, поэтому одновременное открытие
+	// ссылки не создаст два платежа: второй получит конфликт ID заказа (см. adoptPayment)
+	orderID := fmt.Sprintf("inv-%s-%d", invoice.Code, len(invoice.Payments)+1)
+	userID := invoice.UserID
+	if userID == "" {
+		userID = "invoice:" + invoice.Code
+	}
+
+	payment, paymentURL, err := s.payments.CreatePayment(ctx, orderID, userID,
+		invoice.Amount, invoice.Currency, models.URLpayment,
+		models.PaymentMethod{ReturnURL: invoice.ReturnURL, FailURL: invoice.FailURL}, nil,
+		models.PaymentDetails{
+			Description: invoice.Description,
+			Metadata:    map[string]any{"invoice_id": invoice.ID, "invoice_code": invoice.Code},
+		})
+	if errors.Is(err, repo.ErrOrderIDConflict) {
+		return "", s.adoptPayment(ctx, invoice.ID, orderID)
+	}
+	if err != nil {
+		l.Error(ctx, action.PaymentTransactionFail, err, "failed to create invoice payment")
+		return "", err
+	}
+
+	if err := s.repo.AddInvoicePayment(ctx, invoice.ID, models.InvoicePayment{
+		PaymentID:  payment.ID,
+		PaymentURL: paymentURL,
+	}); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to link payment to invoice")
+		return "", err
+	}
+	if payment.Status == models.OrderReview {
+		l.Info(ctx, action.OpenInvoice, "payment is held for risk review", "payment_id", payment.ID)
+		return "", ErrPaymentInReview
+	}
+
+	l.Info(ctx, action.OpenInvoice, "success", "payment_id", payment.ID)
+	return paymentURL, nil
+}
+
+// adoptPayment привязывает к счёту платёж, заказ которого уже существует. Так бывает, если платёж
+// создан, но не привязан (сбой AddInvoicePayment), или ссылку открыли одновременно. Привязка
+// без URL формы сдвигает номер следующего заказа, поэтому повторная оплата не упирается в тот же
+// конфликт; одновременный запрос дописывает URL к своей привязке. Покупателю возвращается
+// ErrOrderIDConflict с предложением повторить.
+func (s *InvoiceService) adoptPayment(ctx context.Context, invoiceID, orderID string) error {
+	l := s.log.With("invoice_id", invoiceID, "order_id", orderID)
+
+	payment, err := s.payments.GetPaymentByOrderID(ctx, orderID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get invoice payment by order ID")
+		return err
+	}
+	if err := s.repo.AddInvoicePayment(ctx, invoiceID, models.InvoicePayment{PaymentID: payment.ID}); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to link payment to invoice", "payment_id", payment.ID)
+		return err
+	}
+
+	l.Warn(ctx, action.OpenInvoice, "invoice payment is already created, adopted it", "payment_id", payment.ID)
+	return repo.ErrOrderIDConflict
+}
+
+// openInvoice — счёт по коду, если он принимает оплату.
+func (s *InvoiceService) openInvoice(ctx context.Context, code string) (*models.Invoice, error) {
+	invoice, err := s.repo.GetInvoiceByCode(ctx, code)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to get invoice", "code", code)
+		return nil, err
+	}
+
+	switch invoice.StatusAt(time.Now()) {
+	case models.InvoicePaid:
+		return nil, ErrInvoicePaid
+	case models.InvoiceExpired:
+		return nil, ErrInvoiceExpired
+	}
+	return invoice, nil
+}
+
+// newInvoiceCode генерирует случайный код публичной ссылки.
+func newInvoiceCode() string {
+	code, err := invoiceCode(rand.Reader)
+	if err != nil {
+		// crypto/rand не возвращает ошибок на поддерживаемых платформах
+		panic(err)
+	}
+	return code
+}
+
+// invoiceCode читает случайные байты из r и переводит их в символы алфавита.
+// Байты не меньше наибольшего кратного длине алфавита отбрасываются: иначе первые символы
+// алфавита выпадали бы чаще остальных.
+func invoiceCode(r io.Reader) (string, error) {
+	limit := 256 - 256%len(invoiceCodeAlphabet)
+
+	code := make([]byte, 0, invoiceCodeLength)
+	buf := make([]byte, invoiceCodeLength)
+	for len(code) < invoiceCodeLength {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if int(b) < limit && len(code) < invoiceCodeLength {
+				code = append(code, invoiceCodeAlphabet[int(b)%len(invoiceCodeAlphabet)])
+			}
+		}
+	}
+	return string(code), nil
+}
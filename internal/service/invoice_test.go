@@ -0,0 +1,96 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInvoiceCodeRejectsBiasedBytes(t *testing.T) {
+	limit := 256 - 256%len(invoiceCodeAlphabet)
+
+	// Первые байты вне допустимого диапазона должны быть пропущены, а не свёрнуты по модулю
+	src := bytes.Repeat([]byte{byte(limit), 255}, invoiceCodeLength/2)
+	for i := range invoiceCodeLength {
+		src = append(src, byte(i))
+	}
+
+	code, err := invoiceCode(bytes.NewReader(src))
+	if err != nil {
+		t.Fatalf("invoiceCode: %v", err)
+	}
+	if want := invoiceCodeAlphabet[:invoiceCodeLength]; code != want {
+		t.Fatalf("invoiceCode = %q, want %q", code, want)
+	}
+}
+
+func TestInvoiceCodeShortRead(t *testing.T) {
+	if _, err := invoiceCode(bytes.NewReader([]byte{1, 2, 3})); err == nil {
+		t.Fatal("invoiceCode succeeded on a short read")
+	}
+}
+
+func TestNewInvoiceCode(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 100 {
+		code := newInvoiceCode()
+		if len(code) != invoiceCodeLength {
+			t.Fatalf("code %q has length %d, want %d", code, len(code), invoiceCodeLength)
+		}
+		for _, c := range code {
+			if !strings.ContainsRune(invoiceCodeAlphabet, c) {
+				t.Fatalf("code %q has symbol %q outside the alphabet", code, c)
+			}
+		}
+		if seen[code] {
+			t.Fatalf("duplicate code %q", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestOpenInvoiceAdoptsUnlinkedPayment(t *testing.T) {
+	broker, store, payments := newRefundFixture()
+	payments.redirects = fakeRedirects{}
+	invoices := &fakeInvoiceRepo{payments: store, failLinks: 1, invoices: map[string]*models.Invoice{
+		"abc": {ID: "inv-1", Code: "abc", Amount: 500, Currency: "KZT", ExpiresAt: time.Now().Add(time.Hour),
+			ReturnURL: "https://shop.example.com/ok", FailURL: "https://shop.example.com/fail"},
+	}}
+	s := NewInvoiceService(payments, fakeCurrencies{}, fakeRedirects{}, invoices, "https://pay.example.com", testLogger())
+	ctx := context.Background()
+
+	// Платёж создан, но не привязан к счёту
+	if _, err := s.OpenInvoice(ctx, "abc"); !errors.Is(err, errFakeLink) {
+		t.Fatalf("first OpenInvoice error = %v, want link failure", err)
+	}
+
+	// Повтор не создаёт заказ заново, а привязывает существующий и просит повторить
+	if _, err := s.OpenInvoice(ctx, "abc"); !errors.Is(err, repo.ErrOrderIDConflict) {
+		t.Fatalf("second OpenInvoice error = %v, want ErrOrderIDConflict", err)
+	}
+	if linked := invoices.invoices["abc"].Payments; len(linked) != 1 || linked[0].PaymentURL != "" {
+		t.Fatalf("invoice payments = %+v, want the orphaned payment without form URL", linked)
+	}
+
+	// Следующая оплата получает новый номер заказа и форму
+	url, err := s.OpenInvoice(ctx, "abc")
+	if err != nil {
+		t.Fatalf("third OpenInvoice: %v", err)
+	}
+	if url != "https://bank.example.com/form/bank-order-2" || broker.orders != 2 {
+		t.Fatalf("OpenInvoice = %s after %d orders, want the second order form", url, broker.orders)
+	}
+	if p := store.byOrder("inv-abc-2"); p == nil {
+		t.Fatal("payment for order inv-abc-2 is not created")
+	}
+
+	// Одноразовый счёт возвращает форму неоплаченного платежа, а не создаёт новый
+	if again, err := s.OpenInvoice(ctx, "abc"); err != nil || again != url || broker.orders != 2 {
+		t.Fatalf("OpenInvoice again = %s, %v after %d orders, want the same form", again, err, broker.orders)
+	}
+}
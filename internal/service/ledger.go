@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+	"time"
+)
+
+// LedgerService — журнал двойной записи, балансы продавцов и выплаты по платежам с распределением.
+// Проводки создаются репозиторием платежей вместе со сменой статуса платежа.
+type LedgerService struct {
+	repo ports.LedgerRepo
+	log  logger.Logger
+}
+
+func NewLedgerService(repo ports.LedgerRepo, log logger.Logger) *LedgerService {
+	return &LedgerService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// SellerBalances — балансы продавца по валютам.
+func (s *LedgerService) SellerBalances(ctx context.Context, sellerID string) ([]models.SellerBalance, error) {
+	l := s.log.With("seller_id", sellerID)
+
+	balances, err := s.repo.SellerBalances(ctx, sellerID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get seller balance")
+		return nil, err
+	}
+
+	l.Debug(ctx, action.SellerBalance, "success", "currencies", len(balances))
+	return balances, nil
+}
+
+// CreatePayout — формирует пакет выплат по всем продавцам с положительным невыплаченным балансом.
+func (s *LedgerService) CreatePayout(ctx context.Context) (models.Payout, error) {
+	s.log.Debug(ctx, action.CreatePayout, "begin")
+
+	payout, err := s.repo.CreatePayout(ctx)
+	if err != nil {
+		s.log.Error(ctx, action.CreatePayout, err, "failed to create payout")
+		return models.Payout{}, err
+	}
+
+	s.log.Info(ctx, action.CreatePayout, "success", "payout_id", payout.ID, "lines", len(payout.Lines))
+	return *payout, nil
+}
+
+// GetPayout — пакет выплат для повторной выгрузки.
+func (s *LedgerService) GetPayout(ctx context.Context, payoutID string) (models.Payout, error) {
+	payout, err := s.repo.GetPayout(ctx, payoutID)
+	if err != nil {
+		s.log.Error(ctx, action.GetPayout, err, "failed to get payout", "payout_id", payoutID)
+		return models.Payout{}, err
+	}
+	return *payout, nil
+}
+
+// TrialBalance — оборотно-сальдовая ведомость на момент asOf.
+// Несходящаяся ведомость логируется: это нарушение инварианта журнала.
+func (s *LedgerService) TrialBalance(ctx context.Context, asOf time.Time) (models.TrialBalance, error) {
+	tb, err := s.repo.TrialBalance(ctx, asOf)
+	if err != nil {
+		s.log.Error(ctx, action.TrialBalance, err, "failed to get trial balance")
+		return models.TrialBalance{}, err
+	}
+
+	if !tb.Balanced() {
+		s.log.Warn(ctx, action.TrialBalance, "trial balance does not balance", "as_of", asOf)
+	}
+	return tb, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+)
+
+// LimitService — лимиты платежей пользователей и мерчантов.
+// Платежи проверяются по лимитам репозиторием платежей при сохранении.
+type LimitService struct {
+	repo       ports.LimitRepo
+	currencies ports.CurrencyService
+	log        logger.Logger
+}
+
+func NewLimitService(repo ports.LimitRepo, currencies ports.CurrencyService, log logger.Logger) *LimitService {
+	return &LimitService{
+		repo:       repo,
+		currencies: currencies,
+		log:        log,
+	}
+}
+
+// CreateLimit — добавляет лимит. Учитывает уже созданные за период платежи.
+func (s *LimitService) CreateLimit(ctx context.Context, limit models.PaymentLimit) (models.PaymentLimit, error) {
+	l := s.log.With("scope", limit.Scope, "subject", limit.Subject, "currency", limit.Currency)
+	l.Debug(ctx, action.CreateLimit, "begin")
+
+	if err := s.currencies.Validate(ctx, limit.Currency); err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "currency is not supported")
+		return models.PaymentLimit{}, err
+	}
+
+	created, err := s.repo.CreateLimit(ctx, limit)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to create limit")
+		return models.PaymentLimit{}, err
+	}
+
+	l.Info(ctx, action.CreateLimit, "success", "limit_id", created.ID)
+	return created, nil
+}
+
+// ListLimits — лимиты, по умолчанию только активные.
+func (s *LimitService) ListLimits(ctx context.Context, includeInactive bool) ([]models.PaymentLimit, error) {
+	limits, err := s.repo.ListLimits(ctx, includeInactive)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to list limits")
+		return nil, err
+	}
+	return limits, nil
+}
+
+// DeactivateLimit — отключает лимит для новых платежей.
+func (s *LimitService) DeactivateLimit(ctx context.Context, limitID string) (models.PaymentLimit, error) {
+	l := s.log.With("limit_id", limitID)
+
+	limit, err := s.repo.DeactivateLimit(ctx, limitID)
+	if err != nil {
+		l.Error(ctx, action.DeactivateLimit, err, "failed to deactivate limit")
+		return models.PaymentLimit{}, err
+	}
+
+	l.Info(ctx, action.DeactivateLimit, "success")
+	return limit, nil
+}
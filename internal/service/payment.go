@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"payment/internal/adapters/repo"
@@ -12,27 +14,40 @@ import (
 )
 
 type PaymentService struct {
-	broker ports.Broker
-	repo   ports.PaymentRepo
-	log    logger.Logger
+	broker     ports.Broker
+	repo       ports.PaymentRepo
+	currencies ports.CurrencyService
+	risk       ports.RiskEngine
+	redirects  ports.RedirectResolver
+	log        logger.Logger
 }
 
-func NewPaymentService(broker ports.Broker, repo ports.PaymentRepo, log logger.Logger) *PaymentService {
+func NewPaymentService(broker ports.Broker, repo ports.PaymentRepo, currencies ports.CurrencyService, risk ports.RiskEngine, redirects ports.RedirectResolver, log logger.Logger) *PaymentService {
 	return &PaymentService{
-		broker: broker,
-		repo:   repo,
-		log:    log,
+		broker:     broker,
+		repo:       repo,
+		currencies: currencies,
+		risk:       risk,
+		redirects:  redirects,
+		log:        log,
 	}
 }
 
 var (
 	ErrDBUnavailable         = errors.New("database unavailable")
 	ErrOrderNotUnique        = errors.New("order ID is not unique")
-	ErrBrokerUnavailable     = errors.New("broker unavailable")
+	ErrBrokerUnavailable     = models.ErrBrokerUnavailable
 	ErrBrokerOperationFailed = errors.New("broker operation failed")
 	ErrUnsupportedCurrency   = errors.New("unsupported currency")
 	ErrUnsupportedOperation  = errors.New("unsupported operation")
 	ErrPaymentNotPaid        = errors.New("payment is not paid yet")
+	ErrPaymentNotCapturable  = errors.New("payment cannot be captured in current state")
+	ErrPaymentNotReversible  = errors.New("payment cannot be reversed in current state")
+	ErrPaymentNotRefundable  = errors.New("payment cannot be refunded in current state")
+	ErrCurrencyMismatch      = errors.New("currency does not match payment currency")
+	ErrUnknownBrokerState    = errors.New("unknown order state at broker")
+	ErrRefundAmountUnknown   = errors.New("refunded amount at broker is unknown")
+	ErrSplitExceedsAmount    = errors.New("split amounts exceed payment amount")
 )
 
 // HealthCheck — проверка доступности БД и брокера.
@@ -55,14 +70,20 @@ func (s *PaymentService) HealthCheck(ctx context.Context) error {
 	return errors.Join(errsList...)
 }
 
-// CreatePayment — создаёт платёж и возвращает URL оплаты.
+// CreatePayment — создаёт платёж операцией operation.
+// Для платёжной формы возвращает URL оплаты, для QR — содержимое QR кода;
+// прямые операции (связка, токен карты, кошелёк) проводятся банком сразу и возвращают пустую строку.
+// Перед обращением к банку платёж проходит проверку риска: отклонённый не создаётся,
+// отложенный сохраняется в статусе REVIEW без обращения к банку до ручного решения.
 func (s *PaymentService) CreatePayment(
 	ctx context.Context,
 	orderID, userID string,
 	amount float64,
 	currency string,
 	operation string,
-	returnURL, failURL string,
+	method models.PaymentMethod,
+	splits []models.SplitRule,
+	details models.PaymentDetails,
 ) (models.Payment, string, error) {
 	l := s.log.With(
 		"order_id", orderID,
@@ -70,74 +91,377 @@ func (s *PaymentService) CreatePayment(
 		"amount", amount,
 		"currency", currency,
 		"operation", operation,
+		"splits", len(splits),
 	)
 	l.Debug(ctx, action.CreatePayment, "begin")
 
+	// ID платежа у мерчанта: под ним платёж сохраняется до ответа банка и остаётся доступен после.
+	// Его же получает подстановка {payment_id} в адресах возврата.
+	localID := newLocalID()
+
 	// Валидация
-	if !IsCurrencySupported(currency) {
-		l.Error(ctx, action.ValidationFailed, ErrUnsupportedCurrency, "currency is not supported")
-		return models.Payment{}, "", ErrUnsupportedCurrency
+	rule, method, err := s.validatePayment(ctx, localID, orderID, userID, amount, operation, method, splits, details)
+	if err != nil {
+		return models.Payment{}, "", err
 	}
-	if !IsOperationSupported(operation) {
-		l.Error(ctx, action.ValidationFailed, ErrUnsupportedOperation, "operation is not supported")
-		return models.Payment{}, "", ErrUnsupportedOperation
+
+	// Локальная модель
+	payment, err := s.newPayment(ctx, orderID, userID, amount, currency, rule, splits, details)
+	if err != nil {
+		return models.Payment{}, "", err
+	}
+
+	// Проверка риска до обращения к банку
+	assessment, err := s.risk.Assess(ctx, models.RiskInput{
+		OrderID:   orderID,
+		UserID:    userID,
+		ClientIP:  payment.ClientIP,
+		Amount:    amount,
+		Currency:  currency,
+		Operation: rule.Operation,
+	})
+	if err != nil {
+		return models.Payment{}, "", err
+	}
+	payment.RiskDecision, payment.RiskRules = assessment.Decision, assessment.Rules
+	audit := newPaymentAudit(ctx, payment, operation, method, splits, details)
+
+	switch assessment.Decision {
+	case models.RiskDeny:
+		l.Warn(ctx, action.AssessRisk, "payment is denied by risk rules", "rules", assessment.Rules)
+		s.auditFailure(ctx, audit, ErrPaymentDenied, nil)
+		return models.Payment{}, "", ErrPaymentDenied
+
+	case models.RiskReview:
+		return s.holdForReview(ctx, localID, payment, method, audit)
+	}
+
+	// Создание заказа у брокера и сохранение в БД
+	return s.registerPayment(ctx, localID, payment, method, audit)
+}
+
+// validatePayment проверяет запрос на создание платежа и возвращает правила операции вместе со способом
+// оплаты, в котором адреса возврата платёжной формы уже разрешены.
+func (s *PaymentService) validatePayment(
+	ctx context.Context,
+	localID, orderID, userID string,
+	amount float64,
+	operation string,
+	method models.PaymentMethod,
+	splits []models.SplitRule,
+	details models.PaymentDetails,
+) (models.OperationRule, models.PaymentMethod, error) {
+	l := s.log.With("order_id", orderID, "user_id", userID)
+
+	// Адреса возврата с платёжной формы: значения по умолчанию, подстановки и список хостов
+	if models.OperationRules[models.PaymentOperation(operation)].HostedPage {
+		vars := models.Payment{OrderID: orderID, UserID: userID}.RedirectVars(localID)
+		returnURL, failURL, err := s.redirects.Resolve(ctx, method.ReturnURL, method.FailURL, vars)
+		if err != nil {
+			return models.OperationRule{}, method, err
+		}
+		method.ReturnURL, method.FailURL = returnURL, failURL
+	}
+
+	rule, err := s.operationRule(ctx, operation, method)
+	if err != nil {
+		return models.OperationRule{}, method, err
+	}
+	if models.SplitsTotal(splits) > amount {
+		l.Error(ctx, action.ValidationFailed, ErrSplitExceedsAmount, "split amounts exceed payment amount")
+		return models.OperationRule{}, method, ErrSplitExceedsAmount
+	}
+	if err := models.ValidateCart(details.Items, amount); err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "cart does not match payment")
+		return models.OperationRule{}, method, err
+	}
+	if rule.Binding {
+		if _, err := s.userBinding(ctx, userID, method.BindingID); err != nil {
+			l.Error(ctx, action.ValidationFailed, err, "binding is not available")
+			return models.OperationRule{}, method, err
+		}
 	}
 
 	uniq, err := s.repo.IsUnique(ctx, orderID)
 	if err != nil {
 		l.Error(ctx, action.DbTransactionFailed, err, "Failed to check order uniqueness")
-		return models.Payment{}, "", err
+		return models.OperationRule{}, method, err
 	}
 
 	if !uniq {
 		l.Error(ctx, action.ValidationFailed, repo.ErrOrderIDConflict, "order ID is not unique")
-		return models.Payment{}, "", repo.ErrOrderIDConflict
+		return models.OperationRule{}, method, repo.ErrOrderIDConflict
+	}
+	return rule, method, nil
+}
+
+// newPayment — локальная модель нового платежа, пересчитанная в валюту, которую принимает банк.
+func (s *PaymentService) newPayment(
+	ctx context.Context,
+	orderID, userID string,
+	amount float64,
+	currency string,
+	rule models.OperationRule,
+	splits []models.SplitRule,
+	details models.PaymentDetails,
+) (models.Payment, error) {
+	conv, err := s.currencies.Convert(ctx, amount, currency)
+	if err != nil {
+		return models.Payment{}, err
 	}
 
-	// Локальная модель
 	payment := models.Payment{
 		OrderID:   orderID,
 		UserID:    userID,
 		Amount:    amount,
 		Currency:  currency,
-		Operation: models.PaymentOperation(operation),
+		Operation: rule.Operation,
 		Status:    models.OrderCreated,
+		Splits:    splits,
+		Merchant:  s.broker.Merchant(),
+		ClientIP:  models.CallerFromContext(ctx).IP,
+
+		Description: details.Description,
+		Metadata:    details.Metadata,
+		Customer:    details.Customer,
+		Items:       details.Items,
+	}
+	payment.Convert(conv)
+	return payment, nil
+}
+
+// newPaymentAudit — запись аудита создания платежа с параметрами запроса мерчанта и решением риска.
+// Сумма, валюта и доли продавцов записываются в том виде, в котором их передал мерчант.
+func newPaymentAudit(ctx context.Context, payment models.Payment, operation string, method models.PaymentMethod, splits []models.SplitRule, details models.PaymentDetails) models.AuditEntry {
+	return newAuditEntry(ctx, "", map[string]any{
+		"order_id":     payment.OrderID,
+		"user_id":      payment.UserID,
+		"amount":       payment.PresentmentAmount,
+		"currency":     payment.PresentmentCurrency,
+		"operation":    operation,
+		"return_url":   method.ReturnURL,
+		"error_url":    method.FailURL,
+		"binding_id":   method.BindingID,
+		"card_token":   method.CardToken,
+		"wallet_type":  method.WalletType,
+		"wallet_token": method.WalletToken,
+		"pre_auth":     method.PreAuth,
+		"splits":       splits,
+		"risk":         payment.RiskDecision,
+		"risk_rules":   payment.RiskRules,
+		"description":  details.Description,
+		"metadata":     details.Metadata,
+		"email":        details.Customer.Email,
+		"phone":        details.Customer.Phone,
+		"items":        len(details.Items),
+	})
+}
+
+// holdForReview сохраняет платёж, отложенный проверкой риска, в статусе REVIEW под ID у мерчанта.
+// Заказ у банка создаётся после ручного решения.
+func (s *PaymentService) holdForReview(ctx context.Context, localID string, payment models.Payment, method models.PaymentMethod, audit models.AuditEntry) (models.Payment, string, error) {
+	payment.ID, payment.Status = localID, models.OrderReview
+	audit.PaymentID = payment.ID
+	if err := s.repo.CreateReview(ctx, payment, method, audit); err != nil {
+		s.persistFailure(ctx, payment, audit, err, "failed to persist payment for review")
+		return models.Payment{}, "", err
+	}
+
+	s.log.Info(ctx, action.CreatePayment, "payment is held for risk review",
+		"order_id", payment.OrderID, "payment_id", payment.ID, "rules", payment.RiskRules)
+	return payment, "", nil
+}
+
+// registerPayment резервирует лимит, создаёт заказ у брокера и сохраняет платёж.
+// До обращения к банку платёж сохраняется в CREATED под ID у мерчанта; резерв снимается,
+// если заказ не создан. Отказ банка по прямой операции сохраняется и возвращается вместе с платежом.
+func (s *PaymentService) registerPayment(ctx context.Context, localID string, payment models.Payment, method models.PaymentMethod, audit models.AuditEntry) (models.Payment, string, error) {
+	l := s.log.With("order_id", payment.OrderID, "reservation_id", localID)
+
+	// Резерв лимита до обращения к банку
+	payment.ID = localID
+	if err := s.repo.Reserve(ctx, payment); err != nil {
+		s.persistFailure(ctx, payment, audit, err, "failed to reserve payment")
+		return models.Payment{}, "", err
 	}
 
 	// Создание заказа у брокера
-	formURL, err := s.broker.CreateOrder(ctx, &payment, returnURL, failURL)
+	next, err := s.executeOperation(ctx, &payment, method)
 	if err != nil {
 		l.Error(ctx, action.PaymentTransactionFail, err, "failed to create payment at broker")
-		return models.Payment{}, "", fmt.Errorf("%w: %v", ErrBrokerOperationFailed, err)
+		s.releaseReservation(ctx, localID)
+		s.auditFailure(ctx, audit, ErrBrokerOperationFailed, err)
+		return models.Payment{}, "", fmt.Errorf("%w: %w", ErrBrokerOperationFailed, err)
+	}
+	audit.PaymentID = payment.ID
+
+	if err := s.checkBrokerState(ctx, payment.ID, models.BrokerStatus{Status: payment.Status}); err != nil {
+		s.releaseReservation(ctx, localID)
+		s.auditFailure(ctx, audit, err, nil)
+		return models.Payment{}, "", err
+	}
+
+	// Отказ банка по прямой операции сохраняется вместе с неуспешной записью аудита
+	declined := payment.Status == models.OrderDeclined
+	if declined {
+		audit.Result, audit.Error = models.AuditFailure, models.ErrPaymentDeclined.Error()
 	}
 
 	// Сохранение в БД
-	if err := s.repo.Create(ctx, payment); err != nil {
+	change := models.StatusChange{Status: payment.Status, Source: models.SourceAPI}
+	if err := s.repo.ConfirmReservation(ctx, localID, payment, change, audit); err != nil {
 		l.Error(ctx, action.DbTransactionFailed, err, "failed to persist payment")
+		s.auditFailure(ctx, audit, err, nil)
 		return models.Payment{}, "", err
 	}
 
-	s.log.With("payment_id", payment.ID, "broker", payment.Broker).
+	if declined {
+		l.Warn(ctx, action.CreatePayment, "payment is declined by broker", "payment_id", payment.ID)
+		return payment, "", models.ErrPaymentDeclined
+	}
+
+	s.log.With("payment_id", payment.ID, "broker", payment.Broker, "status", payment.Status).
 		Info(ctx, action.CreatePayment, "success")
-	return payment, formURL, nil
+	return payment, next, nil
+}
+
+// persistFailure журналирует ошибку сохранения нового платежа. Превышение лимита — отказ в платеже, а не сбой БД.
+func (s *PaymentService) persistFailure(ctx context.Context, payment models.Payment, audit models.AuditEntry, err error, msg string) {
+	if errors.Is(err, models.ErrLimitExceeded) {
+		s.log.Warn(ctx, action.CheckLimits, "payment limit exceeded", "order_id", payment.OrderID, "error", err.Error())
+	} else {
+		s.log.Error(ctx, action.DbTransactionFailed, err, msg, "order_id", payment.OrderID)
+	}
+	s.auditFailure(ctx, audit, err, nil)
+}
+
+// releaseReservation снимает резерв лимита, если заказ у банка не создан. Ошибка только журналируется:
+// оставшийся резерв продолжает занимать лимит, но не приводит к двойному списанию.
+func (s *PaymentService) releaseReservation(ctx context.Context, reservationID string) {
+	if err := s.repo.ReleaseReservation(ctx, reservationID); err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to release payment reservation", "reservation_id", reservationID)
+	}
+}
+
+// newLocalID — ID платежа у мерчанта, под которым платёж сохраняется до получения ID заказа у банка.
+func newLocalID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return "pay-" + hex.EncodeToString(buf)
 }
 
-// GetPayment — возвращает платёж по orderID.
+// operationRule — правила операции, если она известна, её умеет брокер и способ оплаты ей подходит.
+func (s *PaymentService) operationRule(ctx context.Context, operation string, method models.PaymentMethod) (models.OperationRule, error) {
+	l := s.log.With("operation", operation)
+
+	rule, ok := models.OperationRules[models.PaymentOperation(operation)]
+	if !ok {
+		l.Error(ctx, action.ValidationFailed, ErrUnsupportedOperation, "operation is not supported")
+		return models.OperationRule{}, ErrUnsupportedOperation
+	}
+	if err := rule.Validate(method); err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "payment method does not match operation")
+		return models.OperationRule{}, err
+	}
+	if !s.broker.Supports(rule.Operation) {
+		l.Error(ctx, action.ValidationFailed, models.ErrOperationNotSupported, "operation is not supported by broker")
+		return models.OperationRule{}, models.ErrOperationNotSupported
+	}
+	return rule, nil
+}
+
+// executeOperation создаёт заказ у брокера операцией платежа.
+// Прямые операции сразу выставляют статус платежа по ответу банка.
+func (s *PaymentService) executeOperation(ctx context.Context, payment *models.Payment, method models.PaymentMethod) (string, error) {
+	var (
+		status models.BrokerStatus
+		err    error
+	)
+	switch payment.Operation {
+	case models.URLpayment:
+		if method.PreAuth {
+			return s.broker.CreateAuthOrder(ctx, payment, method.ReturnURL, method.FailURL)
+		}
+		return s.broker.CreateOrder(ctx, payment, method.ReturnURL, method.FailURL)
+	case models.QRPayment:
+		return s.broker.CreateQROrder(ctx, payment)
+	case models.BindingPayment:
+		status, err = s.broker.ChargeBinding(ctx, payment, method.BindingID)
+	case models.CardPayment:
+		status, err = s.broker.ChargeCard(ctx, payment, method.CardToken)
+	case models.WalletPayment:
+		status, err = s.broker.ChargeWallet(ctx, payment, method.WalletType, method.WalletToken)
+	default:
+		return "", ErrUnsupportedOperation
+	}
+	if err != nil {
+		return "", err
+	}
+
+	payment.Status = status.Status
+	return "", nil
+}
+
+// GetPayment — возвращает платёж вместе с данными для чека. paymentID — ID заказа у банка
+// или ID платежа у мерчанта из подстановки {payment_id} адреса возврата.
 func (s *PaymentService) GetPayment(ctx context.Context, paymentID string) (models.Payment, error) {
 	l := s.log.With("order_id", paymentID)
 	l.Debug(ctx, action.GetPayment, "begin")
 
 	payment, err := s.repo.GetTransactionByPaymentID(ctx, paymentID)
+	if errors.Is(err, repo.ErrPaymentNotFound) {
+		var current string
+		if current, err = s.repo.PaymentIDByLocalID(ctx, paymentID); err == nil {
+			payment, err = s.repo.GetTransactionByPaymentID(ctx, current)
+		}
+	}
 	if err != nil {
 		l.Error(ctx, action.DbTransactionFailed, err, "failed to get payment")
 		return models.Payment{}, err
 	}
+	if payment.Customer, payment.Items, err = s.repo.GetPaymentCart(ctx, payment.ID); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get payment cart")
+		return models.Payment{}, err
+	}
 
 	s.log.With("payment_id", payment.ID, "amount", payment.Amount, "currency", payment.Currency).
 		Debug(ctx, action.GetPayment, "success")
 	return *payment, nil
 }
 
+// GetPaymentByOrderID — возвращает платёж по ID заказа мерчанта вместе с данными для чека.
+func (s *PaymentService) GetPaymentByOrderID(ctx context.Context, orderID string) (models.Payment, error) {
+	l := s.log.With("order_id", orderID)
+	l.Debug(ctx, action.GetPaymentByOrder, "begin")
+
+	payment, err := s.repo.GetTransactionByOrderID(ctx, orderID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get payment")
+		return models.Payment{}, err
+	}
+	if payment.Customer, payment.Items, err = s.repo.GetPaymentCart(ctx, payment.ID); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get payment cart")
+		return models.Payment{}, err
+	}
+
+	s.log.With("payment_id", payment.ID, "amount", payment.Amount, "currency", payment.Currency).
+		Debug(ctx, action.GetPaymentByOrder, "success")
+	return *payment, nil
+}
+
+// ResolvePaymentID — возвращает ID платежа по paymentID или, если он не задан, по orderID.
+func (s *PaymentService) ResolvePaymentID(ctx context.Context, paymentID, orderID string) (string, error) {
+	if paymentID != "" {
+		return paymentID, nil
+	}
+
+	payment, err := s.GetPaymentByOrderID(ctx, orderID)
+	if err != nil {
+		return "", err
+	}
+	return payment.ID, nil
+}
+
 // GetPaymentStatus — возвращает статус платежа.
 func (s *PaymentService) GetPaymentStatus(ctx context.Context, paymentID string) (models.StatusType, error) {
 	l := s.log.With("order_id", paymentID)
@@ -153,9 +477,25 @@ func (s *PaymentService) GetPaymentStatus(ctx context.Context, paymentID string)
 	return models.StatusType(status.Status), nil
 }
 
-// RefundPayment — инициирует возврат и меняет статус.
-func (s *PaymentService) RefundPayment(ctx context.Context, paymentID, reason string) (models.StatusType, error) {
-	l := s.log.With("payment_id", paymentID, "reason", reason)
+// GetPaymentHistory — возвращает все переходы статуса платежа (от старых к новым).
+func (s *PaymentService) GetPaymentHistory(ctx context.Context, paymentID string) ([]models.PaymentStatus, error) {
+	l := s.log.With("payment_id", paymentID)
+	l.Debug(ctx, action.GetPaymentHistory, "begin")
+
+	history, err := s.repo.GetStatusHistory(ctx, paymentID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get payment status history")
+		return nil, err
+	}
+
+	l.Debug(ctx, action.GetPaymentHistory, "success", "count", len(history))
+	return history, nil
+}
+
+// RefundPayment — возвращает покупателю списанные средства и меняет статус.
+// Нулевая сумма — возврат всего остатка; после частичного возврата платёж переходит в PARTIALLY_REFUNDED.
+func (s *PaymentService) RefundPayment(ctx context.Context, paymentID, reason string, amount float64, currency string) (models.StatusType, error) {
+	l := s.log.With("payment_id", paymentID, "reason", reason, "amount", amount, "currency", currency)
 	l.Debug(ctx, action.RefundPayment, "begin")
 
 	payment, err := s.repo.GetTransactionByPaymentID(ctx, paymentID)
@@ -163,19 +503,56 @@ func (s *PaymentService) RefundPayment(ctx context.Context, paymentID, reason st
 		l.Error(ctx, action.DbTransactionFailed, err, "failed to load payment")
 		return "", err
 	}
+	if payment.Status == models.OrderReview {
+		l.Error(ctx, action.ValidationFailed, ErrPaymentInReview, "payment is under review")
+		return "", ErrPaymentInReview
+	}
+	if !isRefundable(payment.Status) {
+		l.Error(ctx, action.ValidationFailed, ErrPaymentNotRefundable, "payment cannot be refunded", "status", payment.Status)
+		return "", ErrPaymentNotRefundable
+	}
+
+	// Возврат всего остатка
+	if amount == 0 {
+		amount, currency = payment.RefundableAmount(), payment.Currency
+	}
+
+	amount, currency, err = s.settlementAmount(ctx, payment, amount, currency)
+	if err == nil {
+		err = validateRefund(payment, amount)
+	}
+	if err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "refund request does not match payment")
+		return "", err
+	}
+
+	audit := newAuditEntry(ctx, paymentID, map[string]any{
+		"reason":   reason,
+		"amount":   amount,
+		"currency": currency,
+	})
 
-	if err := s.broker.RefundOrder(ctx, paymentID, payment.Amount, payment.Currency); err != nil {
+	brokerCode, err := s.broker.RefundOrder(ctx, paymentID, amount, currency)
+	if err != nil {
 		l.Error(ctx, action.PaymentTransactionFail, err, "broker refund failed")
-		return "", fmt.Errorf("%w: %v", ErrBrokerOperationFailed, err)
+		s.auditFailure(ctx, audit, ErrBrokerOperationFailed, err)
+		return "", fmt.Errorf("%w: %w", ErrBrokerOperationFailed, err)
 	}
 
-	if err := s.repo.Refund(ctx, paymentID, reason, payment.Amount); err != nil {
+	status := models.OrderRefunded
+	if amount < payment.RefundableAmount() {
+		status = models.OrderPartiallyRefunded
+	}
+
+	change := models.StatusChange{Status: status, Source: models.SourceAPI, BrokerCode: brokerCode}
+	if err := s.repo.Refund(ctx, paymentID, reason, amount, change, audit); err != nil {
 		l.Error(ctx, action.DbTransactionFailed, err, "failed to mark refund in db")
+		s.auditFailure(ctx, audit, err, nil)
 		return "", err
 	}
 
-	s.log.With("refunded_amount", payment.Amount).Info(ctx, action.RefundPayment, "success")
-	return models.OrderRefunded, nil
+	s.log.With("refunded_amount", amount).Info(ctx, action.RefundPayment, "success")
+	return status, nil
 }
 
 // SuccessPayment — помечает платёж как успешный (DEPOSITED).
@@ -183,49 +560,75 @@ func (s *PaymentService) SuccessPayment(ctx context.Context, paymentID string) (
 	l := s.log.With("payment_id", paymentID)
 	l.Debug(ctx, action.SuccessPayment, "begin")
 
-	status, err := s.broker.GetOrderStatus(ctx, paymentID)
+	audit := newAuditEntry(ctx, paymentID, nil)
+
+	brokerStatus, err := s.broker.GetOrderStatus(ctx, paymentID)
 	if err != nil {
 		l.Error(ctx, action.PaymentTransactionFail, err, "failed to get payment status")
-		return "", fmt.Errorf("%w: %v", ErrBrokerOperationFailed, err)
+		s.auditFailure(ctx, audit, ErrBrokerOperationFailed, err)
+		return "", fmt.Errorf("%w: %w", ErrBrokerOperationFailed, err)
+	}
+
+	if err := s.checkBrokerState(ctx, paymentID, brokerStatus); err != nil {
+		s.auditFailure(ctx, audit, err, nil)
+		return "", err
 	}
 
+	status := brokerStatus.Status
 	if status != models.OrderApproved && status != models.OrderDeposited {
 		l.Error(ctx, action.ValidationFailed, ErrPaymentNotPaid, "Order is not paid yet")
+		s.auditFailure(ctx, audit, ErrPaymentNotPaid, nil)
 		return "", ErrPaymentNotPaid
 	}
 
-	if err := s.repo.MarkStatus(ctx, paymentID, status); err != nil {
+	change := models.StatusChange{Status: status, Source: models.SourceBankCallback, BrokerCode: brokerStatus.Code}
+	if err := s.repo.MarkStatus(ctx, paymentID, change, audit); err != nil {
 		l.Error(ctx, action.DbTransactionFailed, err, "failed to mark deposited")
+		s.auditFailure(ctx, audit, err, nil)
 		return "", err
 	}
 
+	s.saveBinding(ctx, paymentID)
+
 	l.Info(ctx, action.SuccessPayment, "success")
 	return status, nil
 }
 
-// PaymentsList — список платежей пользователя с пагинацией.
-func (s *PaymentService) PaymentsList(ctx context.Context, userID string, pageNum, pageSize int) ([]models.Payment, error) {
-	offset := (pageNum - 1) * pageSize
-	l := s.log.With("user_id", userID, "page", pageNum, "page_size", pageSize, "offset", offset)
+// PaymentsList — список платежей по фильтру с пагинацией.
+// Если курсор не задан, страница выбирается по номеру pageNum.
+func (s *PaymentService) PaymentsList(ctx context.Context, filter models.PaymentFilter, pageNum int) (models.PaymentPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = models.DefaultPageSize
+	}
+	filter.Limit = min(filter.Limit, models.MaxPageSize)
+	if filter.Cursor == "" && pageNum > 1 {
+		filter.Offset = (pageNum - 1) * filter.Limit
+	}
+
+	l := s.log.With("user_id", filter.UserID, "page", pageNum, "page_size", filter.Limit,
+		"offset", filter.Offset, "sort_by", filter.SortBy, "sort_desc", filter.SortDesc)
 	l.Debug(ctx, action.ListPayments, "begin")
 
-	list, err := s.repo.UserPaymentsList(ctx, userID, offset, pageSize)
+	page, err := s.repo.ListPayments(ctx, filter)
 	if err != nil {
-		l.Error(ctx, action.DbTransactionFailed, err, "failed to get user payments list")
-		return nil, err
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get payments list")
+		return models.PaymentPage{}, err
 	}
 
-	l.Info(ctx, action.ListPayments, "success")
-	return list, nil
+	l.Info(ctx, action.ListPayments, "success", "count", len(page.Payments), "total", page.Total)
+	return page, nil
 }
 
-// AuthPayment — создаёт авторизованный платёж (hold).
+// AuthPayment — создаёт авторизованный платёж (hold): банк только блокирует сумму на платёжной форме,
+// списание выполняется отдельно через DepositPayment. Проходит те же проверки, что CreatePayment:
+// адреса возврата, лимиты и проверку риска, поэтому может быть отклонён или отложен на ручную проверку.
 func (s *PaymentService) AuthPayment(
 	ctx context.Context,
 	orderID, userID string,
 	amount float64,
 	currency string,
 	returnURL, failURL string,
+	details models.PaymentDetails,
 ) (models.Payment, string, error) {
 	l := s.log.With(
 		"order_id", orderID,
@@ -235,108 +638,79 @@ func (s *PaymentService) AuthPayment(
 	)
 	l.Debug(ctx, action.AuthPayment, "begin")
 
-	if !IsCurrencySupported(currency) {
-		l.Error(ctx, action.ValidationFailed, ErrUnsupportedCurrency, "currency is not supported")
-		return models.Payment{}, "", ErrUnsupportedCurrency
-	}
-
-	uniq, err := s.repo.IsUnique(ctx, orderID)
+	method := models.PaymentMethod{ReturnURL: returnURL, FailURL: failURL, PreAuth: true}
+	payment, formURL, err := s.CreatePayment(ctx, orderID, userID, amount, currency, models.URLpayment, method, nil, details)
 	if err != nil {
-		l.Error(ctx, action.DbTransactionFailed, err, "Failed to check order uniqueness")
 		return models.Payment{}, "", err
 	}
 
-	if !uniq {
-		l.Error(ctx, action.ValidationFailed, repo.ErrOrderIDConflict, "order ID is not unique")
-		return models.Payment{}, "", repo.ErrOrderIDConflict
-	}
-
-	payment := models.Payment{
-		OrderID:   orderID,
-		UserID:    userID,
-		Amount:    amount,
-		Currency:  currency,
-		Operation: models.URLpayment,
-		Status:    models.OrderCreated,
-	}
-
-	formURL, err := s.broker.CreateAuthOrder(ctx, &payment, returnURL, failURL)
-	if err != nil {
-		l.Error(ctx, action.PaymentTransactionFail, err, "failed to create auth order at broker")
-		return models.Payment{}, "", fmt.Errorf("%w: %v", ErrBrokerOperationFailed, err)
-	}
-
-	if err := s.repo.Create(ctx, payment); err != nil {
-		l.Error(ctx, action.DbTransactionFailed, err, "failed to persist auth payment")
-		return models.Payment{}, "", err
-	}
-
-	s.log.With("payment_id", payment.ID, "broker", payment.Broker).
+	s.log.With("payment_id", payment.ID, "broker", payment.Broker, "status", payment.Status).
 		Info(ctx, action.AuthPayment, "success")
 	return payment, formURL, nil
 }
 
-// DepositPayment — списывает (capture) ранее авторизованные средства.
-func (s *PaymentService) DepositPayment(ctx context.Context, paymentID string, amount float64, currency string) (models.StatusType, error) {
+// ReversalPayment — разблокирует (reversal) авторизованные, но не списанные средства.
+// Нулевая сумма означает разблокировку всего остатка.
+func (s *PaymentService) ReversalPayment(ctx context.Context, paymentID string, amount float64, currency string) (models.StatusType, error) {
 	l := s.log.With("payment_id", paymentID, "amount", amount, "currency", currency)
-	l.Debug(ctx, action.DepositPayment, "begin")
+	l.Debug(ctx, action.ReversePayment, "begin")
 
-	// Списывание полной суммы
-	if amount == 0 {
-		payment, err := s.repo.GetTransactionByPaymentID(ctx, paymentID)
-		if err != nil {
-			l.Error(ctx, action.DbTransactionFailed, err, "failed to load payment")
-			return "", err
-		}
+	payment, err := s.repo.GetTransactionByPaymentID(ctx, paymentID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to load payment")
+		return "", err
+	}
 
-		amount = payment.Amount
-		currency = payment.Currency
+	if !hasOpenAuthorization(payment.Status) {
+		l.Error(ctx, action.ValidationFailed, ErrPaymentNotReversible, "payment cannot be reversed", "status", payment.Status)
+		return "", ErrPaymentNotReversible
 	}
 
-	// Инициируем списание у брокера
-	if err := s.broker.DepositOrder(ctx, paymentID, amount, currency); err != nil {
-		l.Error(ctx, action.PaymentTransactionFail, err, "broker deposit failed")
-		return "", fmt.Errorf("%w: %v", ErrBrokerOperationFailed, err)
+	// Реверс полной суммы
+	if amount == 0 {
+		amount, currency = payment.RemainingAmount(), payment.Currency
 	}
 
-	// Обновляем статус
-	if err := s.repo.MarkStatus(ctx, paymentID, models.OrderDeposited); err != nil {
-		l.Error(ctx, action.DbTransactionFailed, err, "failed to mark deposited in db")
+	amount, currency, err = s.settlementAmount(ctx, payment, amount, currency)
+	if err == nil {
+		err = validateAgainstPayment(payment, amount)
+	}
+	if err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "reversal request does not match payment")
 		return "", err
 	}
 
-	l.Info(ctx, action.DepositPayment, "success")
-	return models.OrderDeposited, nil
-}
-
-func (s *PaymentService) ReversalPayment(ctx context.Context, paymentID string, amount float64, currency string) (models.StatusType, error) {
-	l := s.log.With("payment_id", paymentID, "amount", amount, "currency", currency)
-	l.Debug(ctx, action.ReversePayment, "begin")
-
-	// Списывание полной суммы
-	if amount == 0 {
-		payment, err := s.repo.GetTransactionByPaymentID(ctx, paymentID)
-		if err != nil {
-			l.Error(ctx, action.DbTransactionFailed, err, "failed to load payment")
-			return "", err
-		}
-
-		amount = payment.Amount
-		currency = payment.Currency
-	}
+	audit := newAuditEntry(ctx, paymentID, map[string]any{
+		"amount":   amount,
+		"currency": currency,
+	})
 
 	// Инициируем реверсирование средств
-	if err := s.broker.ReversalOrder(ctx, paymentID, amount, currency); err != nil {
+	brokerCode, err := s.broker.ReversalOrder(ctx, paymentID, amount, currency)
+	if err != nil {
 		l.Error(ctx, action.PaymentTransactionFail, err, "broker reversal failed")
-		return "", fmt.Errorf("%w: %v", ErrBrokerOperationFailed, err)
+		s.auditFailure(ctx, audit, ErrBrokerOperationFailed, err)
+		return "", fmt.Errorf("%w: %w", ErrBrokerOperationFailed, err)
+	}
+
+	// Статус меняется, только когда заблокированных средств не осталось
+	payment.ReleasedAmount = models.RoundAmount(payment.ReleasedAmount + amount)
+	status := payment.Status
+	if payment.RemainingAmount() == 0 {
+		status = models.OrderReversed
+		if payment.CapturedAmount > 0 {
+			status = models.OrderDeposited
+		}
 	}
 
 	// Обновляем статус
-	if err := s.repo.MarkStatus(ctx, paymentID, models.OrderReversed); err != nil {
+	change := models.StatusChange{Status: status, Source: models.SourceAPI, BrokerCode: brokerCode}
+	if err := s.repo.Release(ctx, paymentID, amount, change, audit); err != nil {
 		l.Error(ctx, action.DbTransactionFailed, err, "failed to mark reversed in db")
+		s.auditFailure(ctx, audit, err, nil)
 		return "", err
 	}
 
-	l.Info(ctx, action.ReversePayment, "success")
-	return models.OrderReversed, nil
+	l.Info(ctx, action.ReversePayment, "success", "released", payment.ReleasedAmount, "status", status)
+	return status, nil
 }
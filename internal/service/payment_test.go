@@ -0,0 +1,492 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newRefundFixture(payments ...models.Payment) (*fakeBroker, *fakePaymentRepo, *PaymentService) {
+	broker, store := newFakeBroker(), newFakePaymentRepo()
+	for _, p := range payments {
+		store.payments[p.ID] = &p
+	}
+	return broker, store, NewPaymentService(broker, store, fakeCurrencies{}, fakeRisk{}, nil, testLogger())
+}
+
+func TestRefundPaymentPartialThenFull(t *testing.T) {
+	broker, store, s := newRefundFixture(models.Payment{
+		ID: "p1", Amount: 1000, CapturedAmount: 1000, Currency: "KZT", Status: models.OrderDeposited,
+	})
+	ctx := context.Background()
+
+	status, err := s.RefundPayment(ctx, "p1", "damaged", 300, "")
+	if err != nil {
+		t.Fatalf("partial RefundPayment: %v", err)
+	}
+	if status != models.OrderPartiallyRefunded {
+		t.Fatalf("status = %s, want PARTIALLY_REFUNDED", status)
+	}
+
+	// Нулевая сумма — возврат остатка
+	status, err = s.RefundPayment(ctx, "p1", "canceled", 0, "KZT")
+	if err != nil {
+		t.Fatalf("remaining RefundPayment: %v", err)
+	}
+	if status != models.OrderRefunded {
+		t.Fatalf("status = %s, want REFUNDED", status)
+	}
+
+	if len(broker.refunds) != 2 || broker.refunds[0] != 300 || broker.refunds[1] != 700 {
+		t.Fatalf("broker refunds = %v, want [300 700]", broker.refunds)
+	}
+	if p := store.payments["p1"]; p.RefundedAmount != 1000 || p.Status != models.OrderRefunded {
+		t.Fatalf("payment = %+v, want 1000 refunded", p)
+	}
+
+	if _, err := s.RefundPayment(ctx, "p1", "again", 0, ""); !errors.Is(err, ErrPaymentNotRefundable) {
+		t.Fatalf("refund of REFUNDED payment error = %v, want ErrPaymentNotRefundable", err)
+	}
+	if len(broker.refunds) != 2 {
+		t.Fatalf("broker refunds = %v, want no third refund", broker.refunds)
+	}
+}
+
+func TestRefundPaymentRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		payment  models.Payment
+		amount   float64
+		currency string
+		want     error
+	}{
+		{"authorized only", models.Payment{Amount: 1000, Status: models.OrderApproved}, 0, "", ErrPaymentNotRefundable},
+		{"partially captured", models.Payment{Amount: 1000, CapturedAmount: 400, Status: models.OrderPartiallyDeposited}, 100, "", ErrPaymentNotRefundable},
+		{"created", models.Payment{Amount: 1000, Status: models.OrderCreated}, 0, "", ErrPaymentNotRefundable},
+		{"reversed", models.Payment{Amount: 1000, Status: models.OrderReversed}, 0, "", ErrPaymentNotRefundable},
+		{"in review", models.Payment{Amount: 1000, Status: models.OrderReview}, 0, "", ErrPaymentInReview},
+		{"above captured", models.Payment{Amount: 1000, CapturedAmount: 400, ReleasedAmount: 600, Status: models.OrderDeposited}, 400.01, "", repo.ErrRefundExceedsCaptured},
+		{"above remainder", models.Payment{Amount: 1000, CapturedAmount: 1000, RefundedAmount: 800, Status: models.OrderPartiallyRefunded}, 300, "", repo.ErrRefundExceedsCaptured},
+		{"other currency", models.Payment{Amount: 1000, CapturedAmount: 1000, Status: models.OrderDeposited}, 100, "USD", ErrCurrencyMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.payment.ID, tt.payment.Currency = "p1", "KZT"
+			broker, _, s := newRefundFixture(tt.payment)
+
+			if _, err := s.RefundPayment(context.Background(), "p1", "", tt.amount, tt.currency); !errors.Is(err, tt.want) {
+				t.Fatalf("RefundPayment error = %v, want %v", err, tt.want)
+			}
+			if len(broker.refunds) != 0 {
+				t.Fatalf("broker refunds = %v, want none", broker.refunds)
+			}
+		})
+	}
+}
+
+func newLimitFixture(daily float64) (*fakeBroker, *fakePaymentRepo, *PaymentService) {
+	broker, store := newFakeBroker(), newFakePaymentRepo(models.Binding{ID: "card", UserID: "u1"})
+	store.limit = &models.PaymentLimit{Scope: models.LimitScopeUser, Currency: "KZT", Daily: daily, Active: true}
+	return broker, store, NewPaymentService(broker, store, fakeCurrencies{}, fakeRisk{}, nil, testLogger())
+}
+
+func TestChargeBindingReservesLimit(t *testing.T) {
+	broker, store, s := newLimitFixture(1000)
+	ctx := context.Background()
+
+	payment, err := s.ChargeBinding(ctx, "order-1", "u1", "card", 600, "KZT")
+	if err != nil {
+		t.Fatalf("ChargeBinding: %v", err)
+	}
+	if payment.ID != "bank-1" || payment.Status != models.OrderDeposited {
+		t.Fatalf("payment = %s %s, want bank-1 DEPOSITED", payment.ID, payment.Status)
+	}
+	if p, _ := store.GetTransactionByOrderID(ctx, "order-1"); p == nil || p.ID != "bank-1" || len(store.payments) != 1 {
+		t.Fatalf("stored payment = %+v, want reservation confirmed under bank-1", p)
+	}
+
+	// Резерв проверяется до банка: превышение лимита не доходит до списания
+	if _, err := s.ChargeBinding(ctx, "order-2", "u1", "card", 600, "KZT"); !errors.Is(err, models.ErrLimitExceeded) {
+		t.Fatalf("ChargeBinding over limit error = %v, want ErrLimitExceeded", err)
+	}
+	if broker.charges != 1 {
+		t.Fatalf("broker charges = %d, want 1", broker.charges)
+	}
+
+	// Возвращённая сумма освобождает лимит
+	if _, err := s.RefundPayment(ctx, "bank-1", "canceled", 0, ""); err != nil {
+		t.Fatalf("RefundPayment: %v", err)
+	}
+	if _, err := s.ChargeBinding(ctx, "order-2", "u1", "card", 600, "KZT"); err != nil {
+		t.Fatalf("ChargeBinding after refund: %v", err)
+	}
+}
+
+func TestChargeBindingBrokerFailureReleasesReservation(t *testing.T) {
+	broker, store, s := newLimitFixture(1000)
+	ctx := context.Background()
+
+	broker.err = models.ErrBrokerUnavailable
+	if _, err := s.ChargeBinding(ctx, "order-1", "u1", "card", 600, "KZT"); !errors.Is(err, ErrBrokerOperationFailed) {
+		t.Fatalf("ChargeBinding error = %v, want ErrBrokerOperationFailed", err)
+	}
+	if len(store.payments) != 0 {
+		t.Fatalf("payments = %d, want reservation released", len(store.payments))
+	}
+
+	// Тот же заказ проходит повторно: ни ID заказа, ни лимит не заняты
+	broker.err = nil
+	if _, err := s.ChargeBinding(ctx, "order-1", "u1", "card", 1000, "KZT"); err != nil {
+		t.Fatalf("ChargeBinding retry: %v", err)
+	}
+}
+
+func TestDeclinedChargeFreesLimit(t *testing.T) {
+	broker, _, s := newLimitFixture(1000)
+	broker.decline["card"] = true
+	ctx := context.Background()
+
+	if _, err := s.ChargeBinding(ctx, "order-1", "u1", "card", 800, "KZT"); !errors.Is(err, models.ErrPaymentDeclined) {
+		t.Fatalf("ChargeBinding error = %v, want ErrPaymentDeclined", err)
+	}
+
+	broker.decline["card"] = false
+	if _, err := s.ChargeBinding(ctx, "order-2", "u1", "card", 800, "KZT"); err != nil {
+		t.Fatalf("ChargeBinding after decline: %v", err)
+	}
+}
+
+func TestPendingPaymentHoldsLimitForFormTTL(t *testing.T) {
+	_, store, s := newLimitFixture(1000)
+	s.redirects = fakeRedirects{}
+	ctx := context.Background()
+	method := models.PaymentMethod{ReturnURL: "https://shop.example.com/ok", FailURL: "https://shop.example.com/fail"}
+
+	pending, _, err := s.CreatePayment(ctx, "order-1", "u1", 600, "KZT", string(models.URLpayment), method, nil, models.PaymentDetails{})
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+
+	// Открытая платёжная форма держит лимит
+	if _, _, err := s.CreatePayment(ctx, "order-2", "u1", 600, "KZT", string(models.URLpayment), method, nil, models.PaymentDetails{}); !errors.Is(err, models.ErrLimitExceeded) {
+		t.Fatalf("CreatePayment with open form error = %v, want ErrLimitExceeded", err)
+	}
+
+	// Брошенная форма перестаёт держать лимит, авторизованный платёж держит его дальше
+	store.payments[pending.ID].CreatedAt = time.Now().Add(-models.PendingLimitTTL)
+	second, _, err := s.CreatePayment(ctx, "order-2", "u1", 600, "KZT", string(models.URLpayment), method, nil, models.PaymentDetails{})
+	if err != nil {
+		t.Fatalf("CreatePayment after form TTL: %v", err)
+	}
+	store.payments[second.ID].Status = models.OrderApproved
+	store.payments[second.ID].CreatedAt = time.Now().Add(-models.PendingLimitTTL)
+	if _, _, err := s.CreatePayment(ctx, "order-3", "u1", 600, "KZT", string(models.URLpayment), method, nil, models.PaymentDetails{}); !errors.Is(err, models.ErrLimitExceeded) {
+		t.Fatalf("CreatePayment over authorized amount error = %v, want ErrLimitExceeded", err)
+	}
+}
+
+func TestApprovePaymentRechecksLimit(t *testing.T) {
+	broker, store, s := newLimitFixture(1000)
+	s.redirects, s.risk = fakeRedirects{}, fakeRisk{decision: models.RiskReview}
+	ctx := context.Background()
+	method := models.PaymentMethod{ReturnURL: "https://shop.example.com/ok", FailURL: "https://shop.example.com/fail"}
+
+	held, _, err := s.CreatePayment(ctx, "order-1", "u1", 600, "KZT", string(models.URLpayment), method, nil, models.PaymentDetails{})
+	if err != nil || held.Status != models.OrderReview {
+		t.Fatalf("CreatePayment = %s, %v; want REVIEW", held.Status, err)
+	}
+
+	// Пока проверка идёт дольше PendingLimitTTL, лимит занимает другой платёж
+	store.payments[held.ID].CreatedAt = time.Now().Add(-models.PendingLimitTTL)
+	s.risk = fakeRisk{}
+	if _, _, err := s.CreatePayment(ctx, "order-2", "u1", 600, "KZT", string(models.URLpayment), method, nil, models.PaymentDetails{}); err != nil {
+		t.Fatalf("CreatePayment while review is stale: %v", err)
+	}
+
+	if _, _, err := s.ApprovePayment(ctx, held.ID, "ok"); !errors.Is(err, models.ErrLimitExceeded) {
+		t.Fatalf("ApprovePayment error = %v, want ErrLimitExceeded", err)
+	}
+	if broker.orders != 1 || store.payments[held.ID].Status != models.OrderReview {
+		t.Fatalf("broker orders = %d, held status = %s; want approval stopped before the bank", broker.orders, store.payments[held.ID].Status)
+	}
+}
+
+func TestGetPaymentByOrderID(t *testing.T) {
+	_, _, s := newRefundFixture(
+		models.Payment{ID: "bank-1", OrderID: "order-1", Amount: 1000, Currency: "KZT", Status: models.OrderDeposited},
+		models.Payment{ID: "bank-2", OrderID: "order-2", Amount: 500, Currency: "KZT", Status: models.OrderCreated},
+	)
+	ctx := context.Background()
+
+	payment, err := s.GetPaymentByOrderID(ctx, "order-2")
+	if err != nil {
+		t.Fatalf("GetPaymentByOrderID: %v", err)
+	}
+	if payment.ID != "bank-2" || payment.Amount != 500 {
+		t.Fatalf("payment = %s %v, want bank-2 500", payment.ID, payment.Amount)
+	}
+
+	if _, err := s.GetPaymentByOrderID(ctx, "order-3"); !errors.Is(err, repo.ErrPaymentNotFound) {
+		t.Fatalf("unknown order error = %v, want ErrPaymentNotFound", err)
+	}
+
+	tests := []struct {
+		paymentID, orderID string
+		want               string
+		wantErr            error
+	}{
+		{paymentID: "bank-1", want: "bank-1"},
+		{paymentID: "bank-9", orderID: "order-1", want: "bank-9"}, // ID платежа важнее, проверяется при вызове
+		{orderID: "order-1", want: "bank-1"},
+		{orderID: "order-3", wantErr: repo.ErrPaymentNotFound},
+	}
+	for _, tt := range tests {
+		got, err := s.ResolvePaymentID(ctx, tt.paymentID, tt.orderID)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("ResolvePaymentID(%q, %q) = %q, %v; want %q, %v", tt.paymentID, tt.orderID, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPaymentHistorySources(t *testing.T) {
+	broker, _, s := newRefundFixture()
+	s.redirects = fakeRedirects{}
+	ctx := context.Background()
+	method := models.PaymentMethod{ReturnURL: "https://shop.example.com/ok", FailURL: "https://shop.example.com/fail"}
+
+	paid, _, err := s.CreatePayment(ctx, "order-1", "u1", 100, "KZT", string(models.URLpayment), method, nil, models.PaymentDetails{})
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+	declined, _, err := s.CreatePayment(ctx, "order-2", "u1", 100, "KZT", string(models.URLpayment), method, nil, models.PaymentDetails{})
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+
+	// Покупатель оплатил и вернулся с формы, второй заказ банк отклонил без возврата покупателя
+	broker.states[paid.ID], broker.states[declined.ID] = models.OrderDeposited, models.OrderDeclined
+	if _, err := s.SuccessPayment(ctx, paid.ID); err != nil {
+		t.Fatalf("SuccessPayment: %v", err)
+	}
+	if _, err := s.SyncPayment(ctx, declined.ID); err != nil {
+		t.Fatalf("SyncPayment: %v", err)
+	}
+
+	tests := []struct {
+		paymentID string
+		want      []models.PaymentStatus
+	}{
+		{paid.ID, []models.PaymentStatus{
+			{PaymentID: paid.ID, Status: string(models.OrderCreated), Source: models.SourceAPI},
+			{PaymentID: paid.ID, Status: string(models.OrderDeposited), Source: models.SourceBankCallback, BrokerCode: "DEPOSITED"},
+		}},
+		{declined.ID, []models.PaymentStatus{
+			{PaymentID: declined.ID, Status: string(models.OrderCreated), Source: models.SourceAPI},
+			{PaymentID: declined.ID, Status: string(models.OrderDeclined), Source: models.SourceReconciler, BrokerCode: "DECLINED"},
+		}},
+	}
+	for _, tt := range tests {
+		history, err := s.GetPaymentHistory(ctx, tt.paymentID)
+		if err != nil {
+			t.Fatalf("GetPaymentHistory(%s): %v", tt.paymentID, err)
+		}
+		if !reflect.DeepEqual(history, tt.want) {
+			t.Errorf("history of %s = %+v, want %+v", tt.paymentID, history, tt.want)
+		}
+	}
+}
+
+func TestSyncPaymentBooksAmounts(t *testing.T) {
+	tests := []struct {
+		name         string
+		payment      models.Payment
+		broker       models.StatusType
+		wantErr      error
+		wantStatus   models.StatusType
+		wantCaptured float64
+		wantRefunded float64
+		wantReleased float64
+	}{
+		{
+			name:    "one-stage payment refunded in bank cabinet",
+			payment: models.Payment{ID: "p1", Amount: 1000, Currency: "KZT", Status: models.OrderCreated},
+			broker:  models.OrderRefunded, wantStatus: models.OrderRefunded, wantCaptured: 1000, wantRefunded: 1000,
+		},
+		{
+			name: "rest of partial refund",
+			payment: models.Payment{ID: "p1", Amount: 1000, CapturedAmount: 1000, RefundedAmount: 300, Currency: "KZT",
+				Status: models.OrderPartiallyRefunded},
+			broker: models.OrderRefunded, wantStatus: models.OrderRefunded, wantCaptured: 1000, wantRefunded: 1000,
+		},
+		{
+			name:    "hold reversed",
+			payment: models.Payment{ID: "p1", Amount: 1000, Currency: "KZT", Status: models.OrderApproved},
+			broker:  models.OrderReversed, wantStatus: models.OrderReversed, wantReleased: 1000,
+		},
+		{
+			name:    "partial refund amount is unknown",
+			payment: models.Payment{ID: "p1", Amount: 1000, CapturedAmount: 1000, Currency: "KZT", Status: models.OrderDeposited},
+			broker:  models.OrderPartiallyRefunded, wantErr: ErrRefundAmountUnknown,
+			wantStatus: models.OrderDeposited, wantCaptured: 1000,
+		},
+		{
+			name:    "declined without amounts",
+			payment: models.Payment{ID: "p1", Amount: 1000, Currency: "KZT", Status: models.OrderCreated},
+			broker:  models.OrderDeclined, wantStatus: models.OrderDeclined,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker, store, s := newRefundFixture(tt.payment)
+			broker.states["p1"] = tt.broker
+
+			if _, err := s.SyncPayment(context.Background(), "p1"); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SyncPayment error = %v, want %v", err, tt.wantErr)
+			}
+
+			p := store.payments["p1"]
+			if p.Status != tt.wantStatus || p.CapturedAmount != tt.wantCaptured ||
+				p.RefundedAmount != tt.wantRefunded || p.ReleasedAmount != tt.wantReleased {
+				t.Fatalf("payment = %s captured %v refunded %v released %v, want %s captured %v refunded %v released %v",
+					p.Status, p.CapturedAmount, p.RefundedAmount, p.ReleasedAmount,
+					tt.wantStatus, tt.wantCaptured, tt.wantRefunded, tt.wantReleased)
+			}
+			if tt.wantErr == nil && len(store.history["p1"]) != 1 {
+				t.Fatalf("history = %+v, want one reconciler record", store.history["p1"])
+			}
+		})
+	}
+}
+
+func TestCreatePaymentOperationChecks(t *testing.T) {
+	hosted := models.PaymentMethod{ReturnURL: "https://shop.example.com/ok", FailURL: "https://shop.example.com/fail"}
+
+	tests := []struct {
+		name        string
+		operation   string
+		method      models.PaymentMethod
+		unsupported []models.PaymentOperation
+		want        error
+	}{
+		{"unknown operation", "SBP_payment", models.PaymentMethod{}, nil, ErrUnsupportedOperation},
+		{"method does not match", string(models.BindingPayment), hosted, nil, models.ErrInvalidPaymentMethod},
+		{"not supported by broker", string(models.CardPayment), models.PaymentMethod{CardToken: "tok_1"},
+			[]models.PaymentOperation{models.CardPayment}, models.ErrOperationNotSupported},
+		{"method checked before broker", string(models.QRPayment), models.PaymentMethod{CardToken: "tok_1"},
+			[]models.PaymentOperation{models.QRPayment}, models.ErrInvalidPaymentMethod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker, store, s := newRefundFixture()
+			s.redirects = fakeRedirects{}
+			broker.unsupported = tt.unsupported
+
+			_, _, err := s.CreatePayment(context.Background(), "order-1", "u1", 100, "KZT", tt.operation, tt.method, nil, models.PaymentDetails{})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("CreatePayment error = %v, want %v", err, tt.want)
+			}
+			if len(store.payments) != 0 || broker.orders != 0 || broker.charges != 0 {
+				t.Fatalf("payments = %d, broker orders = %d, charges = %d; want nothing created",
+					len(store.payments), broker.orders, broker.charges)
+			}
+		})
+	}
+
+	// Операция, которую банк поддерживает, проходит проверки
+	broker, _, s := newRefundFixture()
+	s.redirects = fakeRedirects{}
+	broker.unsupported = []models.PaymentOperation{models.CardPayment}
+	if _, _, err := s.CreatePayment(context.Background(), "order-1", "u1", 100, "KZT", string(models.URLpayment), hosted, nil, models.PaymentDetails{}); err != nil {
+		t.Fatalf("CreatePayment(URL_payment): %v", err)
+	}
+}
+
+func TestPaymentDetailsAreStored(t *testing.T) {
+	details := models.PaymentDetails{Description: "Чай", Metadata: map[string]any{"cart_id": "42", "source": "app"}}
+	method := models.PaymentMethod{ReturnURL: "https://shop.example.com/ok", FailURL: "https://shop.example.com/fail"}
+
+	tests := []struct {
+		name   string
+		create func(s *PaymentService) (models.Payment, error)
+	}{
+		{"create", func(s *PaymentService) (models.Payment, error) {
+			p, _, err := s.CreatePayment(context.Background(), "order-1", "u1", 100, "KZT", string(models.URLpayment), method, nil, details)
+			return p, err
+		}},
+		{"auth", func(s *PaymentService) (models.Payment, error) {
+			p, _, err := s.AuthPayment(context.Background(), "order-1", "u1", 100, "KZT", method.ReturnURL, method.FailURL, details)
+			return p, err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, store, s := newRefundFixture()
+			s.redirects = fakeRedirects{}
+
+			payment, err := tt.create(s)
+			if err != nil {
+				t.Fatalf("create payment: %v", err)
+			}
+			stored := store.payments[payment.ID]
+			if stored == nil || stored.Description != details.Description || !reflect.DeepEqual(stored.Metadata, details.Metadata) {
+				t.Fatalf("stored payment = %+v, want description and metadata %+v", stored, details)
+			}
+
+			// Описание и metadata попадают в аудит создания платежа
+			if len(store.audit) == 0 {
+				t.Fatal("no audit entry for created payment")
+			}
+			params := store.audit[len(store.audit)-1].Params
+			if params["description"] != details.Description || !reflect.DeepEqual(params["metadata"], details.Metadata) {
+				t.Fatalf("audit params = %v, want description and metadata", params)
+			}
+		})
+	}
+}
+
+func TestCreatePaymentValidatesCart(t *testing.T) {
+	method := models.PaymentMethod{ReturnURL: "https://shop.example.com/ok", FailURL: "https://shop.example.com/fail"}
+	items := []models.CartItem{{Name: "Чай", Quantity: 2, Price: 150}, {Name: "Сахар", Quantity: 1, Price: 50.5}}
+
+	tests := []struct {
+		name   string
+		amount float64
+		items  []models.CartItem
+		valid  bool
+		want   error // Причина ошибки валидации, если она есть
+	}{
+		{"total matches", 350.5, items, true, nil},
+		{"total mismatch", 350, items, false, models.ErrCartTotalMismatch},
+		{"negative quantity", 150, []models.CartItem{items[0], {Name: "Скидка", Quantity: -1, Price: 150}}, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker, store, s := newRefundFixture()
+			s.redirects = fakeRedirects{}
+
+			_, _, err := s.CreatePayment(context.Background(), "order-1", "u1", tt.amount, "KZT", string(models.URLpayment), method, nil, models.PaymentDetails{Items: tt.items})
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("CreatePayment: %v", err)
+				}
+				return
+			}
+			var verr *models.ValidationError
+			if !errors.As(err, &verr) || (tt.want != nil && !errors.Is(err, tt.want)) {
+				t.Fatalf("CreatePayment error = %v, want validation error %v", err, tt.want)
+			}
+			if len(store.payments) != 0 || broker.orders != 0 {
+				t.Fatalf("payments = %d, broker orders = %d; want nothing created", len(store.payments), broker.orders)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+	"time"
+)
+
+const (
+	receiptBatchSize   = 50
+	maxReceiptAttempts = 5
+)
+
+// ReceiptService — чеки покупателю после списания (DEPOSITED) и возврата (REFUNDED).
+// Чеки ставятся в очередь репозиторием платежей при смене статуса, регистрируются у фискального
+// провайдера фоновым обработчиком (RunReceipts) и отправляются покупателю, если у платежа есть контакты.
+type ReceiptService struct {
+	payments ports.PaymentRepo
+	repo     ports.ReceiptRepo
+	renderer ports.ReceiptRenderer
+	provider ports.FiscalProvider // nil, если чеки отключены
+	log      logger.Logger
+}
+
+func NewReceiptService(payments ports.PaymentRepo, repo ports.ReceiptRepo, renderer ports.ReceiptRenderer, provider ports.FiscalProvider, log logger.Logger) *ReceiptService {
+	return &ReceiptService{
+		payments: payments,
+		repo:     repo,
+		renderer: renderer,
+		provider: provider,
+		log:      log,
+	}
+}
+
+// GetReceipt — чек платежа и его документ в формате format.
+func (s *ReceiptService) GetReceipt(ctx context.Context, paymentID string, kind models.ReceiptKind, format models.ReceiptFormat) (models.Receipt, models.ReceiptDocument, error) {
+	l := s.log.With("payment_id", paymentID, "kind", kind, "format", format)
+	l.Debug(ctx, action.GetReceipt, "begin")
+
+	receipt, err := s.receipt(ctx, paymentID, kind)
+	if err != nil {
+		return models.Receipt{}, models.ReceiptDocument{}, err
+	}
+
+	document, err := s.renderer.Render(receipt, format)
+	if err != nil {
+		l.Error(ctx, action.GetReceipt, err, "failed to render receipt")
+		return models.Receipt{}, models.ReceiptDocument{}, err
+	}
+	return receipt, document, nil
+}
+
+// ResendReceipt — повторно отправляет чек покупателю. Непустой customer заменяет сохранённые контакты
+// только для этой отправки. Не зарегистрированный чек сначала регистрируется.
+func (s *ReceiptService) ResendReceipt(ctx context.Context, paymentID string, kind models.ReceiptKind, customer models.Customer) (models.Receipt, error) {
+	l := s.log.With("payment_id", paymentID, "kind", kind)
+	l.Debug(ctx, action.ResendReceipt, "begin")
+
+	if s.provider == nil {
+		l.Error(ctx, action.ValidationFailed, models.ErrReceiptsDisabled, "fiscal provider is not configured")
+		return models.Receipt{}, models.ErrReceiptsDisabled
+	}
+
+	receipt, err := s.receipt(ctx, paymentID, kind)
+	if err != nil {
+		return models.Receipt{}, err
+	}
+	if !customer.IsZero() {
+		receipt.Customer = customer
+	}
+	if receipt.Customer.IsZero() {
+		l.Error(ctx, action.ValidationFailed, models.ErrReceiptNoContact, "receipt has no recipient")
+		return models.Receipt{}, models.ErrReceiptNoContact
+	}
+
+	if receipt.Status != models.ReceiptIssued {
+		if receipt, err = s.register(ctx, receipt); err != nil {
+			return models.Receipt{}, err
+		}
+	}
+	if receipt, err = s.send(ctx, receipt); err != nil {
+		return models.Receipt{}, err
+	}
+
+	l.Info(ctx, action.ResendReceipt, "success")
+	return receipt, nil
+}
+
+// ProcessPending — регистрирует и отправляет чеки из очереди. Возвращает число выданных чеков.
+func (s *ReceiptService) ProcessPending(ctx context.Context) (int, error) {
+	issued := 0
+	for {
+		receipts, err := s.repo.PendingReceipts(ctx, receiptBatchSize)
+		if err != nil {
+			s.log.Error(ctx, action.DbTransactionFailed, err, "failed to get pending receipts")
+			return issued, err
+		}
+
+		failed := 0
+		for _, receipt := range receipts {
+			if err := ctx.Err(); err != nil {
+				return issued, err
+			}
+			if s.issue(ctx, receipt) {
+				issued++
+			} else {
+				failed++
+			}
+		}
+
+		// Неудачные чеки остаются в очереди до следующего запуска
+		if len(receipts) < receiptBatchSize || failed > 0 {
+			break
+		}
+	}
+
+	if issued > 0 {
+		s.log.Info(ctx, action.IssueReceipt, "success", "issued", issued)
+	}
+	return issued, nil
+}
+
+// RunReceipts — периодически обрабатывает очередь чеков до отмены ctx.
+func (s *ReceiptService) RunReceipts(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.ProcessPending(ctx); err != nil && ctx.Err() == nil {
+			s.log.Error(ctx, action.IssueReceipt, err, "receipt run failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// issue регистрирует чек из очереди и отправляет его покупателю.
+// Ошибка регистрации учитывается как попытка; ошибка отправки не мешает выдаче чека.
+func (s *ReceiptService) issue(ctx context.Context, receipt models.Receipt) bool {
+	l := s.log.With("payment_id", receipt.PaymentID, "kind", receipt.Kind, "attempt", receipt.Attempts+1)
+
+	if err := s.fill(ctx, &receipt); err != nil {
+		s.markFailed(ctx, receipt, err)
+		return false
+	}
+
+	receipt, err := s.register(ctx, receipt)
+	if err != nil {
+		s.markFailed(ctx, receipt, err)
+		return false
+	}
+
+	if !receipt.Customer.IsZero() {
+		if _, err := s.send(ctx, receipt); err != nil {
+			l.Warn(ctx, action.SendReceipt, "receipt is issued but not delivered", "error", err.Error())
+		}
+	}
+	return true
+}
+
+// register регистрирует чек у фискального провайдера и сохраняет фискальные данные.
+func (s *ReceiptService) register(ctx context.Context, receipt models.Receipt) (models.Receipt, error) {
+	l := s.log.With("payment_id", receipt.PaymentID, "kind", receipt.Kind, "provider", s.provider.Name())
+
+	fiscal, err := s.provider.Register(ctx, receipt)
+	if err != nil {
+		l.Error(ctx, action.IssueReceipt, err, "failed to register receipt")
+		return receipt, err
+	}
+
+	issued, err := s.repo.MarkReceiptIssued(ctx, receipt.ID, fiscal)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to save fiscal data")
+		return receipt, err
+	}
+
+	// Содержимое чека не хранится в таблице чеков
+	issued.OrderID, issued.Merchant, issued.Description = receipt.OrderID, receipt.Merchant, receipt.Description
+	issued.Amount, issued.Currency = receipt.Amount, receipt.Currency
+	issued.Customer, issued.Items = receipt.Customer, receipt.Items
+
+	l.Info(ctx, action.IssueReceipt, "receipt is registered", "fiscal_number", issued.FiscalNumber)
+	return issued, nil
+}
+
+// send отправляет покупателю PDF чека.
+func (s *ReceiptService) send(ctx context.Context, receipt models.Receipt) (models.Receipt, error) {
+	l := s.log.With("payment_id", receipt.PaymentID, "kind", receipt.Kind, "provider", s.provider.Name())
+
+	document, err := s.renderer.Render(receipt, models.ReceiptPDF)
+	if err != nil {
+		l.Error(ctx, action.SendReceipt, err, "failed to render receipt")
+		return models.Receipt{}, err
+	}
+	if err := s.provider.Send(ctx, receipt, document); err != nil {
+		l.Error(ctx, action.SendReceipt, err, "failed to send receipt")
+		return models.Receipt{}, err
+	}
+
+	if err := s.repo.MarkReceiptSent(ctx, receipt.ID); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to mark receipt as sent")
+		return models.Receipt{}, err
+	}
+	now := time.Now()
+	receipt.SentAt = &now
+	return receipt, nil
+}
+
+// receipt — чек платежа с содержимым из сохранённого платежа и корзины.
+func (s *ReceiptService) receipt(ctx context.Context, paymentID string, kind models.ReceiptKind) (models.Receipt, error) {
+	receipt, err := s.repo.GetReceipt(ctx, paymentID, kind)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to get receipt", "payment_id", paymentID, "kind", kind)
+		return models.Receipt{}, err
+	}
+	if err := s.fill(ctx, receipt); err != nil {
+		return models.Receipt{}, err
+	}
+	return *receipt, nil
+}
+
+func (s *ReceiptService) fill(ctx context.Context, receipt *models.Receipt) error {
+	payment, err := s.payments.GetTransactionByPaymentID(ctx, receipt.PaymentID)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to get receipt payment", "payment_id", receipt.PaymentID)
+		return err
+	}
+	if payment.Customer, payment.Items, err = s.payments.GetPaymentCart(ctx, payment.ID); err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to get receipt cart", "payment_id", receipt.PaymentID)
+		return err
+	}
+
+	receipt.Fill(*payment)
+	return nil
+}
+
+func (s *ReceiptService) markFailed(ctx context.Context, receipt models.Receipt, cause error) {
+	if err := s.repo.MarkReceiptFailed(ctx, receipt.ID, cause.Error(), maxReceiptAttempts); err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to record receipt attempt", "payment_id", receipt.PaymentID)
+	}
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment/internal/domain/models"
+	"testing"
+)
+
+func TestResendReceiptDisabled(t *testing.T) {
+	s := NewReceiptService(newFakePaymentRepo(), nil, nil, nil, testLogger())
+
+	_, err := s.ResendReceipt(context.Background(), "bank-1", models.ReceiptSale, models.Customer{Email: "a@example.com"})
+	if !errors.Is(err, models.ErrReceiptsDisabled) {
+		t.Fatalf("ResendReceipt error = %v, want ErrReceiptsDisabled", err)
+	}
+}
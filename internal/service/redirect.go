@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+)
+
+// RedirectService — адреса возврата покупателя с платёжной формы банка.
+type RedirectService struct {
+	repo      ports.RedirectRepo
+	merchant  string   // Логин мерчанта, для которого берётся список хостов
+	returnURL string   // Адрес возврата по умолчанию
+	failURL   string   // Адрес возврата после ошибки по умолчанию
+	hosts     []string // Хосты, если для мерчанта нет своего списка
+	allowHTTP bool
+	log       logger.Logger
+}
+
+func NewRedirectService(
+	repo ports.RedirectRepo,
+	merchant, returnURL, failURL string,
+	hosts []string,
+	allowHTTP bool,
+	log logger.Logger,
+) *RedirectService {
+	return &RedirectService{
+		repo:      repo,
+		merchant:  merchant,
+		returnURL: returnURL,
+		failURL:   failURL,
+		hosts:     hosts,
+		allowHTTP: allowHTTP,
+		log:       log,
+	}
+}
+
+// Resolve — адреса возврата для платежа: пустые заменяются адресами по умолчанию,
+// подстановки раскрываются, результат проверяется по списку хостов мерчанта.
+func (s *RedirectService) Resolve(ctx context.Context, returnURL, failURL string, vars map[string]string) (string, string, error) {
+	policy, err := s.policy(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	verr := models.ValidationError{Cause: models.ErrInvalidRedirectURL}
+	resolve := func(field, raw, fallback string) string {
+		if raw == "" {
+			raw = fallback
+		}
+		if raw == "" {
+			verr.Add(field, field+" is required and no default is configured")
+			return ""
+		}
+
+		expanded := models.ExpandRedirectURL(field, raw, vars, &verr)
+		policy.Check(field, expanded, &verr)
+		return expanded
+	}
+	returnURL = resolve("return_url", returnURL, s.returnURL)
+	failURL = resolve("error_url", failURL, s.failURL)
+
+	if err := verr.Err(); err != nil {
+		s.log.Error(ctx, action.ValidationFailed, err, "redirect URL is not allowed")
+		return "", "", err
+	}
+	return returnURL, failURL, nil
+}
+
+// Validate — проверяет шаблоны адресов возврата заранее (например, при создании счёта).
+func (s *RedirectService) Validate(ctx context.Context, returnURL, failURL string) error {
+	sample := map[string]string{
+		models.RedirectOrderID:   "order",
+		models.RedirectUserID:    "user",
+		models.RedirectPaymentID: "payment",
+	}
+	_, _, err := s.Resolve(ctx, returnURL, failURL, sample)
+	return err
+}
+
+// policy — список хостов мерчанта из БД, иначе из конфигурации. Если оба пусты, возврат запрещён.
+func (s *RedirectService) policy(ctx context.Context) (models.RedirectPolicy, error) {
+	hosts, err := s.repo.MerchantRedirectHosts(ctx, s.merchant)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to load redirect hosts")
+		return models.RedirectPolicy{}, err
+	}
+	if len(hosts) == 0 {
+		hosts = s.hosts
+	}
+	return models.RedirectPolicy{Hosts: hosts, AllowHTTP: s.allowHTTP}, nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment/internal/domain/models"
+	"strings"
+	"testing"
+)
+
+func TestRedirectResolve(t *testing.T) {
+	vars := models.Payment{OrderID: "order 1", UserID: "u1"}.RedirectVars("pay-1")
+
+	tests := []struct {
+		name       string
+		merchant   []string // Хосты мерчанта в БД
+		config     []string // REDIRECT_ALLOWED_HOSTS
+		returnURL  string
+		failURL    string // Пусто — адрес ошибки по умолчанию
+		wantReturn string
+		wantErr    bool
+	}{
+		{
+			name:       "default with placeholders",
+			config:     []string{"shop.example.com"},
+			wantReturn: "https://shop.example.com/orders/order%201?payment=pay-1",
+		},
+		{
+			name:       "merchant list overrides config",
+			merchant:   []string{"*.merchant.example.com"},
+			config:     []string{"shop.example.com"},
+			returnURL:  "https://kz.merchant.example.com/{payment_id}",
+			failURL:    "https://kz.merchant.example.com/fail",
+			wantReturn: "https://kz.merchant.example.com/pay-1",
+		},
+		{
+			name:      "config host rejected when merchant has own list",
+			merchant:  []string{"*.merchant.example.com"},
+			config:    []string{"shop.example.com"},
+			returnURL: "https://shop.example.com/return",
+			wantErr:   true,
+		},
+		{
+			name:      "no hosts configured",
+			returnURL: "https://shop.example.com/return",
+			wantErr:   true,
+		},
+		{
+			name:      "unknown placeholder",
+			config:    []string{"shop.example.com"},
+			returnURL: "https://shop.example.com/{invoice_id}",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewRedirectService(fakeRedirectRepo{"merchant": tt.merchant}, "merchant",
+				"https://shop.example.com/orders/{order_id}?payment={payment_id}", "https://shop.example.com/fail",
+				tt.config, false, testLogger())
+
+			returnURL, failURL, err := s.Resolve(context.Background(), tt.returnURL, tt.failURL, vars)
+			if tt.wantErr {
+				if !errors.Is(err, models.ErrInvalidRedirectURL) {
+					t.Fatalf("Resolve error = %v, want ErrInvalidRedirectURL", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			wantFail := tt.failURL
+			if wantFail == "" {
+				wantFail = "https://shop.example.com/fail"
+			}
+			if returnURL != tt.wantReturn || failURL != wantFail {
+				t.Fatalf("Resolve = %q, %q; want %q, %q", returnURL, failURL, tt.wantReturn, wantFail)
+			}
+		})
+	}
+}
+
+func TestCreatePaymentExpandsPaymentID(t *testing.T) {
+	broker, store := newFakeBroker(), newFakePaymentRepo()
+	redirects := NewRedirectService(fakeRedirectRepo{}, "merchant", "", "", []string{"shop.example.com"}, false, testLogger())
+	s := NewPaymentService(broker, store, fakeCurrencies{}, fakeRisk{}, redirects, testLogger())
+	ctx := context.Background()
+
+	method := models.PaymentMethod{
+		ReturnURL: "https://shop.example.com/payments/{payment_id}",
+		FailURL:   "https://shop.example.com/payments/{payment_id}/fail",
+	}
+	payment, _, err := s.CreatePayment(ctx, "order-1", "u1", 100, "KZT", string(models.URLpayment), method, nil, models.PaymentDetails{})
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+
+	localID, ok := strings.CutPrefix(broker.returnURL, "https://shop.example.com/payments/")
+	if !ok || !strings.HasPrefix(localID, "pay-") {
+		t.Fatalf("return URL = %q, want payment ID known before registration", broker.returnURL)
+	}
+
+	// По ID из адреса возврата мерчант находит платёж с ID заказа у банка
+	got, err := s.GetPayment(ctx, localID)
+	if err != nil {
+		t.Fatalf("GetPayment(%s): %v", localID, err)
+	}
+	if got.ID != payment.ID || got.ID != "bank-order-1" {
+		t.Fatalf("GetPayment(%s) = %s, want %s", localID, got.ID, payment.ID)
+	}
+}
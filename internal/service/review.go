@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+)
+
+// ApprovePayment — одобряет платёж на ручной проверке и проводит его у банка той операцией
+// и тем способом оплаты, с которыми он был создан. Платёж получает ID заказа у банка.
+// Возвращает URL оплаты или QR код, как CreatePayment.
+func (s *PaymentService) ApprovePayment(ctx context.Context, paymentID, note string) (models.Payment, string, error) {
+	l := s.log.With("payment_id", paymentID)
+	l.Debug(ctx, action.ApprovePayment, "begin")
+
+	payment, review, err := s.pendingReview(ctx, paymentID)
+	if err != nil {
+		return models.Payment{}, "", err
+	}
+
+	audit := newAuditEntry(ctx, payment.ID, map[string]any{
+		"review_id": review.ReviewID,
+		"decision":  models.ReviewApproved,
+		"note":      note,
+	})
+
+	if !s.broker.Supports(payment.Operation) {
+		l.Error(ctx, action.ValidationFailed, models.ErrOperationNotSupported, "operation is not supported by broker")
+		s.auditFailure(ctx, audit, models.ErrOperationNotSupported, nil)
+		return models.Payment{}, "", models.ErrOperationNotSupported
+	}
+
+	// Платёж на проверке перестаёт держать лимит через models.PendingLimitTTL, поэтому лимит проверяется заново
+	if err := s.repo.CheckLimits(ctx, payment); err != nil {
+		s.persistFailure(ctx, payment, audit, err, "failed to check payment limits")
+		return models.Payment{}, "", err
+	}
+
+	// Создание заказа у брокера
+	payment.Status = models.OrderCreated
+	next, err := s.executeOperation(ctx, &payment, review.Method)
+	if err != nil {
+		l.Error(ctx, action.PaymentTransactionFail, err, "failed to create payment at broker")
+		s.auditFailure(ctx, audit, ErrBrokerOperationFailed, err)
+		return models.Payment{}, "", fmt.Errorf("%w: %w", ErrBrokerOperationFailed, err)
+	}
+	audit.PaymentID = payment.ID
+
+	if err := s.checkBrokerState(ctx, payment.ID, models.BrokerStatus{Status: payment.Status}); err != nil {
+		s.auditFailure(ctx, audit, err, nil)
+		return models.Payment{}, "", err
+	}
+
+	review.Status = models.ReviewApproved
+	review.Reviewer = models.CallerFromContext(ctx).Actor
+	review.Note = note
+	change := models.StatusChange{Status: payment.Status, Source: models.SourceAPI}
+	paymentURL := ""
+	if payment.Operation == models.URLpayment {
+		paymentURL = next
+	}
+	if err := s.repo.ResolveReview(ctx, *review, payment, paymentURL, change, audit); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to persist approved payment")
+		s.auditFailure(ctx, audit, err, nil)
+		return models.Payment{}, "", err
+	}
+
+	l.Info(ctx, action.ApprovePayment, "success", "bank_payment_id", payment.ID, "status", payment.Status)
+	return payment, next, nil
+}
+
+// RejectPayment — отклоняет платёж на ручной проверке. Платёж переходит в DECLINED без обращения к банку.
+func (s *PaymentService) RejectPayment(ctx context.Context, paymentID, note string) (models.Payment, error) {
+	l := s.log.With("payment_id", paymentID)
+	l.Debug(ctx, action.RejectPayment, "begin")
+
+	payment, review, err := s.pendingReview(ctx, paymentID)
+	if err != nil {
+		return models.Payment{}, err
+	}
+
+	audit := newAuditEntry(ctx, payment.ID, map[string]any{
+		"review_id": review.ReviewID,
+		"decision":  models.ReviewRejected,
+		"note":      note,
+	})
+
+	review.Status = models.ReviewRejected
+	review.Reviewer = models.CallerFromContext(ctx).Actor
+	review.Note = note
+	change := models.StatusChange{Status: models.OrderDeclined, Source: models.SourceAPI}
+	if err := s.repo.ResolveReview(ctx, *review, payment, "", change, audit); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to persist rejected payment")
+		s.auditFailure(ctx, audit, err, nil)
+		return models.Payment{}, err
+	}
+	payment.Status = models.OrderDeclined
+
+	l.Info(ctx, action.RejectPayment, "success")
+	return payment, nil
+}
+
+// pendingReview возвращает платёж в статусе REVIEW и его ручную проверку.
+func (s *PaymentService) pendingReview(ctx context.Context, paymentID string) (models.Payment, *models.PaymentReview, error) {
+	l := s.log.With("payment_id", paymentID)
+
+	payment, err := s.repo.GetTransactionByPaymentID(ctx, paymentID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to load payment")
+		return models.Payment{}, nil, err
+	}
+	if payment.Status != models.OrderReview {
+		l.Error(ctx, action.ValidationFailed, ErrPaymentNotInReview, "payment is not under review", "status", payment.Status)
+		return models.Payment{}, nil, ErrPaymentNotInReview
+	}
+
+	review, err := s.repo.GetReview(ctx, paymentID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to load payment review")
+		return models.Payment{}, nil, err
+	}
+	return *payment, review, nil
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment/internal/adapters/risk"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+	"time"
+)
+
+var (
+	ErrPaymentDenied      = errors.New("payment is denied by risk rules")
+	ErrPaymentInReview    = errors.New("payment is under risk review")
+	ErrPaymentNotInReview = errors.New("payment is not under risk review")
+	ErrRiskRuleConflict   = errors.New("risk rule with this name already exists")
+)
+
+// RiskService — проверка риска платежей по правилам из конфигурации и БД.
+type RiskService struct {
+	repo  ports.RiskRepo
+	rules []models.RiskRule // Правила из файла конфигурации
+	log   logger.Logger
+}
+
+func NewRiskService(repo ports.RiskRepo, rules []models.RiskRule, log logger.Logger) *RiskService {
+	return &RiskService{
+		repo:  repo,
+		rules: rules,
+		log:   log,
+	}
+}
+
+// compiledRule — правило с разобранным выражением
+type compiledRule struct {
+	models.RiskRule
+	program *risk.Program
+}
+
+// Assess проверяет платёж по всем включённым правилам.
+// Правило deny отклоняет платёж. Иначе сработавшее правило allow (например, список доверенных
+// пользователей) пропускает платёж, а правило review без allow отправляет его на ручную проверку.
+func (s *RiskService) Assess(ctx context.Context, input models.RiskInput) (models.RiskAssessment, error) {
+	l := s.log.With("order_id", input.OrderID, "user_id", input.UserID, "ip", input.ClientIP)
+	l.Debug(ctx, action.AssessRisk, "begin")
+
+	rules, err := s.compiledRules(ctx)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to load risk rules")
+		return models.RiskAssessment{}, err
+	}
+	if len(rules) == 0 {
+		return models.RiskAssessment{Decision: models.RiskAllow}, nil
+	}
+
+	env, err := s.environment(ctx, input, rules)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to collect risk facts")
+		return models.RiskAssessment{}, err
+	}
+
+	matched := make(map[models.RiskDecision][]string)
+	for _, rule := range rules {
+		if rule.program.Eval(env) {
+			matched[rule.Decision] = append(matched[rule.Decision], rule.Name)
+		}
+	}
+
+	assessment := models.RiskAssessment{Decision: models.RiskAllow, Rules: matched[models.RiskAllow]}
+	switch {
+	case len(matched[models.RiskDeny]) > 0:
+		assessment = models.RiskAssessment{Decision: models.RiskDeny, Rules: matched[models.RiskDeny]}
+	case len(matched[models.RiskAllow]) > 0:
+	case len(matched[models.RiskReview]) > 0:
+		assessment = models.RiskAssessment{Decision: models.RiskReview, Rules: matched[models.RiskReview]}
+	}
+
+	l.Info(ctx, action.AssessRisk, "success", "decision", assessment.Decision, "rules", assessment.Rules)
+	return assessment, nil
+}
+
+// compiledRules — включённые правила конфигурации и БД с разобранными выражениями.
+// Правило БД с ошибкой в выражении пропускается, чтобы не останавливать приём платежей.
+func (s *RiskService) compiledRules(ctx context.Context) ([]compiledRule, error) {
+	dbRules, err := s.repo.ListRiskRules(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]compiledRule, 0, len(s.rules)+len(dbRules))
+	for _, rule := range append(append([]models.RiskRule{}, s.rules...), dbRules...) {
+		program, err := risk.Compile(rule.Expression)
+		if err != nil {
+			s.log.Warn(ctx, action.AssessRisk, "skip invalid risk rule", "rule", rule.Name, "error", err.Error())
+			continue
+		}
+		rules = append(rules, compiledRule{RiskRule: rule, program: program})
+	}
+	return rules, nil
+}
+
+// environment собирает факты платежа: данные запроса, скорость платежей и вхождение в списки.
+func (s *RiskService) environment(ctx context.Context, input models.RiskInput, rules []compiledRule) (risk.Env, error) {
+	velocity, err := s.repo.Velocity(ctx, input.UserID, input.ClientIP, input.Currency, time.Now())
+	if err != nil {
+		return risk.Env{}, err
+	}
+
+	env := risk.Env{
+		Numbers: map[string]float64{
+			risk.FactAmount:        input.Amount,
+			risk.FactUserCount1h:   float64(velocity.UserCount1h),
+			risk.FactUserCount24h:  float64(velocity.UserCount24h),
+			risk.FactUserAmount24h: velocity.UserAmount24h,
+			risk.FactIPCount1h:     float64(velocity.IPCount1h),
+			risk.FactIPCount24h:    float64(velocity.IPCount24h),
+		},
+		Strings: map[string]string{
+			risk.FactCurrency:  input.Currency,
+			risk.FactUserID:    input.UserID,
+			risk.FactIP:        input.ClientIP,
+			risk.FactOperation: string(input.Operation),
+			risk.FactOrderID:   input.OrderID,
+		},
+	}
+
+	var lists []string
+	for _, rule := range rules {
+		lists = append(lists, rule.program.Lists()...)
+	}
+	values := make([]string, 0, len(env.Strings))
+	for _, value := range env.Strings {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+
+	if env.Lists, err = s.repo.ListMatches(ctx, lists, values); err != nil {
+		return risk.Env{}, err
+	}
+	return env, nil
+}
+
+// ListRules — правила из конфигурации и БД.
+func (s *RiskService) ListRules(ctx context.Context, includeDisabled bool) ([]models.RiskRule, error) {
+	dbRules, err := s.repo.ListRiskRules(ctx, includeDisabled)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to list risk rules")
+		return nil, err
+	}
+	return append(append([]models.RiskRule{}, s.rules...), dbRules...), nil
+}
+
+// CreateRule — добавляет правило в БД. Правило начинает действовать со следующего платежа.
+func (s *RiskService) CreateRule(ctx context.Context, rule models.RiskRule) (models.RiskRule, error) {
+	l := s.log.With("rule", rule.Name, "decision", rule.Decision)
+	l.Debug(ctx, action.CreateRiskRule, "begin")
+
+	if err := risk.ValidateRule(rule); err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "invalid risk rule")
+		return models.RiskRule{}, err
+	}
+	for _, configured := range s.rules {
+		if configured.Name == rule.Name {
+			return models.RiskRule{}, ErrRiskRuleConflict
+		}
+	}
+
+	created, err := s.repo.CreateRiskRule(ctx, rule)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to create risk rule")
+		return models.RiskRule{}, err
+	}
+
+	l.Info(ctx, action.CreateRiskRule, "success", "rule_id", created.ID)
+	return created, nil
+}
+
+// SetRuleEnabled — включает или отключает правило БД. Правила конфигурации меняются только в файле.
+func (s *RiskService) SetRuleEnabled(ctx context.Context, name string, enabled bool) (models.RiskRule, error) {
+	rule, err := s.repo.SetRiskRuleEnabled(ctx, name, enabled)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to update risk rule", "rule", name)
+		return models.RiskRule{}, err
+	}
+
+	s.log.Info(ctx, action.UpdateRiskRule, "success", "rule", name, "enabled", enabled)
+	return rule, nil
+}
+
+// AddListEntry — добавляет значение (ID пользователя, адрес и т.п.) в именованный список.
+func (s *RiskService) AddListEntry(ctx context.Context, list, value string) error {
+	if err := s.repo.AddListEntry(ctx, list, value); err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to add risk list entry", "list", list)
+		return err
+	}
+
+	s.log.Info(ctx, action.UpdateRiskList, "entry added", "list", list)
+	return nil
+}
+
+// RemoveListEntry — удаляет значение из именованного списка.
+func (s *RiskService) RemoveListEntry(ctx context.Context, list, value string) error {
+	if err := s.repo.RemoveListEntry(ctx, list, value); err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to remove risk list entry", "list", list)
+		return err
+	}
+
+	s.log.Info(ctx, action.UpdateRiskList, "entry removed", "list", list)
+	return nil
+}
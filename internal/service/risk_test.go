@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeRiskRepo — правила БД, списки и скорость платежей в памяти.
+type fakeRiskRepo struct {
+	ports.RiskRepo
+	rules    []models.RiskRule
+	lists    map[string][]string
+	velocity models.RiskVelocity
+}
+
+func (r fakeRiskRepo) ListRiskRules(ctx context.Context, includeDisabled bool) ([]models.RiskRule, error) {
+	return r.rules, nil
+}
+
+func (r fakeRiskRepo) ListMatches(ctx context.Context, lists, values []string) (map[string]map[string]bool, error) {
+	matches := make(map[string]map[string]bool)
+	for _, list := range lists {
+		for _, value := range values {
+			if slices.Contains(r.lists[list], value) {
+				if matches[list] == nil {
+					matches[list] = make(map[string]bool)
+				}
+				matches[list][value] = true
+			}
+		}
+	}
+	return matches, nil
+}
+
+func (r fakeRiskRepo) Velocity(ctx context.Context, userID, clientIP, currency string, now time.Time) (models.RiskVelocity, error) {
+	return r.velocity, nil
+}
+
+func TestAssess(t *testing.T) {
+	config := []models.RiskRule{
+		{Name: "blocked", Decision: models.RiskDeny, Expression: `user_id in list("blocked_users")`},
+		{Name: "large", Decision: models.RiskReview, Expression: `amount >= 500000`},
+		{Name: "trusted", Decision: models.RiskAllow, Expression: `user_id in list("trusted_users")`},
+	}
+	repo := fakeRiskRepo{
+		rules: []models.RiskRule{
+			{Name: "fast", Decision: models.RiskReview, Expression: `user_count_1h >= 3`},
+			{Name: "broken", Decision: models.RiskDeny, Expression: `amount >`},
+		},
+		lists: map[string][]string{
+			"blocked_users": {"bad"},
+			"trusted_users": {"vip", "bad"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		input     models.RiskInput
+		velocity  int
+		want      models.RiskDecision
+		wantRules []string
+	}{
+		{"no rule matched", models.RiskInput{UserID: "u1", Amount: 100}, 0, models.RiskAllow, nil},
+		{"review", models.RiskInput{UserID: "u1", Amount: 600000}, 0, models.RiskReview, []string{"large"}},
+		{"all review rules reported", models.RiskInput{UserID: "u1", Amount: 600000}, 3, models.RiskReview, []string{"large", "fast"}},
+		{"allow overrides review", models.RiskInput{UserID: "vip", Amount: 600000}, 0, models.RiskAllow, []string{"trusted"}},
+		{"deny overrides allow", models.RiskInput{UserID: "bad", Amount: 100}, 0, models.RiskDeny, []string{"blocked"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.velocity = models.RiskVelocity{UserCount1h: tt.velocity}
+			s := NewRiskService(repo, config, testLogger())
+
+			got, err := s.Assess(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("Assess: %v", err)
+			}
+			if got.Decision != tt.want || !slices.Equal(got.Rules, tt.wantRules) {
+				t.Fatalf("Assess = %s %v, want %s %v", got.Decision, got.Rules, tt.want, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestAuthPaymentAssessesRisk(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision models.RiskDecision
+		want     models.StatusType
+		wantErr  error
+	}{
+		{"allowed", models.RiskAllow, models.OrderCreated, nil},
+		{"held for review", models.RiskReview, models.OrderReview, nil},
+		{"denied", models.RiskDeny, "", ErrPaymentDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker, store := newFakeBroker(), newFakePaymentRepo()
+			s := NewPaymentService(broker, store, fakeCurrencies{}, fakeRisk{decision: tt.decision}, fakeRedirects{}, testLogger())
+
+			payment, url, err := s.AuthPayment(context.Background(), "order-1", "u1", 1000, "KZT",
+				"https://shop.example.com/ok", "https://shop.example.com/fail", models.PaymentDetails{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AuthPayment error = %v, want %v", err, tt.wantErr)
+			}
+			if payment.Status != tt.want {
+				t.Fatalf("status = %q, want %q", payment.Status, tt.want)
+			}
+			if broker.authOrders != 0 && tt.want != models.OrderCreated {
+				t.Fatalf("auth orders at broker = %d, want none for %s", broker.authOrders, tt.decision)
+			}
+			if tt.want == models.OrderCreated && (broker.authOrders != 1 || url == "") {
+				t.Fatalf("auth orders = %d, url = %q; want one pre-auth order with form URL", broker.authOrders, url)
+			}
+			if tt.want != models.OrderReview {
+				return
+			}
+
+			// Одобренный платёж регистрируется у банка тоже двухстадийным
+			approved, url, err := s.ApprovePayment(context.Background(), payment.ID, "ok")
+			if err != nil {
+				t.Fatalf("ApprovePayment: %v", err)
+			}
+			if broker.authOrders != 1 || broker.orders != 0 || url == "" || approved.Status != models.OrderCreated {
+				t.Fatalf("after approve: auth orders = %d, orders = %d, url = %q, status = %s; want one pre-auth order",
+					broker.authOrders, broker.orders, url, approved.Status)
+			}
+		})
+	}
+}
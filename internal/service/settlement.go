@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"payment/internal/adapters/settlement"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+	"time"
+)
+
+var ErrInvalidPeriod = errors.New("period end must be after period start")
+
+// SettlementService — сверка выписок банка (settlement reports) с платежами в БД.
+type SettlementService struct {
+	repo ports.SettlementRepo
+	log  logger.Logger
+}
+
+func NewSettlementService(repo ports.SettlementRepo, log logger.Logger) *SettlementService {
+	return &SettlementService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// Reconcile — разбирает файл выписки банка за период [from, to) и сохраняет найденные расхождения.
+// Строки сопоставляются с платежами по payment_id, а если он не задан или не найден — по order_id. Суммы строк одного платежа
+// складываются (возвраты — отрицательные строки) и сравниваются со списанным за вычетом возвратов и комиссий.
+func (s *SettlementService) Reconcile(
+	ctx context.Context,
+	fileName string,
+	file io.Reader,
+	mapping models.ColumnMapping,
+	from, to time.Time,
+) (models.Reconciliation, error) {
+	l := s.log.With("file", fileName, "from", from, "to", to)
+	l.Debug(ctx, action.ReconcileSettlement, "begin")
+
+	if !to.After(from) {
+		return models.Reconciliation{}, ErrInvalidPeriod
+	}
+
+	lines, err := settlement.ParseCSV(file, mapping)
+	if err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "failed to parse settlement file")
+		return models.Reconciliation{}, err
+	}
+
+	paymentIDs := make([]string, 0, len(lines))
+	orderIDs := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line.PaymentID != "" {
+			paymentIDs = append(paymentIDs, line.PaymentID)
+		}
+		if line.OrderID != "" {
+			orderIDs = append(orderIDs, line.OrderID)
+		}
+	}
+
+	local, err := s.repo.LocalSettlements(ctx, paymentIDs, orderIDs)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get payments for settlement")
+		return models.Reconciliation{}, err
+	}
+	expected, err := s.repo.SettledPayments(ctx, from, to)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get settled payments")
+		return models.Reconciliation{}, err
+	}
+
+	rec := reconcile(lines, local, expected)
+	rec.FileName = fileName
+	rec.PeriodStart = from
+	rec.PeriodEnd = to
+
+	if rec, err = s.repo.SaveReconciliation(ctx, rec); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to save reconciliation")
+		return models.Reconciliation{}, err
+	}
+
+	l.Info(ctx, action.ReconcileSettlement, "success",
+		"reconciliation_id", rec.ID, "lines", rec.Lines, "matched", rec.Matched, "discrepancies", len(rec.Discrepancies))
+	return rec, nil
+}
+
+// GetReconciliation — сохранённый результат сверки.
+func (s *SettlementService) GetReconciliation(ctx context.Context, reconciliationID string) (models.Reconciliation, error) {
+	rec, err := s.repo.GetReconciliation(ctx, reconciliationID)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to get reconciliation", "reconciliation_id", reconciliationID)
+		return models.Reconciliation{}, err
+	}
+	return *rec, nil
+}
+
+// reconcile сопоставляет строки выписки с платежами.
+// local — платежи, на которые ссылается выписка; expected — платежи периода, которые должны быть в выписке.
+func reconcile(lines []models.SettlementLine, local, expected []models.LocalSettlement) models.Reconciliation {
+	rec := models.Reconciliation{Lines: len(lines)}
+
+	byPaymentID := make(map[string]models.LocalSettlement, len(local))
+	byOrderID := make(map[string]models.LocalSettlement, len(local))
+	for _, p := range local {
+		byPaymentID[p.PaymentID] = p
+		byOrderID[p.OrderID] = p
+	}
+
+	// Строки группируются по платежу в порядке первого появления в файле
+	var order []string
+	groups := make(map[string][]models.SettlementLine)
+	for _, line := range lines {
+		p, ok := byPaymentID[line.PaymentID]
+		if !ok && line.OrderID != "" {
+			p, ok = byOrderID[line.OrderID]
+		}
+		if !ok {
+			rec.Discrepancies = append(rec.Discrepancies, models.Discrepancy{
+				Kind:       models.MissingLocally,
+				PaymentID:  line.PaymentID,
+				OrderID:    line.OrderID,
+				BankAmount: line.Amount,
+				BankStatus: line.Status,
+			})
+			continue
+		}
+		if _, seen := groups[p.PaymentID]; !seen {
+			order = append(order, p.PaymentID)
+		}
+		groups[p.PaymentID] = append(groups[p.PaymentID], line)
+	}
+
+	for _, paymentID := range order {
+		p := byPaymentID[paymentID]
+		matched := true
+
+		var bankAmount float64
+		var bankStatus models.StatusType
+		currencyOK := true
+		for _, line := range groups[paymentID] {
+			bankAmount += line.Amount
+			if line.Status != "" {
+				bankStatus = line.Status
+			}
+			if line.Currency != "" && line.Currency != p.Currency {
+				currencyOK = false
+			}
+		}
+		bankAmount = models.RoundAmount(bankAmount)
+
+		// Суммы в разных валютах не сравниваются
+		kind := models.AmountMismatch
+		if !currencyOK {
+			kind = models.CurrencyMismatch
+		}
+		if !currencyOK || bankAmount != p.Settled {
+			matched = false
+			rec.Discrepancies = append(rec.Discrepancies, models.Discrepancy{
+				Kind:        kind,
+				PaymentID:   p.PaymentID,
+				OrderID:     p.OrderID,
+				BankAmount:  bankAmount,
+				LocalAmount: p.Settled,
+				BankStatus:  bankStatus,
+				LocalStatus: p.Status,
+			})
+		}
+		if bankStatus != "" && bankStatus != p.Status {
+			matched = false
+			rec.Discrepancies = append(rec.Discrepancies, models.Discrepancy{
+				Kind:        models.StatusMismatch,
+				PaymentID:   p.PaymentID,
+				OrderID:     p.OrderID,
+				BankAmount:  bankAmount,
+				LocalAmount: p.Settled,
+				BankStatus:  bankStatus,
+				LocalStatus: p.Status,
+			})
+		}
+		if matched {
+			rec.Matched++
+		}
+	}
+
+	for _, p := range expected {
+		if _, ok := groups[p.PaymentID]; ok || p.Settled == 0 {
+			continue
+		}
+		rec.Discrepancies = append(rec.Discrepancies, models.Discrepancy{
+			Kind:        models.MissingAtBank,
+			PaymentID:   p.PaymentID,
+			OrderID:     p.OrderID,
+			LocalAmount: p.Settled,
+			LocalStatus: p.Status,
+		})
+	}
+
+	return rec
+}
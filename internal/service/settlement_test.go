@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReconcile(t *testing.T) {
+	local := []models.LocalSettlement{
+		{PaymentID: "bank-1", OrderID: "order-1", Currency: "KZT", Status: models.OrderDeposited, Settled: 980},
+		{PaymentID: "bank-2", OrderID: "order-2", Currency: "KZT", Status: models.OrderDeposited, Settled: 500},
+		{PaymentID: "bank-3", OrderID: "order-3", Currency: "KZT", Status: models.OrderDeposited, Settled: 100},
+		{PaymentID: "bank-4", OrderID: "order-4", Currency: "KZT", Status: models.OrderPartiallyRefunded, Settled: 700},
+		{PaymentID: "bank-5", OrderID: "order-5", Currency: "KZT", Status: models.OrderDeposited, Settled: 300},
+		{PaymentID: "bank-6", OrderID: "order-6", Currency: "KZT", Status: models.OrderDeposited, Settled: 250},
+	}
+	expected := append(slices.Clone(local),
+		models.LocalSettlement{PaymentID: "bank-7", OrderID: "order-7", Currency: "KZT", Status: models.OrderDeposited, Settled: 400},
+		models.LocalSettlement{PaymentID: "bank-8", OrderID: "order-8", Currency: "KZT", Status: models.OrderRefunded},
+	)
+	lines := []models.SettlementLine{
+		// Списание и возврат одного платежа складываются
+		{Line: 2, PaymentID: "bank-1", Amount: 1000, Currency: "KZT", Status: models.OrderDeposited},
+		{Line: 3, PaymentID: "bank-1", Amount: -20, Currency: "KZT"},
+		// Сопоставление по order_id без payment_id
+		{Line: 4, OrderID: "order-2", Amount: 500},
+		{Line: 5, PaymentID: "bank-3", Amount: 90, Currency: "KZT"},
+		{Line: 6, PaymentID: "bank-4", Amount: 700, Currency: "KZT", Status: models.OrderRefunded},
+		{Line: 7, PaymentID: "bank-5", Amount: 300, Currency: "USD"},
+		{Line: 8, PaymentID: "bank-404", OrderID: "order-404", Amount: 50, Currency: "KZT"},
+		// payment_id в выписке неизвестен, платёж находится по order_id
+		{Line: 9, PaymentID: "legacy-6", OrderID: "order-6", Amount: 250, Currency: "KZT"},
+	}
+
+	rec := reconcile(lines, local, expected)
+
+	want := []models.Discrepancy{
+		{Kind: models.MissingLocally, PaymentID: "bank-404", OrderID: "order-404", BankAmount: 50},
+		{Kind: models.AmountMismatch, PaymentID: "bank-3", OrderID: "order-3", BankAmount: 90, LocalAmount: 100,
+			LocalStatus: models.OrderDeposited},
+		{Kind: models.StatusMismatch, PaymentID: "bank-4", OrderID: "order-4", BankAmount: 700, LocalAmount: 700,
+			BankStatus: models.OrderRefunded, LocalStatus: models.OrderPartiallyRefunded},
+		{Kind: models.CurrencyMismatch, PaymentID: "bank-5", OrderID: "order-5", BankAmount: 300, LocalAmount: 300,
+			LocalStatus: models.OrderDeposited},
+		{Kind: models.MissingAtBank, PaymentID: "bank-7", OrderID: "order-7", LocalAmount: 400,
+			LocalStatus: models.OrderDeposited},
+	}
+	if !reflect.DeepEqual(rec.Discrepancies, want) {
+		t.Fatalf("discrepancies:\n got %+v\nwant %+v", rec.Discrepancies, want)
+	}
+	// bank-1, bank-2 и bank-6 сошлись; возвращённый целиком bank-8 в выписке не ожидается
+	if rec.Lines != len(lines) || rec.Matched != 3 {
+		t.Fatalf("lines = %d, matched = %d; want %d and 3", rec.Lines, rec.Matched, len(lines))
+	}
+}
+
+// fakeSettlementRepo возвращает платежи, найденные по ссылкам из выписки, и сохраняет результат сверки.
+type fakeSettlementRepo struct {
+	ports.SettlementRepo
+
+	payments             []models.LocalSettlement
+	paymentIDs, orderIDs []string
+	saved                *models.Reconciliation
+}
+
+func (r *fakeSettlementRepo) LocalSettlements(ctx context.Context, paymentIDs, orderIDs []string) ([]models.LocalSettlement, error) {
+	r.paymentIDs, r.orderIDs = paymentIDs, orderIDs
+
+	var found []models.LocalSettlement
+	for _, p := range r.payments {
+		if slices.Contains(paymentIDs, p.PaymentID) || slices.Contains(orderIDs, p.OrderID) {
+			found = append(found, p)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeSettlementRepo) SettledPayments(ctx context.Context, from, to time.Time) ([]models.LocalSettlement, error) {
+	return r.payments, nil
+}
+
+func (r *fakeSettlementRepo) SaveReconciliation(ctx context.Context, rec models.Reconciliation) (models.Reconciliation, error) {
+	rec.ID = "rec-1"
+	r.saved = &rec
+	return rec, nil
+}
+
+func TestReconcileFileFallsBackToOrderID(t *testing.T) {
+	store := &fakeSettlementRepo{payments: []models.LocalSettlement{
+		{PaymentID: "bank-1", OrderID: "order-1", Currency: "KZT", Status: models.OrderDeposited, Settled: 1000},
+	}}
+	s := NewSettlementService(store, testLogger())
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	file := "payment_id,order_id,amount\nold-bank-id,order-1,1000\n"
+	rec, err := s.Reconcile(context.Background(), "march.csv", strings.NewReader(file), models.DefaultColumnMapping(), from, from.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	if !reflect.DeepEqual(store.orderIDs, []string{"order-1"}) {
+		t.Fatalf("order IDs looked up = %v, want order-1 of the line with unknown payment_id", store.orderIDs)
+	}
+	if rec.Matched != 1 || len(rec.Discrepancies) != 0 || store.saved == nil || rec.FileName != "march.csv" {
+		t.Fatalf("reconciliation = %+v, want one matched payment saved", rec)
+	}
+
+	if _, err := s.Reconcile(context.Background(), "march.csv", strings.NewReader(file), models.DefaultColumnMapping(), from, from); !errors.Is(err, ErrInvalidPeriod) {
+		t.Fatalf("Reconcile with empty period error = %v, want ErrInvalidPeriod", err)
+	}
+}
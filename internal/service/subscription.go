@@ -0,0 +1,501 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/adapters/repo"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"payment/internal/domain/ports"
+	"payment/pkg/logger"
+	"time"
+)
+
+const (
+	billingLease     = 10 * time.Minute // Время, на которое подписка захватывается обработчиком списаний
+	billingBatchSize = 100              // Количество подписок, обрабатываемых за один проход
+
+	billingRecheckDelay = time.Hour // Повторная проверка списания без окончательного результата
+)
+
+var (
+	ErrPlanInactive         = errors.New("plan is not active")
+	ErrSubscriptionCanceled = errors.New("subscription is canceled")
+)
+
+type SubscriptionService struct {
+	payments   ports.PaymentService
+	currencies ports.CurrencyService
+	repo       ports.SubscriptionRepo
+	log        logger.Logger
+}
+
+func NewSubscriptionService(payments ports.PaymentService, currencies ports.CurrencyService, repo ports.SubscriptionRepo, log logger.Logger) *SubscriptionService {
+	return &SubscriptionService{
+		payments:   payments,
+		currencies: currencies,
+		repo:       repo,
+		log:        log,
+	}
+}
+
+// CreatePlan — создаёт тарифный план.
+func (s *SubscriptionService) CreatePlan(ctx context.Context, plan models.Plan) (models.Plan, error) {
+	l := s.log.With("name", plan.Name, "amount", plan.Amount, "currency", plan.Currency)
+	l.Debug(ctx, action.CreatePlan, "begin")
+
+	if err := s.currencies.Validate(ctx, plan.Currency); err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "currency is not supported")
+		return models.Plan{}, err
+	}
+
+	plan.Active = true
+	plan, err := s.repo.CreatePlan(ctx, plan)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to create plan")
+		return models.Plan{}, err
+	}
+
+	l.Info(ctx, action.CreatePlan, "success", "plan_id", plan.ID)
+	return plan, nil
+}
+
+// ListPlans — список тарифных планов.
+func (s *SubscriptionService) ListPlans(ctx context.Context, includeInactive bool) ([]models.Plan, error) {
+	plans, err := s.repo.ListPlans(ctx, includeInactive)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to get plans")
+		return nil, err
+	}
+	return plans, nil
+}
+
+// Subscribe — оформляет подписку пользователя на план с оплатой сохранённой картой.
+// Без пробного периода первый период оплачивается сразу.
+func (s *SubscriptionService) Subscribe(ctx context.Context, userID, planID, bindingID string) (models.Subscription, error) {
+	l := s.log.With("user_id", userID, "plan_id", planID, "binding_id", bindingID)
+	l.Debug(ctx, action.Subscribe, "begin")
+
+	plan, err := s.repo.GetPlan(ctx, planID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get plan")
+		return models.Subscription{}, err
+	}
+	if !plan.Active {
+		l.Error(ctx, action.ValidationFailed, ErrPlanInactive, "plan is not active")
+		return models.Subscription{}, ErrPlanInactive
+	}
+
+	if err := s.checkBinding(ctx, userID, bindingID); err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "binding is not available")
+		return models.Subscription{}, err
+	}
+
+	now := time.Now()
+	sub := models.Subscription{
+		UserID:             userID,
+		PlanID:             plan.ID,
+		BindingID:          bindingID,
+		Status:             models.SubscriptionActive,
+		CurrentPeriodStart: now,
+		CurrentPeriodEnd:   now,
+		NextChargeAt:       now,
+	}
+	if plan.TrialDays > 0 {
+		sub.Status = models.SubscriptionTrialing
+		sub.CurrentPeriodEnd = now.AddDate(0, 0, plan.TrialDays)
+		sub.NextChargeAt = sub.CurrentPeriodEnd
+	}
+
+	sub, err = s.repo.CreateSubscription(ctx, sub, models.SubscriptionEvent{Type: models.EventSubscriptionCreated})
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to create subscription")
+		return models.Subscription{}, err
+	}
+	l = l.With("subscription_id", sub.ID)
+
+	// Первый период оплачивается сразу; при неудаче подписка переходит в PAST_DUE
+	// и списание повторяется по графику DunningSchedule
+	if sub.Status == models.SubscriptionActive {
+		s.bill(ctx, sub, *plan, now)
+		return s.GetSubscription(ctx, sub.ID)
+	}
+
+	l.Info(ctx, action.Subscribe, "success", "status", sub.Status)
+	return sub, nil
+}
+
+// GetSubscription — получение подписки по ID.
+func (s *SubscriptionService) GetSubscription(ctx context.Context, subscriptionID string) (models.Subscription, error) {
+	sub, err := s.repo.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to get subscription", "subscription_id", subscriptionID)
+		return models.Subscription{}, err
+	}
+	return *sub, nil
+}
+
+// CancelSubscription — отменяет подписку сразу или по окончании оплаченного периода.
+func (s *SubscriptionService) CancelSubscription(ctx context.Context, subscriptionID string, atPeriodEnd bool) (models.Subscription, error) {
+	l := s.log.With("subscription_id", subscriptionID, "at_period_end", atPeriodEnd)
+	l.Debug(ctx, action.CancelSubscription, "begin")
+
+	sub, err := s.activeSubscription(ctx, subscriptionID)
+	if err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "subscription cannot be canceled")
+		return models.Subscription{}, err
+	}
+
+	event := models.SubscriptionEvent{SubscriptionID: sub.ID, Type: models.EventCancelScheduled}
+	if atPeriodEnd {
+		sub.CancelAtPeriodEnd = true
+	} else {
+		cancelSubscription(&sub, time.Now())
+		event.Type = models.EventSubscriptionCancelled
+	}
+
+	if err := s.repo.UpdateSubscription(ctx, sub, nil, event); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to cancel subscription")
+		return models.Subscription{}, err
+	}
+
+	l.Info(ctx, action.CancelSubscription, "success", "event", event.Type)
+	return sub, nil
+}
+
+// ChangePlan — переводит подписку на другой план той же валюты.
+// Разница за неиспользованную часть периода списывается сразу при повышении
+// или зачисляется в кредит следующего списания при понижении.
+func (s *SubscriptionService) ChangePlan(ctx context.Context, subscriptionID, planID string) (models.Subscription, error) {
+	l := s.log.With("subscription_id", subscriptionID, "plan_id", planID)
+	l.Debug(ctx, action.ChangePlan, "begin")
+
+	sub, err := s.activeSubscription(ctx, subscriptionID)
+	if err != nil {
+		l.Error(ctx, action.ValidationFailed, err, "subscription plan cannot be changed")
+		return models.Subscription{}, err
+	}
+
+	oldPlan, err := s.repo.GetPlan(ctx, sub.PlanID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get current plan")
+		return models.Subscription{}, err
+	}
+	newPlan, err := s.repo.GetPlan(ctx, planID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get new plan")
+		return models.Subscription{}, err
+	}
+	if !newPlan.Active {
+		l.Error(ctx, action.ValidationFailed, ErrPlanInactive, "plan is not active")
+		return models.Subscription{}, ErrPlanInactive
+	}
+	if newPlan.Currency != oldPlan.Currency {
+		l.Error(ctx, action.ValidationFailed, ErrCurrencyMismatch, "plan currency differs")
+		return models.Subscription{}, ErrCurrencyMismatch
+	}
+
+	now := time.Now()
+	event := models.SubscriptionEvent{SubscriptionID: sub.ID, Type: models.EventPlanChanged}
+
+	// В пробном и неоплаченном периоде пересчёт не нужен: следующее списание будет по новому плану
+	if sub.Status == models.SubscriptionActive {
+		diff := models.Prorate(oldPlan.Amount, newPlan.Amount, sub.CurrentPeriodStart, sub.CurrentPeriodEnd, now)
+		switch {
+		case diff > 0:
+			orderID := fmt.Sprintf("sub-%s-%d-prorate-%d", sub.ID, sub.Cycle, now.Unix())
+			payment, err := s.payments.ChargeBinding(ctx, orderID, sub.UserID, sub.BindingID, diff, newPlan.Currency)
+			if outcome, reason := billingOutcome(payment, err); outcome != chargePaid {
+				if reason == nil {
+					reason = ErrPaymentNotPaid
+				}
+				l.Error(ctx, action.PaymentTransactionFail, reason, "failed to charge proration", "payment_id", payment.ID)
+				return models.Subscription{}, reason
+			}
+			event.PaymentID = payment.ID
+			event.Amount = diff
+		case diff < 0:
+			sub.Credit = models.RoundAmount(sub.Credit - diff)
+			event.Amount = diff
+		}
+	}
+	sub.PlanID = newPlan.ID
+
+	if err := s.repo.UpdateSubscription(ctx, sub, nil, event); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to change plan")
+		return models.Subscription{}, err
+	}
+
+	l.Info(ctx, action.ChangePlan, "success", "proration", event.Amount)
+	return sub, nil
+}
+
+// SubscriptionEvents — события подписки (от старых к новым).
+func (s *SubscriptionService) SubscriptionEvents(ctx context.Context, subscriptionID string) ([]models.SubscriptionEvent, error) {
+	events, err := s.repo.ListSubscriptionEvents(ctx, subscriptionID)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to get subscription events", "subscription_id", subscriptionID)
+		return nil, err
+	}
+	return events, nil
+}
+
+// BillDueSubscriptions — списывает оплату по всем подпискам, срок списания которых наступил к now.
+// Ошибка списания отдельной подписки не прерывает процесс и возвращается в BillingResult.Err.
+func (s *SubscriptionService) BillDueSubscriptions(ctx context.Context, now time.Time) ([]models.BillingResult, error) {
+	var results []models.BillingResult
+	var renewed, failed int
+
+	for {
+		subs, err := s.repo.ClaimDueSubscriptions(ctx, now, now.Add(billingLease), billingBatchSize)
+		if err != nil {
+			s.log.Error(ctx, action.DbTransactionFailed, err, "failed to claim due subscriptions")
+			return results, err
+		}
+
+		for _, sub := range subs {
+			res := s.billSubscription(ctx, sub, now)
+			switch {
+			case res.Err != nil:
+				failed++
+			case res.Status == models.SubscriptionActive:
+				renewed++
+			}
+			results = append(results, res)
+		}
+
+		if len(subs) < billingBatchSize {
+			break
+		}
+	}
+
+	if len(results) > 0 {
+		s.log.Info(ctx, action.BillSubscriptions, "success",
+			"total", len(results), "renewed", renewed, "failed", failed)
+	}
+	return results, nil
+}
+
+// RunBilling — периодически списывает оплату по подпискам до отмены ctx.
+func (s *SubscriptionService) RunBilling(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.BillDueSubscriptions(ctx, time.Now()); err != nil {
+			s.log.Error(ctx, action.BillSubscriptions, err, "billing run failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// billSubscription обрабатывает подписку, по которой наступил срок списания.
+func (s *SubscriptionService) billSubscription(ctx context.Context, sub models.Subscription, now time.Time) models.BillingResult {
+	if sub.CancelAtPeriodEnd {
+		cancelSubscription(&sub, now)
+		event := models.SubscriptionEvent{SubscriptionID: sub.ID, Type: models.EventSubscriptionCancelled, Reason: "canceled at period end"}
+		if err := s.repo.UpdateSubscription(ctx, sub, nil, event); err != nil {
+			s.log.Error(ctx, action.DbTransactionFailed, err, "failed to cancel subscription", "subscription_id", sub.ID)
+			return models.BillingResult{SubscriptionID: sub.ID, Status: sub.Status, Err: err}
+		}
+		return models.BillingResult{SubscriptionID: sub.ID, Status: sub.Status}
+	}
+
+	plan, err := s.repo.GetPlan(ctx, sub.PlanID)
+	if err != nil {
+		s.log.Error(ctx, action.DbTransactionFailed, err, "failed to get plan", "subscription_id", sub.ID)
+		return models.BillingResult{SubscriptionID: sub.ID, Status: sub.Status, Err: err}
+	}
+
+	return s.bill(ctx, sub, *plan, now)
+}
+
+// bill списывает оплату следующего периода сохранённой картой.
+// Продлевает подписку только по оплаченному платежу. Отказ назначает повторную попытку
+// по DunningSchedule или отменяет подписку. Платёж без окончательного результата
+// (ручная проверка, обработка у банка, банк недоступен) проверяется повторно тем же заказом.
+func (s *SubscriptionService) bill(ctx context.Context, sub models.Subscription, plan models.Plan, now time.Time) models.BillingResult {
+	l := s.log.With("subscription_id", sub.ID, "cycle", sub.Cycle+1, "attempt", sub.FailedAttempts+1)
+	l.Debug(ctx, action.BillSubscriptions, "begin")
+
+	// Кредит после понижения плана уменьшает сумму списания
+	amount := models.RoundAmount(plan.Amount - sub.Credit)
+	credit := 0.0
+	if amount <= 0 {
+		credit, amount = -amount, 0
+	}
+
+	charge := &models.SubscriptionCharge{
+		SubscriptionID: sub.ID,
+		OrderID:        chargeOrderID(sub),
+		Cycle:          sub.Cycle + 1,
+		Attempt:        sub.FailedAttempts + 1,
+		Amount:         amount,
+	}
+
+	var (
+		outcome   = chargePaid
+		chargeErr error
+	)
+	if amount > 0 {
+		var payment models.Payment
+		payment, chargeErr = s.chargePeriod(ctx, sub, charge.OrderID, amount, plan.Currency)
+		charge.PaymentID = payment.ID
+		outcome, chargeErr = billingOutcome(payment, chargeErr)
+	}
+
+	event := models.SubscriptionEvent{SubscriptionID: sub.ID, PaymentID: charge.PaymentID, Amount: amount}
+	switch outcome {
+	case chargePaid:
+		charge.Succeeded = true
+		start := sub.CurrentPeriodEnd
+		sub.Status = models.SubscriptionActive
+		sub.Cycle++
+		sub.FailedAttempts = 0
+		sub.Credit = credit
+		sub.CurrentPeriodStart = start
+		sub.CurrentPeriodEnd = plan.PeriodEnd(start)
+		sub.NextChargeAt = sub.CurrentPeriodEnd
+		event.Type = models.EventSubscriptionRenewed
+
+	case chargePending:
+		// Попытка не считается: следующая проверка использует тот же заказ
+		l.Warn(ctx, action.BillSubscriptions, "subscription charge is pending", "payment_id", charge.PaymentID, "reason", chargeErr)
+		charge = nil
+		sub.NextChargeAt = now.Add(billingRecheckDelay)
+		event.Type = models.EventSubscriptionPaymentPending
+		if chargeErr != nil {
+			event.Reason = chargeErr.Error()
+		}
+		chargeErr = nil
+
+	case chargeFailed:
+		l.Error(ctx, action.PaymentTransactionFail, chargeErr, "failed to charge subscription")
+		charge.Error = chargeErr.Error()
+		sub.FailedAttempts++
+		event.Reason = chargeErr.Error()
+
+		if sub.FailedAttempts > len(models.DunningSchedule) {
+			cancelSubscription(&sub, now)
+			event.Type = models.EventSubscriptionCancelled
+		} else {
+			sub.Status = models.SubscriptionPastDue
+			sub.NextChargeAt = now.Add(models.DunningSchedule[sub.FailedAttempts-1])
+			event.Type = models.EventSubscriptionFailed
+		}
+	}
+
+	if err := s.repo.UpdateSubscription(ctx, sub, charge, event); err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to save billing result")
+		return models.BillingResult{SubscriptionID: sub.ID, PaymentID: event.PaymentID, Status: sub.Status, Err: err}
+	}
+
+	l.Info(ctx, action.BillSubscriptions, "subscription billed", "event", event.Type, "status", sub.Status)
+	return models.BillingResult{SubscriptionID: sub.ID, PaymentID: event.PaymentID, Status: sub.Status, Err: chargeErr}
+}
+
+// chargePeriod списывает оплату периода сохранённой картой.
+// Заказ с тем же ID уже есть, если прошлая попытка списала деньги, но её результат не сохранился
+// (сбой до UpdateSubscription). Тогда повторного списания нет: статус существующего платежа
+// сверяется с банком и используется как результат попытки.
+func (s *SubscriptionService) chargePeriod(ctx context.Context, sub models.Subscription, orderID string, amount float64, currency string) (models.Payment, error) {
+	payment, err := s.payments.ChargeBinding(ctx, orderID, sub.UserID, sub.BindingID, amount, currency)
+	if !errors.Is(err, repo.ErrOrderIDConflict) {
+		return payment, err
+	}
+
+	payment, err = s.payments.GetPaymentByOrderID(ctx, orderID)
+	if err != nil {
+		return models.Payment{}, err
+	}
+	if payment.Status == models.OrderReview {
+		return payment, nil
+	}
+
+	res, err := s.payments.SyncPayment(ctx, payment.ID)
+	if err != nil {
+		return payment, err
+	}
+	payment.Status = res.Current
+	return payment, nil
+}
+
+// chargeOrderID — ID заказа попытки списания: подписка, номер периода и, для повторов после отказа,
+// номер повтора. Эти значения меняются только вместе с сохранением результата попытки, поэтому
+// повтор после сбоя попадает в тот же заказ. Повтор после отказа требует нового заказа:
+// банк не принимает повторную оплату отклонённого заказа.
+func chargeOrderID(sub models.Subscription) string {
+	orderID := fmt.Sprintf("sub-%s-%d", sub.ID, sub.Cycle+1)
+	if sub.FailedAttempts > 0 {
+		orderID += fmt.Sprintf("-retry-%d", sub.FailedAttempts)
+	}
+	return orderID
+}
+
+// chargeOutcome — результат попытки списания по подписке.
+type chargeOutcome int
+
+const (
+	chargePaid    chargeOutcome = iota // Период оплачен
+	chargePending                      // Результата ещё нет, попытка повторяется тем же заказом
+	chargeFailed                       // Списание не прошло, попытка засчитывается
+)
+
+// billingOutcome определяет результат попытки по платежу и ошибке списания.
+// Для неуспешной попытки возвращает ошибку с причиной.
+func billingOutcome(payment models.Payment, err error) (chargeOutcome, error) {
+	switch {
+	case errors.Is(err, models.ErrBrokerUnavailable):
+		// Ответ банка не получен: списание могло пройти
+		return chargePending, err
+	case err != nil:
+		return chargeFailed, err
+	}
+
+	switch payment.Status {
+	case models.OrderApproved, models.OrderDeposited:
+		return chargePaid, nil
+	case models.OrderReview, models.OrderCreated, models.OrderPending3DS:
+		return chargePending, nil
+	default:
+		return chargeFailed, fmt.Errorf("%w: payment status %s", models.ErrPaymentDeclined, payment.Status)
+	}
+}
+
+// activeSubscription возвращает подписку, если она не отменена.
+func (s *SubscriptionService) activeSubscription(ctx context.Context, subscriptionID string) (models.Subscription, error) {
+	sub, err := s.repo.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return models.Subscription{}, err
+	}
+	if sub.Status == models.SubscriptionCanceled {
+		return models.Subscription{}, ErrSubscriptionCanceled
+	}
+	return *sub, nil
+}
+
+// checkBinding проверяет, что сохранённая карта принадлежит пользователю.
+func (s *SubscriptionService) checkBinding(ctx context.Context, userID, bindingID string) error {
+	bindings, err := s.payments.ListBindings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, b := range bindings {
+		if b.ID == bindingID {
+			return nil
+		}
+	}
+	return repo.ErrBindingNotFound
+}
+
+func cancelSubscription(sub *models.Subscription, now time.Time) {
+	sub.Status = models.SubscriptionCanceled
+	sub.CancelAtPeriodEnd = false
+	sub.CanceledAt = &now
+}
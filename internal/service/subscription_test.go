@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"payment/internal/domain/models"
+	"testing"
+	"time"
+)
+
+type billingFixture struct {
+	broker   *fakeBroker
+	payments *fakePaymentRepo
+	subs     *fakeSubscriptionRepo
+	service  *SubscriptionService
+}
+
+func newBillingFixture(risk models.RiskDecision, plans ...models.Plan) billingFixture {
+	log := testLogger()
+	f := billingFixture{
+		broker: newFakeBroker(),
+		payments: newFakePaymentRepo(
+			models.Binding{ID: "card", UserID: "u1"},
+			models.Binding{ID: "expired-card", UserID: "u1"},
+		),
+		subs: newFakeSubscriptionRepo(plans...),
+	}
+	f.broker.decline["expired-card"] = true
+
+	payments := NewPaymentService(f.broker, f.payments, fakeCurrencies{}, fakeRisk{decision: risk}, nil, log)
+	f.service = NewSubscriptionService(payments, fakeCurrencies{}, f.subs, log)
+	return f
+}
+
+var (
+	monthlyPlan = models.Plan{ID: "monthly", Amount: 990, Currency: "KZT", IntervalMonths: 1, Active: true}
+	trialPlan   = models.Plan{ID: "trial", Amount: 990, Currency: "KZT", IntervalMonths: 1, TrialDays: 7, Active: true}
+)
+
+func TestSubscribeChargesFirstPeriod(t *testing.T) {
+	f := newBillingFixture("", monthlyPlan)
+	ctx := context.Background()
+
+	sub, err := f.service.Subscribe(ctx, "u1", "monthly", "card")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	if sub.Status != models.SubscriptionActive || sub.Cycle != 1 || sub.FailedAttempts != 0 {
+		t.Fatalf("subscription = %+v, want ACTIVE with first period paid", sub)
+	}
+	if want := sub.CurrentPeriodStart.AddDate(0, 1, 0); !sub.CurrentPeriodEnd.Equal(want) || !sub.NextChargeAt.Equal(want) {
+		t.Fatalf("period end = %v, next charge = %v; want %v", sub.CurrentPeriodEnd, sub.NextChargeAt, want)
+	}
+	if len(f.subs.charges) != 1 || !f.subs.charges[0].Succeeded || f.subs.charges[0].OrderID != "sub-"+sub.ID+"-1" {
+		t.Fatalf("charges = %+v, want one successful charge for period 1", f.subs.charges)
+	}
+	if p, _ := f.payments.GetTransactionByOrderID(ctx, "sub-"+sub.ID+"-1"); p == nil || p.Status != models.OrderDeposited {
+		t.Fatalf("payment = %+v, want DEPOSITED", p)
+	}
+}
+
+func TestSubscribeUnknownBinding(t *testing.T) {
+	f := newBillingFixture("", monthlyPlan)
+
+	if _, err := f.service.Subscribe(context.Background(), "u2", "monthly", "card"); err == nil {
+		t.Fatal("Subscribe with another user's binding succeeded")
+	}
+	if f.broker.charges != 0 {
+		t.Fatalf("broker charges = %d, want 0", f.broker.charges)
+	}
+}
+
+func TestBillingDeclineEntersDunning(t *testing.T) {
+	f := newBillingFixture("", trialPlan)
+	ctx := context.Background()
+
+	sub, err := f.service.Subscribe(ctx, "u1", "trial", "expired-card")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if sub.Status != models.SubscriptionTrialing || f.broker.charges != 0 {
+		t.Fatalf("subscription = %s after %d charges, want TRIALING without charge", sub.Status, f.broker.charges)
+	}
+
+	// Конец пробного периода: банк отклоняет списание
+	now := sub.NextChargeAt
+	results, err := f.service.BillDueSubscriptions(ctx, now)
+	if err != nil {
+		t.Fatalf("BillDueSubscriptions: %v", err)
+	}
+	if len(results) != 1 || !errors.Is(results[0].Err, models.ErrPaymentDeclined) {
+		t.Fatalf("results = %+v, want one declined charge", results)
+	}
+
+	sub, _ = f.service.GetSubscription(ctx, sub.ID)
+	if sub.Status != models.SubscriptionPastDue || sub.Cycle != 0 || sub.FailedAttempts != 1 {
+		t.Fatalf("subscription = %+v, want PAST_DUE after first failed attempt", sub)
+	}
+	if want := now.Add(models.DunningSchedule[0]); !sub.NextChargeAt.Equal(want) {
+		t.Fatalf("next charge = %v, want %v", sub.NextChargeAt, want)
+	}
+	declined, _ := f.payments.GetTransactionByOrderID(ctx, "sub-"+sub.ID+"-1")
+	if declined == nil || declined.Status != models.OrderDeclined {
+		t.Fatalf("declined payment = %+v, want persisted as DECLINED", declined)
+	}
+
+	// Повтор по графику новым заказом после замены карты
+	sub.BindingID = "card"
+	f.subs.subs[sub.ID] = sub
+	if _, err := f.service.BillDueSubscriptions(ctx, sub.NextChargeAt); err != nil {
+		t.Fatalf("BillDueSubscriptions: %v", err)
+	}
+
+	sub, _ = f.service.GetSubscription(ctx, sub.ID)
+	if sub.Status != models.SubscriptionActive || sub.Cycle != 1 || sub.FailedAttempts != 0 {
+		t.Fatalf("subscription = %+v, want ACTIVE after successful retry", sub)
+	}
+	if last := f.subs.charges[len(f.subs.charges)-1]; last.OrderID != "sub-"+sub.ID+"-1-retry-1" || !last.Succeeded {
+		t.Fatalf("retry charge = %+v, want successful sub-%s-1-retry-1", last, sub.ID)
+	}
+}
+
+func TestBillingDunningExhaustedCancels(t *testing.T) {
+	f := newBillingFixture("", trialPlan)
+	ctx := context.Background()
+
+	sub, err := f.service.Subscribe(ctx, "u1", "trial", "expired-card")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	for range len(models.DunningSchedule) + 1 {
+		sub, _ = f.service.GetSubscription(ctx, sub.ID)
+		if _, err := f.service.BillDueSubscriptions(ctx, sub.NextChargeAt); err != nil {
+			t.Fatalf("BillDueSubscriptions: %v", err)
+		}
+	}
+
+	sub, _ = f.service.GetSubscription(ctx, sub.ID)
+	if sub.Status != models.SubscriptionCanceled || sub.CanceledAt == nil {
+		t.Fatalf("subscription = %+v, want CANCELED after dunning", sub)
+	}
+	if f.broker.charges != len(models.DunningSchedule)+1 {
+		t.Fatalf("broker charges = %d, want %d", f.broker.charges, len(models.DunningSchedule)+1)
+	}
+}
+
+func TestBillingRetryAfterCrashDoesNotChargeTwice(t *testing.T) {
+	f := newBillingFixture("", trialPlan)
+	ctx := context.Background()
+
+	sub, err := f.service.Subscribe(ctx, "u1", "trial", "card")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// Банк списал деньги, но результат списания не сохранился
+	f.subs.failUpdates = 1
+	now := sub.NextChargeAt
+	if results, _ := f.service.BillDueSubscriptions(ctx, now); len(results) != 1 || !errors.Is(results[0].Err, errFakeUpdate) {
+		t.Fatalf("results = %+v, want failed update", results)
+	}
+
+	results, err := f.service.BillDueSubscriptions(ctx, now.Add(billingLease))
+	if err != nil || len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("results = %+v, %v; want renewal", results, err)
+	}
+
+	sub, _ = f.service.GetSubscription(ctx, sub.ID)
+	if sub.Status != models.SubscriptionActive || sub.Cycle != 1 {
+		t.Fatalf("subscription = %+v, want ACTIVE with period 1 paid", sub)
+	}
+	if f.broker.charges != 1 {
+		t.Fatalf("broker charges = %d, want 1", f.broker.charges)
+	}
+	if results[0].PaymentID != "bank-1" {
+		t.Fatalf("renewal payment = %q, want the original bank-1", results[0].PaymentID)
+	}
+}
+
+func TestBillingPendingReviewIsNotAnAttempt(t *testing.T) {
+	f := newBillingFixture(models.RiskReview, trialPlan)
+	ctx := context.Background()
+
+	sub, err := f.service.Subscribe(ctx, "u1", "trial", "card")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	now := sub.NextChargeAt
+	if _, err := f.service.BillDueSubscriptions(ctx, now); err != nil {
+		t.Fatalf("BillDueSubscriptions: %v", err)
+	}
+
+	sub, _ = f.service.GetSubscription(ctx, sub.ID)
+	if sub.Status != models.SubscriptionTrialing || sub.FailedAttempts != 0 || sub.Cycle != 0 {
+		t.Fatalf("subscription = %+v, want unchanged while payment is in review", sub)
+	}
+	if want := now.Add(billingRecheckDelay); !sub.NextChargeAt.Equal(want) {
+		t.Fatalf("next charge = %v, want recheck at %v", sub.NextChargeAt, want)
+	}
+	if f.broker.charges != 0 || len(f.subs.charges) != 0 {
+		t.Fatalf("broker charges = %d, recorded attempts = %d; want none", f.broker.charges, len(f.subs.charges))
+	}
+	events, _ := f.service.SubscriptionEvents(ctx, sub.ID)
+	if last := events[len(events)-1]; last.Type != models.EventSubscriptionPaymentPending {
+		t.Fatalf("last event = %s, want PAYMENT_PENDING", last.Type)
+	}
+
+	// Повторная проверка находит тот же заказ и не создаёт новый
+	if _, err := f.service.BillDueSubscriptions(ctx, sub.NextChargeAt); err != nil {
+		t.Fatalf("BillDueSubscriptions: %v", err)
+	}
+	if n := len(f.payments.payments); n != 1 {
+		t.Fatalf("payments = %d, want the single payment held for review", n)
+	}
+}
+
+func TestBillingOutcome(t *testing.T) {
+	tests := []struct {
+		name   string
+		status models.StatusType
+		err    error
+		want   chargeOutcome
+	}{
+		{"deposited", models.OrderDeposited, nil, chargePaid},
+		{"approved", models.OrderApproved, nil, chargePaid},
+		{"review", models.OrderReview, nil, chargePending},
+		{"created", models.OrderCreated, nil, chargePending},
+		{"3ds", models.OrderPending3DS, nil, chargePending},
+		{"declined status", models.OrderDeclined, nil, chargeFailed},
+		{"reversed status", models.OrderReversed, nil, chargeFailed},
+		{"declined error", models.OrderDeclined, models.ErrPaymentDeclined, chargeFailed},
+		{"risk denied", "", ErrPaymentDenied, chargeFailed},
+		{"broker unavailable", "", models.ErrBrokerUnavailable, chargePending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := billingOutcome(models.Payment{Status: tt.status}, tt.err)
+			if got != tt.want {
+				t.Fatalf("billingOutcome = %d, want %d", got, tt.want)
+			}
+			if got == chargeFailed && err == nil {
+				t.Fatal("failed outcome without reason")
+			}
+		})
+	}
+}
+
+func TestChargeOrderIDIsStable(t *testing.T) {
+	sub := models.Subscription{ID: "s1", Cycle: 4}
+	if got := chargeOrderID(sub); got != "sub-s1-5" {
+		t.Fatalf("chargeOrderID = %q, want sub-s1-5", got)
+	}
+
+	sub.FailedAttempts = 2
+	if got := chargeOrderID(sub); got != "sub-s1-5-retry-2" {
+		t.Fatalf("chargeOrderID = %q, want sub-s1-5-retry-2", got)
+	}
+
+	sub.NextChargeAt = time.Now()
+	if chargeOrderID(sub) != "sub-s1-5-retry-2" {
+		t.Fatal("chargeOrderID depends on the charge time")
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payment/internal/domain/action"
+	"payment/internal/domain/models"
+	"time"
+)
+
+// Причина возврата, найденного при сверке
+const syncReason = "refunded at broker"
+
+// SyncPayment — сверяет статус платежа с брокером и обновляет его в БД при расхождении.
+func (s *PaymentService) SyncPayment(ctx context.Context, paymentID string) (models.SyncResult, error) {
+	l := s.log.With("payment_id", paymentID)
+	l.Debug(ctx, action.SyncPayment, "begin")
+
+	result := models.SyncResult{PaymentID: paymentID}
+
+	payment, err := s.repo.GetTransactionByPaymentID(ctx, paymentID)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to load payment")
+		return result, err
+	}
+	result.Previous = payment.Status
+
+	// Платёж на ручной проверке ещё не отправлен в банк
+	if payment.Status == models.OrderReview {
+		return result, ErrPaymentInReview
+	}
+
+	brokerStatus, err := s.broker.GetOrderStatus(ctx, paymentID)
+	if err != nil {
+		l.Error(ctx, action.PaymentTransactionFail, err, "failed to get payment status")
+		return result, fmt.Errorf("%w: %w", ErrBrokerOperationFailed, err)
+	}
+	if err := s.checkBrokerState(ctx, paymentID, brokerStatus); err != nil {
+		return result, err
+	}
+	result.Current = brokerStatus.Status
+
+	if result.Previous == result.Current {
+		l.Debug(ctx, action.SyncPayment, "status is up to date", "status", result.Current)
+		return result, nil
+	}
+
+	change := models.StatusChange{Status: brokerStatus.Status, Source: models.SourceReconciler, BrokerCode: brokerStatus.Code}
+	audit := newAuditEntry(ctx, paymentID, map[string]any{
+		"previous_status": result.Previous,
+		"current_status":  result.Current,
+	})
+	if err := s.saveSyncedStatus(ctx, *payment, change, audit); err != nil {
+		if !errors.Is(err, ErrRefundAmountUnknown) {
+			l.Error(ctx, action.DbTransactionFailed, err, "failed to mark synced status")
+			s.auditFailure(ctx, audit, err, nil)
+		}
+		return result, err
+	}
+
+	// Покупатель мог не вернуться с платёжной формы: карта сохраняется при сверке
+	if result.Current == models.OrderApproved || result.Current == models.OrderDeposited {
+		s.saveBinding(ctx, paymentID)
+	}
+
+	l.Info(ctx, action.SyncPayment, "success", "previous", result.Previous, "current", result.Current)
+	return result, nil
+}
+
+// saveSyncedStatus сохраняет статус, найденный при сверке. Возврат и отмена, проведённые мимо сервиса
+// (например, в личном кабинете банка), записываются теми же операциями репозитория, что RefundPayment
+// и ReversalPayment, чтобы суммы, комиссии и журнал соответствовали статусу.
+// Сумму частичного возврата брокер не сообщает, поэтому такой статус не сохраняется и пишется алерт.
+func (s *PaymentService) saveSyncedStatus(ctx context.Context, payment models.Payment, change models.StatusChange, audit models.AuditEntry) error {
+	switch change.Status {
+	case models.OrderRefunded:
+		// Одностадийный платёж до сверки не был отмечен списанным: списывается вся сумма
+		refundable := payment.RefundableAmount()
+		if payment.CapturedAmount == 0 && payment.ReleasedAmount == 0 {
+			refundable = models.RoundAmount(payment.Amount - payment.RefundedAmount)
+		}
+		if refundable > 0 {
+			return s.repo.Refund(ctx, payment.ID, syncReason, refundable, change, audit)
+		}
+
+	case models.OrderReversed:
+		if remaining := payment.RemainingAmount(); remaining > 0 {
+			return s.repo.Release(ctx, payment.ID, remaining, change, audit)
+		}
+
+	case models.OrderPartiallyRefunded:
+		s.log.Error(ctx, action.SyncPayment, ErrRefundAmountUnknown, "payment is partially refunded at broker",
+			"payment_id", payment.ID, "previous", payment.Status)
+		return ErrRefundAmountUnknown
+	}
+
+	return s.repo.MarkStatus(ctx, payment.ID, change, audit)
+}
+
+// ReconcilePayments — сверяет с брокером все платежи, созданные начиная с since.
+// Ошибка сверки отдельного платежа не прерывает процесс и возвращается в SyncResult.Err.
+func (s *PaymentService) ReconcilePayments(ctx context.Context, since time.Time) ([]models.SyncResult, error) {
+	l := s.log.With("since", since)
+	l.Debug(ctx, action.ReconcilePayments, "begin")
+
+	payments, err := s.repo.PaymentsSince(ctx, since)
+	if err != nil {
+		l.Error(ctx, action.DbTransactionFailed, err, "failed to get payments list")
+		return nil, err
+	}
+
+	results := make([]models.SyncResult, 0, len(payments))
+	var changed, failed int
+	for _, p := range payments {
+		if p.Status == models.OrderReview {
+			continue
+		}
+		res, err := s.SyncPayment(ctx, p.ID)
+		res.Err = err
+		switch {
+		case err != nil:
+			failed++
+		case res.Changed():
+			changed++
+		}
+		results = append(results, res)
+	}
+
+	l.Info(ctx, action.ReconcilePayments, "success",
+		"total", len(results), "changed", changed, "failed", failed)
+	return results, nil
+}
+
+// checkBrokerState не даёт сохранить нераспознанное состояние заказа у брокера.
+// Такое состояние означает, что банк вернул значение вне известного набора, поэтому пишется алерт.
+func (s *PaymentService) checkBrokerState(ctx context.Context, paymentID string, status models.BrokerStatus) error {
+	if status.Status != models.OrderUnknown {
+		return nil
+	}
+
+	s.log.Error(ctx, action.UnknownBrokerState, ErrUnknownBrokerState, "broker returned unknown order state",
+		"payment_id", paymentID, "broker_code", status.Code)
+	return fmt.Errorf("%w: %s", ErrUnknownBrokerState, status.Code)
+}
@@ -0,0 +1,5 @@
+CREATE TYPE status_source_enum AS ENUM ('API','BANK_CALLBACK','RECONCILER');
+
+ALTER TABLE TransactionStatus
+    ADD COLUMN Source status_source_enum NOT NULL DEFAULT 'API',
+    ADD COLUMN Broker_code VARCHAR(64);
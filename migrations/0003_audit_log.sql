@@ -0,0 +1,28 @@
+CREATE TYPE audit_result_enum AS ENUM ('SUCCESS','FAILURE');
+
+CREATE TABLE Audit_log (
+    Id BIGSERIAL PRIMARY KEY,
+    Actor VARCHAR(256) NOT NULL,
+    Method VARCHAR(256) NOT NULL,
+    Payment_id VARCHAR(256),
+    Params JSONB NOT NULL DEFAULT '{}',
+    Result audit_result_enum NOT NULL,
+    Error TEXT,
+    Broker_error TEXT,
+    Created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Журнал только дополняется: изменение и удаление записей запрещены
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'Audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_log_append_only
+    BEFORE UPDATE OR DELETE ON Audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+CREATE INDEX idx_audit_payment ON Audit_log(Payment_id);
+CREATE INDEX idx_audit_actor ON Audit_log(Actor);
+CREATE INDEX idx_audit_created_at ON Audit_log(Created_at DESC);
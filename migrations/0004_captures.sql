@@ -0,0 +1,22 @@
+ALTER TYPE status_enum ADD VALUE 'PARTIALLY_DEPOSITED' AFTER 'APPROVED';
+
+ALTER TABLE Transactions
+    ADD COLUMN Captured_amount NUMERIC(18,2) NOT NULL DEFAULT 0,
+    ADD COLUMN Released_amount NUMERIC(18,2) NOT NULL DEFAULT 0;
+
+-- Ранее списанные платежи считаются списанными полностью
+UPDATE Transactions SET Captured_amount = Amount WHERE Current_status IN ('DEPOSITED', 'REFUNDED');
+
+ALTER TABLE Transactions
+    ADD CONSTRAINT chk_transactions_captured CHECK (Captured_amount >= 0 AND Released_amount >= 0 AND Captured_amount + Released_amount <= Amount);
+
+CREATE TABLE Captures (
+    Capture_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    Payment_id VARCHAR(256) NOT NULL REFERENCES Transactions(Payment_id) ON DELETE CASCADE,
+    Amount NUMERIC(18,2) NOT NULL CHECK (Amount > 0),
+    Is_final BOOLEAN NOT NULL DEFAULT FALSE,
+    Broker_code VARCHAR(64),
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_captures_payment ON Captures(Payment_id);
@@ -0,0 +1,2 @@
+ALTER TYPE status_enum ADD VALUE 'PENDING_3DS' AFTER 'CREATED';
+ALTER TYPE status_enum ADD VALUE 'PARTIALLY_REFUNDED' BEFORE 'REFUNDED';
@@ -0,0 +1,13 @@
+ALTER TYPE operation_enum ADD VALUE 'BINDING_payment';
+
+CREATE TABLE Bindings (
+    Binding_id VARCHAR(256) PRIMARY KEY,
+    User_id VARCHAR(256) NOT NULL,
+    Broker VARCHAR(100) NOT NULL,
+    Masked_pan VARCHAR(32) NOT NULL DEFAULT '',
+    Expiry VARCHAR(6) NOT NULL DEFAULT '',
+    Payment_id VARCHAR(256) REFERENCES Transactions(Payment_id) ON DELETE SET NULL,
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_bindings_user ON Bindings(User_id);
@@ -0,0 +1,60 @@
+CREATE TYPE subscription_status_enum AS ENUM ('TRIALING', 'ACTIVE', 'PAST_DUE', 'CANCELED');
+
+CREATE TABLE Plans (
+    Plan_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    Name VARCHAR(256) NOT NULL,
+    Amount NUMERIC(18,2) NOT NULL CHECK (Amount > 0),
+    Currency CHAR(3) NOT NULL,
+    Interval_months INT NOT NULL CHECK (Interval_months > 0),
+    Trial_days INT NOT NULL DEFAULT 0 CHECK (Trial_days >= 0),
+    Active BOOLEAN NOT NULL DEFAULT TRUE,
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE Subscriptions (
+    Subscription_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    User_id VARCHAR(256) NOT NULL,
+    Plan_id UUID NOT NULL REFERENCES Plans(Plan_id),
+    Binding_id VARCHAR(256) NOT NULL,
+    Status subscription_status_enum NOT NULL,
+    Current_period_start TIMESTAMPTZ NOT NULL,
+    Current_period_end TIMESTAMPTZ NOT NULL,
+    Next_charge_at TIMESTAMPTZ NOT NULL,
+    Cycle INT NOT NULL DEFAULT 0,
+    Failed_attempts INT NOT NULL DEFAULT 0,
+    Credit NUMERIC(18,2) NOT NULL DEFAULT 0 CHECK (Credit >= 0),
+    Cancel_at_period_end BOOLEAN NOT NULL DEFAULT FALSE,
+    Canceled_at TIMESTAMPTZ,
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_subscriptions_user ON Subscriptions(User_id);
+CREATE INDEX idx_subscriptions_due ON Subscriptions(Next_charge_at) WHERE Status <> 'CANCELED';
+
+CREATE TABLE Subscription_charges (
+    Charge_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    Subscription_id UUID NOT NULL REFERENCES Subscriptions(Subscription_id) ON DELETE CASCADE,
+    Payment_id VARCHAR(256),
+    Order_id VARCHAR(256) NOT NULL,
+    Cycle INT NOT NULL,
+    Attempt INT NOT NULL,
+    Amount NUMERIC(18,2) NOT NULL,
+    Succeeded BOOLEAN NOT NULL,
+    Error TEXT,
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_subscription_charges_subscription ON Subscription_charges(Subscription_id);
+
+-- События подписок (outbox): читаются потребителями по возрастанию Event_id
+CREATE TABLE Subscription_events (
+    Event_id BIGSERIAL PRIMARY KEY,
+    Subscription_id UUID NOT NULL REFERENCES Subscriptions(Subscription_id) ON DELETE CASCADE,
+    Type VARCHAR(32) NOT NULL,
+    Payment_id VARCHAR(256),
+    Amount NUMERIC(18,2) NOT NULL DEFAULT 0,
+    Reason TEXT,
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_subscription_events_subscription ON Subscription_events(Subscription_id, Event_id);
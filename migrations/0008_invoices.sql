@@ -0,0 +1,21 @@
+CREATE TABLE Invoices (
+    Invoice_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    Code VARCHAR(32) NOT NULL UNIQUE,
+    User_id VARCHAR(256) NOT NULL DEFAULT '',
+    Amount NUMERIC(18,2) NOT NULL CHECK (Amount > 0),
+    Currency CHAR(3) NOT NULL,
+    Description TEXT NOT NULL DEFAULT '',
+    Return_url TEXT NOT NULL,
+    Fail_url TEXT NOT NULL,
+    Multi_use BOOLEAN NOT NULL DEFAULT FALSE,
+    Expires_at TIMESTAMPTZ NOT NULL,
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE Invoice_payments (
+    Invoice_id UUID NOT NULL REFERENCES Invoices(Invoice_id) ON DELETE CASCADE,
+    Payment_id VARCHAR(256) NOT NULL REFERENCES Transactions(Payment_id) ON DELETE CASCADE,
+    Payment_url TEXT NOT NULL,
+    Created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (Invoice_id, Payment_id)
+);
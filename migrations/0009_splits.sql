@@ -0,0 +1,28 @@
+CREATE TABLE Payment_splits (
+    Payment_id VARCHAR(256) NOT NULL REFERENCES Transactions(Payment_id) ON DELETE CASCADE,
+    Seller_id VARCHAR(256) NOT NULL,
+    Amount NUMERIC(18,2) NOT NULL CHECK (Amount > 0),
+    PRIMARY KEY (Payment_id, Seller_id)
+);
+
+CREATE TABLE Payouts (
+    Payout_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Доли участников по платежам: положительные при списании, отрицательные при возврате и реверсе
+CREATE TABLE Ledger_entries (
+    Entry_id BIGSERIAL PRIMARY KEY,
+    Payment_id VARCHAR(256) NOT NULL REFERENCES Transactions(Payment_id) ON DELETE CASCADE,
+    Party VARCHAR(256) NOT NULL,
+    Type VARCHAR(16) NOT NULL,
+    Amount NUMERIC(18,2) NOT NULL,
+    Currency CHAR(3) NOT NULL,
+    Payout_id UUID REFERENCES Payouts(Payout_id),
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_ledger_entries_payment ON Ledger_entries(Payment_id);
+CREATE INDEX idx_ledger_entries_party ON Ledger_entries(Party, Currency);
+CREATE INDEX idx_ledger_entries_unpaid ON Ledger_entries(Party, Currency) WHERE Payout_id IS NULL;
+CREATE INDEX idx_ledger_entries_payout ON Ledger_entries(Payout_id);
@@ -0,0 +1,73 @@
+CREATE TYPE account_type_enum AS ENUM ('ASSET', 'LIABILITY', 'REVENUE', 'EXPENSE', 'MEMO');
+
+CREATE TABLE Accounts (
+    Account_id SERIAL PRIMARY KEY,
+    Code VARCHAR(64) NOT NULL,
+    Type account_type_enum NOT NULL,
+    Currency CHAR(3) NOT NULL,
+    Created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (Code, Currency)
+);
+
+-- Журнал проводок. Платёж не связан внешним ключом: журнал хранится и после удаления платежа
+CREATE TABLE Journal_entries (
+    Entry_id BIGSERIAL PRIMARY KEY,
+    Payment_id VARCHAR(256),
+    Kind VARCHAR(32) NOT NULL,
+    Status status_enum,
+    Created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_journal_entries_payment ON Journal_entries(Payment_id);
+CREATE INDEX idx_journal_entries_created ON Journal_entries(Created_at);
+
+-- Дебет положительный, кредит отрицательный
+CREATE TABLE Postings (
+    Posting_id BIGSERIAL PRIMARY KEY,
+    Entry_id BIGINT NOT NULL REFERENCES Journal_entries(Entry_id),
+    Account_id INT NOT NULL REFERENCES Accounts(Account_id),
+    Amount NUMERIC(18,2) NOT NULL CHECK (Amount <> 0)
+);
+
+CREATE INDEX idx_postings_entry ON Postings(Entry_id);
+CREATE INDEX idx_postings_account ON Postings(Account_id);
+
+-- Проводка должна сходиться в каждой валюте. Проверка отложена до фиксации транзакции,
+-- чтобы строки проводки можно было добавлять по одной
+CREATE FUNCTION check_journal_entry_balanced() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM Postings p
+        JOIN Accounts a ON a.Account_id = p.Account_id
+        WHERE p.Entry_id = NEW.Entry_id
+        GROUP BY a.Currency
+        HAVING SUM(p.Amount) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal entry % is not balanced', NEW.Entry_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER trg_postings_balanced
+    AFTER INSERT ON Postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+
+-- Журнал только дополняется: исправления проводятся новыми проводками
+CREATE FUNCTION forbid_journal_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION '% is append-only', TG_TABLE_NAME
+        USING ERRCODE = 'restrict_violation';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_postings_append_only
+    BEFORE UPDATE OR DELETE ON Postings
+    FOR EACH ROW EXECUTE FUNCTION forbid_journal_change();
+
+CREATE TRIGGER trg_journal_entries_append_only
+    BEFORE UPDATE OR DELETE ON Journal_entries
+    FOR EACH ROW EXECUTE FUNCTION forbid_journal_change();
@@ -0,0 +1,23 @@
+CREATE TABLE Reconciliations (
+    Reconciliation_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    File_name VARCHAR(256) NOT NULL,
+    Period_start TIMESTAMPTZ NOT NULL,
+    Period_end TIMESTAMPTZ NOT NULL,
+    Lines INT NOT NULL,
+    Matched INT NOT NULL,
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE Reconciliation_discrepancies (
+    Discrepancy_id BIGSERIAL PRIMARY KEY,
+    Reconciliation_id UUID NOT NULL REFERENCES Reconciliations(Reconciliation_id) ON DELETE CASCADE,
+    Kind VARCHAR(32) NOT NULL,
+    Payment_id VARCHAR(256),
+    Order_id VARCHAR(256),
+    Bank_amount NUMERIC(18,2),
+    Local_amount NUMERIC(18,2),
+    Bank_status VARCHAR(32),
+    Local_status VARCHAR(32)
+);
+
+CREATE INDEX idx_reconciliation_discrepancies_reconciliation ON Reconciliation_discrepancies(Reconciliation_id);
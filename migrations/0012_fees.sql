@@ -0,0 +1,38 @@
+CREATE TABLE Fee_schedules (
+    Schedule_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    Broker VARCHAR(64),
+    Merchant VARCHAR(256),
+    Currency CHAR(3),
+    Percent NUMERIC(7,4) NOT NULL DEFAULT 0 CHECK (Percent >= 0),
+    Fixed NUMERIC(18,2) NOT NULL DEFAULT 0 CHECK (Fixed >= 0),
+    Min_fee NUMERIC(18,2) NOT NULL DEFAULT 0 CHECK (Min_fee >= 0),
+    Max_fee NUMERIC(18,2) NOT NULL DEFAULT 0 CHECK (Max_fee >= 0),
+    Tiers JSONB NOT NULL DEFAULT '[]',
+    Refund_fixed NUMERIC(18,2) NOT NULL DEFAULT 0 CHECK (Refund_fixed >= 0),
+    Active BOOLEAN NOT NULL DEFAULT TRUE,
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE Payment_fees (
+    Fee_id BIGSERIAL PRIMARY KEY,
+    Payment_id VARCHAR(256) NOT NULL REFERENCES Transactions(Payment_id) ON DELETE CASCADE,
+    Schedule_id UUID NOT NULL REFERENCES Fee_schedules(Schedule_id),
+    Refund_id UUID UNIQUE REFERENCES Refunds(Refund_id) ON DELETE CASCADE,
+    Kind VARCHAR(16) NOT NULL,
+    Base_amount NUMERIC(18,2) NOT NULL,
+    Amount NUMERIC(18,2) NOT NULL,
+    Currency CHAR(3) NOT NULL,
+    Created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_payment_fees_payment ON Payment_fees(Payment_id);
+
+ALTER TABLE Transactions
+    ADD COLUMN Merchant VARCHAR(256),
+    ADD COLUMN Fee_amount NUMERIC(18,2) NOT NULL DEFAULT 0,
+    ADD COLUMN Refunded_amount NUMERIC(18,2) NOT NULL DEFAULT 0;
+
+UPDATE Transactions t
+SET Refunded_amount = r.Total
+FROM (SELECT Payment_id, SUM(Amount) AS Total FROM Refunds GROUP BY Payment_id) r
+WHERE r.Payment_id = t.Payment_id;
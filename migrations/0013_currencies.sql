@@ -0,0 +1,51 @@
+CREATE TABLE Currencies (
+    Code CHAR(3) PRIMARY KEY,
+    Numeric_code CHAR(3) NOT NULL UNIQUE,
+    Exponent SMALLINT NOT NULL DEFAULT 2 CHECK (Exponent BETWEEN 0 AND 4),
+    Broker_supported BOOLEAN NOT NULL DEFAULT FALSE,
+    Enabled BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+INSERT INTO Currencies(Code, Numeric_code, Exponent, Broker_supported, Enabled) VALUES
+    ('KZT', '398', 2, TRUE, TRUE),
+    ('RUB', '643', 2, TRUE, TRUE),
+    ('EUR', '978', 2, TRUE, TRUE),
+    ('USD', '840', 2, TRUE, TRUE),
+    ('GBP', '826', 2, FALSE, FALSE),
+    ('CNY', '156', 2, FALSE, FALSE),
+    ('KGS', '417', 2, FALSE, FALSE),
+    ('UZS', '860', 2, FALSE, FALSE),
+    ('JPY', '392', 0, FALSE, FALSE);
+
+-- Если для мерчанта есть строки, ему доступны только перечисленные валюты
+CREATE TABLE Merchant_currencies (
+    Merchant VARCHAR(256) NOT NULL,
+    Currency CHAR(3) NOT NULL REFERENCES Currencies(Code),
+    PRIMARY KEY (Merchant, Currency)
+);
+
+CREATE TABLE Fx_rates (
+    Base CHAR(3) NOT NULL REFERENCES Currencies(Code),
+    Quote CHAR(3) NOT NULL REFERENCES Currencies(Code),
+    Rate NUMERIC(20,10) NOT NULL CHECK (Rate > 0),
+    Source VARCHAR(64) NOT NULL,
+    As_of TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (Base, Quote, As_of)
+);
+
+ALTER TABLE Transactions
+    ADD COLUMN Presentment_amount NUMERIC(18,4),
+    ADD COLUMN Presentment_currency CHAR(3),
+    ADD COLUMN Fx_rate NUMERIC(20,10) NOT NULL DEFAULT 1,
+    ADD COLUMN Fx_source VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN Fx_rate_at TIMESTAMPTZ;
+
+UPDATE Transactions
+SET Presentment_amount = Amount,
+    Presentment_currency = Currency,
+    Fx_rate_at = COALESCE(Created_at, NOW());
+
+ALTER TABLE Transactions
+    ALTER COLUMN Presentment_amount SET NOT NULL,
+    ALTER COLUMN Presentment_currency SET NOT NULL,
+    ALTER COLUMN Fx_rate_at SET NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TYPE operation_enum ADD VALUE 'CARD_payment';
+ALTER TYPE operation_enum ADD VALUE 'QR_payment';
+ALTER TYPE operation_enum ADD VALUE 'WALLET_payment';
@@ -0,0 +1,78 @@
+CREATE TYPE risk_decision_enum AS ENUM ('ALLOW', 'REVIEW', 'DENY');
+CREATE TYPE review_status_enum AS ENUM ('PENDING', 'APPROVED', 'REJECTED');
+
+ALTER TYPE status_enum ADD VALUE 'REVIEW' BEFORE 'CREATED';
+
+ALTER TABLE Transactions
+    ADD COLUMN Client_ip VARCHAR(64),
+    ADD COLUMN Risk_decision risk_decision_enum,
+    ADD COLUMN Risk_rules TEXT[] NOT NULL DEFAULT '{}';
+
+-- Окна скорости (velocity) по пользователю и адресу
+CREATE INDEX idx_transactions_user_created ON Transactions(User_id, Created_at);
+CREATE INDEX idx_transactions_ip_created ON Transactions(Client_ip, Created_at) WHERE Client_ip IS NOT NULL;
+
+CREATE TABLE Risk_rules (
+    Rule_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    Name VARCHAR(100) NOT NULL UNIQUE,
+    Decision risk_decision_enum NOT NULL,
+    Expression TEXT NOT NULL,
+    Enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    Created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Именованные списки (deny/allow) для правил вида user_id in list("blocked_users")
+CREATE TABLE Risk_list_entries (
+    List_name VARCHAR(100) NOT NULL,
+    Value VARCHAR(256) NOT NULL,
+    Created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (List_name, Value)
+);
+
+CREATE INDEX idx_risk_list_entries_value ON Risk_list_entries(Value);
+
+-- Платёж на ручной проверке создаётся под локальным ID (Review_id) и после одобрения
+-- получает ID заказа у банка, поэтому ссылки на Transactions обновляются каскадно.
+CREATE TABLE Payment_reviews (
+    Payment_id VARCHAR(256) PRIMARY KEY REFERENCES Transactions(Payment_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    Review_id VARCHAR(256) NOT NULL UNIQUE,
+    Status review_status_enum NOT NULL DEFAULT 'PENDING',
+    Method JSONB,
+    Reviewer VARCHAR(256),
+    Note TEXT,
+    Created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    Resolved_at TIMESTAMPTZ
+);
+
+ALTER TABLE TransactionStatus
+    DROP CONSTRAINT transactionstatus_payment_id_fkey,
+    ADD CONSTRAINT transactionstatus_payment_id_fkey
+        FOREIGN KEY (Payment_id) REFERENCES Transactions(Payment_id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE Refunds
+    DROP CONSTRAINT refunds_payment_id_fkey,
+    ADD CONSTRAINT refunds_payment_id_fkey
+        FOREIGN KEY (Payment_id) REFERENCES Transactions(Payment_id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE Captures
+    DROP CONSTRAINT captures_payment_id_fkey,
+    ADD CONSTRAINT captures_payment_id_fkey
+        FOREIGN KEY (Payment_id) REFERENCES Transactions(Payment_id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE Bindings
+    DROP CONSTRAINT bindings_payment_id_fkey,
+    ADD CONSTRAINT bindings_payment_id_fkey
+        FOREIGN KEY (Payment_id) REFERENCES Transactions(Payment_id) ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE Invoice_payments
+    DROP CONSTRAINT invoice_payments_payment_id_fkey,
+    ADD CONSTRAINT invoice_payments_payment_id_fkey
+        FOREIGN KEY (Payment_id) REFERENCES Transactions(Payment_id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE Payment_splits
+    DROP CONSTRAINT payment_splits_payment_id_fkey,
+    ADD CONSTRAINT payment_splits_payment_id_fkey
+        FOREIGN KEY (Payment_id) REFERENCES Transactions(Payment_id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE Ledger_entries
+    DROP CONSTRAINT ledger_entries_payment_id_fkey,
+    ADD CONSTRAINT ledger_entries_payment_id_fkey
+        FOREIGN KEY (Payment_id) REFERENCES Transactions(Payment_id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE Payment_fees
+    DROP CONSTRAINT payment_fees_payment_id_fkey,
+    ADD CONSTRAINT payment_fees_payment_id_fkey
+        FOREIGN KEY (Payment_id) REFERENCES Transactions(Payment_id) ON DELETE CASCADE ON UPDATE CASCADE;
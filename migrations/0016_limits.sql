@@ -0,0 +1,17 @@
+CREATE TYPE limit_scope_enum AS ENUM ('USER', 'MERCHANT');
+
+CREATE TABLE Payment_limits (
+    Limit_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    Scope limit_scope_enum NOT NULL,
+    Subject VARCHAR(256),
+    Currency CHAR(3) NOT NULL,
+    Max_single NUMERIC(18,2) NOT NULL DEFAULT 0 CHECK (Max_single >= 0),
+    Daily NUMERIC(18,2) NOT NULL DEFAULT 0 CHECK (Daily >= 0),
+    Monthly NUMERIC(18,2) NOT NULL DEFAULT 0 CHECK (Monthly >= 0),
+    Active BOOLEAN NOT NULL DEFAULT TRUE,
+    Created_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (Max_single > 0 OR Daily > 0 OR Monthly > 0)
+);
+
+CREATE INDEX idx_payment_limits_lookup ON Payment_limits(Currency, Scope) WHERE Active;
+CREATE INDEX idx_transactions_merchant_created ON Transactions(Merchant, Created_at);
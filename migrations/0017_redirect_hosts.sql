@@ -0,0 +1,7 @@
+-- Хосты, на которые платёжная форма может вернуть покупателя. Запись вида *.example.com
+-- разрешает поддомены. Если для мерчанта нет строк, действует список REDIRECT_ALLOWED_HOSTS.
+CREATE TABLE Merchant_redirect_hosts (
+    Merchant VARCHAR(256) NOT NULL,
+    Host VARCHAR(253) NOT NULL CHECK (Host = LOWER(Host)),
+    PRIMARY KEY (Merchant, Host)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE Transactions
+    ADD COLUMN Metadata JSONB NOT NULL DEFAULT '{}' CHECK (jsonb_typeof(Metadata) = 'object'),
+    ADD COLUMN Description VARCHAR(255);
+
+CREATE INDEX idx_transactions_metadata ON Transactions USING GIN (Metadata jsonb_path_ops);
@@ -0,0 +1,20 @@
+-- Контакты покупателя и позиции корзины для чека
+CREATE TABLE Payment_customers (
+    Payment_id VARCHAR(256) PRIMARY KEY
+        REFERENCES Transactions(Payment_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    Email VARCHAR(254),
+    Phone VARCHAR(16),
+    CHECK (Email IS NOT NULL OR Phone IS NOT NULL)
+);
+
+CREATE TABLE Payment_items (
+    Payment_id VARCHAR(256) NOT NULL
+        REFERENCES Transactions(Payment_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    Position INT NOT NULL,
+    Name VARCHAR(128) NOT NULL,
+    Quantity NUMERIC(18,3) NOT NULL CHECK (Quantity > 0),
+    Price NUMERIC(18,2) NOT NULL CHECK (Price >= 0),
+    Tax_rate NUMERIC(5,2) NOT NULL DEFAULT 0 CHECK (Tax_rate >= 0 AND Tax_rate < 100),
+    Amount NUMERIC(18,2) NOT NULL,
+    PRIMARY KEY (Payment_id, Position)
+);
@@ -0,0 +1,22 @@
+CREATE TYPE receipt_kind_enum AS ENUM ('SALE', 'REFUND');
+CREATE TYPE receipt_status_enum AS ENUM ('PENDING', 'ISSUED', 'FAILED');
+
+-- Чеки ставятся в очередь в транзакции смены статуса платежа и регистрируются фоновым обработчиком
+CREATE TABLE Receipts (
+    Receipt_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    Payment_id VARCHAR(256) NOT NULL
+        REFERENCES Transactions(Payment_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    Kind receipt_kind_enum NOT NULL,
+    Status receipt_status_enum NOT NULL DEFAULT 'PENDING',
+    Provider VARCHAR(64),
+    Fiscal_number VARCHAR(128),
+    Fiscal_url TEXT,
+    Attempts INT NOT NULL DEFAULT 0,
+    Last_error TEXT,
+    Created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    Issued_at TIMESTAMPTZ,
+    Sent_at TIMESTAMPTZ,
+    UNIQUE (Payment_id, Kind)
+);
+
+CREATE INDEX idx_receipts_pending ON Receipts(Created_at) WHERE Status = 'PENDING';
@@ -0,0 +1,2 @@
+-- Actor — вызывающая сторона по ключу API; заявленная клиентом (x-actor) хранится отдельно
+ALTER TABLE Audit_log ADD COLUMN Claimed_actor VARCHAR(256);
@@ -0,0 +1,9 @@
+-- Одностадийные платежи списываются без отдельного capture: списанной считается вся сумма
+UPDATE Transactions
+SET Captured_amount = Amount
+WHERE Captured_amount = 0 AND Released_amount = 0
+  AND Current_status IN ('DEPOSITED', 'PARTIALLY_REFUNDED', 'REFUNDED');
+
+-- Вернуть можно не больше списанного
+ALTER TABLE Transactions
+    ADD CONSTRAINT chk_transactions_refunded CHECK (Refunded_amount >= 0 AND Refunded_amount <= Captured_amount);
@@ -0,0 +1,15 @@
+-- ID платежа у мерчанта: под ним платёж сохраняется до ответа банка (резерв лимита, ручная проверка)
+-- и подставляется в {payment_id} адресов возврата. После замены Payment_id на ID заказа у банка
+-- платёж по-прежнему находится по нему.
+ALTER TABLE Transactions ADD COLUMN Local_id VARCHAR(256);
+
+UPDATE Transactions t
+SET Local_id = r.Review_id
+FROM Payment_reviews r
+WHERE r.Payment_id = t.Payment_id;
+
+CREATE UNIQUE INDEX idx_transactions_local_id ON Transactions(Local_id);
+
+-- Хосты возврата: точное имя или *.example.com для поддоменов
+ALTER TABLE Merchant_redirect_hosts
+    ADD CONSTRAINT chk_redirect_host_form CHECK (Host ~ '^(\*\.)?[a-z0-9-]+(\.[a-z0-9-]+)*$');
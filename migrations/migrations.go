@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS — SQL миграции. Применяются командой `migrate` в порядке имён файлов.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
 )
@@ -26,22 +27,27 @@ type SLogger struct {
 }
 
 func New(level string) Logger {
+	return NewWithWriter(level, os.Stdout)
+}
+
+// NewWithWriter — логгер с произвольным выводом (например, stderr для CLI).
+func NewWithWriter(level string, w io.Writer) Logger {
 	var h slog.Handler
 	switch level {
 	case prodLevel:
-		h = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		h = slog.NewJSONHandler(w, &slog.HandlerOptions{
 			Level: slog.LevelInfo,
 		})
 	case devLevel:
-		h = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		h = slog.NewJSONHandler(w, &slog.HandlerOptions{
 			Level: slog.LevelDebug,
 		})
 	case debugLevel:
-		h = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		h = slog.NewJSONHandler(w, &slog.HandlerOptions{
 			Level: slog.LevelDebug,
 		})
 	default:
-		h = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		h = slog.NewJSONHandler(w, &slog.HandlerOptions{
 			Level: slog.LevelInfo,
 		})
 	}
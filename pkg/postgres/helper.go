@@ -6,10 +6,19 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
-const UniqueViolationCode = "23505"
+const (
+	UniqueViolationCode     = "23505"
+	ForeignKeyViolationCode = "23503"
+)
 
 // IsUniqueViolation проверяет, является ли ошибка нарушением уникального ограничения.
 func IsUniqueViolation(err error) bool {
 	var pgErr *pgconn.PgError
 	return errors.As(err, &pgErr) && pgErr.Code == UniqueViolationCode
 }
+
+// IsForeignKeyViolation проверяет, является ли ошибка нарушением внешнего ключа.
+func IsForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == ForeignKeyViolationCode
+}
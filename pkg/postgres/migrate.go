@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Migrate применяет *.sql файлы из fsys, которые ещё не были применены.
+// Применённые версии хранятся в таблице Schema_migrations.
+// Базу, созданную до появления миграций, первая миграция не пересоздаёт (см. baseline).
+// Каждый файл выполняется в отдельной транзакции.
+// Возвращает список применённых файлов.
+func Migrate(ctx context.Context, pool *pgxpool.Pool, fsys fs.FS) (applied []string, err error) {
+	const op = "postgres.Migrate"
+
+	query := `
+		CREATE TABLE IF NOT EXISTS Schema_migrations (
+			Version VARCHAR(256) PRIMARY KEY,
+			Applied_at TIMESTAMPTZ DEFAULT NOW()
+		);`
+	if _, err := pool.Exec(ctx, query); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	sort.Strings(files)
+
+	if len(files) > 0 {
+		if err := baseline(ctx, pool, path.Base(files[0])); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	for _, file := range files {
+		version := path.Base(file)
+
+		var done bool
+		query = `SELECT EXISTS (SELECT 1 FROM Schema_migrations WHERE Version = $1);`
+		if err := pool.QueryRow(ctx, query, version).Scan(&done); err != nil {
+			return applied, fmt.Errorf("%s: %w", op, err)
+		}
+		if done {
+			continue
+		}
+
+		script, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return applied, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err := applyMigration(ctx, pool, version, string(script)); err != nil {
+			return applied, fmt.Errorf("%s: %s: %w", op, version, err)
+		}
+		applied = append(applied, version)
+	}
+
+	return applied, nil
+}
+
+// baseline помечает первую миграцию применённой, если схема создана до появления миграций
+// (init.sql из docker-entrypoint-initdb.d): таблица Transactions есть, а Schema_migrations пуста.
+func baseline(ctx context.Context, pool *pgxpool.Pool, version string) error {
+	query := `
+		INSERT INTO Schema_migrations(Version)
+		SELECT $1
+		WHERE to_regclass('transactions') IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM Schema_migrations);`
+	_, err := pool.Exec(ctx, query, version)
+	return err
+}
+
+func applyMigration(ctx context.Context, pool *pgxpool.Pool, version, script string) (err error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, script); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO Schema_migrations(Version) VALUES ($1);`
+	if _, err = tx.Exec(ctx, query, version); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}